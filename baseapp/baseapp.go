@@ -2,6 +2,7 @@ package baseapp
 
 import (
 	"fmt"
+	"math"
 	"runtime/debug"
 	"strings"
 
@@ -25,6 +26,10 @@ import (
 // and to avoid affecting the Merkle root.
 var dbHeaderKey = []byte("header")
 
+// Key to store the consensus params in the DB itself, as they are only given
+// to the app in InitChain.
+var dbConsensusParamsKey = []byte("consensus_params")
+
 // Enum mode for app.runTx
 type runTxMode uint8
 
@@ -37,27 +42,55 @@ const (
 	runTxModeDeliver runTxMode = iota
 )
 
+// MsgMode sets what is kept of the msgs of a tx when one of them fails.
+// Each msg runs in its own cache, so the writes of a failed msg are always discarded.
+type MsgMode uint8
+
+const (
+	// Discard the writes of all the msgs of the tx (default)
+	MsgModeAtomic MsgMode = iota
+	// Keep the writes of the msgs run before the failed one
+	MsgModeNested
+)
+
 // The WRSP application
 type BaseApp struct {
 	// initialized on creation
-	Logger     log.Logger
-	name       string               // application name from wrsp.Info
-	cdc        *wire.Codec          // Amino codec
-	db         dbm.DB               // common DB backend
-	cms        sdk.CommitMultiStore // Main (uncached) state
-	router     Router               // handle any kind of message
-	codespacer *sdk.Codespacer      // handle module codespacing
+	Logger      log.Logger
+	name        string               // application name from wrsp.Info
+	cdc         *wire.Codec          // Amino codec
+	db          dbm.DB               // common DB backend
+	storeDBs    map[string]dbm.DB    // DB backends of the IAVL stores not kept in db, by store name
+	cms         sdk.CommitMultiStore // Main (uncached) state
+	router      Router               // handle any kind of message
+	queryRouter QueryRouter          // router for redirecting query calls
+	codespacer  *sdk.Codespacer      // handle module codespacing
+	gasConfig   sdk.GasConfig        // gas costs of KVStore operations
+	msgMode     MsgMode              // what is kept of a tx whose msg fails
 
 	// must be set
 	txDecoder   sdk.TxDecoder   // unmarshal []byte into sdk.Tx
 	anteHandler sdk.AnteHandler // ante handler for fee and auth
 
 	// may be nil
-	initChainer      sdk.InitChainer  // initialize state with validators and state blob
-	beginBlocker     sdk.BeginBlocker // logic to run before any txs
-	endBlocker       sdk.EndBlocker   // logic to run after all txs, and to determine valset changes
-	addrPeerFilter   sdk.PeerFilter   // filter peers by address and port
-	pubkeyPeerFilter sdk.PeerFilter   // filter peers by public key
+	postHandler      sdk.PostHandler      // run after the handler of each message
+	feeRefundHandler sdk.FeeRefundHandler // refund the fees of unused gas
+	initChainer      sdk.InitChainer      // initialize state with validators and state blob
+	beginBlocker     sdk.BeginBlocker     // logic to run before any txs
+	endBlocker       sdk.EndBlocker       // logic to run after all txs, and to determine valset changes
+	addrPeerFilter   sdk.PeerFilter       // filter peers by address and port
+	pubkeyPeerFilter sdk.PeerFilter       // filter peers by public key
+
+	// snapshots of the stores are taken every snapshotInterval blocks if > 0
+	snapshotDir      string
+	snapshotInterval int64
+
+	// the writes of each committed block are sent to stateSink if not nil
+	stateSink     StateSink
+	stateListener *blockListener
+
+	// set on InitChain and loaded from the DB on restart, may be nil
+	consensusParams *wrsp.ConsensusParams // block gas limit
 
 	//--------------------
 	// Volatile
@@ -73,22 +106,36 @@ var _ wrsp.Application = (*BaseApp)(nil)
 
 // Create and name new BaseApp
 // NOTE: The db is used to store the version number for now.
-func NewBaseApp(name string, cdc *wire.Codec, logger log.Logger, db dbm.DB) *BaseApp {
+// The options are applied in order, before any store is mounted.
+func NewBaseApp(name string, cdc *wire.Codec, logger log.Logger, db dbm.DB, options ...func(*BaseApp)) *BaseApp {
 	app := &BaseApp{
-		Logger:     logger,
-		name:       name,
-		cdc:        cdc,
-		db:         db,
-		cms:        store.NewCommitMultiStore(db),
-		router:     NewRouter(),
-		codespacer: sdk.NewCodespacer(),
-		txDecoder:  defaultTxDecoder(cdc),
+		Logger:      logger,
+		name:        name,
+		cdc:         cdc,
+		db:          db,
+		cms:         store.NewCommitMultiStore(db),
+		router:      NewRouter(),
+		queryRouter: NewQueryRouter(),
+		codespacer:  sdk.NewCodespacer(),
+		gasConfig:   sdk.DefaultGasConfig(),
+		txDecoder:   defaultTxDecoder(cdc),
 	}
 	// Register the undefined & root codespaces, which should not be used by any modules
 	app.codespacer.RegisterOrPanic(sdk.CodespaceRoot)
+	for _, option := range options {
+		option(app)
+	}
 	return app
 }
 
+// SetStoreDBs is an option of NewBaseApp to keep the IAVL stores of the
+// given names in their own DB backend, instead of the common one
+func SetStoreDBs(dbs map[string]dbm.DB) func(*BaseApp) {
+	return func(app *BaseApp) {
+		app.storeDBs = dbs
+	}
+}
+
 // BaseApp Name
 func (app *BaseApp) Name() string {
 	return app.name
@@ -106,14 +153,27 @@ func (app *BaseApp) MountStoresIAVL(keys ...*sdk.KVStoreKey) {
 	}
 }
 
+// Mount transient stores to the provided keys in the BaseApp multistore,
+// for data which is wiped on every commit
+func (app *BaseApp) MountStoresTransient(keys ...*sdk.TransientStoreKey) {
+	for _, key := range keys {
+		app.MountStore(key, sdk.StoreTypeTransient)
+	}
+}
+
 // Mount a store to the provided key in the BaseApp multistore, using a specified DB
 func (app *BaseApp) MountStoreWithDB(key sdk.StoreKey, typ sdk.StoreType, db dbm.DB) {
 	app.cms.MountStoreWithDB(key, typ, db)
 }
 
-// Mount a store to the provided key in the BaseApp multistore, using the default DB
+// Mount a store to the provided key in the BaseApp multistore, using the DB
+// set for it with SetStoreDBs, or else the default DB
 func (app *BaseApp) MountStore(key sdk.StoreKey, typ sdk.StoreType) {
-	app.cms.MountStoreWithDB(key, typ, nil)
+	var db dbm.DB
+	if typ == sdk.StoreTypeIAVL {
+		db = app.storeDBs[key.Name()]
+	}
+	app.cms.MountStoreWithDB(key, typ, db)
 }
 
 // Set the txDecoder function
@@ -153,13 +213,36 @@ func (app *BaseApp) SetEndBlocker(endBlocker sdk.EndBlocker) {
 func (app *BaseApp) SetAnteHandler(ah sdk.AnteHandler) {
 	app.anteHandler = ah
 }
+func (app *BaseApp) SetPostHandler(ph sdk.PostHandler) {
+	app.postHandler = ph
+}
+func (app *BaseApp) SetFeeRefundHandler(rh sdk.FeeRefundHandler) {
+	app.feeRefundHandler = rh
+}
+func (app *BaseApp) SetGasConfig(config sdk.GasConfig) {
+	app.gasConfig = config
+}
+func (app *BaseApp) SetMsgMode(mode MsgMode) {
+	app.msgMode = mode
+}
+func (app *BaseApp) SetPruning(pruning sdk.PruningStrategy) {
+	app.cms.SetPruning(pruning)
+}
+func (app *BaseApp) SetSnapshotInterval(dir string, interval int64) {
+	app.snapshotDir = dir
+	app.snapshotInterval = interval
+}
+func (app *BaseApp) SetStateSink(sink StateSink) {
+	app.stateSink = sink
+}
 func (app *BaseApp) SetAddrPeerFilter(pf sdk.PeerFilter) {
 	app.addrPeerFilter = pf
 }
 func (app *BaseApp) SetPubKeyPeerFilter(pf sdk.PeerFilter) {
 	app.pubkeyPeerFilter = pf
 }
-func (app *BaseApp) Router() Router { return app.router }
+func (app *BaseApp) Router() Router           { return app.router }
+func (app *BaseApp) QueryRouter() QueryRouter { return app.queryRouter }
 
 // load latest application version
 func (app *BaseApp) LoadLatestVersion(mainKey sdk.StoreKey) error {
@@ -179,6 +262,12 @@ func (app *BaseApp) LoadVersion(version int64, mainKey sdk.StoreKey) error {
 	return app.initFromStore(mainKey)
 }
 
+// restore the stores from the snapshot at height in dir, which must match
+// the trusted app hash, so that the app starts from that height
+func (app *BaseApp) RestoreSnapshot(dir string, height int64, appHash []byte) error {
+	return app.cms.Restore(dir, height, appHash)
+}
+
 // the last CommitID of the multistore
 func (app *BaseApp) LastCommitID() sdk.CommitID {
 	return app.cms.LastCommitID()
@@ -199,6 +288,17 @@ func (app *BaseApp) initFromStore(mainKey sdk.StoreKey) error {
 		return errors.New("baseapp expects MultiStore with 'main' KVStore")
 	}
 
+	// load the consensus params stored by InitChain
+	paramsBytes := app.db.Get(dbConsensusParamsKey)
+	if len(paramsBytes) != 0 {
+		consensusParams := &wrsp.ConsensusParams{}
+		err := consensusParams.Unmarshal(paramsBytes)
+		if err != nil {
+			return errors.Wrap(err, "failed to parse ConsensusParams")
+		}
+		app.consensusParams = consensusParams
+	}
+
 	// XXX: Do we really need the header? What does it have that we want
 	// here that's not already in the CommitID ? If an app wants to have it,
 	// they can do so in their BeginBlocker. If we force it in baseapp,
@@ -232,12 +332,33 @@ func (app *BaseApp) initFromStore(mainKey sdk.StoreKey) error {
 	return nil
 }
 
+// the gas limit of blocks, zero meaning blocks are not limited
+func (app *BaseApp) maxBlockGas() sdk.Gas {
+	if app.consensusParams == nil || app.consensusParams.BlockSize == nil {
+		return 0
+	}
+	// a negative max gas means blocks are not limited either
+	if app.consensusParams.BlockSize.MaxGas < 0 {
+		return 0
+	}
+	return app.consensusParams.BlockSize.MaxGas
+}
+
+// a gas meter limiting the gas of a block to the max block gas
+func (app *BaseApp) newBlockGasMeter() sdk.GasMeter {
+	maxGas := app.maxBlockGas()
+	if maxGas == 0 {
+		return sdk.NewInfiniteGasMeter()
+	}
+	return sdk.NewGasMeter(maxGas)
+}
+
 // NewContext returns a new Context with the correct store, the given header, and nil txBytes.
 func (app *BaseApp) NewContext(isCheckTx bool, header wrsp.Header) sdk.Context {
 	if isCheckTx {
-		return sdk.NewContext(app.checkState.ms, header, true, app.Logger)
+		return sdk.NewContext(app.checkState.ms, header, true, app.Logger).WithGasConfig(app.gasConfig)
 	}
-	return sdk.NewContext(app.deliverState.ms, header, false, app.Logger)
+	return sdk.NewContext(app.deliverState.ms, header, false, app.Logger).WithGasConfig(app.gasConfig)
 }
 
 type state struct {
@@ -253,15 +374,20 @@ func (app *BaseApp) setCheckState(header wrsp.Header) {
 	ms := app.cms.CacheMultiStore()
 	app.checkState = &state{
 		ms:  ms,
-		ctx: sdk.NewContext(ms, header, true, app.Logger),
+		ctx: sdk.NewContext(ms, header, true, app.Logger).WithGasConfig(app.gasConfig),
 	}
 }
 
 func (app *BaseApp) setDeliverState(header wrsp.Header) {
 	ms := app.cms.CacheMultiStore()
+	if app.stateSink != nil {
+		// The writes of InitChain count as the BeginBlock of the first block
+		app.stateListener = &blockListener{}
+		ms.SetListener(app.stateListener)
+	}
 	app.deliverState = &state{
 		ms:  ms,
-		ctx: sdk.NewContext(ms, header, false, app.Logger),
+		ctx: sdk.NewContext(ms, header, false, app.Logger).WithGasConfig(app.gasConfig),
 	}
 }
 
@@ -293,6 +419,16 @@ func (app *BaseApp) InitChain(req wrsp.RequestInitChain) (res wrsp.ResponseInitC
 	app.setDeliverState(wrsp.Header{ChainID: req.ChainId})
 	app.setCheckState(wrsp.Header{ChainID: req.ChainId})
 
+	// Store the consensus params, as they are not given again on restart
+	if req.ConsensusParams != nil {
+		paramsBytes, err := req.ConsensusParams.Marshal()
+		if err != nil {
+			panic(err)
+		}
+		app.db.SetSync(dbConsensusParamsKey, paramsBytes)
+		app.consensusParams = req.ConsensusParams
+	}
+
 	if app.initChainer == nil {
 		return
 	}
@@ -363,6 +499,10 @@ func (app *BaseApp) Query(req wrsp.RequestQuery) (res wrsp.ResponseQuery) {
 		req.Path = "/" + strings.Join(path[1:], "/")
 		return queryable.Query(req)
 	}
+	// "/custom" prefix for module queriers
+	if len(path) >= 1 && path[0] == "custom" {
+		return app.handleQueryCustom(path, req)
+	}
 	// "/p2p" prefix for p2p queries
 	if len(path) >= 4 && path[0] == "p2p" {
 		if path[1] == "filter" {
@@ -378,6 +518,47 @@ func (app *BaseApp) Query(req wrsp.RequestQuery) (res wrsp.ResponseQuery) {
 	return sdk.ErrUnknownRequest(msg).QueryResult()
 }
 
+// handleQueryCustom routes "/custom/<route>/<path...>" queries to the
+// module querier registered under <route>. Queries run against a cache of
+// the latest committed state, or of the state at req.Height if set, so any
+// writes made by a querier are discarded.
+func (app *BaseApp) handleQueryCustom(path []string, req wrsp.RequestQuery) (res wrsp.ResponseQuery) {
+	if len(path) < 2 || path[1] == "" {
+		return sdk.ErrUnknownRequest("No route for custom query specified").QueryResult()
+	}
+	querier := app.queryRouter.Route(path[1])
+	if querier == nil {
+		return sdk.ErrUnknownRequest(fmt.Sprintf("no custom querier found for route %s", path[1])).QueryResult()
+	}
+
+	height := app.LastBlockHeight()
+	ms := app.cms.CacheMultiStore()
+	if req.Height != 0 && req.Height != height {
+		var err error
+		ms, err = app.cms.CacheMultiStoreWithVersion(req.Height)
+		if err != nil {
+			return sdk.ErrUnknownRequest(fmt.Sprintf("cannot query height %d: %v", req.Height, err)).QueryResult()
+		}
+		height = req.Height
+	}
+	// There is no check state before the first commit after a restart
+	var header wrsp.Header
+	if app.checkState != nil {
+		header = app.checkState.ctx.BlockHeader()
+	}
+	header.Height = height
+	ctx := sdk.NewContext(ms, header, true, app.Logger)
+	resBytes, err := querier(ctx, path[2:], req)
+	if err != nil {
+		return err.QueryResult()
+	}
+	return wrsp.ResponseQuery{
+		Code:   uint32(sdk.WRSPCodeOK),
+		Value:  resBytes,
+		Height: height,
+	}
+}
+
 // Implements WRSP
 func (app *BaseApp) BeginBlock(req wrsp.RequestBeginBlock) (res wrsp.ResponseBeginBlock) {
 	// Initialize the DeliverTx state.
@@ -392,8 +573,13 @@ func (app *BaseApp) BeginBlock(req wrsp.RequestBeginBlock) (res wrsp.ResponseBeg
 		// by InitChain. Context is now updated with Header information.
 		app.deliverState.ctx = app.deliverState.ctx.WithBlockHeader(req.Header)
 	}
+	// Reset the gas available to the txs of the block
+	app.deliverState.ctx = app.deliverState.ctx.WithBlockGasMeter(app.newBlockGasMeter())
 	if app.beginBlocker != nil {
-		res = app.beginBlocker(app.deliverState.ctx, req)
+		// Index the events the begin blocker emits
+		ctx := app.deliverState.ctx.WithEventManager(sdk.NewEventManager())
+		res = app.beginBlocker(ctx, req)
+		res.Tags = append(res.Tags, ctx.EventManager().Events().ToTags().ToKVPairs()...)
 	}
 	// set the signed validators for addition to context in deliverTx
 	app.signedValidators = req.Validators
@@ -427,6 +613,10 @@ func (app *BaseApp) CheckTx(txBytes []byte) (res wrsp.ResponseCheckTx) {
 
 // Implements WRSP
 func (app *BaseApp) DeliverTx(txBytes []byte) (res wrsp.ResponseDeliverTx) {
+	if app.stateListener != nil {
+		app.stateListener.startTx()
+	}
+
 	// Decode the Tx.
 	var result sdk.Result
 	var tx, err = app.txDecoder(txBytes)
@@ -497,6 +687,14 @@ func (app *BaseApp) runTx(mode runTxMode, txBytes []byte, tx sdk.Tx) (result sdk
 		}
 	}
 
+	// Refuse txs wanting more gas than a block can hold, as they can never be included
+	if mode == runTxModeCheck {
+		maxGas := app.maxBlockGas()
+		if stdTx, ok := tx.(auth.StdTx); ok && maxGas != 0 && stdTx.Fee.Gas > maxGas {
+			return sdk.ErrOutOfGas(fmt.Sprintf("tx wants %d gas but the block gas limit is %d", stdTx.Fee.Gas, maxGas)).Result()
+		}
+	}
+
 	// Get the context
 	var ctx sdk.Context
 	if mode == runTxModeCheck || mode == runTxModeSimulate {
@@ -506,87 +704,179 @@ func (app *BaseApp) runTx(mode runTxMode, txBytes []byte, tx sdk.Tx) (result sdk
 		ctx = ctx.WithSigningValidators(app.signedValidators)
 	}
 
-	// Simulate a DeliverTx for gas calculation
+	// Simulate a DeliverTx for gas calculation, on a cache of the check state
+	// which is never written, so that the ante handler does not alter it either
+	var simState sdk.CacheMultiStore
 	if mode == runTxModeSimulate {
-		ctx = ctx.WithIsCheckTx(false)
+		simState = app.checkState.CacheMultiStore()
+		ctx = ctx.WithIsCheckTx(false).WithMultiStore(simState)
+	}
+
+	// Meter the gas of this tx only, until the ante handler sets its own gas meter
+	ctx = ctx.WithGasMeter(sdk.NewInfiniteGasMeter())
+
+	if mode == runTxModeDeliver {
+		blockGasMeter := ctx.BlockGasMeter()
+		if app.blockGasLeft(blockGasMeter) <= 0 {
+			return sdk.ErrOutOfGas("no block gas left to run tx").Result()
+		}
+		// The gas of the tx counts against the block whether it succeeds or not
+		defer func() {
+			gas := ctx.GasMeter().GasConsumed()
+			if left := app.blockGasLeft(blockGasMeter); gas > left {
+				gas = left
+			}
+			blockGasMeter.ConsumeGas(gas, "block gas meter")
+		}()
 	}
 
-	// Run the ante handler.
+	// Run the ante handler, collecting its events apart from the msgs' ones.
+	var anteEvents sdk.Events
 	if app.anteHandler != nil {
-		newCtx, result, abort := app.anteHandler(ctx, tx)
+		anteCtx := ctx.WithEventManager(sdk.NewEventManager())
+		newCtx, result, abort := app.anteHandler(anteCtx, tx)
 		if abort {
 			return result
 		}
 		if !newCtx.IsZero() {
 			ctx = newCtx
 		}
+		anteEvents = anteCtx.EventManager().Events()
+	}
+
+	// Refund the fees of the gas the tx did not use once its msgs have run,
+	// whether they succeed or not, like the fees are deducted by the ante handler.
+	if app.feeRefundHandler != nil {
+		refundCtx := ctx
+		defer func() {
+			gasUsed := refundCtx.GasMeter().GasConsumed()
+			app.feeRefundHandler(refundCtx.WithGasMeter(sdk.NewInfiniteGasMeter()), tx, gasUsed)
+		}()
 	}
 
 	// Get the correct cache
 	var msCache sdk.CacheMultiStore
-	if mode == runTxModeCheck || mode == runTxModeSimulate {
+	if mode == runTxModeCheck {
 		// CacheWrap app.checkState.ms in case it fails.
 		msCache = app.checkState.CacheMultiStore()
 		ctx = ctx.WithMultiStore(msCache)
+	} else if mode == runTxModeSimulate {
+		// CacheWrap the simulation state, so that the msgs see the effects of the ante handler.
+		msCache = simState.CacheMultiStore()
+		ctx = ctx.WithMultiStore(msCache)
 	} else {
 		// CacheWrap app.deliverState.ms in case it fails.
 		msCache = app.deliverState.CacheMultiStore()
 		ctx = ctx.WithMultiStore(msCache)
 	}
 
+	var msgResults sdk.MsgResults
+	result = app.runMsgs(ctx, msCache, msgs, &msgResults)
+	if len(anteEvents) > 0 {
+		result.Tags = anteEvents.ToTags().AppendTags(result.Tags)
+	}
+	result.GasUsed = ctx.GasMeter().GasConsumed()
+	result.Log = msgResults.String()
+
+	// Refuse the tx if it overflows the gas left in the block
+	if mode == runTxModeDeliver {
+		gas := ctx.GasMeter().GasConsumed()
+		if left := app.blockGasLeft(ctx.BlockGasMeter()); gas > left {
+			return sdk.ErrOutOfGas(fmt.Sprintf("tx used %d gas but only %d is left in the block", gas, left)).Result()
+		}
+	}
+
+	// If not a simulated run, write to app.checkState.ms or app.deliverState.ms.
+	// Only update state if all messages pass, unless the msgs run nested.
+	if mode != runTxModeSimulate && (result.IsOK() || app.msgMode == MsgModeNested) {
+		msCache.Write()
+	}
+
+	return result
+}
+
+// Run the msgs of a tx one after the other, each in its own cache of msCache
+// which is written only if the msg succeeds. Execution stops at the first
+// failed msg, whose result is returned, with the data and tags of the msgs
+// before it if they run nested. The result of each msg run is appended to
+// msgResults.
+// The events a msg emits are added to its tags, and are dropped with its
+// writes if it fails.
+func (app *BaseApp) runMsgs(ctx sdk.Context, msCache sdk.CacheMultiStore, msgs []sdk.Msg, msgResults *sdk.MsgResults) sdk.Result {
 	finalResult := sdk.Result{}
-	var logs []string
 	for i, msg := range msgs {
+		msgCache := msCache.CacheMultiStore()
+		msgCtx := ctx.WithMultiStore(msgCache).WithEventManager(sdk.NewEventManager())
+		gasBefore := ctx.GasMeter().GasConsumed()
+
 		// Match route.
+		var result sdk.Result
 		msgType := msg.Type()
 		handler := app.router.Route(msgType)
 		if handler == nil {
-			return sdk.ErrUnknownRequest("Unrecognized Msg type: " + msgType).Result()
+			result = sdk.ErrUnknownRequest("Unrecognized Msg type: " + msgType).Result()
+		} else {
+			result = handler(msgCtx, msg)
+			if app.postHandler != nil {
+				result = app.postHandler(msgCtx, msg, result)
+			}
 		}
 
-		result = handler(ctx, msg)
-
-		// Set gas utilized
-		finalResult.GasUsed += ctx.GasMeter().GasConsumed()
-		finalResult.GasWanted += result.GasWanted
-
-		// Append Data and Tags
-		finalResult.Data = append(finalResult.Data, result.Data...)
-		finalResult.Tags = append(finalResult.Tags, result.Tags...)
+		var events sdk.Events
+		if result.IsOK() {
+			events = msgCtx.EventManager().Events()
+			result.Tags = result.Tags.AppendTags(events.ToTags())
+		}
 
-		// Construct usable logs in multi-message transactions. Messages are 1-indexed in logs.
-		logs = append(logs, fmt.Sprintf("Msg %d: %s", i+1, finalResult.Log))
+		*msgResults = append(*msgResults, sdk.MsgResult{
+			MsgIndex: i,
+			Code:     result.Code,
+			Data:     result.Data,
+			Log:      result.Log,
+			GasUsed:  ctx.GasMeter().GasConsumed() - gasBefore,
+			Tags:     result.Tags,
+			Events:   events,
+		})
 
 		// Stop execution and return on first failed message.
 		if !result.IsOK() {
-			if len(msgs) == 1 {
-				return result
-			}
-			result.GasUsed = finalResult.GasUsed
-			if i == 0 {
-				result.Log = fmt.Sprintf("Msg 1 failed: %s", result.Log)
-			} else {
-				result.Log = fmt.Sprintf("Msg 1-%d Passed. Msg %d failed: %s", i, i+1, result.Log)
+			// The msgs before it only took effect if they run nested, else
+			// their results are only kept in msgResults.
+			if app.msgMode == MsgModeNested {
+				result.Data = finalResult.Data
+				result.Tags = append(finalResult.Tags, result.Tags...)
 			}
+			result.GasWanted += finalResult.GasWanted
 			return result
 		}
-	}
+		msgCache.Write()
 
-	// If not a simulated run and result was successful, write to app.checkState.ms or app.deliverState.ms
-	// Only update state if all messages pass.
-	if mode != runTxModeSimulate && result.IsOK() {
-		msCache.Write()
+		finalResult.GasWanted += result.GasWanted
+		finalResult.Data = append(finalResult.Data, result.Data...)
+		finalResult.Tags = append(finalResult.Tags, result.Tags...)
 	}
-
-	finalResult.Log = strings.Join(logs, "\n")
-
 	return finalResult
 }
 
+// the gas the txs of the block can still consume
+func (app *BaseApp) blockGasLeft(blockGasMeter sdk.GasMeter) sdk.Gas {
+	maxGas := app.maxBlockGas()
+	if maxGas == 0 {
+		return math.MaxInt64
+	}
+	return maxGas - blockGasMeter.GasConsumed()
+}
+
 // Implements WRSP
 func (app *BaseApp) EndBlock(req wrsp.RequestEndBlock) (res wrsp.ResponseEndBlock) {
+	if app.stateListener != nil {
+		app.stateListener.phase = phaseEndBlock
+	}
 	if app.endBlocker != nil {
-		res = app.endBlocker(app.deliverState.ctx, req)
+		// Index the events the end blocker emits
+		ctx := app.deliverState.ctx.WithEventManager(sdk.NewEventManager())
+		res = app.endBlocker(ctx, req)
+		res.Tags = append(res.Tags, ctx.EventManager().Events().ToTags().ToKVPairs()...)
 	}
 	return
 }
@@ -611,6 +901,35 @@ func (app *BaseApp) Commit() (res wrsp.ResponseCommit) {
 		"commit", commitID,
 	)
 
+	// Send the writes of the block to the state sink
+	if app.stateListener != nil {
+		block := app.stateListener.block
+		block.Height = header.Height
+		err := app.stateSink.ListenBlock(block)
+		if err != nil {
+			app.Logger.Error("Failed to send the block state changes", "height", header.Height, "err", err)
+		}
+		app.stateListener = nil
+	}
+
+	// Snapshot the stores, exporting them in the background so that the
+	// next blocks don't wait for it. The version is kept until it's done.
+	if app.snapshotInterval > 0 && commitID.Version%app.snapshotInterval == 0 {
+		export, err := app.cms.Snapshot(app.snapshotDir, store.DefaultSnapshotChunkSize)
+		if err != nil {
+			app.Logger.Error("Failed to snapshot the stores", "height", commitID.Version, "err", err)
+		} else {
+			go func(version int64) {
+				err := export()
+				if err != nil {
+					app.Logger.Error("Failed to snapshot the stores", "height", version, "err", err)
+					return
+				}
+				app.Logger.Info("Snapshotted the stores", "height", version, "dir", app.snapshotDir)
+			}(commitID.Version)
+		}
+	}
+
 	// Reset the Check state to the latest committed
 	// NOTE: safe because Tendermint holds a lock on the mempool for Commit.
 	// Use the header from this latest block.
@@ -408,7 +408,7 @@ func TestSimulateTx(t *testing.T) {
 		var res sdk.Result
 		app.cdc.MustUnmarshalBinary(queryResult.Value, &res)
 		require.Equal(t, sdk.WRSPCodeOK, res.Code, res.Log)
-		require.Equal(t, int64(160), res.GasUsed, res.Log)
+		require.Equal(t, int64(80), res.GasUsed, res.Log)
 		app.EndBlock(wrsp.RequestEndBlock{})
 		app.Commit()
 	}
@@ -466,6 +466,263 @@ func TestTxGasLimits(t *testing.T) {
 	app.Commit()
 }
 
+// Test that the stores accessed through the context charge the gas costs of the app.
+func TestGasConfig(t *testing.T) {
+	app := newBaseApp(t.Name())
+	app.SetGasConfig(sdk.GasConfig{ReadCostFlat: 1, WriteCostFlat: 2, IterNextCostFlat: 3})
+
+	// make a cap key and mount the store
+	capKey := sdk.NewKVStoreKey("main")
+	app.MountStoresIAVL(capKey)
+	err := app.LoadLatestVersion(capKey) // needed to make stores non-nil
+	require.Nil(t, err)
+
+	app.SetAnteHandler(func(ctx sdk.Context, tx sdk.Tx) (newCtx sdk.Context, res sdk.Result, abort bool) { return })
+	app.Router().AddRoute(msgType, func(ctx sdk.Context, msg sdk.Msg) sdk.Result {
+		store := ctx.KVStore(capKey)
+		store.Set([]byte("key1"), []byte("value"))
+		store.Set([]byte("key2"), []byte("value"))
+		store.Get([]byte("key1"))
+		iter := sdk.KVStorePrefixIterator(store, []byte("key"))
+		for ; iter.Valid(); iter.Next() {
+		}
+		iter.Close()
+		return sdk.Result{}
+	})
+
+	app.InitChain(wrsp.RequestInitChain{})
+
+	tx := testUpdatePowerTx{} // doesn't matter
+	header := wrsp.Header{AppHash: []byte("apphash")}
+
+	app.BeginBlock(wrsp.RequestBeginBlock{Header: header})
+	res := app.Deliver(tx)
+	require.True(t, res.IsOK(), fmt.Sprintf("%v", res))
+	require.Equal(t, int64(2*2+1+2*3), res.GasUsed)
+	app.EndBlock(wrsp.RequestEndBlock{})
+	app.Commit()
+}
+
+// Test that the txs of a block cannot consume more than the max block gas.
+func TestMaxBlockGasLimits(t *testing.T) {
+	logger := defaultLogger()
+	db := dbm.NewMemDB()
+	app := NewBaseApp(t.Name(), nil, logger, db)
+
+	// make a cap key and mount the store
+	capKey := sdk.NewKVStoreKey("main")
+	app.MountStoresIAVL(capKey)
+	err := app.LoadLatestVersion(capKey) // needed to make stores non-nil
+	require.Nil(t, err)
+
+	app.SetAnteHandler(func(ctx sdk.Context, tx sdk.Tx) (newCtx sdk.Context, res sdk.Result, abort bool) {
+		newCtx = ctx.WithGasMeter(sdk.NewGasMeter(1000))
+		return
+	})
+	counterKey := []byte("counter")
+	app.Router().AddRoute(msgType, func(ctx sdk.Context, msg sdk.Msg) sdk.Result {
+		ctx.GasMeter().ConsumeGas(30, "counter")
+		store := ctx.KVStore(capKey)
+		store.Set(counterKey, append(store.Get(counterKey), 1))
+		return sdk.Result{}
+	})
+
+	app.InitChain(wrsp.RequestInitChain{
+		ConsensusParams: &wrsp.ConsensusParams{
+			BlockSize: &wrsp.BlockSize{MaxGas: 100},
+		},
+	})
+
+	tx := testUpdatePowerTx{} // doesn't matter
+	header := wrsp.Header{AppHash: []byte("apphash")}
+
+	// three txs fit in the block, the fourth overflows it and is not executed
+	header.Height = 1
+	app.BeginBlock(wrsp.RequestBeginBlock{Header: header})
+	for i := 0; i < 3; i++ {
+		res := app.Deliver(tx)
+		require.True(t, res.IsOK(), fmt.Sprintf("%v", res))
+	}
+	res := app.Deliver(tx)
+	require.Equal(t, sdk.ToWRSPCode(sdk.CodespaceRoot, sdk.CodeOutOfGas), res.Code)
+	require.Equal(t, int64(100), app.deliverState.ctx.BlockGasMeter().GasConsumed())
+	require.Equal(t, []byte{1, 1, 1}, app.deliverState.ctx.KVStore(capKey).Get(counterKey))
+
+	// no gas is left for other txs
+	res = app.Deliver(tx)
+	require.Equal(t, sdk.ToWRSPCode(sdk.CodespaceRoot, sdk.CodeOutOfGas), res.Code)
+	app.EndBlock(wrsp.RequestEndBlock{})
+	app.Commit()
+
+	// the block gas meter is reset in the next block
+	header.Height = 2
+	app.BeginBlock(wrsp.RequestBeginBlock{Header: header})
+	res = app.Deliver(tx)
+	require.True(t, res.IsOK(), fmt.Sprintf("%v", res))
+	require.Equal(t, int64(30), app.deliverState.ctx.BlockGasMeter().GasConsumed())
+	app.EndBlock(wrsp.RequestEndBlock{})
+	app.Commit()
+
+	// txs wanting more gas than the block limit are refused by CheckTx
+	res = app.Check(auth.NewStdTx([]sdk.Msg{tx}, auth.NewStdFee(200), nil, ""))
+	require.Equal(t, sdk.ToWRSPCode(sdk.CodespaceRoot, sdk.CodeOutOfGas), res.Code)
+	res = app.Check(auth.NewStdTx([]sdk.Msg{tx}, auth.NewStdFee(100), nil, ""))
+	require.True(t, res.IsOK(), fmt.Sprintf("%v", res))
+
+	// the limit is kept on restart
+	app = NewBaseApp(t.Name(), nil, logger, db)
+	app.MountStoresIAVL(capKey)
+	err = app.LoadLatestVersion(capKey)
+	require.Nil(t, err)
+	require.Equal(t, int64(100), app.maxBlockGas())
+}
+
+// Test that the fee refund handler runs after the msgs with the gas the tx used,
+// and that its changes are kept even if the msgs fail.
+func TestFeeRefundHandler(t *testing.T) {
+	app := newBaseApp(t.Name())
+
+	// make a cap key and mount the store
+	capKey := sdk.NewKVStoreKey("main")
+	app.MountStoresIAVL(capKey)
+	err := app.LoadLatestVersion(capKey) // needed to make stores non-nil
+	require.Nil(t, err)
+
+	app.SetAnteHandler(func(ctx sdk.Context, tx sdk.Tx) (newCtx sdk.Context, res sdk.Result, abort bool) {
+		newCtx = ctx.WithGasMeter(sdk.NewGasMeter(100))
+		newCtx.GasMeter().ConsumeGas(10, "ante")
+		return
+	})
+	fail := false
+	app.Router().AddRoute(msgType, func(ctx sdk.Context, msg sdk.Msg) sdk.Result {
+		ctx.GasMeter().ConsumeGas(20, "handler")
+		if fail {
+			return sdk.ErrUnauthorized("fail").Result()
+		}
+		return sdk.Result{}
+	})
+	refundKey := []byte("refund")
+	var refunds []sdk.Gas
+	app.SetFeeRefundHandler(func(ctx sdk.Context, tx sdk.Tx, gasUsed sdk.Gas) {
+		refunds = append(refunds, gasUsed)
+		ctx.KVStore(capKey).Set(refundKey, []byte{byte(len(refunds))})
+	})
+
+	app.InitChain(wrsp.RequestInitChain{})
+
+	tx := testUpdatePowerTx{} // doesn't matter
+	header := wrsp.Header{AppHash: []byte("apphash")}
+
+	app.BeginBlock(wrsp.RequestBeginBlock{Header: header})
+	res := app.Deliver(tx)
+	require.True(t, res.IsOK(), fmt.Sprintf("%v", res))
+	fail = true
+	res = app.Deliver(tx)
+	require.Equal(t, sdk.ToWRSPCode(sdk.CodespaceRoot, sdk.CodeUnauthorized), res.Code)
+
+	require.Equal(t, []sdk.Gas{30, 30}, refunds)
+	require.Equal(t, []byte{2}, app.deliverState.ctx.KVStore(capKey).Get(refundKey))
+	app.EndBlock(wrsp.RequestEndBlock{})
+	app.Commit()
+
+	// simulations leave the check state untouched
+	fail = false
+	res = app.Simulate(tx)
+	require.True(t, res.IsOK(), fmt.Sprintf("%v", res))
+	require.Equal(t, []sdk.Gas{30, 30, 30}, refunds)
+	require.Equal(t, []byte{2}, app.checkState.ctx.KVStore(capKey).Get(refundKey))
+}
+
+// Test that the post handler runs after each message and can amend its result.
+func TestPostHandler(t *testing.T) {
+	app := newBaseApp(t.Name())
+
+	// make a cap key and mount the store
+	capKey := sdk.NewKVStoreKey("main")
+	app.MountStoresIAVL(capKey)
+	err := app.LoadLatestVersion(capKey) // needed to make stores non-nil
+	require.Nil(t, err)
+
+	app.SetAnteHandler(func(ctx sdk.Context, tx sdk.Tx) (newCtx sdk.Context, res sdk.Result, abort bool) { return })
+	app.Router().AddRoute(msgType, func(ctx sdk.Context, msg sdk.Msg) sdk.Result {
+		return sdk.Result{Data: []byte("handler")}
+	})
+
+	var postHandled int
+	app.SetPostHandler(sdk.ChainPostHandlers(
+		func(ctx sdk.Context, msg sdk.Msg, result sdk.Result) sdk.Result {
+			postHandled++
+			require.Equal(t, []byte("handler"), result.Data)
+			return result
+		},
+		func(ctx sdk.Context, msg sdk.Msg, result sdk.Result) sdk.Result {
+			return sdk.ErrUnauthorized("post handler").Result()
+		},
+	))
+
+	tx := testUpdatePowerTx{} // doesn't matter
+	header := wrsp.Header{AppHash: []byte("apphash")}
+
+	app.BeginBlock(wrsp.RequestBeginBlock{Header: header})
+	res := app.Deliver(tx)
+	require.Equal(t, 1, postHandled)
+	require.Equal(t, sdk.ToWRSPCode(sdk.CodespaceRoot, sdk.CodeUnauthorized), res.Code)
+	app.EndBlock(wrsp.RequestEndBlock{})
+	app.Commit()
+}
+
+// Test that the events emitted by the handlers and blockers are indexed as tags,
+// and that the events of a failed msg are dropped.
+func TestEmitEvents(t *testing.T) {
+	app := newBaseApp(t.Name())
+
+	// make a cap key and mount the store
+	capKey := sdk.NewKVStoreKey("main")
+	app.MountStoresIAVL(capKey)
+	err := app.LoadLatestVersion(capKey) // needed to make stores non-nil
+	require.Nil(t, err)
+
+	app.SetAnteHandler(func(ctx sdk.Context, tx sdk.Tx) (newCtx sdk.Context, res sdk.Result, abort bool) { return })
+	fail := false
+	app.Router().AddRoute(msgType, func(ctx sdk.Context, msg sdk.Msg) sdk.Result {
+		ctx.EventManager().EmitEvent(sdk.NewEvent("test", sdk.NewAttribute("key", "value")))
+		if fail {
+			return sdk.ErrUnauthorized("failed").Result()
+		}
+		return sdk.Result{}
+	})
+	app.SetBeginBlocker(func(ctx sdk.Context, req wrsp.RequestBeginBlock) wrsp.ResponseBeginBlock {
+		ctx.EventManager().EmitEvent(sdk.NewEvent("begin", sdk.NewAttribute("height", "1")))
+		return wrsp.ResponseBeginBlock{}
+	})
+	app.SetEndBlocker(func(ctx sdk.Context, req wrsp.RequestEndBlock) wrsp.ResponseEndBlock {
+		ctx.EventManager().EmitEvent(sdk.NewEvent("end", sdk.NewAttribute("height", "1")))
+		return wrsp.ResponseEndBlock{}
+	})
+
+	tx := testUpdatePowerTx{} // doesn't matter
+	header := wrsp.Header{AppHash: []byte("apphash")}
+
+	beginRes := app.BeginBlock(wrsp.RequestBeginBlock{Header: header})
+	require.Equal(t, sdk.Tags{sdk.MakeTag("begin.height", []byte("1"))}, sdk.Tags(beginRes.Tags))
+
+	res := app.Deliver(tx)
+	require.True(t, res.IsOK(), fmt.Sprintf("%v", res))
+	require.Equal(t, sdk.Tags{sdk.MakeTag("test.key", []byte("value"))}, res.Tags)
+	msgResults, err := sdk.ParseMsgResults(res.Log)
+	require.Nil(t, err)
+	require.Equal(t, sdk.Events{sdk.NewEvent("test", sdk.NewAttribute("key", "value"))}, msgResults[0].Events)
+
+	fail = true
+	res = app.Deliver(tx)
+	require.False(t, res.IsOK())
+	require.Empty(t, res.Tags)
+
+	endRes := app.EndBlock(wrsp.RequestEndBlock{})
+	require.Equal(t, sdk.Tags{sdk.MakeTag("end.height", []byte("1"))}, sdk.Tags(endRes.Tags))
+	app.Commit()
+}
+
 // Test that we can only query from the latest committed state.
 func TestQuery(t *testing.T) {
 	app := newBaseApp(t.Name())
@@ -513,6 +770,106 @@ func TestQuery(t *testing.T) {
 	require.Equal(t, value, res.Value)
 }
 
+// Test custom queries are routed to the registered module querier
+func TestCustomQuery(t *testing.T) {
+	app := newBaseApp(t.Name())
+
+	capKey := sdk.NewKVStoreKey("main")
+	app.MountStoresIAVL(capKey)
+	err := app.LoadLatestVersion(capKey) // needed to make stores non-nil
+	require.Nil(t, err)
+
+	app.InitChain(wrsp.RequestInitChain{})
+	app.Commit()
+
+	app.QueryRouter().AddRoute("test", func(ctx sdk.Context, path []string, req wrsp.RequestQuery) ([]byte, sdk.Error) {
+		if len(path) != 1 || path[0] != "echo" {
+			return nil, sdk.ErrUnknownRequest("unknown test query")
+		}
+		return req.Data, nil
+	})
+
+	res := app.Query(wrsp.RequestQuery{Path: "/custom/test/echo", Data: []byte("hello")})
+	require.Equal(t, uint32(sdk.WRSPCodeOK), res.Code)
+	require.Equal(t, []byte("hello"), res.Value)
+
+	res = app.Query(wrsp.RequestQuery{Path: "/custom/test/unknown"})
+	require.NotEqual(t, uint32(sdk.WRSPCodeOK), res.Code)
+
+	res = app.Query(wrsp.RequestQuery{Path: "/custom/nonexistent/echo"})
+	require.NotEqual(t, uint32(sdk.WRSPCodeOK), res.Code)
+}
+
+func TestCustomQueryHeight(t *testing.T) {
+	app := newBaseApp(t.Name())
+
+	capKey := sdk.NewKVStoreKey("main")
+	app.MountStoresIAVL(capKey)
+	err := app.LoadLatestVersion(capKey) // needed to make stores non-nil
+	require.Nil(t, err)
+
+	// the querier reads the value of key in the main store
+	key := []byte("key")
+	app.QueryRouter().AddRoute("test", func(ctx sdk.Context, path []string, req wrsp.RequestQuery) ([]byte, sdk.Error) {
+		require.Equal(t, ctx.BlockHeight(), req.Height)
+		return ctx.KVStore(capKey).Get(key), nil
+	})
+
+	app.InitChain(wrsp.RequestInitChain{})
+	for height := int64(1); height <= 3; height++ {
+		app.BeginBlock(wrsp.RequestBeginBlock{Header: wrsp.Header{Height: height}})
+		app.deliverState.ctx.KVStore(capKey).Set(key, []byte{byte(height)})
+		app.EndBlock(wrsp.RequestEndBlock{})
+		app.Commit()
+	}
+
+	// each height sees the value written in its block
+	for height := int64(1); height <= 3; height++ {
+		res := app.Query(wrsp.RequestQuery{Path: "/custom/test", Height: height})
+		require.Equal(t, uint32(sdk.WRSPCodeOK), res.Code, res.Log)
+		require.Equal(t, height, res.Height)
+		require.Equal(t, []byte{byte(height)}, res.Value)
+	}
+
+	// heights which were not committed yet cannot be queried
+	res := app.Query(wrsp.RequestQuery{Path: "/custom/test", Height: 4})
+	require.Equal(t, sdk.ToWRSPCode(sdk.CodespaceRoot, sdk.CodeUnknownRequest), sdk.WRSPCodeType(res.Code))
+}
+
+// Test that custom queries are served before the first block after a restart.
+func TestCustomQueryAfterRestart(t *testing.T) {
+	logger := defaultLogger()
+	db := dbm.NewMemDB()
+	name := t.Name()
+	app := NewBaseApp(name, nil, logger, db)
+
+	capKey := sdk.NewKVStoreKey("main")
+	app.MountStoresIAVL(capKey)
+	err := app.LoadLatestVersion(capKey)
+	require.Nil(t, err)
+
+	app.InitChain(wrsp.RequestInitChain{})
+	app.BeginBlock(wrsp.RequestBeginBlock{Header: wrsp.Header{Height: 1}})
+	app.EndBlock(wrsp.RequestEndBlock{})
+	app.Commit()
+
+	// reload the app, which has no check state until its next commit
+	app = NewBaseApp(name, nil, logger, db)
+	app.MountStoresIAVL(capKey)
+	err = app.LoadLatestVersion(capKey)
+	require.Nil(t, err)
+	require.Nil(t, app.checkState)
+
+	app.QueryRouter().AddRoute("test", func(ctx sdk.Context, path []string, req wrsp.RequestQuery) ([]byte, sdk.Error) {
+		return []byte{byte(ctx.BlockHeight())}, nil
+	})
+
+	res := app.Query(wrsp.RequestQuery{Path: "/custom/test"})
+	require.Equal(t, uint32(sdk.WRSPCodeOK), res.Code, res.Log)
+	require.Equal(t, int64(1), res.Height)
+	require.Equal(t, []byte{1}, res.Value)
+}
+
 // Test p2p filter queries
 func TestP2PQuery(t *testing.T) {
 	app := newBaseApp(t.Name())
@@ -966,6 +1323,80 @@ func TestSendBurn(t *testing.T) {
 	require.Equal(t, sdk.Coins(nil), app.accountKeeper.GetCoins(app.deliverState.ctx, addr2), "Balance2 changed after invalid tx")
 }
 
+// tests that a failed msg keeps the msgs before it only when they run nested
+func TestMsgMode(t *testing.T) {
+	for _, mode := range []MsgMode{MsgModeAtomic, MsgModeNested} {
+		// Create app.
+		app := newTestApp(t.Name())
+		capKey := sdk.NewKVStoreKey("key")
+		app.MountStoresIAVL(capKey)
+		app.SetTxDecoder(func(txBytes []byte) (sdk.Tx, sdk.Error) {
+			var tx auth.StdTx
+			fromJSON(txBytes, &tx)
+			return tx, nil
+		})
+		app.SetMsgMode(mode)
+
+		err := app.LoadLatestVersion(capKey)
+		require.Nil(t, err)
+
+		app.accountMapper = auth.NewAccountMapper(app.cdc, capKey, &auth.BaseAccount{})
+		app.accountKeeper = bank.NewKeeper(app.accountMapper)
+
+		app.SetAnteHandler(auth.NewAnteHandler(app.accountMapper, auth.FeeCollectionKeeper{}))
+		burn := newHandleBurn(app.accountKeeper)
+		app.Router().AddRoute("burn", func(ctx sdk.Context, msg sdk.Msg) sdk.Result {
+			result := burn(ctx, msg)
+			if result.IsOK() {
+				result.Data = []byte("burned")
+				result.Tags = sdk.Tags{sdk.MakeTag("burn.result", []byte("burned"))}
+			} else {
+				result.Tags = sdk.Tags{sdk.MakeTag("burn.result", []byte("failed"))}
+			}
+			return result
+		})
+
+		app.InitChain(wrsp.RequestInitChain{})
+		app.BeginBlock(wrsp.RequestBeginBlock{})
+		app.deliverState.ctx = app.deliverState.ctx.WithChainID(t.Name())
+
+		priv := makePrivKey("my secret")
+		addr := priv.PubKey().Address()
+		app.accountKeeper.AddCoins(app.deliverState.ctx, addr, sdk.Coins{{"foocoin", sdk.NewInt(100)}})
+
+		// the second burn overdraws the account
+		msg1 := testBurnMsg{addr, sdk.Coins{{"foocoin", sdk.NewInt(60)}}}
+		msg2 := testBurnMsg{addr, sdk.Coins{{"foocoin", sdk.NewInt(60)}}}
+		tx := GenTx(t.Name(), []sdk.Msg{msg1, msg2}, []int64{0}, []int64{0}, priv)
+
+		res := app.Deliver(tx)
+		require.Equal(t, sdk.WRSPCodeType(0x1000a), res.Code, "Allowed tx to pass with insufficient funds")
+
+		msgResults, err := sdk.ParseMsgResults(res.Log)
+		require.Nil(t, err, res.Log)
+		require.Len(t, msgResults, 2)
+		require.True(t, msgResults[0].IsOK())
+		require.Equal(t, 1, msgResults[1].MsgIndex)
+		require.Equal(t, res.Code, msgResults[1].Code)
+		require.True(t, msgResults[0].GasUsed > 0)
+		// the tx gas also counts the ante handler
+		require.True(t, res.GasUsed > msgResults[0].GasUsed+msgResults[1].GasUsed)
+
+		// the tx only reports the results of the msgs which took effect
+		failed := sdk.MakeTag("burn.result", []byte("failed"))
+		coins := app.accountKeeper.GetCoins(app.deliverState.ctx, addr)
+		if mode == MsgModeNested {
+			require.Equal(t, sdk.Coins{{"foocoin", sdk.NewInt(40)}}, coins, "Discarded the msg before the failed one")
+			require.Equal(t, []byte("burned"), res.Data)
+			require.Equal(t, sdk.Tags{sdk.MakeTag("burn.result", []byte("burned")), failed}, res.Tags)
+		} else {
+			require.Equal(t, sdk.Coins{{"foocoin", sdk.NewInt(100)}}, coins, "Allowed valid msg to pass in invalid tx")
+			require.Empty(t, res.Data)
+			require.Equal(t, sdk.Tags{failed}, res.Tags)
+		}
+	}
+}
+
 //----------------------------------------
 
 func randPower() int64 {
@@ -0,0 +1,121 @@
+package baseapp
+
+import (
+	"io"
+	"os"
+	"sync"
+
+	sdk "github.com/tepleton/tepleton-sdk/types"
+	"github.com/tepleton/tepleton-sdk/wire"
+)
+
+// StateSink receives the state changes of each committed block.
+type StateSink interface {
+	// Called on Commit, after the stores are committed. An error is logged
+	// but does not halt the app.
+	ListenBlock(block BlockChanges) error
+}
+
+// BlockChanges are the writes to the stores during a block, in order.
+// The writes to transient stores are not included.
+type BlockChanges struct {
+	Height     int64             `json:"height"`
+	BeginBlock []sdk.StoreKVPair `json:"begin_block"` // also holds the writes of InitChain on the first block
+	Txs        []TxChanges       `json:"txs"`         // one entry per DeliverTx, failed ones included
+	EndBlock   []sdk.StoreKVPair `json:"end_block"`
+}
+
+// TxChanges are the writes to the stores during a DeliverTx.
+type TxChanges struct {
+	Changes []sdk.StoreKVPair `json:"changes"`
+}
+
+// phases of a block, for the blockListener
+const (
+	phaseBeginBlock = iota
+	phaseDeliverTx
+	phaseEndBlock
+)
+
+// blockListener collects the writes to the deliver state into BlockChanges.
+// Implements sdk.WriteListener.
+type blockListener struct {
+	phase int
+	block BlockChanges
+}
+
+var _ sdk.WriteListener = (*blockListener)(nil)
+
+// start the writes of a new DeliverTx
+func (bl *blockListener) startTx() {
+	bl.phase = phaseDeliverTx
+	bl.block.Txs = append(bl.block.Txs, TxChanges{})
+}
+
+// Implements sdk.WriteListener.
+func (bl *blockListener) OnWrite(storeKey sdk.StoreKey, key []byte, value []byte, delete bool) {
+	if _, ok := storeKey.(*sdk.TransientStoreKey); ok {
+		return
+	}
+	pair := sdk.StoreKVPair{StoreKey: storeKey.Name(), Delete: delete, Key: key, Value: value}
+	switch bl.phase {
+	case phaseBeginBlock:
+		bl.block.BeginBlock = append(bl.block.BeginBlock, pair)
+	case phaseDeliverTx:
+		last := len(bl.block.Txs) - 1
+		bl.block.Txs[last].Changes = append(bl.block.Txs[last].Changes, pair)
+	case phaseEndBlock:
+		bl.block.EndBlock = append(bl.block.EndBlock, pair)
+	}
+}
+
+//----------------------------------------
+
+// FileStateSink appends the BlockChanges of each block to a file, as
+// length-prefixed amino records. Use ReadBlockChanges to read them back.
+type FileStateSink struct {
+	mtx  sync.Mutex
+	cdc  *wire.Codec
+	file *os.File
+}
+
+var _ StateSink = (*FileStateSink)(nil)
+
+// NewFileStateSink opens the file at path for appending, creating it if needed.
+func NewFileStateSink(path string) (*FileStateSink, error) {
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0644)
+	if err != nil {
+		return nil, err
+	}
+	return &FileStateSink{cdc: wire.NewCodec(), file: file}, nil
+}
+
+// Implements StateSink.
+func (fs *FileStateSink) ListenBlock(block BlockChanges) error {
+	bz, err := fs.cdc.MarshalBinary(block)
+	if err != nil {
+		return err
+	}
+	fs.mtx.Lock()
+	defer fs.mtx.Unlock()
+	_, err = fs.file.Write(bz)
+	if err != nil {
+		return err
+	}
+	return fs.file.Sync()
+}
+
+// Close the file of the sink.
+func (fs *FileStateSink) Close() error {
+	fs.mtx.Lock()
+	defer fs.mtx.Unlock()
+	return fs.file.Close()
+}
+
+// ReadBlockChanges reads the next record written by a FileStateSink.
+// It returns io.EOF when there are no more records.
+func ReadBlockChanges(r io.Reader) (BlockChanges, error) {
+	var block BlockChanges
+	_, err := wire.NewCodec().UnmarshalBinaryReader(r, &block, 0)
+	return block, err
+}
@@ -0,0 +1,124 @@
+package baseapp
+
+import (
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	wrsp "github.com/tepleton/tepleton/wrsp/types"
+
+	sdk "github.com/tepleton/tepleton-sdk/types"
+)
+
+// keeps the blocks it is sent
+type recordSink struct {
+	blocks []BlockChanges
+}
+
+func (rs *recordSink) ListenBlock(block BlockChanges) error {
+	rs.blocks = append(rs.blocks, block)
+	return nil
+}
+
+func TestStateSink(t *testing.T) {
+	app := newBaseApp(t.Name())
+	capKey := sdk.NewKVStoreKey("main")
+	tkey := sdk.NewTransientStoreKey("transient")
+	app.MountStoresIAVL(capKey)
+	app.MountStoresTransient(tkey)
+	sink := &recordSink{}
+	app.SetStateSink(sink)
+
+	app.SetTxDecoder(func(txBytes []byte) (sdk.Tx, sdk.Error) {
+		return testTx{int64(int8(txBytes[0]))}, nil
+	})
+	app.SetInitChainer(func(ctx sdk.Context, req wrsp.RequestInitChain) wrsp.ResponseInitChain {
+		ctx.KVStore(capKey).Set([]byte("init"), []byte("1"))
+		return wrsp.ResponseInitChain{}
+	})
+	app.SetBeginBlocker(func(ctx sdk.Context, req wrsp.RequestBeginBlock) wrsp.ResponseBeginBlock {
+		ctx.KVStore(capKey).Set([]byte("begin"), []byte{byte(req.Header.Height)})
+		return wrsp.ResponseBeginBlock{}
+	})
+	app.SetEndBlocker(func(ctx sdk.Context, req wrsp.RequestEndBlock) wrsp.ResponseEndBlock {
+		ctx.KVStore(capKey).Delete([]byte("init"))
+		return wrsp.ResponseEndBlock{}
+	})
+	app.SetAnteHandler(func(ctx sdk.Context, tx sdk.Tx) (newCtx sdk.Context, res sdk.Result, abort bool) { return })
+	app.Router().AddRoute(msgType2, func(ctx sdk.Context, msg sdk.Msg) sdk.Result {
+		n := byte(msg.(testTx).positiveNum)
+		ctx.KVStore(tkey).Set([]byte("tx"), []byte{n})
+		ctx.KVStore(capKey).Set([]byte("tx"), []byte{n})
+		return sdk.Result{}
+	})
+	require.Nil(t, app.LoadLatestVersion(capKey))
+
+	app.InitChain(wrsp.RequestInitChain{})
+	app.BeginBlock(wrsp.RequestBeginBlock{Header: wrsp.Header{Height: 1}})
+	require.True(t, app.DeliverTx([]byte{7}).IsOK())
+	require.False(t, app.DeliverTx([]byte{0xff}).IsOK()) // fails ValidateBasic
+	app.EndBlock(wrsp.RequestEndBlock{})
+	app.Commit()
+
+	mainPair := func(key, value []byte) sdk.StoreKVPair {
+		return sdk.StoreKVPair{StoreKey: "main", Delete: value == nil, Key: key, Value: value}
+	}
+	require.Equal(t, []BlockChanges{{
+		Height:     1,
+		BeginBlock: []sdk.StoreKVPair{mainPair([]byte("init"), []byte("1")), mainPair([]byte("begin"), []byte{1})},
+		Txs:        []TxChanges{{Changes: []sdk.StoreKVPair{mainPair([]byte("tx"), []byte{7})}}, {}},
+		EndBlock:   []sdk.StoreKVPair{mainPair([]byte("init"), nil)},
+	}}, sink.blocks)
+
+	// the next block starts empty
+	app.BeginBlock(wrsp.RequestBeginBlock{Header: wrsp.Header{Height: 2}})
+	app.Commit()
+	require.Len(t, sink.blocks, 2)
+	require.Equal(t, BlockChanges{
+		Height:     2,
+		BeginBlock: []sdk.StoreKVPair{mainPair([]byte("begin"), []byte{2})},
+	}, sink.blocks[1])
+}
+
+func TestFileStateSink(t *testing.T) {
+	dir, err := ioutil.TempDir("", "state_sink")
+	require.Nil(t, err)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "blocks")
+
+	blocks := []BlockChanges{{
+		Height:     1,
+		BeginBlock: []sdk.StoreKVPair{{StoreKey: "main", Key: []byte("a"), Value: []byte("1")}},
+		Txs: []TxChanges{{Changes: []sdk.StoreKVPair{
+			{StoreKey: "main", Key: []byte("b"), Value: []byte("2")},
+			{StoreKey: "acc", Delete: true, Key: []byte("c")},
+		}}},
+	}, {
+		Height:   2,
+		EndBlock: []sdk.StoreKVPair{{StoreKey: "main", Delete: true, Key: []byte("a")}},
+	}}
+
+	// blocks are appended, also after reopening the file
+	sink, err := NewFileStateSink(path)
+	require.Nil(t, err)
+	require.Nil(t, sink.ListenBlock(blocks[0]))
+	require.Nil(t, sink.Close())
+	sink, err = NewFileStateSink(path)
+	require.Nil(t, err)
+	require.Nil(t, sink.ListenBlock(blocks[1]))
+	require.Nil(t, sink.Close())
+
+	file, err := os.Open(path)
+	require.Nil(t, err)
+	defer file.Close()
+	for _, expected := range blocks {
+		block, err := ReadBlockChanges(file)
+		require.Nil(t, err)
+		require.Equal(t, expected, block)
+	}
+	_, err = ReadBlockChanges(file)
+	require.Equal(t, io.EOF, err)
+}
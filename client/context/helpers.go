@@ -2,15 +2,19 @@ package context
 
 import (
 	"fmt"
+	"math"
+	"strings"
 
 	"github.com/tepleton/tmlibs/common"
 
 	"github.com/pkg/errors"
 
+	"github.com/tepleton/tepleton-sdk/store"
 	"github.com/tepleton/tepleton-sdk/wire"
 	"github.com/tepleton/tepleton-sdk/x/auth"
 	rpcclient "github.com/tepleton/tepleton/rpc/client"
 	ctypes "github.com/tepleton/tepleton/rpc/core/types"
+	wrsp "github.com/tepleton/tepleton/wrsp/types"
 	cmn "github.com/tepleton/tmlibs/common"
 
 	"github.com/tepleton/tepleton-sdk/client"
@@ -65,11 +69,41 @@ func (ctx CoreContext) Query(path string) (res []byte, err error) {
 	return ctx.query(path, nil)
 }
 
+// QueryWithData queries information about the connected node with the
+// provided path and data, e.g. a module's custom querier
+func (ctx CoreContext) QueryWithData(path string, data []byte) (res []byte, err error) {
+	return ctx.query(path, data)
+}
+
 // QueryStore from Tendermint with the provided key and storename
 func (ctx CoreContext) QueryStore(key cmn.HexBytes, storeName string) (res []byte, err error) {
 	return ctx.queryStore(key, storeName, "key")
 }
 
+// QueryStoreWithProof queries the key in the named store at ctx.Height and
+// returns the value along with the merkle proof of it against the app hash,
+// and the height the value was read at
+func (ctx CoreContext) QueryStoreWithProof(key cmn.HexBytes, storeName string) (res []byte, proof []byte, height int64, err error) {
+	node, err := ctx.GetNode()
+	if err != nil {
+		return res, proof, height, err
+	}
+
+	opts := rpcclient.WRSPQueryOptions{
+		Height:  ctx.Height,
+		Trusted: false,
+	}
+	result, err := node.WRSPQueryWithOptions(fmt.Sprintf("/store/%s/key", storeName), key, opts)
+	if err != nil {
+		return res, proof, height, err
+	}
+	resp := result.Response
+	if resp.Code != uint32(0) {
+		return res, proof, height, errors.Errorf("query failed: (%d) %s", resp.Code, resp.Log)
+	}
+	return resp.Value, resp.Proof, resp.Height, nil
+}
+
 // Query from Tendermint with the provided storename and subspace
 func (ctx CoreContext) QuerySubspace(cdc *wire.Codec, subspace []byte, storeName string) (res []sdk.KVPair, err error) {
 	resRaw, err := ctx.queryStore(subspace, storeName, "subspace")
@@ -87,8 +121,21 @@ func (ctx CoreContext) query(path string, key common.HexBytes) (res []byte, err
 		return res, err
 	}
 
+	storeName, endPath, isStore := storeQuery(path)
+	verify := !ctx.TrustNode && isStore && (endPath == "key" || endPath == "subspace")
+
+	height := ctx.Height
+	if verify && height == 0 {
+		// the latest height whose app hash is in a header already, so the
+		// proof can be checked without waiting for the next block
+		height, err = provableHeight(node)
+		if err != nil {
+			return res, err
+		}
+	}
+
 	opts := rpcclient.WRSPQueryOptions{
-		Height:  ctx.Height,
+		Height:  height,
 		Trusted: ctx.TrustNode,
 	}
 	result, err := node.WRSPQueryWithOptions(path, key, opts)
@@ -99,9 +146,73 @@ func (ctx CoreContext) query(path string, key common.HexBytes) (res []byte, err
 	if resp.Code != uint32(0) {
 		return res, errors.Errorf("query failed: (%d) %s", resp.Code, resp.Log)
 	}
+
+	// verify the values read from a store, the other queries have no proof
+	if verify {
+		err = ctx.verifyProof(node, storeName, endPath, resp)
+		if err != nil {
+			return res, err
+		}
+	}
 	return resp.Value, nil
 }
 
+// the store name and the end path of a "/store/<storeName>/<endPath>" query
+func storeQuery(path string) (storeName, endPath string, ok bool) {
+	paths := strings.Split(path, "/")
+	if len(paths) != 4 || paths[0] != "" || paths[1] != "store" {
+		return "", "", false
+	}
+	return paths[2], paths[3], true
+}
+
+// the latest height the node has the header of the next block of
+func provableHeight(node rpcclient.Client) (int64, error) {
+	status, err := node.Status()
+	if err != nil {
+		return 0, err
+	}
+	height := status.SyncInfo.LatestBlockHeight - 1
+	if height < 1 {
+		// nothing is provable before the second block, wait for it
+		return 0, nil
+	}
+	return height, nil
+}
+
+// Verify the proof of a store query against the app hash of the block at the
+// queried height, which is in the header of the next block.
+// TODO: certify the header against a trusted validator set. Until then the
+// header comes from the node which is queried, so a proof only shows that the
+// response is consistent with the chain the node claims to follow.
+func (ctx CoreContext) verifyProof(node rpcclient.Client, storeName, endPath string, resp wrsp.ResponseQuery) error {
+	if len(resp.Proof) == 0 {
+		return errors.Errorf("query of store %s returned no proof", storeName)
+	}
+	height := resp.Height + 1
+	err := rpcclient.WaitForHeight(node, height, nil)
+	if err != nil {
+		return err
+	}
+	commit, err := node.Commit(&height)
+	if err != nil {
+		return err
+	}
+	appHash := commit.Header.AppHash
+	switch {
+	case endPath == "subspace":
+		err = store.VerifyMultiStoreSubspace(resp.Proof, storeName, resp.Key, resp.Value, appHash)
+	case len(resp.Value) > 0:
+		err = store.VerifyMultiStoreProof(resp.Proof, storeName, resp.Key, resp.Value, appHash)
+	default:
+		err = store.VerifyMultiStoreAbsence(resp.Proof, storeName, resp.Key, appHash)
+	}
+	if err != nil {
+		return errors.Wrapf(err, "failed to verify the proof of store %s at height %d", storeName, resp.Height)
+	}
+	return nil
+}
+
 // Query from Tendermint with the provided storename and path
 func (ctx CoreContext) queryStore(key cmn.HexBytes, storeName, endPath string) (res []byte, err error) {
 	path := fmt.Sprintf("/store/%s/%s", storeName, endPath)
@@ -147,7 +258,7 @@ func (ctx CoreContext) SignAndBuild(name, passphrase string, msgs []sdk.Msg, cdc
 		Sequence:      sequence,
 		Msgs:          msgs,
 		Memo:          memo,
-		Fee:           auth.NewStdFee(ctx.Gas, sdk.Coin{}), // TODO run simulate to estimate gas?
+		Fee:           auth.NewStdFee(ctx.Gas, sdk.Coin{}),
 	}
 
 	keybase, err := keys.GetKeyBase()
@@ -175,6 +286,44 @@ func (ctx CoreContext) SignAndBuild(name, passphrase string, msgs []sdk.Msg, cdc
 	return cdc.MarshalBinary(tx)
 }
 
+// EstimateGas simulates the transaction of the msgs signed by name, and
+// returns the gas it consumed multiplied by ctx.GasAdjustment
+func (ctx CoreContext) EstimateGas(name, passphrase string, msgs []sdk.Msg, cdc *wire.Codec) (int64, error) {
+	// the simulation must not run out of gas
+	txBytes, err := ctx.WithGas(math.MaxInt64).SignAndBuild(name, passphrase, msgs, cdc)
+	if err != nil {
+		return 0, err
+	}
+
+	res, err := ctx.QueryWithData("/app/simulate", txBytes)
+	if err != nil {
+		return 0, err
+	}
+	var result sdk.Result
+	err = cdc.UnmarshalBinary(res, &result)
+	if err != nil {
+		return 0, err
+	}
+	if !result.IsOK() {
+		return 0, errors.Errorf("simulation failed: (%d) %s", result.Code, result.Log)
+	}
+
+	return int64(ctx.GasAdjustment * float64(result.GasUsed)), nil
+}
+
+// EnsureGas - return a copy of the context with the gas of the msgs estimated
+// by simulation if ctx.Simulate is set
+func (ctx CoreContext) EnsureGas(name, passphrase string, msgs []sdk.Msg, cdc *wire.Codec) (CoreContext, error) {
+	if !ctx.Simulate {
+		return ctx, nil
+	}
+	gas, err := ctx.EstimateGas(name, passphrase, msgs, cdc)
+	if err != nil {
+		return ctx, err
+	}
+	return ctx.WithGas(gas).WithSimulate(false), nil
+}
+
 // sign and build the transaction from the msg
 func (ctx CoreContext) ensureSignBuild(name string, msgs []sdk.Msg, cdc *wire.Codec) (tyBytes []byte, err error) {
 	ctx, err = EnsureAccountNumber(ctx)
@@ -206,6 +355,13 @@ func (ctx CoreContext) ensureSignBuild(name string, msgs []sdk.Msg, cdc *wire.Co
 			return nil, fmt.Errorf("Error fetching passphrase: %v", err)
 		}
 	}
+	if ctx.Simulate {
+		ctx, err = ctx.EnsureGas(name, passphrase, msgs, cdc)
+		if err != nil {
+			return nil, fmt.Errorf("Error estimating gas: %v", err)
+		}
+		fmt.Printf("Estimated gas: %d\n", ctx.Gas)
+	}
 	txBytes, err = ctx.SignAndBuild(name, passphrase, msgs, cdc)
 	if err != nil {
 		return nil, fmt.Errorf("Error signing transaction: %v", err)
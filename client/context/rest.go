@@ -0,0 +1,25 @@
+package context
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/pkg/errors"
+)
+
+// RestHeight is the URL parameter of REST queries for the block height to query
+const RestHeight = "height"
+
+// WithHeightFromRequest - return a copy of the context querying at the height
+// of the request URL, e.g. ?height=10, if it has one
+func (c CoreContext) WithHeightFromRequest(r *http.Request) (CoreContext, error) {
+	heightStr := r.URL.Query().Get(RestHeight)
+	if heightStr == "" {
+		return c, nil
+	}
+	height, err := strconv.ParseInt(heightStr, 10, 64)
+	if err != nil || height < 0 {
+		return c, errors.Errorf("invalid height %q, must be a non-negative integer", heightStr)
+	}
+	return c.WithHeight(height), nil
+}
@@ -11,6 +11,8 @@ type CoreContext struct {
 	ChainID         string
 	Height          int64
 	Gas             int64
+	GasAdjustment   float64
+	Simulate        bool
 	TrustNode       bool
 	NodeURI         string
 	FromAddressName string
@@ -41,6 +43,19 @@ func (c CoreContext) WithGas(gas int64) CoreContext {
 	return c
 }
 
+// WithGasAdjustment - return a copy of the context with an updated gas adjustment
+func (c CoreContext) WithGasAdjustment(gasAdjustment float64) CoreContext {
+	c.GasAdjustment = gasAdjustment
+	return c
+}
+
+// WithSimulate - return a copy of the context with an updated simulate flag,
+// estimating the gas of the txs by simulating them in EnsureGas
+func (c CoreContext) WithSimulate(simulate bool) CoreContext {
+	c.Simulate = simulate
+	return c
+}
+
 // WithTrustNode - return a copy of the context with an updated TrustNode flag
 func (c CoreContext) WithTrustNode(trustNode bool) CoreContext {
 	c.TrustNode = trustNode
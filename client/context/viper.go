@@ -27,10 +27,18 @@ func NewCoreContextFromViper() CoreContext {
 			chainID = def
 		}
 	}
+	// the gas flag is validated when parsed
+	simulate, gas, _ := client.ParseGas(viper.GetString(client.FlagGas))
+	gasAdjustment := viper.GetFloat64(client.FlagGasAdjustment)
+	if gasAdjustment <= 0 {
+		gasAdjustment = client.DefaultGasAdjustment
+	}
 	return CoreContext{
 		ChainID:         chainID,
 		Height:          viper.GetInt64(client.FlagHeight),
-		Gas:             viper.GetInt64(client.FlagGas),
+		Gas:             gas,
+		GasAdjustment:   gasAdjustment,
+		Simulate:        simulate,
 		TrustNode:       viper.GetBool(client.FlagTrustNode),
 		FromAddressName: viper.GetString(client.FlagName),
 		NodeURI:         nodeURI,
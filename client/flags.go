@@ -1,6 +1,11 @@
 package client
 
-import "github.com/spf13/cobra"
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/spf13/cobra"
+)
 
 // nolint
 const (
@@ -9,6 +14,7 @@ const (
 	FlagNode          = "node"
 	FlagHeight        = "height"
 	FlagGas           = "gas"
+	FlagGasAdjustment = "gas-adjustment"
 	FlagTrustNode     = "trust-node"
 	FlagName          = "name"
 	FlagAccountNumber = "account-number"
@@ -17,6 +23,16 @@ const (
 	FlagFee           = "fee"
 )
 
+// nolint
+const (
+	// GasFlagSimulate is the value of the gas flag estimating the gas of the
+	// tx by simulating it
+	GasFlagSimulate = "simulate"
+
+	DefaultGasLimit      = 200000
+	DefaultGasAdjustment = 1.2
+)
+
 // LineBreak can be included in a command list to provide a blank line
 // to help with readability
 var LineBreak = &cobra.Command{Run: func(*cobra.Command, []string) {}}
@@ -24,7 +40,8 @@ var LineBreak = &cobra.Command{Run: func(*cobra.Command, []string) {}}
 // GetCommands adds common flags to query commands
 func GetCommands(cmds ...*cobra.Command) []*cobra.Command {
 	for _, c := range cmds {
-		// TODO: make this default false when we support proofs
+		// TODO: make this default false when headers are certified against a
+		// trusted validator set
 		c.Flags().Bool(FlagTrustNode, true, "Don't verify proofs for responses")
 		c.Flags().Bool(FlagUseLedger, false, "Use a connected Ledger device")
 		c.Flags().String(FlagChainID, "", "Chain ID of tepleton node")
@@ -45,7 +62,48 @@ func PostCommands(cmds ...*cobra.Command) []*cobra.Command {
 		c.Flags().String(FlagChainID, "", "Chain ID of tepleton node")
 		c.Flags().String(FlagNode, "tcp://localhost:26657", "<host>:<port> to tepleton rpc interface for this chain")
 		c.Flags().Bool(FlagUseLedger, false, "Use a connected Ledger device")
-		c.Flags().Int64(FlagGas, 200000, "gas limit to set per-transaction")
+		c.Flags().Var(&gasFlagVar{gas: DefaultGasLimit}, FlagGas, fmt.Sprintf(
+			"gas limit to set per-transaction; set to %q to estimate it by simulating the transaction", GasFlagSimulate))
+		c.Flags().Float64(FlagGasAdjustment, DefaultGasAdjustment,
+			"factor the gas estimated by simulation is multiplied by, as it may be underestimated")
 	}
 	return cmds
 }
+
+// ParseGas parses the gas of a transaction, which is either a gas limit or
+// GasFlagSimulate to estimate it. An empty gas is a zero gas limit.
+func ParseGas(gas string) (simulate bool, limit int64, err error) {
+	switch gas {
+	case "":
+		return false, 0, nil
+	case GasFlagSimulate:
+		return true, 0, nil
+	}
+	limit, err = strconv.ParseInt(gas, 10, 64)
+	if err != nil {
+		return false, 0, fmt.Errorf("gas must be either an integer or %q", GasFlagSimulate)
+	}
+	return false, limit, nil
+}
+
+// gasFlagVar is the value of the gas flag, validated when it is set
+type gasFlagVar struct {
+	simulate bool
+	gas      int64
+}
+
+func (v *gasFlagVar) String() string {
+	if v.simulate {
+		return GasFlagSimulate
+	}
+	return strconv.FormatInt(v.gas, 10)
+}
+
+func (v *gasFlagVar) Set(s string) (err error) {
+	v.simulate, v.gas, err = ParseGas(s)
+	return err
+}
+
+func (v *gasFlagVar) Type() string {
+	return "gas"
+}
@@ -0,0 +1,49 @@
+package client
+
+import (
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseGas(t *testing.T) {
+	cases := []struct {
+		gas      string
+		simulate bool
+		limit    int64
+		expErr   bool
+	}{
+		{"", false, 0, false},
+		{"simulate", true, 0, false},
+		{"1000", false, 1000, false},
+		{"-1", false, -1, false},
+		{"max", false, 0, true},
+		{"1.5", false, 0, true},
+	}
+
+	for _, tc := range cases {
+		simulate, limit, err := ParseGas(tc.gas)
+		if tc.expErr {
+			require.NotNil(t, err, tc.gas)
+			continue
+		}
+		require.Nil(t, err, tc.gas)
+		require.Equal(t, tc.simulate, simulate, tc.gas)
+		require.Equal(t, tc.limit, limit, tc.gas)
+	}
+}
+
+func TestGasFlag(t *testing.T) {
+	cmd := PostCommands(&cobra.Command{Use: "test"})[0]
+	flag := cmd.Flags().Lookup(FlagGas)
+	require.Equal(t, "200000", flag.Value.String())
+
+	require.Nil(t, cmd.Flags().Set(FlagGas, "simulate"))
+	require.Equal(t, GasFlagSimulate, flag.Value.String())
+	require.Nil(t, cmd.Flags().Set(FlagGas, "5000"))
+	require.Equal(t, "5000", flag.Value.String())
+
+	// invalid gas is refused when the flag is parsed
+	require.NotNil(t, cmd.Flags().Set(FlagGas, "lots"))
+}
@@ -258,6 +258,16 @@ func TestCoinSend(t *testing.T) {
 
 	require.Equal(t, "steak", mycoins.Denom)
 	require.Equal(t, int64(1), mycoins.Amount.Int64())
+
+	// the gas of a tx can be estimated by simulating it
+	_, resultTx = doSendWithGas(t, port, seed, name, password, addr, "simulate")
+	tests.WaitForHeight(resultTx.Height+1, port)
+	require.Equal(t, uint32(0), resultTx.CheckTx.Code)
+	require.Equal(t, uint32(0), resultTx.DeliverTx.Code)
+	require.True(t, resultTx.DeliverTx.GasUsed > 0)
+
+	acc = getAccount(t, port, addr)
+	require.Equal(t, initialBalance[0].Amount.SubRaw(2), acc.GetCoins()[0].Amount)
 }
 
 func TestIBCTransfer(t *testing.T) {
@@ -269,24 +279,14 @@ func TestIBCTransfer(t *testing.T) {
 	acc := getAccount(t, port, addr)
 	initialBalance := acc.GetCoins()
 
-	// create TX
-	resultTx := doIBCTransfer(t, port, seed, name, password, addr)
-
-	tests.WaitForHeight(resultTx.Height+1, port)
-
-	// check if tx was committed
-	require.Equal(t, uint32(0), resultTx.CheckTx.Code)
-	require.Equal(t, uint32(0), resultTx.DeliverTx.Code)
+	// coins can only be sent over an open channel, and this chain has none
+	res, body := doIBCTransfer(t, port, seed, name, password, addr)
+	require.Equal(t, http.StatusInternalServerError, res.StatusCode, body)
+	require.Contains(t, body, "unknown IBC channel")
 
 	// query sender
 	acc = getAccount(t, port, addr)
-	coins := acc.GetCoins()
-	mycoins := coins[0]
-
-	require.Equal(t, "steak", mycoins.Denom)
-	require.Equal(t, initialBalance[0].Amount.SubRaw(1), mycoins.Amount)
-
-	// TODO: query ibc egress packet state
+	require.Equal(t, initialBalance, acc.GetCoins())
 }
 
 func TestTxs(t *testing.T) {
@@ -300,7 +300,7 @@ func TestTxs(t *testing.T) {
 	require.Equal(t, http.StatusBadRequest, res.StatusCode, body)
 
 	// query empty
-	res, body = Request(t, port, "GET", fmt.Sprintf("/txs?tag=sender_bech32='%s'", "tepletonaccaddr1jawd35d9aq4u76sr3fjalmcqc8hqygs9gtnmv3"), nil)
+	res, body = Request(t, port, "GET", fmt.Sprintf("/txs?event=message.sender_bech32='%s'", "tepletonaccaddr1jawd35d9aq4u76sr3fjalmcqc8hqygs9gtnmv3"), nil)
 	require.Equal(t, http.StatusOK, res.StatusCode, body)
 	require.Equal(t, "[]", body)
 
@@ -337,7 +337,7 @@ func TestTxs(t *testing.T) {
 	// query sender
 	// also tests url decoding
 	addrBech := sdk.MustBech32ifyAcc(addr)
-	res, body = Request(t, port, "GET", "/txs?tag=sender_bech32=%27"+addrBech+"%27", nil)
+	res, body = Request(t, port, "GET", "/txs?event=message.sender_bech32=%27"+addrBech+"%27", nil)
 	require.Equal(t, http.StatusOK, res.StatusCode, body)
 
 	err = cdc.UnmarshalJSON([]byte(body), &indexedTxs)
@@ -347,13 +347,25 @@ func TestTxs(t *testing.T) {
 
 	// query recipient
 	receiveAddrBech := sdk.MustBech32ifyAcc(receiveAddr)
-	res, body = Request(t, port, "GET", fmt.Sprintf("/txs?tag=recipient_bech32='%s'", receiveAddrBech), nil)
+	res, body = Request(t, port, "GET", fmt.Sprintf("/txs?event=transfer.recipient_bech32='%s'", receiveAddrBech), nil)
 	require.Equal(t, http.StatusOK, res.StatusCode, body)
 
 	err = cdc.UnmarshalJSON([]byte(body), &indexedTxs)
 	require.NoError(t, err)
 	require.Equal(t, 1, len(indexedTxs))
 	require.Equal(t, resultTx.Height, indexedTxs[0].Height)
+
+	// query the action and the sender together
+	res, body = Request(t, port, "GET", fmt.Sprintf("/txs?event=message.action=send&event=message.sender_bech32='%s'", addrBech), nil)
+	require.Equal(t, http.StatusOK, res.StatusCode, body)
+
+	err = cdc.UnmarshalJSON([]byte(body), &indexedTxs)
+	require.NoError(t, err)
+	require.Equal(t, 1, len(indexedTxs))
+
+	// events must have a type and an attribute
+	res, body = Request(t, port, "GET", "/txs?event=action=send", nil)
+	require.Equal(t, http.StatusBadRequest, res.StatusCode, body)
 }
 
 func TestValidatorsQuery(t *testing.T) {
@@ -402,7 +414,7 @@ func TestBonding(t *testing.T) {
 
 	// query validator
 	bond := getDelegation(t, port, addr, validator1Owner)
-	require.Equal(t, "60/1", bond.Shares.String())
+	require.Equal(t, "60.000000000000000000", bond.Shares.String())
 
 	//////////////////////
 	// testing unbonding
@@ -413,7 +425,7 @@ func TestBonding(t *testing.T) {
 
 	// query validator
 	bond = getDelegation(t, port, addr, validator1Owner)
-	require.Equal(t, "30/1", bond.Shares.String())
+	require.Equal(t, "30.000000000000000000", bond.Shares.String())
 
 	// check if tx was committed
 	require.Equal(t, uint32(0), resultTx.CheckTx.Code)
@@ -620,6 +632,10 @@ func getAccount(t *testing.T, port string, addr sdk.Address) auth.Account {
 }
 
 func doSend(t *testing.T, port, seed, name, password string, addr sdk.Address) (receiveAddr sdk.Address, resultTx ctypes.ResultBroadcastTxCommit) {
+	return doSendWithGas(t, port, seed, name, password, addr, "10000")
+}
+
+func doSendWithGas(t *testing.T, port, seed, name, password string, addr sdk.Address, gas string) (receiveAddr sdk.Address, resultTx ctypes.ResultBroadcastTxCommit) {
 
 	// create receive address
 	kb := client.MockKeyBase()
@@ -644,10 +660,10 @@ func doSend(t *testing.T, port, seed, name, password string, addr sdk.Address) (
 		"password":"%s",
 		"account_number":"%d",
 		"sequence":"%d",
-		"gas": "10000",
+		"gas": "%s",
 		"amount":[%s],
 		"chain_id":"%s"
-	}`, name, password, accnum, sequence, coinbz, chainID))
+	}`, name, password, accnum, sequence, gas, coinbz, chainID))
 	res, body := Request(t, port, "POST", "/accounts/"+receiveAddrBech+"/send", jsonStr)
 	require.Equal(t, http.StatusOK, res.StatusCode, body)
 
@@ -657,7 +673,7 @@ func doSend(t *testing.T, port, seed, name, password string, addr sdk.Address) (
 	return receiveAddr, resultTx
 }
 
-func doIBCTransfer(t *testing.T, port, seed, name, password string, addr sdk.Address) (resultTx ctypes.ResultBroadcastTxCommit) {
+func doIBCTransfer(t *testing.T, port, seed, name, password string, addr sdk.Address) (*http.Response, string) {
 	// create receive address
 	kb := client.MockKeyBase()
 	receiveInfo, _, err := kb.CreateMnemonic("receive_address", cryptoKeys.English, "1234567890", cryptoKeys.SigningAlgo("secp256k1"))
@@ -665,8 +681,6 @@ func doIBCTransfer(t *testing.T, port, seed, name, password string, addr sdk.Add
 	receiveAddr := receiveInfo.GetPubKey().Address()
 	receiveAddrBech := sdk.MustBech32ifyAcc(receiveAddr)
 
-	chainID := viper.GetString(client.FlagChainID)
-
 	// get the account to get the sequence
 	acc := getAccount(t, port, addr)
 	accnum := acc.GetAccountNumber()
@@ -679,21 +693,15 @@ func doIBCTransfer(t *testing.T, port, seed, name, password string, addr sdk.Add
 		"account_number":"%d",
 		"sequence": "%d",
 		"gas": "100000",
-		"chain_id": "%s",
+		"src_channel": "channel-0",
 		"amount":[
 			{
 				"denom": "%s",
 				"amount": "1"
 			}
 		]
-	}`, name, password, accnum, sequence, chainID, "steak"))
-	res, body := Request(t, port, "POST", "/ibc/testchain/"+receiveAddrBech+"/send", jsonStr)
-	require.Equal(t, http.StatusOK, res.StatusCode, body)
-
-	err = cdc.UnmarshalJSON([]byte(body), &resultTx)
-	require.Nil(t, err)
-
-	return resultTx
+	}`, name, password, accnum, sequence, "steak"))
+	return Request(t, port, "POST", "/ibc/testchain/"+receiveAddrBech+"/send", jsonStr)
 }
 
 func getSigningInfo(t *testing.T, port string, validatorAddr sdk.Address) slashing.ValidatorSigningInfo {
@@ -62,6 +62,9 @@ func ServeCommand(cdc *wire.Codec) *cobra.Command {
 	cmd.Flags().StringP(client.FlagChainID, "c", "", "ID of chain we connect to")
 	cmd.Flags().StringP(client.FlagNode, "n", "tcp://localhost:26657", "Node to connect to")
 	cmd.Flags().IntP(flagMaxOpenConnections, "o", 1000, "Maximum open connections")
+	// TODO: make this default false when headers are certified against a
+	// trusted validator set
+	cmd.Flags().Bool(client.FlagTrustNode, true, "Don't verify proofs for responses")
 	return cmd
 }
 
@@ -87,6 +87,10 @@ func formatTxResult(cdc *wire.Codec, res *ctypes.ResultTx) (txInfo, error) {
 		Tx:     tx,
 		Result: res.TxResult,
 	}
+	// txs run before the msg results were logged have a plain text log
+	if msgResults, err := sdk.ParseMsgResults(res.TxResult.Log); err == nil {
+		info.MsgResults = msgResults
+	}
 	return info, nil
 }
 
@@ -96,6 +100,8 @@ type txInfo struct {
 	Height int64                  `json:"height"`
 	Tx     sdk.Tx                 `json:"tx"`
 	Result wrsp.ResponseDeliverTx `json:"result"`
+	// results of the msgs of the tx, decoded from the log of its result
+	MsgResults sdk.MsgResults `json:"msg_results,omitempty"`
 }
 
 func parseTx(cdc *wire.Codec, txBytes []byte) (sdk.Tx, error) {
@@ -19,17 +19,22 @@ import (
 )
 
 const (
-	flagTags = "tag"
-	flagAny  = "any"
+	flagTags   = "tag"
+	flagEvents = "event"
+	flagAny    = "any"
 )
 
 // default client command to search through tagged transactions
 func SearchTxCmd(cdc *wire.Codec) *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "txs",
-		Short: "Search for all transactions that match the given tags",
+		Short: "Search for all transactions that match the given tags or events",
 		RunE: func(cmd *cobra.Command, args []string) error {
-			tags := viper.GetStringSlice(flagTags)
+			eventTags, err := eventsToTags(viper.GetStringSlice(flagEvents))
+			if err != nil {
+				return err
+			}
+			tags := append(viper.GetStringSlice(flagTags), eventTags...)
 
 			txs, err := searchTxs(context.NewCoreContextFromViper(), cdc, tags)
 			if err != nil {
@@ -49,6 +54,7 @@ func SearchTxCmd(cdc *wire.Codec) *cobra.Command {
 	// TODO: change this to false once proofs built in
 	cmd.Flags().Bool(client.FlagTrustNode, true, "Don't verify proofs for responses")
 	cmd.Flags().StringSlice(flagTags, nil, "Tags that must match (may provide multiple)")
+	cmd.Flags().StringSlice(flagEvents, nil, "Events that must match, as <type>.<attribute>=<value>, e.g. message.action=delegate (may provide multiple)")
 	cmd.Flags().Bool(flagAny, false, "Return transactions that match ANY tag, rather than ALL")
 	return cmd
 }
@@ -82,6 +88,43 @@ func searchTxs(ctx context.CoreContext, cdc *wire.Codec, tags []string) ([]txInf
 	return info, nil
 }
 
+// eventsToTags turns the events to search for, e.g. "transfer.recipient=<address>",
+// into query conditions on the tags the events are indexed as.
+// Postfix the attribute with _bech32 to search bech32-encoded addresses.
+func eventsToTags(events []string) ([]string, error) {
+	tags := make([]string, 0, len(events))
+	for _, event := range events {
+		keyValue := strings.SplitN(event, "=", 2)
+		if len(keyValue) != 2 {
+			return nil, fmt.Errorf("event %s must be a <type>.<attribute>=<value> pair", event)
+		}
+		if _, _, ok := sdk.SplitEventKey(keyValue[0]); !ok {
+			return nil, fmt.Errorf("event key %s must be <type>.<attribute>", keyValue[0])
+		}
+		tag, err := queryTag(keyValue[0], strings.Trim(keyValue[1], "'"))
+		if err != nil {
+			return nil, err
+		}
+		tags = append(tags, tag)
+	}
+	return tags, nil
+}
+
+// queryTag returns the query condition on a tag, decoding the bech32 value
+// of keys postfixed with _bech32 to the address the tag holds
+func queryTag(key, value string) (string, error) {
+	if strings.HasSuffix(key, "_bech32") {
+		prefix := strings.Split(value, "1")[0]
+		bz, err := sdk.GetFromBech32(value, prefix)
+		if err != nil {
+			return "", err
+		}
+		key = strings.TrimSuffix(key, "_bech32")
+		value = sdk.Address(bz).String()
+	}
+	return key + "='" + value + "'", nil
+}
+
 func formatTxResults(cdc *wire.Codec, res []*ctypes.ResultTx) ([]txInfo, error) {
 	var err error
 	out := make([]txInfo, len(res))
@@ -101,33 +144,48 @@ func formatTxResults(cdc *wire.Codec, res []*ctypes.ResultTx) ([]txInfo, error)
 func SearchTxRequestHandlerFn(ctx context.CoreContext, cdc *wire.Codec) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		tag := r.FormValue("tag")
-		if tag == "" {
-			w.WriteHeader(400)
-			w.Write([]byte("You need to provide at least a tag as a key=value pair to search for. Postfix the key with _bech32 to search bech32-encoded addresses or public keys"))
-			return
-		}
-		keyValue := strings.Split(tag, "=")
-		key := keyValue[0]
-		value, err := url.QueryUnescape(keyValue[1])
-		if err != nil {
+		events := r.Form["event"]
+		if tag == "" && len(events) == 0 {
 			w.WriteHeader(400)
-			w.Write([]byte("Could not decode address: " + err.Error()))
+			w.Write([]byte("You need to provide at least a tag as a key=value pair, or an event as a <type>.<attribute>=<value> pair, to search for. Postfix the key with _bech32 to search bech32-encoded addresses or public keys"))
 			return
 		}
-		if strings.HasSuffix(key, "_bech32") {
-			bech32address := strings.Trim(value, "'")
-			prefix := strings.Split(bech32address, "1")[0]
-			bz, err := sdk.GetFromBech32(bech32address, prefix)
+
+		var tags []string
+		if tag != "" {
+			keyValue := strings.SplitN(tag, "=", 2)
+			if len(keyValue) != 2 {
+				w.WriteHeader(400)
+				w.Write([]byte("The tag must be a key=value pair"))
+				return
+			}
+			key := keyValue[0]
+			value, err := url.QueryUnescape(keyValue[1])
 			if err != nil {
 				w.WriteHeader(400)
-				w.Write([]byte(err.Error()))
+				w.Write([]byte("Could not decode address: " + err.Error()))
 				return
 			}
+			if strings.HasSuffix(key, "_bech32") {
+				tag, err = queryTag(key, strings.Trim(value, "'"))
+				if err != nil {
+					w.WriteHeader(400)
+					w.Write([]byte(err.Error()))
+					return
+				}
+			}
+			tags = append(tags, tag)
+		}
 
-			tag = strings.TrimRight(key, "_bech32") + "='" + sdk.Address(bz).String() + "'"
+		eventTags, err := eventsToTags(events)
+		if err != nil {
+			w.WriteHeader(400)
+			w.Write([]byte(err.Error()))
+			return
 		}
+		tags = append(tags, eventTags...)
 
-		txs, err := searchTxs(ctx, cdc, []string{tag})
+		txs, err := searchTxs(ctx, cdc, tags)
 		if err != nil {
 			w.WriteHeader(500)
 			w.Write([]byte(err.Error()))
@@ -127,7 +127,7 @@ func TestGaiaCLICreateValidator(t *testing.T) {
 
 	validator := executeGetValidator(t, fmt.Sprintf("toncli stake validator %v --output=json %v", barCech, flags))
 	require.Equal(t, validator.Owner, barAddr)
-	require.Equal(t, "2/1", validator.PoolShares.Amount.String())
+	require.Equal(t, "2.000000000000000000", validator.PoolShares.Amount.String())
 
 	// unbond a single share
 	unbondStr := fmt.Sprintf("toncli stake unbond %v", flags)
@@ -144,7 +144,7 @@ func TestGaiaCLICreateValidator(t *testing.T) {
 	barAcc = executeGetAccount(t, fmt.Sprintf("toncli account %v %v", barCech, flags))
 	require.Equal(t, int64(9), barAcc.GetCoins().AmountOf("steak").Int64(), "%v", barAcc)
 	validator = executeGetValidator(t, fmt.Sprintf("toncli stake validator %v --output=json %v", barCech, flags))
-	require.Equal(t, "1/1", validator.PoolShares.Amount.String())
+	require.Equal(t, "1.000000000000000000", validator.PoolShares.Amount.String())
 }
 
 func TestGaiaCLISubmitProposal(t *testing.T) {
@@ -56,10 +56,26 @@ func main() {
 		Use:   "ibc",
 		Short: "Inter-Blockchain Communication subcommands",
 	}
+	ibcCmd.AddCommand(
+		client.GetCommands(
+			ibccmd.GetCmdQueryEscrow("ibc", cdc),
+			ibccmd.GetCmdQueryClient("ibc", cdc),
+			ibccmd.GetCmdQueryConnection("ibc", cdc),
+			ibccmd.GetCmdQueryChannel("ibc", cdc),
+		)...)
 	ibcCmd.AddCommand(
 		client.PostCommands(
 			ibccmd.IBCTransferCmd(cdc),
 			ibccmd.IBCRelayCmd(cdc),
+			ibccmd.CreateClientCmd(cdc),
+			ibccmd.ConnectionOpenInitCmd(cdc),
+			ibccmd.ConnectionOpenTryCmd(cdc),
+			ibccmd.ConnectionOpenAckCmd(cdc),
+			ibccmd.ConnectionOpenConfirmCmd(cdc),
+			ibccmd.ChannelOpenInitCmd(cdc),
+			ibccmd.ChannelOpenTryCmd(cdc),
+			ibccmd.ChannelOpenAckCmd(cdc),
+			ibccmd.ChannelOpenConfirmCmd(cdc),
 		)...)
 
 	advancedCmd := &cobra.Command{
@@ -111,13 +127,19 @@ func main() {
 	govCmd.AddCommand(
 		client.GetCommands(
 			govcmd.GetCmdQueryProposal("gov", cdc),
+			govcmd.GetCmdQueryProposals("gov", cdc),
 			govcmd.GetCmdQueryVote("gov", cdc),
+			govcmd.GetCmdQueryVotes("gov", cdc),
+			govcmd.GetCmdQueryDeposit("gov", cdc),
+			govcmd.GetCmdQueryDeposits("gov", cdc),
+			govcmd.GetCmdQueryTally("gov", cdc),
 		)...)
 	govCmd.AddCommand(
 		client.PostCommands(
 			govcmd.GetCmdSubmitProposal(cdc),
 			govcmd.GetCmdDeposit(cdc),
 			govcmd.GetCmdVote(cdc),
+			govcmd.GetCmdVoteWeighted(cdc),
 		)...)
 	rootCmd.AddCommand(
 		govCmd,
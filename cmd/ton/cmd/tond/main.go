@@ -11,6 +11,7 @@ import (
 	dbm "github.com/tepleton/tmlibs/db"
 	"github.com/tepleton/tmlibs/log"
 
+	bam "github.com/tepleton/tepleton-sdk/baseapp"
 	"github.com/tepleton/tepleton-sdk/cmd/ton/app"
 	"github.com/tepleton/tepleton-sdk/server"
 )
@@ -28,6 +29,10 @@ func main() {
 	server.AddCommands(ctx, cdc, rootCmd, app.GaiaAppInit(),
 		server.ConstructAppCreator(newApp, "ton"),
 		server.ConstructAppExporter(exportAppStateAndTMValidators, "ton"))
+	rootCmd.AddCommand(
+		server.RepairStoreCmd(ctx, "ton"),
+		server.DBCmd(ctx, "ton"),
+	)
 
 	// prepare and add flags
 	executor := cli.PrepareBaseCmd(rootCmd, "GA", app.DefaultNodeHome)
@@ -38,11 +43,11 @@ func main() {
 	}
 }
 
-func newApp(logger log.Logger, db dbm.DB) wrsp.Application {
-	return app.NewGaiaApp(logger, db)
+func newApp(logger log.Logger, db dbm.DB, baseAppOptions ...func(*bam.BaseApp)) wrsp.Application {
+	return app.NewGaiaApp(logger, db, baseAppOptions...)
 }
 
-func exportAppStateAndTMValidators(logger log.Logger, db dbm.DB) (json.RawMessage, []tmtypes.GenesisValidator, error) {
-	gapp := app.NewGaiaApp(logger, db)
+func exportAppStateAndTMValidators(logger log.Logger, db dbm.DB, baseAppOptions ...func(*bam.BaseApp)) (json.RawMessage, []tmtypes.GenesisValidator, error) {
+	gapp := app.NewGaiaApp(logger, db, baseAppOptions...)
 	return gapp.ExportAppStateAndValidators()
 }
@@ -12,6 +12,7 @@ import (
 	dbm "github.com/tepleton/tmlibs/db"
 	"github.com/tepleton/tmlibs/log"
 
+	bam "github.com/tepleton/tepleton-sdk/baseapp"
 	"github.com/tepleton/tepleton-sdk/examples/basecoin/app"
 	"github.com/tepleton/tepleton-sdk/server"
 )
@@ -29,6 +30,10 @@ func main() {
 	server.AddCommands(ctx, cdc, rootCmd, server.DefaultAppInit,
 		server.ConstructAppCreator(newApp, "basecoin"),
 		server.ConstructAppExporter(exportAppStateAndTMValidators, "basecoin"))
+	rootCmd.AddCommand(
+		server.RepairStoreCmd(ctx, "basecoin"),
+		server.DBCmd(ctx, "basecoin"),
+	)
 
 	// prepare and add flags
 	rootDir := os.ExpandEnv("$HOME/.basecoind")
@@ -40,11 +45,11 @@ func main() {
 	}
 }
 
-func newApp(logger log.Logger, db dbm.DB) wrsp.Application {
-	return app.NewBasecoinApp(logger, db)
+func newApp(logger log.Logger, db dbm.DB, baseAppOptions ...func(*bam.BaseApp)) wrsp.Application {
+	return app.NewBasecoinApp(logger, db, baseAppOptions...)
 }
 
-func exportAppStateAndTMValidators(logger log.Logger, db dbm.DB) (json.RawMessage, []tmtypes.GenesisValidator, error) {
-	bapp := app.NewBasecoinApp(logger, db)
+func exportAppStateAndTMValidators(logger log.Logger, db dbm.DB, baseAppOptions ...func(*bam.BaseApp)) (json.RawMessage, []tmtypes.GenesisValidator, error) {
+	bapp := app.NewBasecoinApp(logger, db, baseAppOptions...)
 	return bapp.ExportAppStateAndValidators()
 }
@@ -12,6 +12,7 @@ import (
 	dbm "github.com/tepleton/tmlibs/db"
 	"github.com/tepleton/tmlibs/log"
 
+	bam "github.com/tepleton/tepleton-sdk/baseapp"
 	"github.com/tepleton/tepleton-sdk/examples/democoin/app"
 	"github.com/tepleton/tepleton-sdk/server"
 	"github.com/tepleton/tepleton-sdk/wire"
@@ -46,12 +47,12 @@ func CoolAppGenState(cdc *wire.Codec, appGenTxs []json.RawMessage) (appState jso
 	return
 }
 
-func newApp(logger log.Logger, db dbm.DB) wrsp.Application {
-	return app.NewDemocoinApp(logger, db)
+func newApp(logger log.Logger, db dbm.DB, baseAppOptions ...func(*bam.BaseApp)) wrsp.Application {
+	return app.NewDemocoinApp(logger, db, baseAppOptions...)
 }
 
-func exportAppStateAndTMValidators(logger log.Logger, db dbm.DB) (json.RawMessage, []tmtypes.GenesisValidator, error) {
-	dapp := app.NewDemocoinApp(logger, db)
+func exportAppStateAndTMValidators(logger log.Logger, db dbm.DB, baseAppOptions ...func(*bam.BaseApp)) (json.RawMessage, []tmtypes.GenesisValidator, error) {
+	dapp := app.NewDemocoinApp(logger, db, baseAppOptions...)
 	return dapp.ExportAppStateAndValidators()
 }
 
@@ -68,6 +69,10 @@ func main() {
 	server.AddCommands(ctx, cdc, rootCmd, CoolAppInit,
 		server.ConstructAppCreator(newApp, "democoin"),
 		server.ConstructAppExporter(exportAppStateAndTMValidators, "democoin"))
+	rootCmd.AddCommand(
+		server.RepairStoreCmd(ctx, "democoin"),
+		server.DBCmd(ctx, "democoin"),
+	)
 
 	// prepare and add flags
 	rootDir := os.ExpandEnv("$HOME/.democoind")
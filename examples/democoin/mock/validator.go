@@ -10,7 +10,7 @@ import (
 // Validator implements sdk.Validator
 type Validator struct {
 	Address sdk.Address
-	Power   sdk.Rat
+	Power   sdk.Dec
 }
 
 // Implements sdk.Validator
@@ -29,13 +29,13 @@ func (v Validator) GetPubKey() crypto.PubKey {
 }
 
 // Implements sdk.Validator
-func (v Validator) GetPower() sdk.Rat {
+func (v Validator) GetPower() sdk.Dec {
 	return v.Power
 }
 
 // Implements sdk.Validator
-func (v Validator) GetDelegatorShares() sdk.Rat {
-	return sdk.ZeroRat()
+func (v Validator) GetDelegatorShares() sdk.Dec {
+	return sdk.ZeroDec()
 }
 
 // Implements sdk.Validator
@@ -83,8 +83,8 @@ func (vs *ValidatorSet) Validator(ctx sdk.Context, addr sdk.Address) sdk.Validat
 }
 
 // TotalPower implements sdk.ValidatorSet
-func (vs *ValidatorSet) TotalPower(ctx sdk.Context) sdk.Rat {
-	res := sdk.ZeroRat()
+func (vs *ValidatorSet) TotalPower(ctx sdk.Context) sdk.Dec {
+	res := sdk.ZeroDec()
 	for _, val := range vs.Validators {
 		res = res.Add(val.Power)
 	}
@@ -112,7 +112,7 @@ func (vs *ValidatorSet) RemoveValidator(addr sdk.Address) {
 }
 
 // Implements sdk.ValidatorSet
-func (vs *ValidatorSet) Slash(ctx sdk.Context, pubkey crypto.PubKey, height int64, power int64, amt sdk.Rat) {
+func (vs *ValidatorSet) Slash(ctx sdk.Context, pubkey crypto.PubKey, height int64, power int64, amt sdk.Dec) {
 	panic("not implemented")
 }
 
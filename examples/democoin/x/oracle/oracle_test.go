@@ -105,9 +105,9 @@ func TestOracle(t *testing.T) {
 	addr3 := []byte("addr3")
 	addr4 := []byte("addr4")
 	valset := &mock.ValidatorSet{[]mock.Validator{
-		{addr1, sdk.NewRat(7)},
-		{addr2, sdk.NewRat(7)},
-		{addr3, sdk.NewRat(1)},
+		{addr1, sdk.NewDec(7)},
+		{addr2, sdk.NewDec(7)},
+		{addr3, sdk.NewDec(1)},
 	}}
 
 	key := sdk.NewKVStoreKey("testkey")
@@ -117,7 +117,7 @@ func TestOracle(t *testing.T) {
 	require.Nil(t, err)
 	ctx = ctx.WithBlockHeader(wrsp.Header{ValidatorsHash: bz})
 
-	ork := NewKeeper(sdk.NewPrefixStoreGetter(key, []byte("oracle")), cdc, valset, sdk.NewRat(2, 3), 100)
+	ork := NewKeeper(sdk.NewPrefixStoreGetter(key, []byte("oracle")), cdc, valset, sdk.NewDec(2).Quo(sdk.NewDec(3)), 100)
 	h := seqHandler(ork, key, sdk.CodespaceRoot)
 
 	// Nonmock.Validator signed, transaction failed
@@ -169,7 +169,7 @@ func TestOracle(t *testing.T) {
 	require.Equal(t, 1, getSequence(ctx, key))
 
 	// Should handle mock.Validator set change
-	valset.AddValidator(mock.Validator{addr4, sdk.NewRat(12)})
+	valset.AddValidator(mock.Validator{addr4, sdk.NewDec(12)})
 	bz, err = json.Marshal(valset)
 	require.Nil(t, err)
 	ctx = ctx.WithBlockHeader(wrsp.Header{ValidatorsHash: bz})
@@ -2,12 +2,13 @@ package server
 
 import (
 	"encoding/json"
-	"path/filepath"
 
 	wrsp "github.com/tepleton/tepleton/wrsp/types"
 	tmtypes "github.com/tepleton/tepleton/types"
 	dbm "github.com/tepleton/tmlibs/db"
 	"github.com/tepleton/tmlibs/log"
+
+	bam "github.com/tepleton/tepleton-sdk/baseapp"
 )
 
 // AppCreator lets us lazily initialize app, using home dir
@@ -18,26 +19,25 @@ type AppCreator func(string, log.Logger) (wrsp.Application, error)
 type AppExporter func(home string, log log.Logger) (json.RawMessage, []tmtypes.GenesisValidator, error)
 
 // ConstructAppCreator returns an application generation function
-func ConstructAppCreator(appFn func(log.Logger, dbm.DB) wrsp.Application, name string) AppCreator {
+// The app gets the option to keep its stores in the DB backends of the config file.
+func ConstructAppCreator(appFn func(log.Logger, dbm.DB, ...func(*bam.BaseApp)) wrsp.Application, name string) AppCreator {
 	return func(rootDir string, logger log.Logger) (wrsp.Application, error) {
-		dataDir := filepath.Join(rootDir, "data")
-		db, err := dbm.NewGoLevelDB(name, dataDir)
+		db, storeDBs, err := openAppDBs(rootDir, name)
 		if err != nil {
 			return nil, err
 		}
-		app := appFn(logger, db)
+		app := appFn(logger, db, bam.SetStoreDBs(storeDBs))
 		return app, nil
 	}
 }
 
 // ConstructAppExporter returns an application export function
-func ConstructAppExporter(appFn func(log.Logger, dbm.DB) (json.RawMessage, []tmtypes.GenesisValidator, error), name string) AppExporter {
+func ConstructAppExporter(appFn func(log.Logger, dbm.DB, ...func(*bam.BaseApp)) (json.RawMessage, []tmtypes.GenesisValidator, error), name string) AppExporter {
 	return func(rootDir string, logger log.Logger) (json.RawMessage, []tmtypes.GenesisValidator, error) {
-		dataDir := filepath.Join(rootDir, "data")
-		db, err := dbm.NewGoLevelDB(name, dataDir)
+		db, storeDBs, err := openAppDBs(rootDir, name)
 		if err != nil {
 			return nil, nil, err
 		}
-		return appFn(logger, db)
+		return appFn(logger, db, bam.SetStoreDBs(storeDBs))
 	}
 }
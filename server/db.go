@@ -0,0 +1,161 @@
+package server
+
+import (
+	"fmt"
+	"path/filepath"
+	"sort"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"github.com/syndtr/goleveldb/leveldb/util"
+
+	dbm "github.com/tepleton/tmlibs/db"
+
+	"github.com/tepleton/tepleton-sdk/store"
+)
+
+// Config file table of the DB backends of the stores, by store name, e.g.
+//
+//	[store-backends]
+//	acc = "goleveldb"
+//
+// The other stores are kept in the app DB.
+const flagStoreBackends = "store-backends"
+
+// the DB backends a store can be kept in
+var storeBackends = map[string]dbm.DBBackendType{
+	"goleveldb": dbm.GoLevelDBBackend,
+	"memdb":     dbm.MemDBBackend, // not persisted, for testing
+	"fsdb":      dbm.FSDBBackend,  // one file per key
+}
+
+// open the DBs of the stores with a backend in the config file, named
+// after the app DB name, e.g. <name>-acc.db in the data directory
+func openStoreDBs(dataDir, name string) (map[string]dbm.DB, error) {
+	backends := viper.GetStringMapString(flagStoreBackends)
+	dbs := make(map[string]dbm.DB, len(backends))
+	for storeName, backend := range backends {
+		backendType, ok := storeBackends[backend]
+		if !ok {
+			return nil, errors.Errorf("unknown backend %s of store %s, must be goleveldb, memdb or fsdb", backend, storeName)
+		}
+		dbs[storeName] = dbm.NewDB(name+"-"+storeName, backendType, dataDir)
+	}
+	return dbs, nil
+}
+
+// open the app DB and the DBs of its stores
+func openAppDBs(home, name string) (db dbm.DB, storeDBs map[string]dbm.DB, err error) {
+	dataDir := filepath.Join(home, "data")
+	db, err = dbm.NewGoLevelDB(name, dataDir)
+	if err != nil {
+		return nil, nil, err
+	}
+	storeDBs, err = openStoreDBs(dataDir, name)
+	if err != nil {
+		db.Close()
+		return nil, nil, err
+	}
+	return db, storeDBs, nil
+}
+
+func closeAppDBs(db dbm.DB, storeDBs map[string]dbm.DB) {
+	db.Close()
+	for _, storeDB := range storeDBs {
+		storeDB.Close()
+	}
+}
+
+// DBCmd groups the commands to manage the app DBs
+func DBCmd(ctx *Context, dbName string) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "db",
+		Short: "Manage the app databases",
+	}
+	cmd.AddCommand(
+		dbStatsCmd(dbName),
+		dbCompactCmd(dbName),
+	)
+	return cmd
+}
+
+func dbStatsCmd(dbName string) *cobra.Command {
+	return &cobra.Command{
+		Use:   "stats",
+		Short: "Print the number of keys and the size of each app store",
+		Long: `Print the number of keys of each app store, and their size in bytes with
+their values, including the past versions of the store.
+The node must not be running.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			db, storeDBs, err := openAppDBs(viper.GetString("home"), dbName)
+			if err != nil {
+				return err
+			}
+			defer closeAppDBs(db, storeDBs)
+
+			stats, err := store.MultiStoreStats(db, storeDBs)
+			if err != nil {
+				return err
+			}
+			fmt.Printf("%-20s %-10s %12s %16s\n", "STORE", "BACKEND", "KEYS", "SIZE")
+			for _, st := range stats {
+				backend := "app"
+				if _, ok := storeDBs[st.Name]; ok {
+					backend = viper.GetStringMapString(flagStoreBackends)[st.Name]
+				}
+				fmt.Printf("%-20s %-10s %12d %16d\n", st.Name, backend, st.Keys, st.Size)
+			}
+			return nil
+		},
+	}
+}
+
+func dbCompactCmd(dbName string) *cobra.Command {
+	return &cobra.Command{
+		Use:   "compact",
+		Short: "Compact the app databases",
+		Long: `Compact the app database and the databases of the stores kept apart,
+when their backend supports it (goleveldb).
+The node must not be running.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			db, storeDBs, err := openAppDBs(viper.GetString("home"), dbName)
+			if err != nil {
+				return err
+			}
+			defer closeAppDBs(db, storeDBs)
+
+			err = compactDB(dbName, db)
+			if err != nil {
+				return err
+			}
+			names := make([]string, 0, len(storeDBs))
+			for name := range storeDBs {
+				names = append(names, name)
+			}
+			sort.Strings(names)
+			for _, name := range names {
+				err = compactDB("store "+name, storeDBs[name])
+				if err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+	}
+}
+
+// compact the db if it is a goleveldb
+func compactDB(name string, db dbm.DB) error {
+	ldb, ok := db.(*dbm.GoLevelDB)
+	if !ok {
+		fmt.Printf("Skipped %s, its backend does not support compaction\n", name)
+		return nil
+	}
+	err := ldb.DB().CompactRange(util.Range{})
+	if err != nil {
+		return errors.Errorf("error compacting %s: %v", name, err)
+	}
+	fmt.Printf("Compacted %s\n", name)
+	return nil
+}
@@ -0,0 +1,27 @@
+package server
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOpenStoreDBs(t *testing.T) {
+	defer viper.Reset()
+	dir, err := ioutil.TempDir("", "store-dbs")
+	require.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	viper.Set(flagStoreBackends, map[string]string{"acc": "memdb"})
+	dbs, err := openStoreDBs(dir, "app")
+	require.Nil(t, err)
+	require.Len(t, dbs, 1)
+	require.NotNil(t, dbs["acc"])
+
+	viper.Set(flagStoreBackends, map[string]string{"acc": "rocksdb"})
+	_, err = openStoreDBs(dir, "app")
+	require.NotNil(t, err)
+}
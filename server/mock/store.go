@@ -46,11 +46,27 @@ func (ms multiStore) LoadVersion(ver int64) error {
 	panic("not implemented")
 }
 
+func (ms multiStore) SetPruning(pruning sdk.PruningStrategy) {
+	panic("not implemented")
+}
+
+func (ms multiStore) CacheMultiStoreWithVersion(version int64) (sdk.CacheMultiStore, error) {
+	panic("not implemented")
+}
+
+func (ms multiStore) Snapshot(dir string, chunkSize int) (func() error, error) {
+	panic("not implemented")
+}
+
+func (ms multiStore) Restore(dir string, height int64, appHash []byte) error {
+	panic("not implemented")
+}
+
 func (ms multiStore) GetKVStore(key sdk.StoreKey) sdk.KVStore {
 	return ms.kv[key]
 }
 
-func (ms multiStore) GetKVStoreWithGas(meter sdk.GasMeter, key sdk.StoreKey) sdk.KVStore {
+func (ms multiStore) GetKVStoreWithGas(meter sdk.GasMeter, config sdk.GasConfig, key sdk.StoreKey) sdk.KVStore {
 	panic("not implemented")
 }
 
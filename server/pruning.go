@@ -0,0 +1,65 @@
+package server
+
+import (
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+
+	wrsp "github.com/tepleton/tepleton/wrsp/types"
+
+	sdk "github.com/tepleton/tepleton-sdk/types"
+)
+
+const (
+	flagPruning           = "pruning"
+	flagPruningKeepRecent = "pruning-keep-recent"
+	flagPruningKeepEvery  = "pruning-keep-every"
+
+	pruningNothing    = "nothing"
+	pruningEverything = "everything"
+	pruningSyncable   = "syncable"
+	pruningCustom     = "custom"
+)
+
+// ParsePruningStrategy returns the pruning strategy of the given name,
+// keeping the keepRecent latest versions and every keepEvery-th one for
+// the custom strategy
+func ParsePruningStrategy(name string, keepRecent, keepEvery int64) (sdk.PruningStrategy, error) {
+	switch name {
+	case pruningNothing:
+		return sdk.PruneNothing, nil
+	case pruningEverything:
+		return sdk.PruneEverything, nil
+	case pruningSyncable:
+		return sdk.PruneSyncable, nil
+	case pruningCustom:
+		if keepRecent <= 0 || keepEvery < 0 {
+			return sdk.PruningStrategy{}, errors.Errorf("invalid custom pruning, keep-recent %d and keep-every %d", keepRecent, keepEvery)
+		}
+		return sdk.NewPruningStrategy(keepRecent, keepEvery), nil
+	default:
+		return sdk.PruningStrategy{}, errors.Errorf("unknown pruning strategy %s", name)
+	}
+}
+
+// pruner is implemented by apps built on BaseApp
+type pruner interface {
+	SetPruning(pruning sdk.PruningStrategy)
+}
+
+// set the pruning strategy of the app from the start flags, or the config file
+func setPruning(app wrsp.Application) error {
+	pruning, err := ParsePruningStrategy(viper.GetString(flagPruning),
+		viper.GetInt64(flagPruningKeepRecent), viper.GetInt64(flagPruningKeepEvery))
+	if err != nil {
+		return err
+	}
+	p, ok := app.(pruner)
+	if !ok {
+		if pruning != sdk.PruneNothing {
+			return errors.New("app does not support pruning")
+		}
+		return nil
+	}
+	p.SetPruning(pruning)
+	return nil
+}
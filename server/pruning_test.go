@@ -0,0 +1,36 @@
+package server
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	sdk "github.com/tepleton/tepleton-sdk/types"
+)
+
+func TestParsePruningStrategy(t *testing.T) {
+	cases := []struct {
+		name                  string
+		keepRecent, keepEvery int64
+		expected              sdk.PruningStrategy
+		expErr                bool
+	}{
+		{"nothing", 0, 0, sdk.PruneNothing, false},
+		{"everything", 5, 5, sdk.PruneEverything, false},
+		{"syncable", 0, 0, sdk.PruneSyncable, false},
+		{"custom", 10, 100, sdk.NewPruningStrategy(10, 100), false},
+		{"custom", 10, 0, sdk.NewPruningStrategy(10, 0), false},
+		{"custom", 0, 100, sdk.PruningStrategy{}, true},
+		{"some", 0, 0, sdk.PruningStrategy{}, true},
+	}
+
+	for _, tc := range cases {
+		pruning, err := ParsePruningStrategy(tc.name, tc.keepRecent, tc.keepEvery)
+		if tc.expErr {
+			require.NotNil(t, err, tc.name)
+			continue
+		}
+		require.Nil(t, err, tc.name)
+		require.Equal(t, tc.expected, pruning, tc.name)
+	}
+}
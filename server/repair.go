@@ -0,0 +1,54 @@
+package server
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/tepleton/tepleton-sdk/store"
+)
+
+const flagDryRun = "dry-run"
+
+// RepairStoreCmd rolls back the app stores which committed past the latest
+// version of the app, as when the node stopped in the middle of a commit.
+// The app state is in the database dbName of the data directory, and the
+// databases of the stores kept apart.
+func RepairStoreCmd(ctx *Context, dbName string) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "repair-store",
+		Short: "Check the app stores agree on the latest version, and roll back the ones past it",
+		Long: `Check the app stores agree on the latest committed version of the app.
+The stores which committed past it, because the node stopped in the middle
+of a commit, are rolled back to it, unless --dry-run is set.
+The node must not be running.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			db, storeDBs, err := openAppDBs(viper.GetString("home"), dbName)
+			if err != nil {
+				return err
+			}
+			defer closeAppDBs(db, storeDBs)
+
+			fix := !viper.GetBool(flagDryRun)
+			version, repairs, err := store.RepairMultiStore(db, storeDBs, fix)
+			if err != nil {
+				return err
+			}
+			if len(repairs) == 0 {
+				fmt.Printf("The stores are consistent at version %d\n", version)
+				return nil
+			}
+			for _, repair := range repairs {
+				if fix {
+					fmt.Printf("Rolled back store %s from version %d to %d\n", repair.Name, repair.LatestVersion, version)
+				} else {
+					fmt.Printf("Store %s is at version %d, past the latest version %d\n", repair.Name, repair.LatestVersion, version)
+				}
+			}
+			return nil
+		},
+	}
+	cmd.Flags().Bool(flagDryRun, false, "Only report the stores to roll back")
+	return cmd
+}
@@ -0,0 +1,89 @@
+package server
+
+import (
+	"encoding/hex"
+	"fmt"
+	"path/filepath"
+	"strconv"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	wrsp "github.com/tepleton/tepleton/wrsp/types"
+)
+
+const (
+	flagSnapshotInterval = "snapshot-interval"
+	flagSnapshotDir      = "snapshot-dir"
+)
+
+// snapshotter is implemented by apps built on BaseApp
+type snapshotter interface {
+	SetSnapshotInterval(dir string, interval int64)
+	RestoreSnapshot(dir string, height int64, appHash []byte) error
+}
+
+// the snapshot directory from the flags, by default in the data directory
+func snapshotDir(home string) string {
+	dir := viper.GetString(flagSnapshotDir)
+	if dir == "" {
+		dir = filepath.Join(home, "data", "snapshots")
+	}
+	return dir
+}
+
+// set up the periodic snapshots of the app from the start flags
+func setSnapshotInterval(app wrsp.Application, home string) error {
+	interval := viper.GetInt64(flagSnapshotInterval)
+	if interval <= 0 {
+		return nil
+	}
+	s, ok := app.(snapshotter)
+	if !ok {
+		return errors.New("app does not support snapshots")
+	}
+	s.SetSnapshotInterval(snapshotDir(home), interval)
+	return nil
+}
+
+// RestoreSnapshotCmd rebuilds the app state from a snapshot
+func RestoreSnapshotCmd(ctx *Context, appCreator AppCreator) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "restore-snapshot <height> <app-hash>",
+		Short: "Restore the app state from a snapshot",
+		Long: `Restore the app state of an empty node from the snapshot taken at height,
+after checking it matches the trusted app hash of that height, given in hex.
+The app then starts from that height.`,
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			height, err := strconv.ParseInt(args[0], 10, 64)
+			if err != nil || height <= 0 {
+				return errors.Errorf("invalid height %s", args[0])
+			}
+			appHash, err := hex.DecodeString(args[1])
+			if err != nil {
+				return errors.Errorf("invalid app hash %s: %v", args[1], err)
+			}
+
+			home := viper.GetString("home")
+			app, err := appCreator(home, ctx.Logger)
+			if err != nil {
+				return err
+			}
+			s, ok := app.(snapshotter)
+			if !ok {
+				return errors.New("app does not support snapshots")
+			}
+			dir := snapshotDir(home)
+			err = s.RestoreSnapshot(dir, height, appHash)
+			if err != nil {
+				return errors.Errorf("error restoring snapshot: %v\n", err)
+			}
+			fmt.Printf("Restored the snapshot at height %d from %s\n", height, dir)
+			return nil
+		},
+	}
+	cmd.Flags().String(flagSnapshotDir, "", "Snapshot directory (default <home>/data/snapshots)")
+	return cmd
+}
@@ -38,6 +38,12 @@ func StartCmd(ctx *Context, appCreator AppCreator) *cobra.Command {
 	// basic flags for wrsp app
 	cmd.Flags().Bool(flagWithTendermint, true, "run wrsp app embedded in-process with tepleton")
 	cmd.Flags().String(flagAddress, "tcp://0.0.0.0:26658", "Listen address")
+	cmd.Flags().String(flagPruning, pruningNothing, "Pruning strategy: nothing, everything, syncable or custom (also read from the config file)")
+	cmd.Flags().Int64(flagPruningKeepRecent, 0, "Number of recent versions kept by the custom pruning strategy")
+	cmd.Flags().Int64(flagPruningKeepEvery, 0, "Keep every this many versions with the custom pruning strategy (0 to keep none)")
+	cmd.Flags().Int64(flagSnapshotInterval, 0, "Snapshot the app state every this many blocks (0 to disable)")
+	cmd.Flags().String(flagSnapshotDir, "", "Snapshot directory (default <home>/data/snapshots)")
+	cmd.Flags().String(flagStateSinkFile, "", "Append the state changes of each block to this file (disabled if empty)")
 
 	// AddNodeFlags adds support for all tepleton-specific command line options
 	tcmd.AddNodeFlags(cmd)
@@ -52,6 +58,18 @@ func startStandAlone(ctx *Context, appCreator AppCreator) error {
 	if err != nil {
 		return err
 	}
+	err = setPruning(app)
+	if err != nil {
+		return err
+	}
+	err = setSnapshotInterval(app, home)
+	if err != nil {
+		return err
+	}
+	err = setStateSink(app)
+	if err != nil {
+		return err
+	}
 
 	svr, err := server.NewServer(addr, "socket", app)
 	if err != nil {
@@ -81,6 +99,18 @@ func startInProcess(ctx *Context, appCreator AppCreator) error {
 	if err != nil {
 		return err
 	}
+	err = setPruning(app)
+	if err != nil {
+		return err
+	}
+	err = setSnapshotInterval(app, home)
+	if err != nil {
+		return err
+	}
+	err = setStateSink(app)
+	if err != nil {
+		return err
+	}
 
 	// Create & start tepleton node
 	n, err := node.NewNode(cfg,
@@ -0,0 +1,37 @@
+package server
+
+import (
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+
+	wrsp "github.com/tepleton/tepleton/wrsp/types"
+
+	"github.com/tepleton/tepleton-sdk/baseapp"
+)
+
+const (
+	flagStateSinkFile = "state-sink-file"
+)
+
+// stateStreamer is implemented by apps built on BaseApp
+type stateStreamer interface {
+	SetStateSink(sink baseapp.StateSink)
+}
+
+// set up the streaming of the state changes of the app from the start flags
+func setStateSink(app wrsp.Application) error {
+	path := viper.GetString(flagStateSinkFile)
+	if path == "" {
+		return nil
+	}
+	s, ok := app.(stateStreamer)
+	if !ok {
+		return errors.New("app does not support state streaming")
+	}
+	sink, err := baseapp.NewFileStateSink(path)
+	if err != nil {
+		return err
+	}
+	s.SetStateSink(sink)
+	return nil
+}
@@ -116,6 +116,7 @@ func AddCommands(
 		client.LineBreak,
 		tepletonCmd,
 		ExportCmd(ctx, cdc, appExport),
+		RestoreSnapshotCmd(ctx, appCreator),
 		client.LineBreak,
 		version.VersionCmd,
 	)
@@ -53,6 +53,15 @@ func (cms cacheMultiStore) Write() {
 	}
 }
 
+// Implements CacheMultiStore.
+// Only the writes to this multistore are notified, not those to its cache-wraps
+// before they are written.
+func (cms cacheMultiStore) SetListener(listener sdk.WriteListener) {
+	for key, store := range cms.stores {
+		cms.stores[key] = listenKVStore{store.(CacheKVStore), key, listener}
+	}
+}
+
 // Implements CacheWrapper.
 func (cms cacheMultiStore) CacheWrap() CacheWrap {
 	return cms.CacheMultiStore().(CacheWrap)
@@ -74,6 +83,6 @@ func (cms cacheMultiStore) GetKVStore(key StoreKey) KVStore {
 }
 
 // Implements MultiStore.
-func (cms cacheMultiStore) GetKVStoreWithGas(meter sdk.GasMeter, key StoreKey) KVStore {
-	return NewGasKVStore(meter, cms.GetKVStore(key))
+func (cms cacheMultiStore) GetKVStoreWithGas(meter sdk.GasMeter, config sdk.GasConfig, key StoreKey) KVStore {
+	return NewGasKVStore(meter, config, cms.GetKVStore(key))
 }
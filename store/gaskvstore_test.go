@@ -11,13 +11,13 @@ import (
 func newGasKVStore() KVStore {
 	meter := sdk.NewGasMeter(1000)
 	mem := dbStoreAdapter{dbm.NewMemDB()}
-	return NewGasKVStore(meter, mem)
+	return NewGasKVStore(meter, sdk.DefaultGasConfig(), mem)
 }
 
 func TestGasKVStoreBasic(t *testing.T) {
 	mem := dbStoreAdapter{dbm.NewMemDB()}
 	meter := sdk.NewGasMeter(1000)
-	st := NewGasKVStore(meter, mem)
+	st := NewGasKVStore(meter, sdk.DefaultGasConfig(), mem)
 	require.Empty(t, st.Get(keyFmt(1)), "Expected `key1` to be empty")
 	st.Set(keyFmt(1), valFmt(1))
 	require.Equal(t, valFmt(1), st.Get(keyFmt(1)))
@@ -29,7 +29,7 @@ func TestGasKVStoreBasic(t *testing.T) {
 func TestGasKVStoreIterator(t *testing.T) {
 	mem := dbStoreAdapter{dbm.NewMemDB()}
 	meter := sdk.NewGasMeter(1000)
-	st := NewGasKVStore(meter, mem)
+	st := NewGasKVStore(meter, sdk.DefaultGasConfig(), mem)
 	require.Empty(t, st.Get(keyFmt(1)), "Expected `key1` to be empty")
 	require.Empty(t, st.Get(keyFmt(2)), "Expected `key2` to be empty")
 	st.Set(keyFmt(1), valFmt(1))
@@ -47,22 +47,84 @@ func TestGasKVStoreIterator(t *testing.T) {
 	iterator.Next()
 	require.False(t, iterator.Valid())
 	require.Panics(t, iterator.Next)
-	require.Equal(t, meter.GasConsumed(), sdk.Gas(356))
+	require.Equal(t, meter.GasConsumed(), sdk.Gas(446))
 }
 
 func TestGasKVStoreOutOfGasSet(t *testing.T) {
 	mem := dbStoreAdapter{dbm.NewMemDB()}
 	meter := sdk.NewGasMeter(0)
-	st := NewGasKVStore(meter, mem)
+	st := NewGasKVStore(meter, sdk.DefaultGasConfig(), mem)
 	require.Panics(t, func() { st.Set(keyFmt(1), valFmt(1)) }, "Expected out-of-gas")
 }
 
 func TestGasKVStoreOutOfGasIterator(t *testing.T) {
 	mem := dbStoreAdapter{dbm.NewMemDB()}
 	meter := sdk.NewGasMeter(200)
-	st := NewGasKVStore(meter, mem)
+	st := NewGasKVStore(meter, sdk.DefaultGasConfig(), mem)
 	st.Set(keyFmt(1), valFmt(1))
 	iterator := st.Iterator(nil, nil)
 	iterator.Next()
 	require.Panics(t, func() { iterator.Value() }, "Expected out-of-gas")
 }
+
+func TestGasKVStoreConfig(t *testing.T) {
+	mem := dbStoreAdapter{dbm.NewMemDB()}
+	meter := sdk.NewGasMeter(1000)
+	config := sdk.GasConfig{WriteCostFlat: 7, HasCost: 3, IterNextCostFlat: 2}
+	st := NewGasKVStore(meter, config, mem)
+	st.Set(keyFmt(1), valFmt(1))
+	st.Set(keyFmt(2), valFmt(2))
+	require.True(t, st.Has(keyFmt(1)))
+	require.Equal(t, sdk.Gas(17), meter.GasConsumed())
+
+	// only the configured costs are charged while iterating
+	iterator := st.Iterator(nil, nil)
+	for ; iterator.Valid(); iterator.Next() {
+		iterator.Key()
+		iterator.Value()
+	}
+	iterator.Close()
+	require.Equal(t, sdk.Gas(21), meter.GasConsumed())
+}
+
+func benchmarkKVStoreGetSet(b *testing.B, st KVStore) {
+	for i := 0; i < b.N; i++ {
+		st.Set(keyFmt(i%1000), valFmt(i))
+		st.Get(keyFmt(i % 1000))
+	}
+}
+
+func benchmarkKVStoreIterator(b *testing.B, st KVStore) {
+	for i := 0; i < 1000; i++ {
+		st.Set(keyFmt(i), valFmt(i))
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		iterator := st.Iterator(nil, nil)
+		for ; iterator.Valid(); iterator.Next() {
+			iterator.Key()
+			iterator.Value()
+		}
+		iterator.Close()
+	}
+}
+
+// The benchmarks of the gas KVStore are to be compared with the ones of the
+// store it wraps, to measure the overhead of gas metering.
+func BenchmarkKVStoreGetSet(b *testing.B) {
+	benchmarkKVStoreGetSet(b, dbStoreAdapter{dbm.NewMemDB()})
+}
+
+func BenchmarkGasKVStoreGetSet(b *testing.B) {
+	mem := dbStoreAdapter{dbm.NewMemDB()}
+	benchmarkKVStoreGetSet(b, NewGasKVStore(sdk.NewInfiniteGasMeter(), sdk.DefaultGasConfig(), mem))
+}
+
+func BenchmarkKVStoreIterator(b *testing.B) {
+	benchmarkKVStoreIterator(b, dbStoreAdapter{dbm.NewMemDB()})
+}
+
+func BenchmarkGasKVStoreIterator(b *testing.B) {
+	mem := dbStoreAdapter{dbm.NewMemDB()}
+	benchmarkKVStoreIterator(b, NewGasKVStore(sdk.NewInfiniteGasMeter(), sdk.DefaultGasConfig(), mem))
+}
@@ -0,0 +1,165 @@
+package store
+
+import (
+	"bytes"
+	"fmt"
+	"runtime"
+	"sort"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/tepleton/iavl"
+	cmn "github.com/tepleton/tmlibs/common"
+	dbm "github.com/tepleton/tmlibs/db"
+)
+
+// checkIteratorLeaks returns a function failing the test if iavlIterators
+// were left open, or goroutines left running, since it was called.
+func checkIteratorLeaks(t *testing.T) func() {
+	iterators, goroutines := atomic.LoadInt64(&openIAVLIterators), runtime.NumGoroutine()
+	return func() {
+		require.Equal(t, iterators, atomic.LoadInt64(&openIAVLIterators), "unclosed iavlIterators")
+		require.True(t, runtime.NumGoroutine() <= goroutines, "leaked goroutines")
+	}
+}
+
+// make a tree with n keys, which are returned sorted
+func newIteratorTree(n int) (*iavl.VersionedTree, [][]byte) {
+	tree := iavl.NewVersionedTree(dbm.NewMemDB(), cacheSize)
+	keys := make([][]byte, n)
+	for i := 0; i < n; i++ {
+		keys[i] = []byte(fmt.Sprintf("key%06d", i))
+		tree.Set(keys[i], keys[i])
+	}
+	tree.SaveVersion()
+	return tree, keys
+}
+
+func TestIAVLIteratorBatches(t *testing.T) {
+	defer checkIteratorLeaks(t)()
+
+	// several batches and a partial one
+	n := 3*iavlIteratorBatchSize + 5
+	tree, keys := newIteratorTree(n)
+
+	cases := []struct {
+		start, end []byte
+		expected   [][]byte
+	}{
+		{nil, nil, keys},
+		{keys[10], nil, keys[10:]},
+		{nil, keys[2*iavlIteratorBatchSize], keys[:2*iavlIteratorBatchSize]},
+		{keys[1], keys[iavlIteratorBatchSize+1], keys[1 : iavlIteratorBatchSize+1]},
+		{keys[5], keys[5], nil},
+	}
+
+	for i, tc := range cases {
+		for _, ascending := range []bool{true, false} {
+			var expected [][]byte
+			expected = append(expected, tc.expected...)
+			if !ascending {
+				sort.Slice(expected, func(i, j int) bool { return bytes.Compare(expected[i], expected[j]) > 0 })
+			}
+
+			iter := newIAVLIterator(tree.Tree(), tc.start, tc.end, ascending)
+			var got [][]byte
+			for ; iter.Valid(); iter.Next() {
+				require.Equal(t, iter.Key(), iter.Value())
+				got = append(got, iter.Key())
+			}
+			iter.Close()
+			require.Equal(t, expected, got, "case %d, ascending %v", i, ascending)
+		}
+	}
+}
+
+func TestIAVLIteratorClose(t *testing.T) {
+	tree, _ := newIteratorTree(10)
+	open := atomic.LoadInt64(&openIAVLIterators)
+
+	// the detector counts the iterators not closed yet
+	iter := newIAVLIterator(tree.Tree(), nil, nil, true)
+	require.Equal(t, open+1, atomic.LoadInt64(&openIAVLIterators))
+
+	// closing releases the iterator once, even if closed again
+	iter.Close()
+	iter.Close()
+	require.Equal(t, open, atomic.LoadInt64(&openIAVLIterators))
+	require.False(t, iter.Valid())
+	require.Panics(t, func() { iter.Next() })
+}
+
+//----------------------------------------
+// Benchmarks against the previous iterator, which got each item from a
+// goroutine walking the tree.
+
+type chanIAVLIterator struct {
+	iterCh  chan cmn.KVPair
+	quitCh  chan struct{}
+	invalid bool
+	key     []byte
+	value   []byte
+}
+
+func newChanIAVLIterator(tree *iavl.Tree, start, end []byte, ascending bool) *chanIAVLIterator {
+	iter := &chanIAVLIterator{
+		iterCh: make(chan cmn.KVPair),
+		quitCh: make(chan struct{}),
+	}
+	go func() {
+		tree.IterateRange(start, end, ascending, func(key, value []byte) bool {
+			select {
+			case <-iter.quitCh:
+				return true
+			case iter.iterCh <- cmn.KVPair{Key: key, Value: value}:
+				return false
+			}
+		})
+		close(iter.iterCh)
+	}()
+	iter.Next()
+	return iter
+}
+
+func (iter *chanIAVLIterator) Domain() (start, end []byte) { return nil, nil }
+func (iter *chanIAVLIterator) Valid() bool                 { return !iter.invalid }
+func (iter *chanIAVLIterator) Key() []byte                 { return iter.key }
+func (iter *chanIAVLIterator) Value() []byte               { return iter.value }
+func (iter *chanIAVLIterator) Close()                      { close(iter.quitCh) }
+func (iter *chanIAVLIterator) Next() {
+	kvPair, ok := <-iter.iterCh
+	if ok {
+		iter.key, iter.value = kvPair.Key, kvPair.Value
+	} else {
+		iter.invalid = true
+	}
+}
+
+func benchmarkIterator(b *testing.B, n int, newIter func(tree *iavl.Tree) Iterator) {
+	tree, _ := newIteratorTree(n)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		iter := newIter(tree.Tree())
+		for ; iter.Valid(); iter.Next() {
+			_ = iter.Value()
+		}
+		iter.Close()
+	}
+}
+
+func BenchmarkIAVLIterator(b *testing.B) {
+	for _, n := range []int{10, 1000, 10000} {
+		b.Run(fmt.Sprintf("pull-%d", n), func(b *testing.B) {
+			benchmarkIterator(b, n, func(tree *iavl.Tree) Iterator {
+				return newIAVLIterator(tree, nil, nil, true)
+			})
+		})
+		b.Run(fmt.Sprintf("chan-%d", n), func(b *testing.B) {
+			benchmarkIterator(b, n, func(tree *iavl.Tree) Iterator {
+				return newChanIAVLIterator(tree, nil, nil, true)
+			})
+		})
+	}
+}
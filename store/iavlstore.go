@@ -2,7 +2,7 @@ package store
 
 import (
 	"fmt"
-	"sync"
+	"sync/atomic"
 
 	"github.com/tepleton/go-amino"
 	"github.com/tepleton/iavl"
@@ -14,18 +14,17 @@ import (
 )
 
 const (
-	defaultIAVLCacheSize  = 10000
-	defaultIAVLNumHistory = 1<<53 - 1 // DEPRECATED
+	defaultIAVLCacheSize = 10000
 )
 
 // load the iavl store
-func LoadIAVLStore(db dbm.DB, id CommitID) (CommitStore, error) {
+func LoadIAVLStore(db dbm.DB, id CommitID, pruning sdk.PruningStrategy) (CommitStore, error) {
 	tree := iavl.NewVersionedTree(db, defaultIAVLCacheSize)
 	_, err := tree.LoadVersion(id.Version)
 	if err != nil {
 		return nil, err
 	}
-	store := newIAVLStore(tree, defaultIAVLNumHistory)
+	store := newIAVLStore(tree, pruning)
 	return store, nil
 }
 
@@ -34,6 +33,7 @@ func LoadIAVLStore(db dbm.DB, id CommitID) (CommitStore, error) {
 var _ KVStore = (*iavlStore)(nil)
 var _ CommitStore = (*iavlStore)(nil)
 var _ Queryable = (*iavlStore)(nil)
+var _ pruner = (*iavlStore)(nil)
 
 // iavlStore Implements KVStore and CommitStore.
 type iavlStore struct {
@@ -41,22 +41,27 @@ type iavlStore struct {
 	// The underlying tree.
 	tree *iavl.VersionedTree
 
-	// How many old versions we hold onto.
-	// A value of 0 means keep all history.
-	numHistory int64
+	// Which old versions we hold onto.
+	pruning sdk.PruningStrategy
+
+	// A version left unpruned while it was pinned, to release later.
+	unpinned int64
 }
 
 // CONTRACT: tree should be fully loaded.
-// TODO: use more numHistory's, so the below nolint can be removed
-// nolint: unparam
-func newIAVLStore(tree *iavl.VersionedTree, numHistory int64) *iavlStore {
+func newIAVLStore(tree *iavl.VersionedTree, pruning sdk.PruningStrategy) *iavlStore {
 	st := &iavlStore{
-		tree:       tree,
-		numHistory: numHistory,
+		tree:    tree,
+		pruning: pruning,
 	}
 	return st
 }
 
+// Set the pruning strategy, applied from the next commit.
+func (st *iavlStore) SetPruning(pruning sdk.PruningStrategy) {
+	st.pruning = pruning
+}
+
 // Implements Committer.
 func (st *iavlStore) Commit() CommitID {
 
@@ -67,20 +72,42 @@ func (st *iavlStore) Commit() CommitID {
 		panic(err)
 	}
 
-	// Release an old version of history
-	if st.numHistory > 0 && (st.numHistory < st.tree.Version64()) {
-		toRelease := version - st.numHistory
-		err := st.tree.DeleteVersion(toRelease)
+	return CommitID{
+		Version: version,
+		Hash:    hash,
+	}
+}
+
+// Implements pruner.
+// Releases the version which is no longer recent once latest is committed,
+// unless it is kept or pinned, eg. while a snapshot exports it. The
+// rootMultiStore prunes only after it wrote the commitInfo of latest, so that
+// the previous version is still there to roll back to if its commit is
+// interrupted.
+func (st *iavlStore) prune(latest, pinned int64) {
+	if st.pruning.KeepRecent <= 0 {
+		return
+	}
+	// The version before may be left when the last pruning was interrupted,
+	// and an older one when it was pinned.
+	// They may not exist when the strategy changed, or after a restore.
+	toRelease := latest - st.pruning.KeepRecent
+	unpinned := st.unpinned
+	st.unpinned = 0
+	for _, version := range []int64{unpinned, toRelease - 1, toRelease} {
+		if version <= 0 || st.pruning.Keep(version, latest) || !st.tree.VersionExists(version) {
+			continue
+		}
+		if version == pinned {
+			st.unpinned = version
+			continue
+		}
+		err := st.tree.DeleteVersion(version)
 		if err != nil {
 			// TODO: Handle with #870
 			panic(err)
 		}
 	}
-
-	return CommitID{
-		Version: version,
-		Hash:    hash,
-	}
 }
 
 // Implements Committer.
@@ -163,6 +190,13 @@ func (st *iavlStore) Query(req wrsp.RequestQuery) (res wrsp.ResponseQuery) {
 	// store the height we chose in the response
 	res.Height = height
 
+	if req.Height != 0 {
+		_, err := st.versionStore(height)
+		if err != nil {
+			return sdk.ErrUnknownRequest(err.Error()).QueryResult()
+		}
+	}
+
 	switch req.Path {
 	case "/store", "/key": // Get by key
 		key := req.Data // Data holds the key bytes
@@ -188,7 +222,24 @@ func (st *iavlStore) Query(req wrsp.RequestQuery) (res wrsp.ResponseQuery) {
 		subspace := req.Data
 		res.Key = subspace
 		var KVs []KVPair
-		iterator := sdk.KVStorePrefixIterator(st, subspace)
+		if req.Prove {
+			// the proof and the pairs are read from the version we answer for
+			proof, err := proveSubspace(tree, subspace, height)
+			if err != nil {
+				res.Log = err.Error()
+				break
+			}
+			KVs = proof.pairs(subspace)
+			res.Proof = cdc.MustMarshalBinary(proof)
+			res.Value = cdc.MustMarshalBinary(KVs)
+			break
+		}
+		// an explicit height reads that version, not the latest one
+		var store KVStore = st
+		if req.Height != 0 {
+			store, _ = st.versionStore(height)
+		}
+		iterator := sdk.KVStorePrefixIterator(store, subspace)
 		for ; iterator.Valid(); iterator.Next() {
 			KVs = append(KVs, KVPair{iterator.Key(), iterator.Value()})
 		}
@@ -203,7 +254,21 @@ func (st *iavlStore) Query(req wrsp.RequestQuery) (res wrsp.ResponseQuery) {
 
 //----------------------------------------
 
+// The number of items an iavlIterator reads from the tree at a time.
+const iavlIteratorBatchSize = 256
+
+// The number of iavlIterators not closed yet, to catch leaks in tests.
+var openIAVLIterators int64
+
+// OpenIAVLIterators returns the number of iterators of the IAVL stores which
+// are not closed yet, for tests to check they close what they open.
+func OpenIAVLIterators() int64 {
+	return atomic.LoadInt64(&openIAVLIterators)
+}
+
 // Implements Iterator.
+// It walks the tree without goroutines, reading the items of the domain in
+// batches, each resuming the range after the last key of the previous one.
 type iavlIterator struct {
 	// Underlying store
 	tree *iavl.Tree
@@ -214,64 +279,42 @@ type iavlIterator struct {
 	// Iteration order
 	ascending bool
 
-	// Channel to push iteration values.
-	iterCh chan cmn.KVPair
-
-	// Close this to release goroutine.
-	quitCh chan struct{}
+	// The current batch of items, the current one at index pos.
+	batch []cmn.KVPair
+	pos   int
 
-	// Close this to signal that state is initialized.
-	initCh chan struct{}
+	// True once the last batch of the domain is read.
+	exhausted bool
 
-	//----------------------------------------
-	// What follows are mutable state.
-	mtx sync.Mutex
-
-	invalid bool   // True once, true forever
-	key     []byte // The current key
-	value   []byte // The current value
+	closed bool
 }
 
 var _ Iterator = (*iavlIterator)(nil)
 
 // newIAVLIterator will create a new iavlIterator.
-// CONTRACT: Caller must release the iavlIterator, as each one creates a new
-// goroutine.
+// CONTRACT: Caller must release the iavlIterator.
 func newIAVLIterator(tree *iavl.Tree, start, end []byte, ascending bool) *iavlIterator {
 	iter := &iavlIterator{
 		tree:      tree,
 		start:     cp(start),
 		end:       cp(end),
 		ascending: ascending,
-		iterCh:    make(chan cmn.KVPair, 0), // Set capacity > 0?
-		quitCh:    make(chan struct{}),
-		initCh:    make(chan struct{}),
+		batch:     make([]cmn.KVPair, 0, iavlIteratorBatchSize),
 	}
-	go iter.iterateRoutine()
-	go iter.initRoutine()
+	atomic.AddInt64(&openIAVLIterators, 1)
+	iter.readBatch(iter.start, iter.end)
 	return iter
 }
 
-// Run this to funnel items from the tree to iterCh.
-func (iter *iavlIterator) iterateRoutine() {
-	iter.tree.IterateRange(
-		iter.start, iter.end, iter.ascending,
-		func(key, value []byte) bool {
-			select {
-			case <-iter.quitCh:
-				return true // done with iteration.
-			case iter.iterCh <- cmn.KVPair{key, value}:
-				return false // yay.
-			}
-		},
-	)
-	close(iter.iterCh) // done.
-}
-
-// Run this to fetch the first item.
-func (iter *iavlIterator) initRoutine() {
-	iter.receiveNext()
-	close(iter.initCh)
+// Read the next items of the range [start, end) of the tree.
+func (iter *iavlIterator) readBatch(start, end []byte) {
+	iter.batch = iter.batch[:0]
+	iter.pos = 0
+	iter.tree.IterateRange(start, end, iter.ascending, func(key, value []byte) bool {
+		iter.batch = append(iter.batch, cmn.KVPair{Key: key, Value: value})
+		return len(iter.batch) == iavlIteratorBatchSize
+	})
+	iter.exhausted = len(iter.batch) < iavlIteratorBatchSize
 }
 
 // Implements Iterator.
@@ -281,78 +324,55 @@ func (iter *iavlIterator) Domain() (start, end []byte) {
 
 // Implements Iterator.
 func (iter *iavlIterator) Valid() bool {
-	iter.waitInit()
-	iter.mtx.Lock()
-	defer iter.mtx.Unlock()
-
-	return !iter.invalid
+	return iter.pos < len(iter.batch)
 }
 
 // Implements Iterator.
 func (iter *iavlIterator) Next() {
-	iter.waitInit()
-	iter.mtx.Lock()
-	defer iter.mtx.Unlock()
 	iter.assertIsValid()
 
-	iter.receiveNext()
+	iter.pos++
+	if iter.pos < len(iter.batch) || iter.exhausted {
+		return
+	}
+
+	// Resume after the last key read.
+	last := iter.batch[len(iter.batch)-1].Key
+	if iter.ascending {
+		iter.readBatch(append(cp(last), 0), iter.end)
+	} else {
+		iter.readBatch(iter.start, last)
+	}
 }
 
 // Implements Iterator.
 func (iter *iavlIterator) Key() []byte {
-	iter.waitInit()
-	iter.mtx.Lock()
-	defer iter.mtx.Unlock()
 	iter.assertIsValid()
 
-	return iter.key
+	return iter.batch[iter.pos].Key
 }
 
 // Implements Iterator.
 func (iter *iavlIterator) Value() []byte {
-	iter.waitInit()
-	iter.mtx.Lock()
-	defer iter.mtx.Unlock()
 	iter.assertIsValid()
 
-	return iter.value
+	return iter.batch[iter.pos].Value
 }
 
 // Implements Iterator.
 func (iter *iavlIterator) Close() {
-	close(iter.quitCh)
+	if iter.closed {
+		return
+	}
+	iter.closed = true
+	iter.batch, iter.pos = nil, 0
+	atomic.AddInt64(&openIAVLIterators, -1)
 }
 
 //----------------------------------------
 
-func (iter *iavlIterator) setNext(key, value []byte) {
-	iter.assertIsValid()
-
-	iter.key = key
-	iter.value = value
-}
-
-func (iter *iavlIterator) setInvalid() {
-	iter.assertIsValid()
-
-	iter.invalid = true
-}
-
-func (iter *iavlIterator) waitInit() {
-	<-iter.initCh
-}
-
-func (iter *iavlIterator) receiveNext() {
-	kvPair, ok := <-iter.iterCh
-	if ok {
-		iter.setNext(kvPair.Key, kvPair.Value)
-	} else {
-		iter.setInvalid()
-	}
-}
-
 func (iter *iavlIterator) assertIsValid() {
-	if iter.invalid {
+	if !iter.Valid() {
 		panic("invalid iterator")
 	}
 }
@@ -14,8 +14,8 @@ import (
 )
 
 var (
-	cacheSize        = 100
-	numHistory int64 = 5
+	cacheSize = 100
+	pruning   = sdk.NewPruningStrategy(5, 0)
 )
 
 var (
@@ -45,7 +45,7 @@ func newTree(t *testing.T, db dbm.DB) (*iavl.VersionedTree, CommitID) {
 func TestIAVLStoreGetSetHasDelete(t *testing.T) {
 	db := dbm.NewMemDB()
 	tree, _ := newTree(t, db)
-	iavlStore := newIAVLStore(tree, numHistory)
+	iavlStore := newIAVLStore(tree, pruning)
 
 	key := "hello"
 
@@ -68,9 +68,11 @@ func TestIAVLStoreGetSetHasDelete(t *testing.T) {
 }
 
 func TestIAVLIterator(t *testing.T) {
+	defer checkIteratorLeaks(t)()
+
 	db := dbm.NewMemDB()
 	tree, _ := newTree(t, db)
-	iavlStore := newIAVLStore(tree, numHistory)
+	iavlStore := newIAVLStore(tree, pruning)
 	iter := iavlStore.Iterator([]byte("aloha"), []byte("hellz"))
 	expected := []string{"aloha", "hello"}
 	var i int
@@ -83,6 +85,7 @@ func TestIAVLIterator(t *testing.T) {
 		i++
 	}
 	require.Equal(t, len(expected), i)
+	iter.Close()
 
 	iter = iavlStore.Iterator([]byte("golang"), []byte("rocks"))
 	expected = []string{"hello"}
@@ -94,6 +97,7 @@ func TestIAVLIterator(t *testing.T) {
 		i++
 	}
 	require.Equal(t, len(expected), i)
+	iter.Close()
 
 	iter = iavlStore.Iterator(nil, []byte("golang"))
 	expected = []string{"aloha"}
@@ -105,6 +109,7 @@ func TestIAVLIterator(t *testing.T) {
 		i++
 	}
 	require.Equal(t, len(expected), i)
+	iter.Close()
 
 	iter = iavlStore.Iterator(nil, []byte("shalom"))
 	expected = []string{"aloha", "hello"}
@@ -116,6 +121,7 @@ func TestIAVLIterator(t *testing.T) {
 		i++
 	}
 	require.Equal(t, len(expected), i)
+	iter.Close()
 
 	iter = iavlStore.Iterator(nil, nil)
 	expected = []string{"aloha", "hello"}
@@ -127,6 +133,7 @@ func TestIAVLIterator(t *testing.T) {
 		i++
 	}
 	require.Equal(t, len(expected), i)
+	iter.Close()
 
 	iter = iavlStore.Iterator([]byte("golang"), nil)
 	expected = []string{"hello"}
@@ -138,12 +145,15 @@ func TestIAVLIterator(t *testing.T) {
 		i++
 	}
 	require.Equal(t, len(expected), i)
+	iter.Close()
 }
 
 func TestIAVLSubspaceIterator(t *testing.T) {
+	defer checkIteratorLeaks(t)()
+
 	db := dbm.NewMemDB()
 	tree, _ := newTree(t, db)
-	iavlStore := newIAVLStore(tree, numHistory)
+	iavlStore := newIAVLStore(tree, pruning)
 
 	iavlStore.Set([]byte("test1"), []byte("test1"))
 	iavlStore.Set([]byte("test2"), []byte("test2"))
@@ -167,6 +177,7 @@ func TestIAVLSubspaceIterator(t *testing.T) {
 		i++
 	}
 	require.Equal(t, len(expected), i)
+	iter.Close()
 
 	iter = sdk.KVStorePrefixIterator(iavlStore, []byte{byte(55), byte(255), byte(255)})
 	expected2 := [][]byte{
@@ -182,6 +193,7 @@ func TestIAVLSubspaceIterator(t *testing.T) {
 		i++
 	}
 	require.Equal(t, len(expected), i)
+	iter.Close()
 
 	iter = sdk.KVStorePrefixIterator(iavlStore, []byte{byte(255), byte(255)})
 	expected2 = [][]byte{
@@ -197,12 +209,15 @@ func TestIAVLSubspaceIterator(t *testing.T) {
 		i++
 	}
 	require.Equal(t, len(expected), i)
+	iter.Close()
 }
 
 func TestIAVLReverseSubspaceIterator(t *testing.T) {
+	defer checkIteratorLeaks(t)()
+
 	db := dbm.NewMemDB()
 	tree, _ := newTree(t, db)
-	iavlStore := newIAVLStore(tree, numHistory)
+	iavlStore := newIAVLStore(tree, pruning)
 
 	iavlStore.Set([]byte("test1"), []byte("test1"))
 	iavlStore.Set([]byte("test2"), []byte("test2"))
@@ -226,6 +241,7 @@ func TestIAVLReverseSubspaceIterator(t *testing.T) {
 		i++
 	}
 	require.Equal(t, len(expected), i)
+	iter.Close()
 
 	iter = sdk.KVStoreReversePrefixIterator(iavlStore, []byte{byte(55), byte(255), byte(255)})
 	expected2 := [][]byte{
@@ -241,6 +257,7 @@ func TestIAVLReverseSubspaceIterator(t *testing.T) {
 		i++
 	}
 	require.Equal(t, len(expected), i)
+	iter.Close()
 
 	iter = sdk.KVStoreReversePrefixIterator(iavlStore, []byte{byte(255), byte(255)})
 	expected2 = [][]byte{
@@ -256,12 +273,13 @@ func TestIAVLReverseSubspaceIterator(t *testing.T) {
 		i++
 	}
 	require.Equal(t, len(expected), i)
+	iter.Close()
 }
 
 func TestIAVLStoreQuery(t *testing.T) {
 	db := dbm.NewMemDB()
 	tree := iavl.NewVersionedTree(db, cacheSize)
-	iavlStore := newIAVLStore(tree, numHistory)
+	iavlStore := newIAVLStore(tree, pruning)
 
 	k1, v1 := []byte("key1"), []byte("val1")
 	k2, v2 := []byte("key2"), []byte("val2")
@@ -315,6 +333,10 @@ func TestIAVLStoreQuery(t *testing.T) {
 	// and for the subspace
 	qres = iavlStore.Query(querySub)
 	require.Equal(t, uint32(sdk.CodeOK), qres.Code)
+	require.Equal(t, valExpSubEmpty, qres.Value)
+	querySub.Height = cid.Version
+	qres = iavlStore.Query(querySub)
+	require.Equal(t, uint32(sdk.CodeOK), qres.Code)
 	require.Equal(t, valExpSub1, qres.Value)
 
 	// modify
@@ -325,6 +347,9 @@ func TestIAVLStoreQuery(t *testing.T) {
 	qres = iavlStore.Query(query)
 	require.Equal(t, uint32(sdk.CodeOK), qres.Code)
 	require.Equal(t, v1, qres.Value)
+	qres = iavlStore.Query(querySub)
+	require.Equal(t, uint32(sdk.CodeOK), qres.Code)
+	require.Equal(t, valExpSub1, qres.Value)
 
 	// update to latest in the query and we are happy
 	query.Height = cid.Version
@@ -336,6 +361,7 @@ func TestIAVLStoreQuery(t *testing.T) {
 	require.Equal(t, uint32(sdk.CodeOK), qres.Code)
 	require.Equal(t, v2, qres.Value)
 	// and for the subspace
+	querySub.Height = cid.Version
 	qres = iavlStore.Query(querySub)
 	require.Equal(t, uint32(sdk.CodeOK), qres.Code)
 	require.Equal(t, valExpSub2, qres.Value)
@@ -346,3 +372,92 @@ func TestIAVLStoreQuery(t *testing.T) {
 	require.Equal(t, uint32(sdk.CodeOK), qres.Code)
 	require.Equal(t, v1, qres.Value)
 }
+
+func TestIAVLVersionStore(t *testing.T) {
+	db := dbm.NewMemDB()
+	tree := iavl.NewVersionedTree(db, cacheSize)
+	iavlStore := newIAVLStore(tree, sdk.NewPruningStrategy(2, 0))
+
+	for _, k := range []string{"a", "b", "c", "d"} {
+		iavlStore.Set([]byte(k), []byte("1"))
+	}
+	cid := iavlStore.Commit()
+	iavlStore.Set([]byte("b"), []byte("2"))
+	iavlStore.Delete([]byte("c"))
+	iavlStore.Commit()
+
+	// the view of the first version does not see the later writes
+	vs, err := iavlStore.versionStore(cid.Version)
+	require.Nil(t, err)
+	require.Equal(t, []byte("1"), vs.Get([]byte("b")))
+	require.True(t, vs.Has([]byte("c")))
+	require.Nil(t, vs.Get([]byte("e")))
+	require.Panics(t, func() { vs.Set([]byte("e"), []byte("1")) })
+
+	keys := func(iter Iterator) (keys string) {
+		for ; iter.Valid(); iter.Next() {
+			keys += string(iter.Key())
+		}
+		iter.Close()
+		return
+	}
+	require.Equal(t, "abcd", keys(vs.Iterator(nil, nil)))
+	require.Equal(t, "bc", keys(vs.Iterator([]byte("b"), []byte("d"))))
+	require.Equal(t, "abc", keys(vs.Iterator(nil, []byte("d"))))
+	require.Equal(t, "cd", keys(vs.Iterator([]byte("c"), nil)))
+	require.Equal(t, "", keys(vs.Iterator([]byte("c"), []byte("c"))))
+	require.Equal(t, "dcba", keys(vs.ReverseIterator(nil, nil)))
+	require.Equal(t, "cb", keys(vs.ReverseIterator([]byte("b"), []byte("d"))))
+
+	// the writes to its cache-wrap are not written through
+	cache := vs.CacheWrap().(CacheKVStore)
+	cache.Set([]byte("e"), []byte("1"))
+	require.Equal(t, "abcde", keys(cache.Iterator(nil, nil)))
+
+	// pruned or future versions have no view
+	iavlStore.prune(iavlStore.Commit().Version, 0)
+	_, err = iavlStore.versionStore(cid.Version)
+	require.NotNil(t, err)
+	require.Contains(t, err.Error(), "pruned")
+	_, err = iavlStore.versionStore(10)
+	require.NotNil(t, err)
+}
+
+func TestIAVLPruning(t *testing.T) {
+	db := dbm.NewMemDB()
+	tree := iavl.NewVersionedTree(db, cacheSize)
+	iavlStore := newIAVLStore(tree, sdk.NewPruningStrategy(2, 3))
+
+	key := []byte("key")
+	for i := int64(1); i <= 10; i++ {
+		iavlStore.Set(key, []byte{byte(i)})
+		cid := iavlStore.Commit()
+		require.Equal(t, i, cid.Version)
+		iavlStore.prune(cid.Version, 0)
+	}
+
+	// the two latest versions and every third one are kept
+	for i := int64(1); i <= 10; i++ {
+		kept := i >= 9 || i%3 == 0
+		require.Equal(t, kept, tree.VersionExists(i), "version %d", i)
+
+		query := wrsp.RequestQuery{Path: "/key", Data: key, Height: i}
+		qres := iavlStore.Query(query)
+		if kept {
+			require.Equal(t, uint32(sdk.CodeOK), qres.Code)
+			require.Equal(t, []byte{byte(i)}, qres.Value)
+		} else {
+			require.Equal(t, sdk.ToWRSPCode(sdk.CodespaceRoot, sdk.CodeUnknownRequest), sdk.WRSPCodeType(qres.Code))
+			require.Contains(t, qres.Log, "pruned")
+		}
+	}
+
+	// pruning can be changed on a live store
+	iavlStore.SetPruning(sdk.PruneEverything)
+	iavlStore.Set(key, []byte{11})
+	iavlStore.Commit()
+	require.True(t, tree.VersionExists(10), "versions are only pruned once the multistore committed")
+	iavlStore.prune(11, 0)
+	require.False(t, tree.VersionExists(10))
+	require.True(t, tree.VersionExists(11))
+}
@@ -0,0 +1,116 @@
+package store
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+
+	"github.com/tepleton/iavl"
+	cmn "github.com/tepleton/tmlibs/common"
+
+	sdk "github.com/tepleton/tepleton-sdk/types"
+)
+
+var _ KVStore = iavlVersionStore{}
+
+// iavlVersionStore is a read-only view of a committed version of an iavlStore,
+// to query the historical state.
+type iavlVersionStore struct {
+	tree    *iavl.VersionedTree
+	version int64
+}
+
+// Returns the view of a committed version of the store, or an error if it
+// does not exist or was pruned.
+func (st *iavlStore) versionStore(version int64) (iavlVersionStore, error) {
+	if !st.tree.VersionExists(version) {
+		if version > 0 && version < st.tree.Version64() {
+			return iavlVersionStore{}, fmt.Errorf("version %d of the store was pruned", version)
+		}
+		return iavlVersionStore{}, fmt.Errorf("no version %d of the store, latest is %d", version, st.tree.Version64())
+	}
+	return iavlVersionStore{st.tree, version}, nil
+}
+
+// Implements Store.
+func (vs iavlVersionStore) GetStoreType() StoreType {
+	return sdk.StoreTypeIAVL
+}
+
+// Implements Store.
+func (vs iavlVersionStore) CacheWrap() CacheWrap {
+	return NewCacheKVStore(vs)
+}
+
+// Implements KVStore.
+func (vs iavlVersionStore) Get(key []byte) []byte {
+	_, value := vs.tree.GetVersioned(key, vs.version)
+	return value
+}
+
+// Implements KVStore.
+func (vs iavlVersionStore) Has(key []byte) bool {
+	return vs.Get(key) != nil
+}
+
+// Implements KVStore.
+func (vs iavlVersionStore) Set(key, value []byte) {
+	panic("cannot write to a past version of an iavlStore")
+}
+
+// Implements KVStore.
+func (vs iavlVersionStore) Delete(key []byte) {
+	panic("cannot write to a past version of an iavlStore")
+}
+
+// Implements KVStore.
+func (vs iavlVersionStore) Prefix(prefix []byte) KVStore {
+	return prefixStore{vs, prefix}
+}
+
+// Implements KVStore.
+func (vs iavlVersionStore) Iterator(start, end []byte) Iterator {
+	return &memIterator{start: start, end: end, items: vs.items(start, end, true)}
+}
+
+// Implements KVStore.
+// Like iavlStore, it iterates over [start, end) in descending order.
+func (vs iavlVersionStore) ReverseIterator(start, end []byte) Iterator {
+	return &memIterator{start: start, end: end, items: vs.items(start, end, false)}
+}
+
+// The items of the domain [start, end) of the version, in order.
+// The ranges of the versioned tree include their end, and are descending when
+// the end is nil, so a range without end reads the whole version.
+func (vs iavlVersionStore) items(start, end []byte, ascending bool) []cmn.KVPair {
+	if start != nil && end != nil && bytes.Compare(start, end) >= 0 {
+		return nil
+	}
+	rangeStart := start
+	if end == nil {
+		rangeStart = nil
+	}
+	// The version exists, so the only error left is an empty tree.
+	keys, values, _, err := vs.tree.GetVersionedRangeWithProof(rangeStart, end, 0, vs.version)
+	if err != nil {
+		return nil
+	}
+
+	items := make([]cmn.KVPair, 0, len(keys))
+	for i, key := range keys {
+		if start != nil && bytes.Compare(key, start) < 0 {
+			continue
+		}
+		if end != nil && bytes.Compare(key, end) >= 0 {
+			continue
+		}
+		items = append(items, cmn.KVPair{Key: key, Value: values[i]})
+	}
+	sort.Slice(items, func(i, j int) bool {
+		if ascending {
+			return bytes.Compare(items[i].Key, items[j].Key) < 0
+		}
+		return bytes.Compare(items[i].Key, items[j].Key) > 0
+	})
+	return items
+}
@@ -0,0 +1,38 @@
+package store
+
+import (
+	sdk "github.com/tepleton/tepleton-sdk/types"
+)
+
+// listenKVStore notifies a listener of the writes to a CacheKVStore, either
+// direct or from the Write of its cache-wraps.
+type listenKVStore struct {
+	CacheKVStore
+	key      StoreKey
+	listener sdk.WriteListener
+}
+
+var _ CacheKVStore = listenKVStore{}
+
+// Implements KVStore.
+func (ls listenKVStore) Set(key, value []byte) {
+	ls.CacheKVStore.Set(key, value)
+	ls.listener.OnWrite(ls.key, key, value, false)
+}
+
+// Implements KVStore.
+func (ls listenKVStore) Delete(key []byte) {
+	ls.CacheKVStore.Delete(key)
+	ls.listener.OnWrite(ls.key, key, nil, true)
+}
+
+// Implements KVStore.
+func (ls listenKVStore) Prefix(prefix []byte) KVStore {
+	return prefixStore{ls, prefix}
+}
+
+// Implements Store.
+// The cache-wrap writes through the listenKVStore, so that its writes are notified.
+func (ls listenKVStore) CacheWrap() CacheWrap {
+	return NewCacheKVStore(ls)
+}
@@ -0,0 +1,76 @@
+package store
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	dbm "github.com/tepleton/tmlibs/db"
+
+	sdk "github.com/tepleton/tepleton-sdk/types"
+)
+
+// records the writes it is notified of
+type recordListener struct {
+	writes []sdk.StoreKVPair
+}
+
+func (rl *recordListener) OnWrite(storeKey sdk.StoreKey, key []byte, value []byte, delete bool) {
+	rl.writes = append(rl.writes, sdk.StoreKVPair{StoreKey: storeKey.Name(), Delete: delete, Key: key, Value: value})
+}
+
+func TestCacheMultiStoreListener(t *testing.T) {
+	multi := newMultiStoreWithMounts(dbm.NewMemDB())
+	require.Nil(t, multi.LoadLatestVersion())
+	key1, key2 := multi.keysByName["store1"], multi.keysByName["store2"]
+	multi.GetKVStore(key1).Set([]byte("gone"), []byte("soon"))
+	multi.Commit()
+
+	listener := &recordListener{}
+	cms := multi.CacheMultiStore()
+	cms.SetListener(listener)
+
+	// direct writes are notified, also through a prefix store
+	cms.GetKVStore(key1).Set([]byte("a"), []byte("1"))
+	cms.GetKVStore(key2).Prefix([]byte("p/")).Delete([]byte("b"))
+	require.Equal(t, []sdk.StoreKVPair{
+		{StoreKey: "store1", Key: []byte("a"), Value: []byte("1")},
+		{StoreKey: "store2", Delete: true, Key: []byte("p/b")},
+	}, listener.writes)
+
+	// the writes of a cache-wrap are notified once it is written
+	listener.writes = nil
+	child := cms.CacheMultiStore()
+	child.GetKVStore(key1).Set([]byte("c"), []byte("3"))
+	child.GetKVStore(key1).Set([]byte("c"), []byte("4"))
+	child.GetKVStore(key1).Delete([]byte("gone"))
+	nested := child.CacheMultiStore()
+	nested.GetKVStore(key2).Set([]byte("d"), []byte("5"))
+	nested.Write()
+	require.Nil(t, listener.writes)
+	child.Write()
+	require.Equal(t, []sdk.StoreKVPair{
+		{StoreKey: "store1", Key: []byte("c"), Value: []byte("4")},
+		{StoreKey: "store1", Delete: true, Key: []byte("gone")},
+		{StoreKey: "store2", Key: []byte("d"), Value: []byte("5")},
+	}, sortedByStore(listener.writes))
+
+	// writing the listened store to the root multistore is not notified
+	listener.writes = nil
+	cms.Write()
+	require.Nil(t, listener.writes)
+	require.Equal(t, []byte("4"), multi.GetKVStore(key1).Get([]byte("c")))
+}
+
+// the cache-wraps write their stores in map order, so sort the writes by
+// store, keeping the order of the writes to each store
+func sortedByStore(writes []sdk.StoreKVPair) []sdk.StoreKVPair {
+	var sorted []sdk.StoreKVPair
+	for _, name := range []string{"store1", "store2", "store3"} {
+		for _, w := range writes {
+			if w.StoreKey == name {
+				sorted = append(sorted, w)
+			}
+		}
+	}
+	return sorted
+}
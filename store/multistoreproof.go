@@ -0,0 +1,154 @@
+package store
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/tepleton/iavl"
+
+	sdk "github.com/tepleton/tepleton-sdk/types"
+)
+
+// MultiStoreProof proves that a key/value pair is committed to by the app
+// hash of a rootMultiStore. It combines the IAVL proof of the key within a
+// substore with the commit info of all substores, from which the app hash
+// can be recomputed.
+type MultiStoreProof struct {
+	StoreName  string
+	StoreInfos []storeInfo
+	StoreProof []byte // IAVL proof of the key within the named substore
+}
+
+// VerifyMultiStoreProof checks that value is stored under key in the substore
+// storeName of the multistore whose commit hash is appHash. proofBytes is the
+// Proof returned by a rootMultiStore query with Prove set.
+func VerifyMultiStoreProof(proofBytes []byte, storeName string, key, value, appHash []byte) error {
+	proof, storeHash, err := verifyStoreHash(proofBytes, storeName, appHash)
+	if err != nil {
+		return err
+	}
+
+	var keyProof iavl.KeyExistsProof
+	err = cdc.UnmarshalBinary(proof.StoreProof, &keyProof)
+	if err != nil {
+		return fmt.Errorf("failed to decode store proof: %v", err)
+	}
+	return keyProof.Verify(key, value, storeHash)
+}
+
+// VerifyMultiStoreAbsence checks that nothing is stored under key in the
+// substore storeName of the multistore whose commit hash is appHash.
+func VerifyMultiStoreAbsence(proofBytes []byte, storeName string, key, appHash []byte) error {
+	proof, storeHash, err := verifyStoreHash(proofBytes, storeName, appHash)
+	if err != nil {
+		return err
+	}
+
+	var keyProof iavl.KeyAbsentProof
+	err = cdc.UnmarshalBinary(proof.StoreProof, &keyProof)
+	if err != nil {
+		return fmt.Errorf("failed to decode store proof: %v", err)
+	}
+	return keyProof.Verify(key, nil, storeHash)
+}
+
+// VerifyMultiStoreSubspace checks that value, the pairs returned by a
+// subspace query, are all the pairs under subspace in the substore storeName
+// of the multistore whose commit hash is appHash.
+func VerifyMultiStoreSubspace(proofBytes []byte, storeName string, subspace, value, appHash []byte) error {
+	proof, storeHash, err := verifyStoreHash(proofBytes, storeName, appHash)
+	if err != nil {
+		return err
+	}
+
+	var rangeProof SubspaceProof
+	err = cdc.UnmarshalBinary(proof.StoreProof, &rangeProof)
+	if err != nil {
+		return fmt.Errorf("failed to decode store proof: %v", err)
+	}
+	err = rangeProof.Proof.Verify(subspace, sdk.PrefixEndBytes(subspace), 0, rangeProof.Keys, rangeProof.Values, storeHash)
+	if err != nil {
+		return err
+	}
+
+	var kvs []KVPair
+	err = cdc.UnmarshalBinary(value, &kvs)
+	if err != nil {
+		return fmt.Errorf("failed to decode subspace pairs: %v", err)
+	}
+	proven := rangeProof.pairs(subspace)
+	if len(kvs) != len(proven) {
+		return fmt.Errorf("subspace has %d pairs, proof has %d", len(kvs), len(proven))
+	}
+	for i, kv := range kvs {
+		if !bytes.Equal(kv.Key, proven[i].Key) || !bytes.Equal(kv.Value, proven[i].Value) {
+			return fmt.Errorf("subspace pair %X does not match the proof", kv.Key)
+		}
+	}
+	return nil
+}
+
+// verifyStoreHash decodes the proof and checks that the substore hashes in it
+// add up to appHash, returning the hash of the substore storeName.
+func verifyStoreHash(proofBytes []byte, storeName string, appHash []byte) (proof MultiStoreProof, storeHash []byte, err error) {
+	err = cdc.UnmarshalBinary(proofBytes, &proof)
+	if err != nil {
+		return proof, nil, fmt.Errorf("failed to decode multistore proof: %v", err)
+	}
+	if proof.StoreName != storeName {
+		return proof, nil, fmt.Errorf("proof is for store %s, expected %s", proof.StoreName, storeName)
+	}
+
+	// The app hash is computed over the stores by name, where a duplicate
+	// name would hide the info of the other one, so each name must be unique.
+	infos := make(map[string]storeInfo, len(proof.StoreInfos))
+	for _, si := range proof.StoreInfos {
+		if _, ok := infos[si.Name]; ok {
+			return proof, nil, fmt.Errorf("duplicate commit info for store %s", si.Name)
+		}
+		infos[si.Name] = si
+	}
+
+	cInfo := commitInfo{StoreInfos: proof.StoreInfos}
+	if !bytes.Equal(cInfo.Hash(), appHash) {
+		return proof, nil, fmt.Errorf("multistore proof does not match app hash")
+	}
+
+	si, ok := infos[storeName]
+	if !ok {
+		return proof, nil, fmt.Errorf("no commit info for store %s", storeName)
+	}
+	return proof, si.Core.CommitID.Hash, nil
+}
+
+// SubspaceProof proves the pairs of a subspace of an IAVL tree. The proven
+// range includes its end, the first key past the subspace, so the pairs of
+// the subspace are the proven pairs with its prefix.
+type SubspaceProof struct {
+	Keys   [][]byte
+	Values [][]byte
+	Proof  iavl.KeyRangeProof
+}
+
+// proveSubspace proves the pairs under subspace in version of tree.
+func proveSubspace(tree *iavl.VersionedTree, subspace []byte, version int64) (proof SubspaceProof, err error) {
+	end := sdk.PrefixEndBytes(subspace)
+	if end == nil {
+		return proof, fmt.Errorf("cannot prove subspace %X without end", subspace)
+	}
+	keys, values, rangeProof, err := tree.GetVersionedRangeWithProof(subspace, end, 0, version)
+	if err != nil {
+		return proof, err
+	}
+	return SubspaceProof{keys, values, *rangeProof}, nil
+}
+
+// pairs returns the proven pairs under subspace, in order.
+func (proof SubspaceProof) pairs(subspace []byte) (kvs []KVPair) {
+	for i, key := range proof.Keys {
+		if bytes.HasPrefix(key, subspace) {
+			kvs = append(kvs, KVPair{key, proof.Values[i]})
+		}
+	}
+	return kvs
+}
@@ -66,7 +66,7 @@ func testPrefixStore(t *testing.T, baseStore KVStore, prefix []byte) {
 func TestIAVLStorePrefix(t *testing.T) {
 	db := dbm.NewMemDB()
 	tree := iavl.NewVersionedTree(db, cacheSize)
-	iavlStore := newIAVLStore(tree, numHistory)
+	iavlStore := newIAVLStore(tree, pruning)
 
 	testPrefixStore(t, iavlStore, []byte("test"))
 }
@@ -80,7 +80,7 @@ func TestCacheKVStorePrefix(t *testing.T) {
 func TestGasKVStorePrefix(t *testing.T) {
 	meter := sdk.NewGasMeter(100000000)
 	mem := dbStoreAdapter{dbm.NewMemDB()}
-	gasStore := NewGasKVStore(meter, mem)
+	gasStore := NewGasKVStore(meter, sdk.DefaultGasConfig(), mem)
 
 	testPrefixStore(t, gasStore, []byte("test"))
 }
@@ -0,0 +1,137 @@
+package store
+
+import (
+	"fmt"
+
+	"github.com/tepleton/iavl"
+	dbm "github.com/tepleton/tmlibs/db"
+
+	sdk "github.com/tepleton/tepleton-sdk/types"
+)
+
+// Key of the version being committed, set before the stores commit and
+// deleted with the write of its commitInfo.
+const commitIntentKey = "s/intent"
+
+// StoreRepair is a store which committed past the latest version of its
+// multistore, as when the process stopped while the multistore committed.
+type StoreRepair struct {
+	Name          string `json:"name"`
+	LatestVersion int64  `json:"latest_version"` // of the store, before the repair
+}
+
+// RepairMultiStore finds the IAVL stores of the multistore in the db which
+// committed past its latest version, and rolls them back to it if fix is set.
+// It returns the latest version of the multistore.
+// The stores in storeDBs are kept in their own db, see MountStoreWithDB.
+func RepairMultiStore(db dbm.DB, storeDBs map[string]dbm.DB, fix bool) (version int64, repairs []StoreRepair, err error) {
+	version = getLatestVersion(db)
+	if version == 0 {
+		// Without a commitInfo, the stores are not known.
+		return 0, nil, nil
+	}
+	cInfo, err := getCommitInfo(db, version)
+	if err != nil {
+		return version, nil, err
+	}
+	for _, storeInfo := range cInfo.StoreInfos {
+		latest, err := rollbackIAVLStore(storeDB(db, storeDBs[storeInfo.Name], storeInfo.Name), version, fix)
+		if err != nil {
+			return version, repairs, fmt.Errorf("failed to repair store %s: %v", storeInfo.Name, err)
+		}
+		if latest > version {
+			repairs = append(repairs, StoreRepair{storeInfo.Name, latest})
+		}
+	}
+	if fix {
+		db.Delete([]byte(commitIntentKey))
+	}
+	return version, repairs, nil
+}
+
+// Rolls back the IAVL stores which committed past the version, if the commit
+// of the next version was interrupted before its commitInfo was written.
+// The stores are otherwise expected to be at the version.
+func (rs *rootMultiStore) recoverCommit(version int64) error {
+	intent := getCommitIntent(rs.db)
+	interrupted := intent == version+1
+	for _, params := range rs.storesParams {
+		if params.typ != sdk.StoreTypeIAVL {
+			continue
+		}
+		latest, err := rollbackIAVLStore(rs.storeDB(params), version, interrupted)
+		if err != nil {
+			return fmt.Errorf("failed to roll back store %s: %v", params.key.Name(), err)
+		}
+		if latest > version && !interrupted {
+			return fmt.Errorf("store %s is at version %d, past the latest version %d of the rootMultiStore; run repair-store",
+				params.key.Name(), latest, version)
+		}
+	}
+	if intent != 0 {
+		rs.db.DeleteSync([]byte(commitIntentKey))
+	}
+	return nil
+}
+
+// Returns the latest version of the IAVL store in the db, and if it is past
+// the version and fix is set, deletes the versions past it.
+func rollbackIAVLStore(db dbm.DB, version int64, fix bool) (latest int64, err error) {
+	tree := iavl.NewVersionedTree(db, defaultIAVLCacheSize)
+	latest, err = tree.LoadVersion(0)
+	if err != nil || latest <= version || !fix {
+		return latest, err
+	}
+
+	// Nothing was committed, so all the store holds is from the interrupted commit.
+	if version == 0 {
+		deleteAll(db)
+		return latest, nil
+	}
+
+	// The versions past the one loaded can be deleted.
+	tree = iavl.NewVersionedTree(db, defaultIAVLCacheSize)
+	_, err = tree.LoadVersion(version)
+	if err != nil {
+		return latest, err
+	}
+	for v := latest; v > version; v-- {
+		if !tree.VersionExists(v) {
+			continue
+		}
+		err = tree.DeleteVersion(v)
+		if err != nil {
+			return latest, err
+		}
+	}
+	return latest, nil
+}
+
+// delete all the keys of the db
+func deleteAll(db dbm.DB) {
+	var keys [][]byte
+	iter := db.Iterator(nil, nil)
+	for ; iter.Valid(); iter.Next() {
+		keys = append(keys, iter.Key())
+	}
+	iter.Close()
+	for _, key := range keys {
+		db.Delete(key)
+	}
+}
+
+// Record the version about to be committed, synchronously so that it is on
+// disk before any store commits.
+func setCommitIntent(db dbm.DB, version int64) {
+	db.SetSync([]byte(commitIntentKey), cdc.MustMarshalBinary(version))
+}
+
+// The version being committed, 0 if none.
+func getCommitIntent(db dbm.DB) (version int64) {
+	bz := db.Get([]byte(commitIntentKey))
+	if bz == nil {
+		return 0
+	}
+	cdc.MustUnmarshalBinary(bz, &version)
+	return version
+}
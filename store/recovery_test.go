@@ -0,0 +1,138 @@
+package store
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	dbm "github.com/tepleton/tmlibs/db"
+
+	sdk "github.com/tepleton/tepleton-sdk/types"
+)
+
+// a multistore whose stores are kept under their own prefix of the db
+func newMultiStoreWithPrefixes(db dbm.DB) *rootMultiStore {
+	store := NewCommitMultiStore(db)
+	store.MountStoreWithDB(sdk.NewKVStoreKey("store1"), sdk.StoreTypeIAVL, nil)
+	store.MountStoreWithDB(sdk.NewKVStoreKey("store2"), sdk.StoreTypeIAVL, nil)
+	return store
+}
+
+// commits version 1, then only store1 of version 2, as if the process
+// stopped in the middle of the commit, and returns the version 2 of store1
+func interruptCommit(t *testing.T, db dbm.DB, withIntent bool) CommitID {
+	multi := newMultiStoreWithPrefixes(db)
+	require.Nil(t, multi.LoadLatestVersion())
+	key1, key2 := multi.keysByName["store1"], multi.keysByName["store2"]
+	multi.GetKVStore(key1).Set([]byte("k"), []byte("v1"))
+	multi.GetKVStore(key2).Set([]byte("k"), []byte("v1"))
+	multi.Commit()
+
+	multi.GetKVStore(key1).Set([]byte("k"), []byte("v2"))
+	multi.GetKVStore(key2).Set([]byte("k"), []byte("v2"))
+	if withIntent {
+		setCommitIntent(db, 2)
+	}
+	return multi.stores[key1].Commit()
+}
+
+// checks the multistore is at version 1 and commits version 2 again
+func checkRecovered(t *testing.T, db dbm.DB, interrupted CommitID) {
+	multi := newMultiStoreWithPrefixes(db)
+	require.Nil(t, multi.LoadLatestVersion())
+	require.Equal(t, int64(1), multi.LastCommitID().Version)
+	key1, key2 := multi.keysByName["store1"], multi.keysByName["store2"]
+	require.Equal(t, []byte("v1"), multi.GetKVStore(key1).Get([]byte("k")))
+	require.Equal(t, int64(1), multi.GetCommitKVStore(key1).LastCommitID().Version)
+
+	multi.GetKVStore(key1).Set([]byte("k"), []byte("v2"))
+	multi.GetKVStore(key2).Set([]byte("k"), []byte("v2"))
+	require.Equal(t, int64(2), multi.Commit().Version)
+	require.Equal(t, interrupted, multi.GetCommitKVStore(key1).LastCommitID())
+	require.Equal(t, int64(0), getCommitIntent(db))
+}
+
+func TestMultiStoreRecoverInterruptedCommit(t *testing.T) {
+	db := dbm.NewMemDB()
+	interrupted := interruptCommit(t, db, true)
+	require.Equal(t, int64(2), interrupted.Version)
+
+	// the stores past the intent are rolled back on load
+	checkRecovered(t, db, interrupted)
+}
+
+func TestMultiStoreRecoverWithPruning(t *testing.T) {
+	db := dbm.NewMemDB()
+	multi := newMultiStoreWithPrefixes(db)
+	multi.SetPruning(sdk.PruneEverything)
+	require.Nil(t, multi.LoadLatestVersion())
+	key1, key2 := multi.keysByName["store1"], multi.keysByName["store2"]
+	for i := byte(1); i <= 2; i++ {
+		multi.GetKVStore(key1).Set([]byte("k"), []byte{i})
+		multi.GetKVStore(key2).Set([]byte("k"), []byte{i})
+		multi.Commit()
+	}
+
+	// only store1 commits version 3, which must not prune version 2
+	multi.GetKVStore(key1).Set([]byte("k"), []byte{3})
+	multi.GetKVStore(key2).Set([]byte("k"), []byte{3})
+	setCommitIntent(db, 3)
+	interrupted := multi.stores[key1].Commit()
+
+	// the stores are rolled back to version 2 on load
+	multi = newMultiStoreWithPrefixes(db)
+	multi.SetPruning(sdk.PruneEverything)
+	require.Nil(t, multi.LoadLatestVersion())
+	require.Equal(t, int64(2), multi.LastCommitID().Version)
+	require.Equal(t, []byte{2}, multi.GetKVStore(key1).Get([]byte("k")))
+
+	// and version 3 commits again, pruning version 2 once written
+	multi.GetKVStore(key1).Set([]byte("k"), []byte{3})
+	multi.GetKVStore(key2).Set([]byte("k"), []byte{3})
+	require.Equal(t, int64(3), multi.Commit().Version)
+	require.Equal(t, interrupted, multi.GetCommitKVStore(key1).LastCommitID())
+	require.False(t, multi.stores[key1].(*iavlStore).tree.VersionExists(2))
+}
+
+func TestMultiStoreRecoverFirstCommit(t *testing.T) {
+	db := dbm.NewMemDB()
+	multi := newMultiStoreWithPrefixes(db)
+	require.Nil(t, multi.LoadLatestVersion())
+	key1 := multi.keysByName["store1"]
+	multi.GetKVStore(key1).Set([]byte("k"), []byte("v1"))
+	setCommitIntent(db, 1)
+	multi.stores[key1].Commit()
+
+	// the store is emptied, as nothing was committed
+	multi = newMultiStoreWithPrefixes(db)
+	require.Nil(t, multi.LoadLatestVersion())
+	require.Equal(t, int64(0), multi.LastCommitID().Version)
+	require.Nil(t, multi.GetKVStore(key1).Get([]byte("k")))
+	require.Equal(t, int64(1), multi.Commit().Version)
+}
+
+func TestRepairMultiStore(t *testing.T) {
+	db := dbm.NewMemDB()
+	interrupted := interruptCommit(t, db, false)
+
+	// without the intent, loading fails
+	multi := newMultiStoreWithPrefixes(db)
+	require.NotNil(t, multi.LoadLatestVersion())
+
+	// reporting leaves the stores as they are
+	version, repairs, err := RepairMultiStore(db, nil, false)
+	require.Nil(t, err)
+	require.Equal(t, int64(1), version)
+	require.Equal(t, []StoreRepair{{Name: "store1", LatestVersion: 2}}, repairs)
+	_, repairs, err = RepairMultiStore(db, nil, false)
+	require.Nil(t, err)
+	require.Len(t, repairs, 1)
+
+	// fixing rolls the stores back
+	_, repairs, err = RepairMultiStore(db, nil, true)
+	require.Nil(t, err)
+	require.Len(t, repairs, 1)
+	_, repairs, err = RepairMultiStore(db, nil, false)
+	require.Nil(t, err)
+	require.Nil(t, repairs)
+	checkRecovered(t, db, interrupted)
+}
@@ -3,6 +3,7 @@ package store
 import (
 	"fmt"
 	"strings"
+	"sync/atomic"
 
 	"golang.org/x/crypto/ripemd160"
 
@@ -25,9 +26,14 @@ const (
 type rootMultiStore struct {
 	db           dbm.DB
 	lastCommitID CommitID
+	pruning      sdk.PruningStrategy
 	storesParams map[StoreKey]storeParams
 	stores       map[StoreKey]CommitStore
 	keysByName   map[string]StoreKey
+
+	// The version being exported by a snapshot, which is not pruned
+	// meanwhile, or 0. Accessed atomically.
+	snapshotVersion int64
 }
 
 var _ CommitMultiStore = (*rootMultiStore)(nil)
@@ -48,6 +54,17 @@ func (rs *rootMultiStore) GetStoreType() StoreType {
 	return sdk.StoreTypeMulti
 }
 
+// Implements CommitMultiStore.
+// The strategy applies to the stores already loaded as well.
+func (rs *rootMultiStore) SetPruning(pruning sdk.PruningStrategy) {
+	rs.pruning = pruning
+	for _, store := range rs.stores {
+		if s, ok := store.(pruner); ok {
+			s.SetPruning(pruning)
+		}
+	}
+}
+
 // Implements CommitMultiStore.
 func (rs *rootMultiStore) MountStoreWithDB(key StoreKey, typ StoreType, db dbm.DB) {
 	if key == nil {
@@ -75,8 +92,14 @@ func (rs *rootMultiStore) GetCommitKVStore(key StoreKey) CommitKVStore {
 }
 
 // Implements CommitMultiStore.
+// The stores which committed past the latest version, because the last
+// commit was interrupted, are rolled back first.
 func (rs *rootMultiStore) LoadLatestVersion() error {
 	ver := getLatestVersion(rs.db)
+	err := rs.recoverCommit(ver)
+	if err != nil {
+		return err
+	}
 	return rs.LoadVersion(ver)
 }
 
@@ -105,8 +128,13 @@ func (rs *rootMultiStore) LoadVersion(ver int64) error {
 		return err
 	}
 
-	// Load each Store
+	// Load each Store, the transient ones being left out of the commitInfo
 	var newStores = make(map[StoreKey]CommitStore)
+	for key, storeParams := range rs.storesParams {
+		if storeParams.typ == sdk.StoreTypeTransient {
+			newStores[key] = newTransientStore()
+		}
+	}
 	for _, storeInfo := range cInfo.StoreInfos {
 		key, commitID := rs.nameToKey(storeInfo.Name), storeInfo.Core.CommitID
 		storeParams := rs.storesParams[key]
@@ -141,16 +169,28 @@ func (rs *rootMultiStore) LastCommitID() CommitID {
 // Implements Committer/CommitStore.
 func (rs *rootMultiStore) Commit() CommitID {
 
-	// Commit stores.
+	// Commit stores, recording the intent first so that an interrupted
+	// commit can be rolled back.
 	version := rs.lastCommitID.Version + 1
+	setCommitIntent(rs.db, version)
 	commitInfo := commitStores(version, rs.stores)
 
 	// Need to update atomically.
 	batch := rs.db.NewBatch()
 	setCommitInfo(batch, version, commitInfo)
 	setLatestVersion(batch, version)
+	batch.Delete([]byte(commitIntentKey))
 	batch.Write()
 
+	// Prune the old versions only once the commitInfo is written, so that
+	// an interrupted commit can still be rolled back to the previous one.
+	pinned := atomic.LoadInt64(&rs.snapshotVersion)
+	for _, store := range rs.stores {
+		if s, ok := store.(pruner); ok {
+			s.prune(version, pinned)
+		}
+	}
+
 	// Prepare for next version.
 	commitID := CommitID{
 		Version: version,
@@ -173,6 +213,29 @@ func (rs *rootMultiStore) CacheMultiStore() CacheMultiStore {
 	return newCacheMultiStoreFromRMS(rs)
 }
 
+// Implements CommitMultiStore.
+// The IAVL stores are read at the version, the other stores as they are.
+func (rs *rootMultiStore) CacheMultiStoreWithVersion(version int64) (CacheMultiStore, error) {
+	cms := cacheMultiStore{
+		db:         NewCacheKVStore(dbStoreAdapter{rs.db}),
+		stores:     make(map[StoreKey]CacheWrap, len(rs.stores)),
+		keysByName: rs.keysByName,
+	}
+	for key, store := range rs.stores {
+		st, ok := store.(*iavlStore)
+		if !ok {
+			cms.stores[key] = store.CacheWrap()
+			continue
+		}
+		vs, err := st.versionStore(version)
+		if err != nil {
+			return nil, fmt.Errorf("store %s: %v", key.Name(), err)
+		}
+		cms.stores[key] = vs.CacheWrap()
+	}
+	return cms, nil
+}
+
 // Implements MultiStore.
 func (rs *rootMultiStore) GetStore(key StoreKey) Store {
 	return rs.stores[key]
@@ -184,8 +247,8 @@ func (rs *rootMultiStore) GetKVStore(key StoreKey) KVStore {
 }
 
 // Implements MultiStore.
-func (rs *rootMultiStore) GetKVStoreWithGas(meter sdk.GasMeter, key StoreKey) KVStore {
-	return NewGasKVStore(meter, rs.GetKVStore(key))
+func (rs *rootMultiStore) GetKVStoreWithGas(meter sdk.GasMeter, config sdk.GasConfig, key StoreKey) KVStore {
+	return NewGasKVStore(meter, config, rs.GetKVStore(key))
 }
 
 // getStoreByName will first convert the original name to
@@ -206,7 +269,8 @@ func (rs *rootMultiStore) getStoreByName(name string) Store {
 // Query calls substore.Query with the same `req` where `req.Path` is
 // modified to remove the substore prefix.
 // Ie. `req.Path` here is `/<substore>/<path>`, and trimmed to `/<path>` for the substore.
-// TODO: add proof for `multistore -> substore`.
+// With req.Prove, the proof of the substore is extended into a MultiStoreProof
+// against the app hash at the queried height.
 func (rs *rootMultiStore) Query(req wrsp.RequestQuery) wrsp.ResponseQuery {
 	// Query just routes this to a substore.
 	path := req.Path
@@ -229,6 +293,20 @@ func (rs *rootMultiStore) Query(req wrsp.RequestQuery) wrsp.ResponseQuery {
 	// trim the path and make the query
 	req.Path = subpath
 	res := queryable.Query(req)
+	if !req.Prove || !sdk.WRSPCodeType(res.Code).IsOK() || len(res.Proof) == 0 {
+		return res
+	}
+
+	// extend the substore proof up to the app hash at the queried height
+	cInfo, err2 := getCommitInfo(rs.db, res.Height)
+	if err2 != nil {
+		return sdk.ErrInternal(err2.Error()).QueryResult()
+	}
+	res.Proof = cdc.MustMarshalBinary(MultiStoreProof{
+		StoreName:  storeName,
+		StoreInfos: cInfo.StoreInfos,
+		StoreProof: res.Proof,
+	})
 	return res
 }
 
@@ -250,20 +328,31 @@ func parsePath(path string) (storeName string, subpath string, err sdk.Error) {
 
 //----------------------------------------
 
-func (rs *rootMultiStore) loadCommitStoreFromParams(id CommitID, params storeParams) (store CommitStore, err error) {
-	var db dbm.DB
-	if params.db != nil {
-		db = dbm.NewPrefixDB(params.db, []byte("s/_/"))
-	} else {
-		db = dbm.NewPrefixDB(rs.db, []byte("s/k:"+params.key.Name()+"/"))
+// the db of a store, either its own or a prefix of the rootMultiStore db
+func (rs *rootMultiStore) storeDB(params storeParams) dbm.DB {
+	return storeDB(rs.db, params.db, params.key.Name())
+}
+
+// the db of the store of the name in the multistore db, or in its own db if not nil
+func storeDB(db dbm.DB, own dbm.DB, name string) dbm.DB {
+	if own != nil {
+		return dbm.NewPrefixDB(own, []byte("s/_/"))
 	}
+	return dbm.NewPrefixDB(db, []byte("s/k:"+name+"/"))
+}
+
+func (rs *rootMultiStore) loadCommitStoreFromParams(id CommitID, params storeParams) (store CommitStore, err error) {
+	db := rs.storeDB(params)
 	switch params.typ {
 	case sdk.StoreTypeMulti:
 		panic("recursive MultiStores not yet supported")
 		// TODO: id?
 		// return NewCommitMultiStore(db, id)
 	case sdk.StoreTypeIAVL:
-		store, err = LoadIAVLStore(db, id)
+		store, err = LoadIAVLStore(db, id, rs.pruning)
+		return
+	case sdk.StoreTypeTransient:
+		store = newTransientStore()
 		return
 	case sdk.StoreTypeDB:
 		panic("dbm.DB is not a CommitStore")
@@ -284,6 +373,12 @@ func (rs *rootMultiStore) nameToKey(name string) StoreKey {
 //----------------------------------------
 // storeParams
 
+// stores whose old versions can be pruned
+type pruner interface {
+	SetPruning(pruning sdk.PruningStrategy)
+	prune(latest, pinned int64)
+}
+
 type storeParams struct {
 	key StoreKey
 	db  dbm.DB
@@ -381,6 +476,11 @@ func commitStores(version int64, storeMap map[StoreKey]CommitStore) commitInfo {
 		// Commit
 		commitID := store.Commit()
 
+		// Transient stores are wiped, and left out of the app hash
+		if store.GetStoreType() == sdk.StoreTypeTransient {
+			continue
+		}
+
 		// Record CommitID
 		si := storeInfo{}
 		si.Name = key.Name()
@@ -151,12 +151,131 @@ func TestMultiStoreQuery(t *testing.T) {
 	qres = multi.Query(query)
 	require.Equal(t, sdk.ToWRSPCode(sdk.CodespaceRoot, sdk.CodeOK), sdk.WRSPCodeType(qres.Code))
 	require.Nil(t, qres.Value)
+	err = VerifyMultiStoreAbsence(qres.Proof, "store2", k, cid.Hash)
+	require.Nil(t, err)
+	err = VerifyMultiStoreProof(qres.Proof, "store2", k, v, cid.Hash)
+	require.NotNil(t, err)
 
 	// Test store2 data.
 	query.Data = k2
 	qres = multi.Query(query)
 	require.Equal(t, sdk.ToWRSPCode(sdk.CodespaceRoot, sdk.CodeOK), sdk.WRSPCodeType(qres.Code))
 	require.Equal(t, v2, qres.Value)
+
+	// Test the proof up to the app hash.
+	err = VerifyMultiStoreProof(qres.Proof, "store2", k2, v2, cid.Hash)
+	require.Nil(t, err)
+	err = VerifyMultiStoreProof(qres.Proof, "store2", k2, v, cid.Hash)
+	require.NotNil(t, err)
+	err = VerifyMultiStoreProof(qres.Proof, "store1", k2, v2, cid.Hash)
+	require.NotNil(t, err)
+	err = VerifyMultiStoreProof(qres.Proof, "store2", k2, v2, []byte("apphash"))
+	require.NotNil(t, err)
+}
+
+func TestMultiStoreProofDuplicateStore(t *testing.T) {
+	multi := newMultiStoreWithMounts(dbm.NewMemDB())
+	require.Nil(t, multi.LoadLatestVersion())
+	k, v := []byte("key"), []byte("value")
+	multi.getStoreByName("store2").(KVStore).Set(k, v)
+	cid := multi.Commit()
+
+	// a store with a forged value for the key
+	forger := newMultiStoreWithMounts(dbm.NewMemDB())
+	require.Nil(t, forger.LoadLatestVersion())
+	forged := []byte("forged")
+	forger.getStoreByName("store2").(KVStore).Set(k, forged)
+	forger.Commit()
+
+	query := wrsp.RequestQuery{Path: "/store2/key", Data: k, Height: cid.Version, Prove: true}
+	var honest, fake MultiStoreProof
+	require.Nil(t, cdc.UnmarshalBinary(multi.Query(query).Proof, &honest))
+	require.Nil(t, cdc.UnmarshalBinary(forger.Query(query).Proof, &fake))
+
+	// the forged root of the store put before the real one does not change
+	// the app hash, but must not be used to verify the key
+	var fakeInfo storeInfo
+	for _, si := range fake.StoreInfos {
+		if si.Name == "store2" {
+			fakeInfo = si
+		}
+	}
+	proof := MultiStoreProof{
+		StoreName:  "store2",
+		StoreInfos: append([]storeInfo{fakeInfo}, honest.StoreInfos...),
+		StoreProof: fake.StoreProof,
+	}
+	require.Equal(t, cid.Hash, commitInfo{StoreInfos: proof.StoreInfos}.Hash())
+	err := VerifyMultiStoreProof(cdc.MustMarshalBinary(proof), "store2", k, forged, cid.Hash)
+	require.NotNil(t, err)
+}
+
+func TestMultiStoreHistoricalQuery(t *testing.T) {
+	multi := newMultiStoreWithMounts(dbm.NewMemDB())
+	require.Nil(t, multi.LoadLatestVersion())
+	store1 := multi.getStoreByName("store1").(KVStore)
+	key1 := multi.keysByName["store1"]
+
+	k := []byte("key")
+	store1.Set(k, []byte("v1"))
+	cid1 := multi.Commit()
+	store1.Set(k, []byte("v2"))
+	cid2 := multi.Commit()
+
+	// each height is proven against its own app hash
+	for _, c := range []struct {
+		cid   CommitID
+		value []byte
+	}{{cid1, []byte("v1")}, {cid2, []byte("v2")}} {
+		query := wrsp.RequestQuery{Path: "/store1/key", Data: k, Height: c.cid.Version, Prove: true}
+		qres := multi.Query(query)
+		require.Equal(t, sdk.ToWRSPCode(sdk.CodespaceRoot, sdk.CodeOK), sdk.WRSPCodeType(qres.Code))
+		require.Equal(t, c.cid.Version, qres.Height)
+		require.Equal(t, c.value, qres.Value)
+		require.Nil(t, VerifyMultiStoreProof(qres.Proof, "store1", k, c.value, c.cid.Hash))
+	}
+	query := wrsp.RequestQuery{Path: "/store1/key", Data: k, Height: cid1.Version, Prove: true}
+	qres := multi.Query(query)
+	require.NotNil(t, VerifyMultiStoreProof(qres.Proof, "store1", k, []byte("v1"), cid2.Hash))
+
+	// the stores can be cache-wrapped at a past version
+	cms, err := multi.CacheMultiStoreWithVersion(cid1.Version)
+	require.Nil(t, err)
+	require.Equal(t, []byte("v1"), cms.GetKVStore(key1).Get(k))
+	cms.GetKVStore(key1).Set(k, []byte("v3"))
+	require.Equal(t, []byte("v3"), cms.GetKVStore(key1).Get(k))
+	require.Equal(t, []byte("v2"), store1.Get(k))
+
+	_, err = multi.CacheMultiStoreWithVersion(cid2.Version + 1)
+	require.NotNil(t, err)
+}
+
+func TestMultiStoreSubspaceQuery(t *testing.T) {
+	multi := newMultiStoreWithMounts(dbm.NewMemDB())
+	require.Nil(t, multi.LoadLatestVersion())
+	store1 := multi.getStoreByName("store1").(KVStore)
+
+	// the key past the subspace is in the proven range, but not in the result
+	store1.Set([]byte("a/1"), []byte("v1"))
+	store1.Set([]byte("a/2"), []byte("v2"))
+	store1.Set([]byte("a0"), []byte("v3"))
+	store1.Set([]byte("b"), []byte("v4"))
+	cid := multi.Commit()
+
+	subspace := []byte("a/")
+	query := wrsp.RequestQuery{Path: "/store1/subspace", Data: subspace, Height: cid.Version, Prove: true}
+	qres := multi.Query(query)
+	require.Equal(t, sdk.ToWRSPCode(sdk.CodespaceRoot, sdk.CodeOK), sdk.WRSPCodeType(qres.Code))
+	var kvs []KVPair
+	require.Nil(t, cdc.UnmarshalBinary(qres.Value, &kvs))
+	require.Equal(t, []KVPair{{[]byte("a/1"), []byte("v1")}, {[]byte("a/2"), []byte("v2")}}, kvs)
+	require.Nil(t, VerifyMultiStoreSubspace(qres.Proof, "store1", subspace, qres.Value, cid.Hash))
+
+	// dropping a pair or proving another subspace fails
+	dropped := cdc.MustMarshalBinary(kvs[:1])
+	require.NotNil(t, VerifyMultiStoreSubspace(qres.Proof, "store1", subspace, dropped, cid.Hash))
+	require.NotNil(t, VerifyMultiStoreSubspace(qres.Proof, "store1", []byte("b"), qres.Value, cid.Hash))
+	require.NotNil(t, VerifyMultiStoreSubspace(qres.Proof, "store2", subspace, qres.Value, cid.Hash))
 }
 
 //-----------------------------------------------------------------------
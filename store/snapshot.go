@@ -0,0 +1,369 @@
+package store
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync/atomic"
+
+	"github.com/tepleton/go-amino"
+	"github.com/tepleton/iavl"
+	cmn "github.com/tepleton/tmlibs/common"
+	dbm "github.com/tepleton/tmlibs/db"
+
+	sdk "github.com/tepleton/tepleton-sdk/types"
+)
+
+const (
+	// DefaultSnapshotChunkSize is the number of bytes of store data per chunk file.
+	DefaultSnapshotChunkSize = 10 * 1024 * 1024
+
+	snapshotManifestFile = "manifest.json"
+)
+
+// SnapshotManifest describes a snapshot of the rootMultiStore at a height.
+// It is written with the chunk files in <dir>/<height>/.
+type SnapshotManifest struct {
+	Height     int64    `json:"height"`
+	Hash       []byte   `json:"hash"`        // the commitInfo hash, ie. the app hash
+	CommitInfo []byte   `json:"commit_info"` // the amino encoded commitInfo
+	Chunks     [][]byte `json:"chunks"`      // the sha256 hash of each chunk file
+}
+
+// snapshotItem is an entry of the db of a store.
+// Snapshots copy the IAVL nodes of the version as they are stored, so that
+// the restored trees have the same hashes as the exported ones.
+type snapshotItem struct {
+	Store string
+	Key   []byte
+	Value []byte
+}
+
+// SnapshotDir returns the directory of the snapshot at height in dir.
+func SnapshotDir(dir string, height int64) string {
+	return filepath.Join(dir, strconv.FormatInt(height, 10))
+}
+
+// LoadSnapshotManifest reads the manifest of the snapshot at height in dir.
+func LoadSnapshotManifest(dir string, height int64) (SnapshotManifest, error) {
+	var manifest SnapshotManifest
+	bz, err := ioutil.ReadFile(filepath.Join(SnapshotDir(dir, height), snapshotManifestFile))
+	if err != nil {
+		return manifest, err
+	}
+	err = json.Unmarshal(bz, &manifest)
+	return manifest, err
+}
+
+// Implements CommitMultiStore.
+// Snapshot pins the last committed version of all the mounted IAVL stores
+// and returns the function exporting it into chunk files of about chunkSize
+// bytes. The export can run while the stores commit later versions: the
+// pinned version is not pruned until it is done.
+// Only one snapshot can be taken at a time.
+func (rs *rootMultiStore) Snapshot(dir string, chunkSize int) (export func() error, err error) {
+	height := rs.lastCommitID.Version
+	if height == 0 {
+		return nil, fmt.Errorf("cannot snapshot before the first commit")
+	}
+	if chunkSize <= 0 {
+		chunkSize = DefaultSnapshotChunkSize
+	}
+	cInfo, err := getCommitInfo(rs.db, height)
+	if err != nil {
+		return nil, err
+	}
+	dbs := make(map[string]dbm.DB, len(cInfo.StoreInfos))
+	for _, storeInfo := range cInfo.StoreInfos {
+		params := rs.storesParams[rs.nameToKey(storeInfo.Name)]
+		if params.typ != sdk.StoreTypeIAVL {
+			return nil, fmt.Errorf("cannot snapshot store %s of type %v", storeInfo.Name, params.typ)
+		}
+		dbs[storeInfo.Name] = rs.storeDB(params)
+	}
+
+	if !atomic.CompareAndSwapInt64(&rs.snapshotVersion, 0, height) {
+		return nil, fmt.Errorf("a snapshot of version %d is being taken", atomic.LoadInt64(&rs.snapshotVersion))
+	}
+	export = func() error {
+		defer atomic.StoreInt64(&rs.snapshotVersion, 0)
+		return exportSnapshot(SnapshotDir(dir, height), cInfo, dbs, chunkSize)
+	}
+	return export, nil
+}
+
+// write the chunks of the nodes of the version of the stores, then the manifest
+func exportSnapshot(snapshotDir string, cInfo commitInfo, dbs map[string]dbm.DB, chunkSize int) error {
+	err := os.MkdirAll(snapshotDir, 0755)
+	if err != nil {
+		return err
+	}
+	manifest := SnapshotManifest{
+		Height:     cInfo.Version,
+		Hash:       cInfo.Hash(),
+		CommitInfo: cdc.MustMarshalBinary(cInfo),
+	}
+
+	var items []snapshotItem
+	size := 0
+	writeChunk := func() error {
+		bz := cdc.MustMarshalBinary(items)
+		hash := sha256.Sum256(bz)
+		name := filepath.Join(snapshotDir, strconv.Itoa(len(manifest.Chunks)))
+		err := ioutil.WriteFile(name, bz, 0644)
+		if err != nil {
+			return err
+		}
+		manifest.Chunks = append(manifest.Chunks, hash[:])
+		items, size = nil, 0
+		return nil
+	}
+
+	for _, storeInfo := range cInfo.StoreInfos {
+		err = exportIAVLVersion(dbs[storeInfo.Name], cInfo.Version, func(key, value []byte) error {
+			items = append(items, snapshotItem{storeInfo.Name, key, value})
+			size += len(key) + len(value)
+			if size >= chunkSize {
+				return writeChunk()
+			}
+			return nil
+		})
+		if err != nil {
+			return fmt.Errorf("failed to export store %s: %v", storeInfo.Name, err)
+		}
+	}
+	if len(items) > 0 {
+		err = writeChunk()
+		if err != nil {
+			return err
+		}
+	}
+
+	// Write the manifest last, so that only complete snapshots have one.
+	bz, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(filepath.Join(snapshotDir, snapshotManifestFile), bz, 0644)
+}
+
+// The layout of the nodes of an IAVL tree in its db: the nodes are stored
+// under their hash, and the hash of the root of each version under the
+// version.
+const (
+	iavlNodeKeyFmt = "n/%X"
+	iavlRootKeyFmt = "r/%010d"
+)
+
+// Passes the db entries of the version of the IAVL store in the db to emit:
+// the root record of the version and the nodes reachable from it, which are
+// read straight from the db, so that the nodes of the other versions are
+// neither read nor exported.
+func exportIAVLVersion(db dbm.DB, version int64, emit func(key, value []byte) error) error {
+	rootKey := []byte(fmt.Sprintf(iavlRootKeyFmt, version))
+	rootHash := db.Get(rootKey)
+	if rootHash == nil {
+		return fmt.Errorf("version %d was pruned", version)
+	}
+	err := emit(rootKey, rootHash)
+	if err != nil {
+		return err
+	}
+	if len(rootHash) == 0 {
+		// the tree of the version is empty
+		return nil
+	}
+
+	hashes := [][]byte{rootHash}
+	for len(hashes) > 0 {
+		hash := hashes[len(hashes)-1]
+		hashes = hashes[:len(hashes)-1]
+
+		key := []byte(fmt.Sprintf(iavlNodeKeyFmt, hash))
+		value := db.Get(key)
+		if value == nil {
+			return fmt.Errorf("node %X of version %d is missing", hash, version)
+		}
+		err = emit(key, value)
+		if err != nil {
+			return err
+		}
+		left, right, err := iavlNodeChildren(value)
+		if err != nil {
+			return fmt.Errorf("failed to decode node %X: %v", hash, err)
+		}
+		if left != nil {
+			hashes = append(hashes, right, left)
+		}
+	}
+	return nil
+}
+
+// Returns the hashes of the children of the encoded IAVL node, which are nil
+// for a leaf. The node is encoded as its height, size, version and key,
+// followed by the hashes of its children or, for a leaf, its value.
+func iavlNodeChildren(bz []byte) (left, right []byte, err error) {
+	height, n, err := amino.DecodeInt8(bz)
+	if err != nil {
+		return nil, nil, err
+	}
+	bz = bz[n:]
+	// skip the size and the version
+	for i := 0; i < 2; i++ {
+		_, n, err = amino.DecodeVarint(bz)
+		if err != nil {
+			return nil, nil, err
+		}
+		bz = bz[n:]
+	}
+	_, n, err = amino.DecodeByteSlice(bz)
+	if err != nil {
+		return nil, nil, err
+	}
+	bz = bz[n:]
+	if height == 0 {
+		return nil, nil, nil
+	}
+
+	left, n, err = amino.DecodeByteSlice(bz)
+	if err != nil {
+		return nil, nil, err
+	}
+	bz = bz[n:]
+	right, _, err = amino.DecodeByteSlice(bz)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(left) == 0 || len(right) == 0 {
+		return nil, nil, fmt.Errorf("inner node of height %d without two children", height)
+	}
+	return left, right, nil
+}
+
+// Implements CommitMultiStore.
+// Restore rebuilds the stores from the snapshot at height in dir, after
+// checking that it commits to the trusted app hash, and loads that version.
+// The stores must be mounted and empty.
+func (rs *rootMultiStore) Restore(dir string, height int64, appHash []byte) error {
+	if rs.lastCommitID.Version != 0 || getLatestVersion(rs.db) != 0 {
+		return fmt.Errorf("cannot restore a snapshot into a non-empty rootMultiStore")
+	}
+	manifest, err := LoadSnapshotManifest(dir, height)
+	if err != nil {
+		return err
+	}
+	if manifest.Height != height {
+		return fmt.Errorf("snapshot manifest is for height %d, not %d", manifest.Height, height)
+	}
+
+	// Check the snapshot commits to the trusted app hash before writing anything.
+	var cInfo commitInfo
+	err = cdc.UnmarshalBinary(manifest.CommitInfo, &cInfo)
+	if err != nil {
+		return fmt.Errorf("failed to parse snapshot commitInfo: %v", err)
+	}
+	if cInfo.Version != height {
+		return fmt.Errorf("snapshot commitInfo is for height %d, not %d", cInfo.Version, height)
+	}
+	if !bytes.Equal(cInfo.Hash(), appHash) {
+		return fmt.Errorf("snapshot app hash %X does not match the trusted app hash %X", cInfo.Hash(), appHash)
+	}
+	dbs := make(map[string]dbm.DB, len(cInfo.StoreInfos))
+	for _, storeInfo := range cInfo.StoreInfos {
+		key := rs.keysByName[storeInfo.Name]
+		if key == nil {
+			return fmt.Errorf("snapshot store %s is not mounted", storeInfo.Name)
+		}
+		dbs[storeInfo.Name] = rs.storeDB(rs.storesParams[key])
+	}
+
+	// Leave the stores empty if the snapshot is refused.
+	err = restoreSnapshotStores(SnapshotDir(dir, height), manifest, cInfo, dbs)
+	if err != nil {
+		for _, db := range dbs {
+			deleteAll(db)
+		}
+		return err
+	}
+
+	batch := rs.db.NewBatch()
+	setCommitInfo(batch, height, cInfo)
+	setLatestVersion(batch, height)
+	batch.Write()
+	return rs.LoadVersion(height)
+}
+
+// Writes the store entries of each chunk of the snapshot into the dbs of the
+// stores, and checks the restored stores match the commitInfo.
+func restoreSnapshotStores(snapshotDir string, manifest SnapshotManifest, cInfo commitInfo, dbs map[string]dbm.DB) error {
+	for i, chunkHash := range manifest.Chunks {
+		bz, err := ioutil.ReadFile(filepath.Join(snapshotDir, strconv.Itoa(i)))
+		if err != nil {
+			return err
+		}
+		hash := sha256.Sum256(bz)
+		if !bytes.Equal(hash[:], chunkHash) {
+			return fmt.Errorf("snapshot chunk %d has hash %X, expected %X", i, hash, chunkHash)
+		}
+		var items []snapshotItem
+		err = cdc.UnmarshalBinary(bz, &items)
+		if err != nil {
+			return fmt.Errorf("failed to parse snapshot chunk %d: %v", i, err)
+		}
+		for _, item := range items {
+			db, ok := dbs[item.Store]
+			if !ok {
+				return fmt.Errorf("snapshot chunk %d has an entry of unknown store %s", i, item.Store)
+			}
+			db.Set(item.Key, item.Value)
+		}
+	}
+
+	for _, storeInfo := range cInfo.StoreInfos {
+		err := verifyIAVLStore(dbs[storeInfo.Name], storeInfo.Core.CommitID)
+		if err != nil {
+			return fmt.Errorf("restored store %s is invalid: %v", storeInfo.Name, err)
+		}
+	}
+	return nil
+}
+
+// Checks the IAVL store in the db commits to the hash at the version. The
+// hashes the nodes are stored under are not trusted: the proof of each leaf
+// is checked against the hash, which recomputes the hashes from the contents
+// of the nodes up to the root.
+func verifyIAVLStore(db dbm.DB, id CommitID) error {
+	tree := iavl.NewVersionedTree(db, defaultIAVLCacheSize)
+	_, err := tree.LoadVersion(id.Version)
+	if err != nil {
+		return err
+	}
+	if !bytes.Equal(tree.Hash(), id.Hash) {
+		return fmt.Errorf("root hash %X, expected %X", tree.Hash(), id.Hash)
+	}
+
+	var leaves []cmn.KVPair
+	tree.Tree().IterateRange(nil, nil, true, func(key, value []byte) bool {
+		leaves = append(leaves, cmn.KVPair{Key: key, Value: value})
+		return false
+	})
+	for _, leaf := range leaves {
+		value, proof, err := tree.GetVersionedWithProof(leaf.Key, id.Version)
+		if err != nil {
+			return err
+		}
+		if !bytes.Equal(value, leaf.Value) {
+			return fmt.Errorf("leaf %X has two values", leaf.Key)
+		}
+		err = proof.Verify(leaf.Key, leaf.Value, id.Hash)
+		if err != nil {
+			return fmt.Errorf("leaf %X does not match the hash: %v", leaf.Key, err)
+		}
+	}
+	return nil
+}
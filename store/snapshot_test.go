@@ -0,0 +1,140 @@
+package store
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	dbm "github.com/tepleton/tmlibs/db"
+
+	sdk "github.com/tepleton/tepleton-sdk/types"
+)
+
+func newSnapshotMultiStore(t *testing.T) *rootMultiStore {
+	store := NewCommitMultiStore(dbm.NewMemDB())
+	store.MountStoreWithDB(sdk.NewKVStoreKey("store1"), sdk.StoreTypeIAVL, nil)
+	store.MountStoreWithDB(sdk.NewKVStoreKey("store2"), sdk.StoreTypeIAVL, nil)
+	err := store.LoadLatestVersion()
+	require.Nil(t, err)
+	return store
+}
+
+func TestSnapshotRestore(t *testing.T) {
+	dir, err := ioutil.TempDir("", "snapshot")
+	require.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	// nothing to snapshot before the first commit
+	multi := newSnapshotMultiStore(t)
+	_, err = multi.Snapshot(dir, 0)
+	require.NotNil(t, err)
+
+	// commit a few versions, overwriting some keys
+	for i := 0; i < 3; i++ {
+		for j := 0; j < 20; j++ {
+			key := []byte(fmt.Sprintf("key%d", j))
+			multi.getStoreByName("store1").(KVStore).Set(key, []byte(fmt.Sprintf("value%d-%d", i, j)))
+			multi.getStoreByName("store2").(KVStore).Set(key, []byte(fmt.Sprintf("other%d-%d", i, j)))
+		}
+		multi.Commit()
+	}
+	cid := multi.LastCommitID()
+
+	// use small chunks to get several of them
+	export, err := multi.Snapshot(dir, 512)
+	require.Nil(t, err)
+
+	// the version is pinned until exported, even if the next commits prune it
+	_, err = multi.Snapshot(dir, 512)
+	require.NotNil(t, err)
+	multi.SetPruning(sdk.PruneEverything)
+	multi.getStoreByName("store1").(KVStore).Set([]byte("later"), []byte("value"))
+	multi.Commit()
+	require.Nil(t, export())
+	require.True(t, multi.getStoreByName("store1").(*iavlStore).tree.VersionExists(cid.Version))
+
+	manifest, err := LoadSnapshotManifest(dir, cid.Version)
+	require.Nil(t, err)
+	require.Equal(t, cid.Version, manifest.Height)
+	require.Equal(t, cid.Hash, manifest.Hash)
+	require.True(t, len(manifest.Chunks) > 1)
+
+	// the nodes committed after the version are not exported
+	for i := range manifest.Chunks {
+		bz, err := ioutil.ReadFile(filepath.Join(SnapshotDir(dir, cid.Version), fmt.Sprint(i)))
+		require.Nil(t, err)
+		var items []snapshotItem
+		require.Nil(t, cdc.UnmarshalBinary(bz, &items))
+		for _, item := range items {
+			require.False(t, bytes.Contains(item.Value, []byte("later")), "node of a later version exported")
+			require.False(t, bytes.HasPrefix(item.Key, []byte("o/")), "orphan record exported")
+		}
+	}
+
+	// the snapshot must match the trusted app hash
+	restored := newSnapshotMultiStore(t)
+	require.NotNil(t, restored.Restore(dir, cid.Version, []byte("apphash")))
+	require.NotNil(t, restored.Restore(dir, cid.Version+1, cid.Hash))
+
+	err = restored.Restore(dir, cid.Version, cid.Hash)
+	require.Nil(t, err)
+	require.Equal(t, cid, restored.LastCommitID())
+	require.Equal(t, []byte("value2-7"), restored.getStoreByName("store1").(KVStore).Get([]byte("key7")))
+	require.Equal(t, []byte("other2-19"), restored.getStoreByName("store2").(KVStore).Get([]byte("key19")))
+
+	// only the version was exported
+	_, value := restored.getStoreByName("store1").(*iavlStore).tree.GetVersioned([]byte("key7"), cid.Version-1)
+	require.Nil(t, value)
+
+	// the restored stores keep committing like the exported ones
+	restored.getStoreByName("store1").(KVStore).Set([]byte("later"), []byte("value"))
+	require.Equal(t, multi.LastCommitID(), restored.Commit())
+
+	// the version is released by the commit after the export
+	multi.Commit()
+	require.False(t, multi.getStoreByName("store1").(*iavlStore).tree.VersionExists(cid.Version))
+
+	// only empty stores can be restored
+	require.NotNil(t, restored.Restore(dir, cid.Version, cid.Hash))
+
+	// tampered chunks are refused
+	chunk := filepath.Join(SnapshotDir(dir, cid.Version), "0")
+	bz, err := ioutil.ReadFile(chunk)
+	require.Nil(t, err)
+	bz[len(bz)-1]++
+	require.Nil(t, ioutil.WriteFile(chunk, bz, 0644))
+	err = newSnapshotMultiStore(t).Restore(dir, cid.Version, cid.Hash)
+	require.NotNil(t, err)
+
+	// so are chunks whose nodes don't match the app hash, even though the
+	// manifest lists their hashes
+	var items []snapshotItem
+	bz[len(bz)-1]--
+	require.Nil(t, cdc.UnmarshalBinary(bz, &items))
+	for i, item := range items {
+		if bytes.Contains(item.Value, []byte("value2-")) {
+			items[i].Value = bytes.Replace(item.Value, []byte("value2-"), []byte("value9-"), 1)
+			break
+		}
+	}
+	bz = cdc.MustMarshalBinary(items)
+	require.Nil(t, ioutil.WriteFile(chunk, bz, 0644))
+	manifest.Chunks[0] = sha256Sum(bz)
+	mbz, err := json.Marshal(manifest)
+	require.Nil(t, err)
+	require.Nil(t, ioutil.WriteFile(filepath.Join(SnapshotDir(dir, cid.Version), snapshotManifestFile), mbz, 0644))
+	tampered := newSnapshotMultiStore(t)
+	require.NotNil(t, tampered.Restore(dir, cid.Version, cid.Hash))
+	require.Nil(t, tampered.getStoreByName("store1").(KVStore).Get([]byte("key7")))
+}
+
+func sha256Sum(bz []byte) []byte {
+	hash := sha256.Sum256(bz)
+	return hash[:]
+}
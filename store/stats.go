@@ -0,0 +1,49 @@
+package store
+
+import (
+	"sort"
+
+	dbm "github.com/tepleton/tmlibs/db"
+)
+
+// StoreStats are the number of keys of a store in its db, and their size
+// with their values, including the past versions and the IAVL nodes.
+type StoreStats struct {
+	Name string `json:"name"`
+	Keys int64  `json:"keys"`
+	Size int64  `json:"size"` // in bytes
+}
+
+// MultiStoreStats returns the stats of the stores of the multistore in the
+// db, by name, for the stores of its latest version and the ones in storeDBs.
+// The stores in storeDBs are kept in their own db, see MountStoreWithDB.
+func MultiStoreStats(db dbm.DB, storeDBs map[string]dbm.DB) ([]StoreStats, error) {
+	names := make(map[string]bool)
+	for name := range storeDBs {
+		names[name] = true
+	}
+	version := getLatestVersion(db)
+	if version > 0 {
+		cInfo, err := getCommitInfo(db, version)
+		if err != nil {
+			return nil, err
+		}
+		for _, storeInfo := range cInfo.StoreInfos {
+			names[storeInfo.Name] = true
+		}
+	}
+
+	stats := make([]StoreStats, 0, len(names))
+	for name := range names {
+		st := StoreStats{Name: name}
+		iter := storeDB(db, storeDBs[name], name).Iterator(nil, nil)
+		for ; iter.Valid(); iter.Next() {
+			st.Keys++
+			st.Size += int64(len(iter.Key()) + len(iter.Value()))
+		}
+		iter.Close()
+		stats = append(stats, st)
+	}
+	sort.Slice(stats, func(i, j int) bool { return stats[i].Name < stats[j].Name })
+	return stats, nil
+}
@@ -0,0 +1,43 @@
+package store
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	dbm "github.com/tepleton/tmlibs/db"
+
+	sdk "github.com/tepleton/tepleton-sdk/types"
+)
+
+func TestMultiStoreStats(t *testing.T) {
+	db, ownDB := dbm.NewMemDB(), dbm.NewMemDB()
+	multi := NewCommitMultiStore(db)
+	key1, key2 := sdk.NewKVStoreKey("store1"), sdk.NewKVStoreKey("store2")
+	multi.MountStoreWithDB(key1, sdk.StoreTypeIAVL, nil)
+	multi.MountStoreWithDB(key2, sdk.StoreTypeIAVL, ownDB)
+	require.Nil(t, multi.LoadLatestVersion())
+
+	// only the stores given are known before the first commit
+	stats, err := MultiStoreStats(db, map[string]dbm.DB{"store2": ownDB})
+	require.Nil(t, err)
+	require.Equal(t, []StoreStats{{Name: "store2"}}, stats)
+
+	multi.GetKVStore(key1).Set([]byte("k"), []byte("v"))
+	multi.Commit()
+	stats, err = MultiStoreStats(db, map[string]dbm.DB{"store2": ownDB})
+	require.Nil(t, err)
+	require.Len(t, stats, 2)
+	require.Equal(t, "store1", stats[0].Name)
+	require.True(t, stats[0].Keys > 0)
+	require.True(t, stats[0].Size > stats[0].Keys)
+	require.Equal(t, "store2", stats[1].Name)
+
+	// the keys of the store are all in its own db
+	var ownKeys int64
+	iter := ownDB.Iterator(nil, nil)
+	for ; iter.Valid(); iter.Next() {
+		ownKeys++
+	}
+	iter.Close()
+	require.Equal(t, ownKeys, stats[1].Keys)
+}
@@ -0,0 +1,47 @@
+package store
+
+import (
+	dbm "github.com/tepleton/tmlibs/db"
+
+	sdk "github.com/tepleton/tepleton-sdk/types"
+)
+
+var _ KVStore = (*transientStore)(nil)
+var _ CommitStore = (*transientStore)(nil)
+
+// transientStore is an in-memory store for scratch data, wiped on Commit.
+// It has no Merkle tree nor history, and is left out of the app hash.
+type transientStore struct {
+	dbStoreAdapter
+}
+
+func newTransientStore() *transientStore {
+	return &transientStore{dbStoreAdapter{dbm.NewMemDB()}}
+}
+
+// Implements Committer.
+// Wipes the store, as its content only lasts until the commit.
+func (ts *transientStore) Commit() CommitID {
+	ts.dbStoreAdapter = dbStoreAdapter{dbm.NewMemDB()}
+	return CommitID{}
+}
+
+// Implements Committer.
+func (ts *transientStore) LastCommitID() CommitID {
+	return CommitID{}
+}
+
+// Implements Store.
+func (ts *transientStore) GetStoreType() StoreType {
+	return sdk.StoreTypeTransient
+}
+
+// Implements Store.
+func (ts *transientStore) CacheWrap() CacheWrap {
+	return NewCacheKVStore(ts)
+}
+
+// Implements KVStore.
+func (ts *transientStore) Prefix(prefix []byte) KVStore {
+	return prefixStore{ts, prefix}
+}
@@ -0,0 +1,54 @@
+package store
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	dbm "github.com/tepleton/tmlibs/db"
+
+	sdk "github.com/tepleton/tepleton-sdk/types"
+)
+
+func TestTransientStore(t *testing.T) {
+	k, v := []byte("hello"), []byte("world")
+	tstore := newTransientStore()
+
+	require.Nil(t, tstore.Get(k))
+	tstore.Set(k, v)
+	require.Equal(t, v, tstore.Get(k))
+
+	// caches see the wiped store after the commit
+	cache := tstore.CacheWrap().(CacheKVStore)
+	require.Equal(t, CommitID{}, tstore.Commit())
+	require.Nil(t, tstore.Get(k))
+	require.Nil(t, cache.Get(k))
+}
+
+func TestMultiStoreTransient(t *testing.T) {
+	db := dbm.NewMemDB()
+	multi := newMultiStoreWithMounts(db)
+	tkey := sdk.NewTransientStoreKey("transient")
+	multi.MountStoreWithDB(tkey, sdk.StoreTypeTransient, nil)
+	require.Nil(t, multi.LoadLatestVersion())
+
+	// the transient store is left out of the app hash
+	k, v := []byte("hello"), []byte("world")
+	multi.GetKVStore(tkey).Set(k, v)
+	cid := multi.Commit()
+	committed := make(map[StoreKey]CommitStore)
+	for key, store := range multi.stores {
+		if key != tkey {
+			committed[key] = store
+		}
+	}
+	require.Equal(t, hashStores(committed), cid.Hash)
+	require.Nil(t, multi.GetKVStore(tkey).Get(k))
+
+	// and still mounted once the stores are loaded again
+	multi = newMultiStoreWithMounts(db)
+	multi.MountStoreWithDB(tkey, sdk.StoreTypeTransient, nil)
+	require.Nil(t, multi.LoadLatestVersion())
+	require.Equal(t, cid, multi.LastCommitID())
+	multi.GetKVStore(tkey).Set(k, v)
+	require.Equal(t, v, multi.GetKVStore(tkey).Get(k))
+}
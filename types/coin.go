@@ -273,13 +273,22 @@ func (coins Coins) Sort() Coins {
 // Parsing
 
 var (
-	// Denominations can be 3 ~ 16 characters long.
-	reDnm  = `[[:alpha:]][[:alnum:]]{2,15}`
-	reAmt  = `[[:digit:]]+`
-	reSpc  = `[[:space:]]*`
-	reCoin = regexp.MustCompile(fmt.Sprintf(`^(%s)%s(%s)$`, reAmt, reSpc, reDnm))
+	// Denominations can be 3 ~ 16 characters long, optionally prefixed with
+	// the "<port>/<channel>/" of each IBC hop a voucher was received through,
+	// e.g. "transfer/channel-0/steak". Vouchers can nest at most 4 hops deep
+	// and identifiers are at most 64 characters, which bounds their length.
+	reDnm   = `(?:[[:alnum:]\-_.]{1,64}/[[:alnum:]\-_.]{1,64}/){0,4}[[:alpha:]][[:alnum:]]{2,15}`
+	reAmt   = `[[:digit:]]+`
+	reSpc   = `[[:space:]]*`
+	reCoin  = regexp.MustCompile(fmt.Sprintf(`^(%s)%s(%s)$`, reAmt, reSpc, reDnm))
+	reDenom = regexp.MustCompile(fmt.Sprintf(`^%s$`, reDnm))
 )
 
+// IsValidDenom returns whether denom is a well formed denomination.
+func IsValidDenom(denom string) bool {
+	return reDenom.MatchString(denom)
+}
+
 // ParseCoin parses a cli input for one coin type, returning errors if invalid.
 // This returns an error on an empty string as well.
 func ParseCoin(coinStr string) (coin Coin, err error) {
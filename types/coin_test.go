@@ -1,6 +1,7 @@
 package types
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -240,6 +241,12 @@ func TestParse(t *testing.T) {
 		{"11me coin, 12you coin", false, nil}, // no spaces in coin names
 		{"1.2btc", false, nil},                // amount must be integer
 		{"5foo-bar", false, nil},              // once more, only letters in coin name
+		{"7transfer/channel-0/foo", true, Coins{{"transfer/channel-0/foo", NewInt(7)}}},
+		{"7transfer/channel-1/transfer/channel-0/foo", true, Coins{{"transfer/channel-1/transfer/channel-0/foo", NewInt(7)}}},
+		{"7transfer/channel-0/", false, nil},                                 // voucher prefix needs a denomination
+		{"7channel-0/foo", false, nil},                                       // voucher prefix needs a port and a channel
+		{"7" + strings.Repeat("transfer/channel-0/", 5) + "foo", false, nil}, // vouchers nest at most 4 hops
+		{"7transfer/" + strings.Repeat("c", 65) + "/foo", false, nil},        // identifiers are at most 64 characters
 	}
 
 	for _, tc := range cases {
@@ -46,6 +46,9 @@ func NewContext(ms MultiStore, header wrsp.Header, isCheckTx bool, logger log.Lo
 	c = c.WithLogger(logger)
 	c = c.WithSigningValidators(nil)
 	c = c.WithGasMeter(NewInfiniteGasMeter())
+	c = c.WithBlockGasMeter(NewInfiniteGasMeter())
+	c = c.WithGasConfig(DefaultGasConfig())
+	c = c.WithEventManager(NewEventManager())
 	return c
 }
 
@@ -71,7 +74,7 @@ func (c Context) Value(key interface{}) interface{} {
 
 // KVStore fetches a KVStore from the MultiStore.
 func (c Context) KVStore(key StoreKey) KVStore {
-	return c.multiStore().GetKVStoreWithGas(c.GasMeter(), key)
+	return c.multiStore().GetKVStoreWithGas(c.GasMeter(), c.GasConfig(), key)
 }
 
 //----------------------------------------
@@ -132,6 +135,9 @@ const (
 	contextKeyLogger
 	contextKeySigningValidators
 	contextKeyGasMeter
+	contextKeyBlockGasMeter
+	contextKeyGasConfig
+	contextKeyEventManager
 )
 
 // NOTE: Do not expose MultiStore.
@@ -166,6 +172,15 @@ func (c Context) SigningValidators() []wrsp.SigningValidator {
 func (c Context) GasMeter() GasMeter {
 	return c.Value(contextKeyGasMeter).(GasMeter)
 }
+func (c Context) BlockGasMeter() GasMeter {
+	return c.Value(contextKeyBlockGasMeter).(GasMeter)
+}
+func (c Context) GasConfig() GasConfig {
+	return c.Value(contextKeyGasConfig).(GasConfig)
+}
+func (c Context) EventManager() *EventManager {
+	return c.Value(contextKeyEventManager).(*EventManager)
+}
 func (c Context) WithMultiStore(ms MultiStore) Context {
 	return c.withValue(contextKeyMultiStore, ms)
 }
@@ -194,6 +209,15 @@ func (c Context) WithSigningValidators(SigningValidators []wrsp.SigningValidator
 func (c Context) WithGasMeter(meter GasMeter) Context {
 	return c.withValue(contextKeyGasMeter, meter)
 }
+func (c Context) WithBlockGasMeter(meter GasMeter) Context {
+	return c.withValue(contextKeyBlockGasMeter, meter)
+}
+func (c Context) WithGasConfig(config GasConfig) Context {
+	return c.withValue(contextKeyGasConfig, config)
+}
+func (c Context) WithEventManager(em *EventManager) Context {
+	return c.withValue(contextKeyEventManager, em)
+}
 
 // Cache the multistore and return a new cached context. The cached context is
 // written to the context when writeCache is called.
@@ -0,0 +1,375 @@
+package types
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// DecCoin holds a decimal amount of one currency, for the fractions of
+// coins left over by proportional distributions (fees, rewards, commissions)
+type DecCoin struct {
+	Denom  string `json:"denom"`
+	Amount Dec    `json:"amount"`
+}
+
+func NewDecCoin(denom string, amount int64) DecCoin {
+	return DecCoin{
+		Denom:  denom,
+		Amount: NewDec(amount),
+	}
+}
+
+func NewDecCoinFromDec(denom string, amount Dec) DecCoin {
+	return DecCoin{
+		Denom:  denom,
+		Amount: amount,
+	}
+}
+
+func NewDecCoinFromCoin(coin Coin) DecCoin {
+	return DecCoin{
+		Denom:  coin.Denom,
+		Amount: NewDecFromInt(coin.Amount),
+	}
+}
+
+// String provides a human-readable representation of a coin
+func (coin DecCoin) String() string {
+	return fmt.Sprintf("%v%v", coin.Amount, coin.Denom)
+}
+
+// SameDenomAs returns true if the two coins are the same denom
+func (coin DecCoin) SameDenomAs(other DecCoin) bool {
+	return (coin.Denom == other.Denom)
+}
+
+// IsZero returns if this represents no money
+func (coin DecCoin) IsZero() bool {
+	return coin.Amount.IsZero()
+}
+
+// IsEqual returns true if the two coins have the same denom and amount
+func (coin DecCoin) IsEqual(other DecCoin) bool {
+	return coin.SameDenomAs(other) && (coin.Amount.Equal(other.Amount))
+}
+
+// IsPositive returns true if coin amount is positive
+func (coin DecCoin) IsPositive() bool {
+	return (coin.Amount.Sign() == 1)
+}
+
+// IsNotNegative returns true if coin amount is not negative
+func (coin DecCoin) IsNotNegative() bool {
+	return (coin.Amount.Sign() != -1)
+}
+
+// Adds amounts of two coins with same denom
+func (coin DecCoin) Plus(coinB DecCoin) DecCoin {
+	if !coin.SameDenomAs(coinB) {
+		return coin
+	}
+	return DecCoin{coin.Denom, coin.Amount.Add(coinB.Amount)}
+}
+
+// Subtracts amounts of two coins with same denom
+func (coin DecCoin) Minus(coinB DecCoin) DecCoin {
+	if !coin.SameDenomAs(coinB) {
+		return coin
+	}
+	return DecCoin{coin.Denom, coin.Amount.Sub(coinB.Amount)}
+}
+
+// TruncateDecimal returns the coin with the integer part of the amount,
+// and the decimal change left over
+func (coin DecCoin) TruncateDecimal() (Coin, DecCoin) {
+	truncated := coin.Amount.TruncateInt()
+	change := coin.Amount.Sub(NewDecFromInt(truncated))
+	return Coin{coin.Denom, truncated}, DecCoin{coin.Denom, change}
+}
+
+//----------------------------------------
+// DecCoins
+
+// DecCoins is a set of DecCoin, one per currency, sorted like Coins
+type DecCoins []DecCoin
+
+// NewDecCoins converts the coins to decimal coins
+func NewDecCoins(coins Coins) DecCoins {
+	decCoins := make(DecCoins, len(coins))
+	for i, coin := range coins {
+		decCoins[i] = NewDecCoinFromCoin(coin)
+	}
+	return decCoins
+}
+
+func (coins DecCoins) String() string {
+	if len(coins) == 0 {
+		return ""
+	}
+
+	out := ""
+	for _, coin := range coins {
+		out += fmt.Sprintf("%v,", coin.String())
+	}
+	return out[:len(out)-1]
+}
+
+// IsValid asserts the DecCoins are sorted, and don't have 0 amounts
+func (coins DecCoins) IsValid() bool {
+	switch len(coins) {
+	case 0:
+		return true
+	case 1:
+		return !coins[0].IsZero()
+	default:
+		lowDenom := coins[0].Denom
+		for _, coin := range coins[1:] {
+			if coin.Denom <= lowDenom {
+				return false
+			}
+			if coin.IsZero() {
+				return false
+			}
+			// we compare each coin against the last denom
+			lowDenom = coin.Denom
+		}
+		return true
+	}
+}
+
+// Plus combines two sets of coins
+// CONTRACT: Plus will never return DecCoins where one DecCoin has a 0 amount.
+func (coins DecCoins) Plus(coinsB DecCoins) DecCoins {
+	sum := ([]DecCoin)(nil)
+	indexA, indexB := 0, 0
+	lenA, lenB := len(coins), len(coinsB)
+	for {
+		if indexA == lenA {
+			if indexB == lenB {
+				return sum
+			}
+			return append(sum, coinsB[indexB:]...)
+		} else if indexB == lenB {
+			return append(sum, coins[indexA:]...)
+		}
+		coinA, coinB := coins[indexA], coinsB[indexB]
+		switch strings.Compare(coinA.Denom, coinB.Denom) {
+		case -1:
+			sum = append(sum, coinA)
+			indexA++
+		case 0:
+			if coinA.Amount.Add(coinB.Amount).IsZero() {
+				// ignore 0 sum coin type
+			} else {
+				sum = append(sum, coinA.Plus(coinB))
+			}
+			indexA++
+			indexB++
+		case 1:
+			sum = append(sum, coinB)
+			indexB++
+		}
+	}
+}
+
+// Negative returns a set of coins with all amount negative
+func (coins DecCoins) Negative() DecCoins {
+	res := make([]DecCoin, 0, len(coins))
+	for _, coin := range coins {
+		res = append(res, DecCoin{
+			Denom:  coin.Denom,
+			Amount: coin.Amount.Neg(),
+		})
+	}
+	return res
+}
+
+// Minus subtracts a set of coins from another (adds the inverse)
+func (coins DecCoins) Minus(coinsB DecCoins) DecCoins {
+	return coins.Plus(coinsB.Negative())
+}
+
+// MulDec multiplies all the amounts by the decimal, e.g. by the fraction of
+// a distribution going to one recipient. The coins whose amount rounds to
+// 0 are removed.
+func (coins DecCoins) MulDec(d Dec) DecCoins {
+	res := make([]DecCoin, 0, len(coins))
+	for _, coin := range coins {
+		product := DecCoin{
+			Denom:  coin.Denom,
+			Amount: coin.Amount.Mul(d),
+		}
+		if !product.IsZero() {
+			res = append(res, product)
+		}
+	}
+	return res
+}
+
+// QuoDec divides all the amounts by the decimal. The coins whose amount
+// rounds to 0 are removed.
+func (coins DecCoins) QuoDec(d Dec) DecCoins {
+	res := make([]DecCoin, 0, len(coins))
+	for _, coin := range coins {
+		quotient := DecCoin{
+			Denom:  coin.Denom,
+			Amount: coin.Amount.Quo(d),
+		}
+		if !quotient.IsZero() {
+			res = append(res, quotient)
+		}
+	}
+	return res
+}
+
+// TruncateDecimal returns the coins with the integer part of the amounts,
+// and the decimal change left over. Neither holds 0 amounts.
+func (coins DecCoins) TruncateDecimal() (Coins, DecCoins) {
+	var truncated Coins
+	var change DecCoins
+	for _, coin := range coins {
+		truncatedCoin, changeCoin := coin.TruncateDecimal()
+		if !truncatedCoin.IsZero() {
+			truncated = append(truncated, truncatedCoin)
+		}
+		if !changeCoin.IsZero() {
+			change = append(change, changeCoin)
+		}
+	}
+	return truncated, change
+}
+
+// IsZero returns true if there are no coins
+// or all coins are zero.
+func (coins DecCoins) IsZero() bool {
+	for _, coin := range coins {
+		if !coin.IsZero() {
+			return false
+		}
+	}
+	return true
+}
+
+// IsEqual returns true if the two sets of DecCoins have the same value
+func (coins DecCoins) IsEqual(coinsB DecCoins) bool {
+	if len(coins) != len(coinsB) {
+		return false
+	}
+	for i := 0; i < len(coins); i++ {
+		if !coins[i].IsEqual(coinsB[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// IsNotNegative returns true if there is no currency with a negative value
+// (even no coins is true here)
+func (coins DecCoins) IsNotNegative() bool {
+	for _, coin := range coins {
+		if !coin.IsNotNegative() {
+			return false
+		}
+	}
+	return true
+}
+
+// Returns the amount of a denom from coins
+func (coins DecCoins) AmountOf(denom string) Dec {
+	switch len(coins) {
+	case 0:
+		return ZeroDec()
+	case 1:
+		coin := coins[0]
+		if coin.Denom == denom {
+			return coin.Amount
+		}
+		return ZeroDec()
+	default:
+		midIdx := len(coins) / 2 // 2:1, 3:1, 4:2
+		coin := coins[midIdx]
+		if denom < coin.Denom {
+			return coins[:midIdx].AmountOf(denom)
+		} else if denom == coin.Denom {
+			return coin.Amount
+		} else {
+			return coins[midIdx+1:].AmountOf(denom)
+		}
+	}
+}
+
+//----------------------------------------
+// Sort interface
+
+// nolint
+func (coins DecCoins) Len() int           { return len(coins) }
+func (coins DecCoins) Less(i, j int) bool { return coins[i].Denom < coins[j].Denom }
+func (coins DecCoins) Swap(i, j int)      { coins[i], coins[j] = coins[j], coins[i] }
+
+var _ sort.Interface = DecCoins{}
+
+// Sort is a helper function to sort the set of coins inplace
+func (coins DecCoins) Sort() DecCoins {
+	sort.Sort(coins)
+	return coins
+}
+
+//----------------------------------------
+// Parsing
+
+var (
+	reDecAmt  = `[[:digit:]]+(?:\.[[:digit:]]+)?`
+	reDecCoin = regexp.MustCompile(fmt.Sprintf(`^(%s)%s(%s)$`, reDecAmt, reSpc, reDnm))
+)
+
+// ParseDecCoin parses a cli input for one decimal coin, e.g. "1.5steak",
+// returning errors if invalid. This returns an error on an empty string as well.
+func ParseDecCoin(coinStr string) (coin DecCoin, err error) {
+	coinStr = strings.TrimSpace(coinStr)
+
+	matches := reDecCoin.FindStringSubmatch(coinStr)
+	if matches == nil {
+		err = fmt.Errorf("invalid decimal coin expression: %s", coinStr)
+		return
+	}
+	denomStr, amountStr := matches[2], matches[1]
+
+	amount, errDec := NewDecFromStr(amountStr)
+	if errDec != nil {
+		err = fmt.Errorf("invalid decimal coin amount %s: %v", amountStr, errDec.Error())
+		return
+	}
+
+	return DecCoin{denomStr, amount}, nil
+}
+
+// ParseDecCoins will parse out a list of decimal coins separated by commas.
+// If nothing is provided, it returns nil DecCoins.
+// Returned coins are sorted.
+func ParseDecCoins(coinsStr string) (coins DecCoins, err error) {
+	coinsStr = strings.TrimSpace(coinsStr)
+	if len(coinsStr) == 0 {
+		return nil, nil
+	}
+
+	coinStrs := strings.Split(coinsStr, ",")
+	for _, coinStr := range coinStrs {
+		coin, err := ParseDecCoin(coinStr)
+		if err != nil {
+			return nil, err
+		}
+		coins = append(coins, coin)
+	}
+
+	// Sort coins for determinism.
+	coins.Sort()
+
+	// Validate coins before returning.
+	if !coins.IsValid() {
+		return nil, fmt.Errorf("parseDecCoins invalid: %#v", coins)
+	}
+
+	return coins, nil
+}
@@ -0,0 +1,117 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDecCoinTruncate(t *testing.T) {
+	coin := NewDecCoinFromDec("steak", NewDecWithPrec(25, 1))
+	truncated, change := coin.TruncateDecimal()
+	require.True(t, truncated.IsEqual(NewCoin("steak", 2)))
+	require.True(t, change.IsEqual(NewDecCoinFromDec("steak", NewDecWithPrec(5, 1))))
+
+	coins := DecCoins{
+		NewDecCoinFromDec("atom", NewDecWithPrec(5, 1)),
+		NewDecCoin("mycoin", 3),
+		NewDecCoinFromDec("steak", NewDecWithPrec(125, 2)),
+	}
+	truncatedCoins, changeCoins := coins.TruncateDecimal()
+	require.True(t, truncatedCoins.IsEqual(Coins{NewCoin("mycoin", 3), NewCoin("steak", 1)}))
+	require.True(t, changeCoins.IsEqual(DecCoins{
+		NewDecCoinFromDec("atom", NewDecWithPrec(5, 1)),
+		NewDecCoinFromDec("steak", NewDecWithPrec(25, 2)),
+	}))
+	require.True(t, truncatedCoins.IsValid())
+	require.True(t, changeCoins.IsValid())
+
+	// the truncated coins and the change add up to the coins
+	require.True(t, NewDecCoins(truncatedCoins).Plus(changeCoins).IsEqual(coins))
+}
+
+func TestDecCoinsArithmetic(t *testing.T) {
+	one := NewDecCoin("atom", 1)
+	half := NewDecCoinFromDec("atom", NewDecWithPrec(5, 1))
+	steak := NewDecCoin("steak", 2)
+
+	cases := []struct {
+		inputOne, inputTwo, expected DecCoins
+	}{
+		{DecCoins{one}, DecCoins{half}, DecCoins{NewDecCoinFromDec("atom", NewDecWithPrec(15, 1))}},
+		{DecCoins{one}, DecCoins{steak}, DecCoins{one, steak}},
+		{DecCoins{half, steak}, DecCoins{half}, DecCoins{one, steak}},
+		{DecCoins{half}, DecCoins{half}.Negative(), nil},
+	}
+	for tcIndex, tc := range cases {
+		res := tc.inputOne.Plus(tc.inputTwo)
+		require.True(t, res.IsValid())
+		require.True(t, tc.expected.IsEqual(res), "tc %d: expected %v, got %v", tcIndex, tc.expected, res)
+	}
+
+	require.True(t, DecCoins{one, steak}.Minus(DecCoins{half}).IsEqual(DecCoins{half, steak}))
+	require.False(t, DecCoins{half}.Minus(DecCoins{one}).IsNotNegative())
+
+	// a third of the coins, with the 18th decimal rounded
+	third := DecCoins{one, steak}.MulDec(NewDec(1).Quo(NewDec(3)))
+	require.Equal(t, "0.333333333333333333atom,0.666666666666666666steak", third.String())
+	require.True(t, DecCoins{one, steak}.QuoDec(NewDec(2)).IsEqual(DecCoins{half, NewDecCoin("steak", 1)}))
+	require.True(t, DecCoins{one}.MulDec(ZeroDec()).IsZero())
+
+	require.True(t, DecCoins{half, steak}.AmountOf("atom").Equal(NewDecWithPrec(5, 1)))
+	require.True(t, DecCoins{half, steak}.AmountOf("steak").Equal(NewDec(2)))
+	require.True(t, DecCoins{half, steak}.AmountOf("photon").IsZero())
+}
+
+func TestDecCoinsValidAndSort(t *testing.T) {
+	good := DecCoins{NewDecCoin("gas", 1), NewDecCoin("mineral", 1), NewDecCoin("tree", 1)}
+	unsorted := DecCoins{NewDecCoin("tree", 1), NewDecCoin("gas", 1), NewDecCoin("mineral", 1)}
+	dup := DecCoins{NewDecCoin("gas", 1), NewDecCoin("gas", 1)}
+	zero := DecCoins{NewDecCoin("gas", 0), NewDecCoin("mineral", 1)}
+
+	require.True(t, good.IsValid())
+	require.False(t, unsorted.IsValid())
+	require.False(t, dup.IsValid())
+	require.False(t, zero.IsValid())
+	require.True(t, unsorted.Sort().IsValid())
+	require.True(t, unsorted.IsEqual(good))
+}
+
+func TestParseDecCoins(t *testing.T) {
+	cases := []struct {
+		input    string
+		valid    bool
+		expected DecCoins
+	}{
+		{"", true, nil},
+		{"1steak", true, DecCoins{NewDecCoin("steak", 1)}},
+		{"1.5 steak, 0.25atom", true, DecCoins{
+			NewDecCoinFromDec("atom", NewDecWithPrec(25, 2)),
+			NewDecCoinFromDec("steak", NewDecWithPrec(15, 1)),
+		}},
+		{"0.000000000000000001chainA/steak", true, DecCoins{
+			NewDecCoinFromDec("chainA/steak", NewDecWithPrec(1, 18)),
+		}},
+		{"0.0000000000000000001steak", false, nil}, // too many decimals
+		{"1.steak", false, nil},
+		{"-1steak", false, nil},
+		{"1steak,2steak", false, nil}, // duplicate denoms
+		{"0steak", false, nil},        // zero amount
+	}
+
+	for tcIndex, tc := range cases {
+		res, err := ParseDecCoins(tc.input)
+		if !tc.valid {
+			require.NotNil(t, err, "%s: %#v, tc #%d", tc.input, res, tcIndex)
+			continue
+		}
+		require.Nil(t, err, "%s: %+v", tc.input, err)
+		require.True(t, tc.expected.IsEqual(res), "tc #%d: expected %v, got %v", tcIndex, tc.expected, res)
+	}
+
+	// the coins parse back from their string
+	coins := DecCoins{NewDecCoinFromDec("atom", NewDecWithPrec(25, 2)), NewDecCoin("steak", 7)}
+	res, err := ParseDecCoins(coins.String())
+	require.Nil(t, err)
+	require.True(t, coins.IsEqual(res))
+}
@@ -0,0 +1,344 @@
+package types
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// Dec is a signed decimal with a fixed precision of 18 digits after the
+// decimal point, stored as the integer of its value times 10^18.
+// Results of multiplications and divisions are rounded with bankers rounding.
+//
+// NOTE: never use new(Dec) or else we will panic
+// unmarshalling into the nil embedded big.Int
+type Dec struct {
+	*big.Int `json:"int"`
+}
+
+// number of digits after the decimal point
+const Precision = 18
+
+var (
+	precisionReuse       = new(big.Int).Exp(big.NewInt(10), big.NewInt(Precision), nil)
+	fivePrecision        = new(big.Int).Quo(precisionReuse, big.NewInt(2))
+	precisionMultipliers [Precision + 1]*big.Int
+)
+
+func init() {
+	for i := 0; i <= Precision; i++ {
+		precisionMultipliers[i] = precisionMultiplier(int64(i))
+	}
+}
+
+// the multiplier of a value with prec digits after the decimal point
+func precisionMultiplier(prec int64) *big.Int {
+	if prec < 0 || prec > Precision {
+		panic(fmt.Sprintf("too much precision, maximum %v, provided %v", Precision, prec))
+	}
+	if precisionMultipliers[prec] != nil {
+		return precisionMultipliers[prec]
+	}
+	return new(big.Int).Exp(big.NewInt(10), big.NewInt(Precision-prec), nil)
+}
+
+// nolint - common values
+func ZeroDec() Dec { return Dec{new(big.Int)} }
+func OneDec() Dec  { return Dec{new(big.Int).Set(precisionReuse)} }
+
+// NewDec creates a Dec from an integer
+func NewDec(i int64) Dec {
+	return NewDecWithPrec(i, 0)
+}
+
+// NewDecWithPrec creates a Dec from an integer with prec digits after the
+// decimal point, e.g. NewDecWithPrec(15, 1) is 1.5
+func NewDecWithPrec(i, prec int64) Dec {
+	return Dec{new(big.Int).Mul(big.NewInt(i), precisionMultiplier(prec))}
+}
+
+// NewDecFromBigInt creates a Dec from a big.Int
+func NewDecFromBigInt(i *big.Int) Dec {
+	return Dec{new(big.Int).Mul(i, precisionReuse)}
+}
+
+// NewDecFromInt creates a Dec from an Int
+func NewDecFromInt(i Int) Dec {
+	return NewDecFromBigInt(i.BigInt())
+}
+
+// NewDecFromStr creates a Dec from a decimal or integer string, e.g. "-1.5".
+// It errors if the string has more than Precision digits after the decimal point.
+func NewDecFromStr(str string) (d Dec, err Error) {
+	if len(str) == 0 {
+		return d, ErrUnknownRequest("decimal string is empty")
+	}
+
+	// first extract any negative symbol
+	neg := false
+	if str[0] == '-' {
+		neg = true
+		str = str[1:]
+	}
+	if len(str) == 0 {
+		return d, ErrUnknownRequest("decimal string is empty")
+	}
+
+	strs := strings.Split(str, ".")
+	lenDecs := 0
+	combinedStr := strs[0]
+	switch len(strs) {
+	case 1:
+	case 2:
+		if len(strs[0]) == 0 || len(strs[1]) == 0 {
+			return d, ErrUnknownRequest("not a decimal string")
+		}
+		lenDecs = len(strs[1])
+		combinedStr += strs[1]
+	default:
+		return d, ErrUnknownRequest("not a decimal string")
+	}
+	if lenDecs > Precision {
+		return d, ErrUnknownRequest(
+			fmt.Sprintf("too much precision, maximum %v, len decimal %v", Precision, lenDecs))
+	}
+	for _, c := range combinedStr {
+		if c < '0' || c > '9' {
+			return d, ErrUnknownRequest("not a decimal string")
+		}
+	}
+
+	// add some extra zero's to correct to the Precision factor
+	combinedStr += strings.Repeat("0", Precision-lenDecs)
+	combined, ok := new(big.Int).SetString(combinedStr, 10)
+	if !ok {
+		return d, ErrUnknownRequest("not a decimal string")
+	}
+	if neg {
+		combined.Neg(combined)
+	}
+	return Dec{combined}, nil
+}
+
+// MustNewDecFromStr is NewDecFromStr, panicking on error, for constants and tests
+func MustNewDecFromStr(str string) Dec {
+	d, err := NewDecFromStr(str)
+	if err != nil {
+		panic(err)
+	}
+	return d
+}
+
+// nolint
+func (d Dec) IsNil() bool       { return d.Int == nil }                 // is decimal nil
+func (d Dec) IsZero() bool      { return (d.Int).Sign() == 0 }          // is equal to zero
+func (d Dec) Equal(d2 Dec) bool { return (d.Int).Cmp(d2.Int) == 0 }     // equal decimals
+func (d Dec) GT(d2 Dec) bool    { return (d.Int).Cmp(d2.Int) > 0 }      // greater than
+func (d Dec) GTE(d2 Dec) bool   { return (d.Int).Cmp(d2.Int) >= 0 }     // greater than or equal
+func (d Dec) LT(d2 Dec) bool    { return (d.Int).Cmp(d2.Int) < 0 }      // less than
+func (d Dec) LTE(d2 Dec) bool   { return (d.Int).Cmp(d2.Int) <= 0 }     // less than or equal
+func (d Dec) Neg() Dec          { return Dec{new(big.Int).Neg(d.Int)} } // reverse the decimal sign
+func (d Dec) Abs() Dec          { return Dec{new(big.Int).Abs(d.Int)} } // absolute value
+
+// Add - addition
+func (d Dec) Add(d2 Dec) Dec {
+	return Dec{new(big.Int).Add(d.Int, d2.Int)}
+}
+
+// Sub - subtraction
+func (d Dec) Sub(d2 Dec) Dec {
+	return Dec{new(big.Int).Sub(d.Int, d2.Int)}
+}
+
+// Mul - multiplication, rounded to Precision with bankers rounding
+func (d Dec) Mul(d2 Dec) Dec {
+	mul := new(big.Int).Mul(d.Int, d2.Int)
+	return Dec{chopPrecisionAndRound(mul)}
+}
+
+// MulInt - multiplication by an Int
+func (d Dec) MulInt(i Int) Dec {
+	return Dec{new(big.Int).Mul(d.Int, i.BigInt())}
+}
+
+// Quo - quotient, rounded to Precision with bankers rounding
+func (d Dec) Quo(d2 Dec) Dec {
+	// multiply twice the precision so the remainder can be rounded
+	mul := new(big.Int).Mul(d.Int, precisionReuse)
+	mul.Mul(mul, precisionReuse)
+	quo := new(big.Int).Quo(mul, d2.Int)
+	return Dec{chopPrecisionAndRound(quo)}
+}
+
+// QuoInt - quotient by an Int, truncated
+func (d Dec) QuoInt(i Int) Dec {
+	return Dec{new(big.Int).Quo(d.Int, i.BigInt())}
+}
+
+// String returns the decimal with all its Precision digits, e.g. "-1.500000000000000000"
+func (d Dec) String() string {
+	if d.Int == nil {
+		return ZeroDec().String()
+	}
+	str := new(big.Int).Abs(d.Int).String()
+	if len(str) <= Precision {
+		str = strings.Repeat("0", Precision+1-len(str)) + str
+	}
+	intStr, decStr := str[:len(str)-Precision], str[len(str)-Precision:]
+	if d.Int.Sign() < 0 {
+		intStr = "-" + intStr
+	}
+	return intStr + "." + decStr
+}
+
+// chop the Precision digits of the integer, rounding the remainder with
+// bankers rounding: halves are rounded to the even number
+//
+// NOTE: mutates d
+func chopPrecisionAndRound(d *big.Int) *big.Int {
+	// round the absolute value, so negative halves also go to the even number
+	if d.Sign() == -1 {
+		d.Neg(d)
+		d = chopPrecisionAndRound(d)
+		return d.Neg(d)
+	}
+
+	quo, rem := new(big.Int), new(big.Int)
+	quo.QuoRem(d, precisionReuse, rem)
+	if rem.Sign() == 0 {
+		return quo
+	}
+
+	switch rem.Cmp(fivePrecision) {
+	case -1:
+		return quo
+	case 1:
+		return quo.Add(quo, oneInt)
+	default: // bankers rounding must take place
+		if quo.Bit(0) == 0 {
+			return quo
+		}
+		return quo.Add(quo, oneInt)
+	}
+}
+
+var oneInt = big.NewInt(1)
+
+// RoundInt64 rounds the decimal to an integer with bankers rounding
+func (d Dec) RoundInt64() int64 {
+	chopped := chopPrecisionAndRound(new(big.Int).Set(d.Int))
+	if !chopped.IsInt64() {
+		panic("Int64() out of bound")
+	}
+	return chopped.Int64()
+}
+
+// RoundInt rounds the decimal to an Int with bankers rounding
+func (d Dec) RoundInt() Int {
+	return NewIntFromBigInt(chopPrecisionAndRound(new(big.Int).Set(d.Int)))
+}
+
+// TruncateInt64 truncates the decimals of the number
+func (d Dec) TruncateInt64() int64 {
+	quo := new(big.Int).Quo(d.Int, precisionReuse)
+	if !quo.IsInt64() {
+		panic("Int64() out of bound")
+	}
+	return quo.Int64()
+}
+
+// TruncateInt truncates the decimals of the number to an Int
+func (d Dec) TruncateInt() Int {
+	return NewIntFromBigInt(new(big.Int).Quo(d.Int, precisionReuse))
+}
+
+// ToLeftPadded rounds the decimal to an integer and left pads it with zeros
+// up to totalDigits, so that the strings sort like the numbers
+func (d Dec) ToLeftPadded(totalDigits int8) string {
+	intStr := d.RoundInt().String()
+	fcode := `%0` + strconv.Itoa(int(totalDigits)) + `s`
+	return fmt.Sprintf(fcode, intStr)
+}
+
+//___________________________________________________________________________________
+
+// MarshalAmino defines custom encoding scheme
+func (d Dec) MarshalAmino() (string, error) {
+	if d.Int == nil {
+		d.Int = new(big.Int)
+	}
+	bz, err := d.Int.MarshalText()
+	return string(bz), err
+}
+
+// UnmarshalAmino defines custom decoding scheme
+func (d *Dec) UnmarshalAmino(text string) (err error) {
+	tempInt := new(big.Int)
+	err = tempInt.UnmarshalText([]byte(text))
+	if err != nil {
+		return err
+	}
+	d.Int = tempInt
+	return nil
+}
+
+// MarshalJSON encodes the decimal as its string, e.g. "1.500000000000000000"
+func (d Dec) MarshalJSON() ([]byte, error) {
+	return json.Marshal(d.String())
+}
+
+// UnmarshalJSON decodes the decimal from its string
+func (d *Dec) UnmarshalJSON(bz []byte) error {
+	var text string
+	err := json.Unmarshal(bz, &text)
+	if err != nil {
+		return err
+	}
+	newDec, err2 := NewDecFromStr(text)
+	if err2 != nil {
+		return err2
+	}
+	d.Int = newDec.Int
+	return nil
+}
+
+//___________________________________________________________________________________
+// helpers
+
+// test if two decimal arrays are equal
+func DecsEqual(d1s, d2s []Dec) bool {
+	if len(d1s) != len(d2s) {
+		return false
+	}
+
+	for i, d1 := range d1s {
+		if !d1.Equal(d2s[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// intended to be used with require/assert:  require.True(DecEq(...))
+func DecEq(t *testing.T, exp, got Dec) (*testing.T, bool, string, Dec, Dec) {
+	return t, exp.Equal(got), "expected:\t%v\ngot:\t\t%v", exp, got
+}
+
+// MinDec returns the smallest of the decimals
+func MinDec(d1, d2 Dec) Dec {
+	if d1.LT(d2) {
+		return d1
+	}
+	return d2
+}
+
+// MaxDec returns the largest of the decimals
+func MaxDec(d1, d2 Dec) Dec {
+	if d1.LT(d2) {
+		return d2
+	}
+	return d1
+}
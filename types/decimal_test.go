@@ -0,0 +1,227 @@
+package types
+
+import (
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewDecFromStr(t *testing.T) {
+	largeBigInt, success := new(big.Int).SetString("3144605511029693144278234343371835", 10)
+	require.True(t, success)
+	tests := []struct {
+		decimalStr string
+		expErr     bool
+		exp        Dec
+	}{
+		{"", true, Dec{}},
+		{"-", true, Dec{}},
+		{"0", false, NewDec(0)},
+		{"1", false, NewDec(1)},
+		{"1.1", false, NewDecWithPrec(11, 1)},
+		{"0.75", false, NewDecWithPrec(75, 2)},
+		{"-0.75", false, NewDecWithPrec(-75, 2)},
+		{"0.000000000000000001", false, NewDecWithPrec(1, 18)},
+		{"0.0000000000000000001", true, Dec{}},
+		{"3144605511029693144278234343371835", false, NewDecFromBigInt(largeBigInt)},
+		{"1.", true, Dec{}},
+		{".1", true, Dec{}},
+		{"1.1.1", true, Dec{}},
+		{"1a", true, Dec{}},
+		{"-1-", true, Dec{}},
+	}
+
+	for tcIndex, tc := range tests {
+		res, err := NewDecFromStr(tc.decimalStr)
+		if tc.expErr {
+			require.NotNil(t, err, "error expected, tc #%d", tcIndex)
+			continue
+		}
+		require.Nil(t, err, "unexpected error, tc #%d", tcIndex)
+		require.True(t, res.Equal(tc.exp), "equality was incorrect, tc #%d", tcIndex)
+	}
+}
+
+func TestDecString(t *testing.T) {
+	tests := []struct {
+		d    Dec
+		want string
+	}{
+		{NewDec(0), "0.000000000000000000"},
+		{NewDec(1), "1.000000000000000000"},
+		{NewDec(10), "10.000000000000000000"},
+		{NewDec(12340), "12340.000000000000000000"},
+		{NewDecWithPrec(12340, 4), "1.234000000000000000"},
+		{NewDecWithPrec(12340, 5), "0.123400000000000000"},
+		{NewDecWithPrec(12340, 8), "0.000123400000000000"},
+		{NewDecWithPrec(1009009009009009009, 17), "10.090090090090090090"},
+		{NewDecWithPrec(-15, 1), "-1.500000000000000000"},
+	}
+	for tcIndex, tc := range tests {
+		require.Equal(t, tc.want, tc.d.String(), "bad String(), index: %v", tcIndex)
+		res, err := NewDecFromStr(tc.want)
+		require.Nil(t, err)
+		require.True(t, tc.d.Equal(res), "bad round trip, index: %v", tcIndex)
+	}
+}
+
+func TestDecArithmetic(t *testing.T) {
+	tests := []struct {
+		d1, d2                         Dec
+		expMul, expQuo, expAdd, expSub Dec
+	}{
+		// d1         d2         MUL           DIV           ADD           SUB
+		{NewDec(0), NewDec(0), NewDec(0), NewDec(0), NewDec(0), NewDec(0)},
+		{NewDec(1), NewDec(0), NewDec(0), NewDec(0), NewDec(1), NewDec(1)},
+		{NewDec(0), NewDec(1), NewDec(0), NewDec(0), NewDec(1), NewDec(-1)},
+		{NewDec(0), NewDec(-1), NewDec(0), NewDec(0), NewDec(-1), NewDec(1)},
+		{NewDec(-1), NewDec(0), NewDec(0), NewDec(0), NewDec(-1), NewDec(-1)},
+
+		{NewDec(1), NewDec(1), NewDec(1), NewDec(1), NewDec(2), NewDec(0)},
+		{NewDec(-1), NewDec(-1), NewDec(1), NewDec(1), NewDec(-2), NewDec(0)},
+		{NewDec(1), NewDec(-1), NewDec(-1), NewDec(-1), NewDec(0), NewDec(2)},
+		{NewDec(-1), NewDec(1), NewDec(-1), NewDec(-1), NewDec(0), NewDec(-2)},
+
+		{NewDec(3), NewDec(7), NewDec(21), NewDecWithPrec(428571428571428571, 18), NewDec(10), NewDec(-4)},
+		{NewDec(2), NewDec(4), NewDec(8), NewDecWithPrec(5, 1), NewDec(6), NewDec(-2)},
+		{NewDec(100), NewDec(100), NewDec(10000), NewDec(1), NewDec(200), NewDec(0)},
+
+		{NewDecWithPrec(15, 1), NewDecWithPrec(15, 1), NewDecWithPrec(225, 2),
+			NewDec(1), NewDec(3), NewDec(0)},
+		{NewDecWithPrec(3333, 4), NewDecWithPrec(333, 4), NewDecWithPrec(1109889, 8),
+			MustNewDecFromStr("10.009009009009009009"), NewDecWithPrec(3666, 4), NewDecWithPrec(3, 1)},
+	}
+
+	for tcIndex, tc := range tests {
+		resAdd := tc.d1.Add(tc.d2)
+		resSub := tc.d1.Sub(tc.d2)
+		resMul := tc.d1.Mul(tc.d2)
+		require.True(t, tc.expAdd.Equal(resAdd), "exp %v, res %v, tc %d", tc.expAdd, resAdd, tcIndex)
+		require.True(t, tc.expSub.Equal(resSub), "exp %v, res %v, tc %d", tc.expSub, resSub, tcIndex)
+		require.True(t, tc.expMul.Equal(resMul), "exp %v, res %v, tc %d", tc.expMul, resMul, tcIndex)
+
+		if tc.d2.IsZero() { // panic for divide by zero
+			require.Panics(t, func() { tc.d1.Quo(tc.d2) })
+		} else {
+			resQuo := tc.d1.Quo(tc.d2)
+			require.True(t, tc.expQuo.Equal(resQuo), "exp %v, res %v, tc %d", tc.expQuo.String(), resQuo.String(), tcIndex)
+		}
+	}
+}
+
+func TestBankerRoundChop(t *testing.T) {
+	tests := []struct {
+		d1  Dec
+		exp int64
+	}{
+		{MustNewDecFromStr("0.25"), 0},
+		{MustNewDecFromStr("0"), 0},
+		{MustNewDecFromStr("1"), 1},
+		{MustNewDecFromStr("0.75"), 1},
+		{MustNewDecFromStr("0.5"), 0},
+		{MustNewDecFromStr("7.5"), 8},
+		{MustNewDecFromStr("1.5"), 2},
+		{MustNewDecFromStr("2.5"), 2},
+		{MustNewDecFromStr("0.545"), 1}, // 0.545-> 1 even though 5 is first decimal and 1 not even
+		{MustNewDecFromStr("1.545"), 2},
+		{MustNewDecFromStr("-1.5"), -2},
+		{MustNewDecFromStr("-2.5"), -2},
+		{MustNewDecFromStr("-2.6"), -3},
+	}
+
+	for tcIndex, tc := range tests {
+		require.Equal(t, tc.exp, tc.d1.RoundInt64(), "exp %v, res %v, tc %d", tc.exp, tc.d1.RoundInt64(), tcIndex)
+		require.Equal(t, NewInt(tc.exp), tc.d1.RoundInt(), "tc %d", tcIndex)
+	}
+
+	// the 19th decimal of products is rounded to the even number
+	half := NewDecWithPrec(5, 1)
+	require.True(t, NewDecWithPrec(1, 18).Mul(half).IsZero())
+	require.True(t, NewDecWithPrec(3, 18).Mul(half).Equal(NewDecWithPrec(2, 18)))
+}
+
+func TestDecTruncate(t *testing.T) {
+	tests := []struct {
+		d1  Dec
+		exp int64
+	}{
+		{MustNewDecFromStr("0"), 0},
+		{MustNewDecFromStr("0.25"), 0},
+		{MustNewDecFromStr("0.75"), 0},
+		{MustNewDecFromStr("1"), 1},
+		{MustNewDecFromStr("1.5"), 1},
+		{MustNewDecFromStr("7.5"), 7},
+		{MustNewDecFromStr("-1.5"), -1},
+	}
+
+	for tcIndex, tc := range tests {
+		require.Equal(t, tc.exp, tc.d1.TruncateInt64(), "tc %d", tcIndex)
+		require.Equal(t, NewInt(tc.exp), tc.d1.TruncateInt(), "tc %d", tcIndex)
+	}
+}
+
+func TestDecToLeftPadded(t *testing.T) {
+	tests := []struct {
+		dec    Dec
+		digits int8
+		exp    string
+	}{
+		{NewDecWithPrec(100, 2), 8, "00000001"},
+		{NewDecWithPrec(1000000, 2), 8, "00010000"},
+		{NewDecWithPrec(1000000, 2), 10, "0000010000"},
+		{NewDecWithPrec(1000000, 2), 1, "10000"},
+	}
+	for tcIndex, tc := range tests {
+		require.Equal(t, tc.exp, tc.dec.ToLeftPadded(tc.digits), "tc %d", tcIndex)
+	}
+}
+
+func TestDecSerialization(t *testing.T) {
+	d := NewDecWithPrec(-12345, 3)
+
+	bz, err := cdc.MarshalBinary(d)
+	require.Nil(t, err)
+	var d2 Dec
+	require.Nil(t, cdc.UnmarshalBinary(bz, &d2))
+	require.True(t, d.Equal(d2))
+
+	bz, err = json.Marshal(d)
+	require.Nil(t, err)
+	require.Equal(t, `"-12.345000000000000000"`, string(bz))
+	var d3 Dec
+	require.Nil(t, json.Unmarshal(bz, &d3))
+	require.True(t, d.Equal(d3))
+
+	// embedded in a struct
+	type testDEmbedStruct struct {
+		Field1 string `json:"f1"`
+		Field2 int    `json:"f2"`
+		Field3 Dec    `json:"f3"`
+	}
+	obj := testDEmbedStruct{"foo", 10, NewDecWithPrec(1, 3)}
+	bz, err = cdc.MarshalJSON(obj)
+	require.Nil(t, err)
+	require.Equal(t, `{"f1":"foo","f2":10,"f3":"0.001000000000000000"}`, string(bz))
+	var obj2 testDEmbedStruct
+	require.Nil(t, cdc.UnmarshalJSON(bz, &obj2))
+	require.True(t, obj.Field3.Equal(obj2.Field3))
+}
+
+func TestDecsEqual(t *testing.T) {
+	tests := []struct {
+		d1s, d2s []Dec
+		eq       bool
+	}{
+		{[]Dec{NewDec(0)}, []Dec{NewDec(0)}, true},
+		{[]Dec{NewDec(0)}, []Dec{NewDec(1)}, false},
+		{[]Dec{NewDec(0)}, []Dec{}, false},
+		{[]Dec{NewDec(0), NewDec(1)}, []Dec{NewDec(0), NewDec(1)}, true},
+		{[]Dec{NewDec(1), NewDec(0)}, []Dec{NewDec(0), NewDec(1)}, false},
+	}
+
+	for tcIndex, tc := range tests {
+		require.Equal(t, tc.eq, DecsEqual(tc.d1s, tc.d2s), "tc %d", tcIndex)
+	}
+}
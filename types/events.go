@@ -0,0 +1,128 @@
+package types
+
+import (
+	"strings"
+)
+
+// Attribute is a key-value pair of an event, both human readable strings
+type Attribute struct {
+	Key   string `json:"key"`
+	Value string `json:"value"`
+}
+
+// NewAttribute creates an attribute
+func NewAttribute(key, value string) Attribute {
+	return Attribute{Key: key, Value: value}
+}
+
+// String returns the attribute as key=value
+func (a Attribute) String() string {
+	return a.Key + "=" + a.Value
+}
+
+// Event is something that happened while processing a message or a block,
+// e.g. a transfer, described by its type and attributes.
+// Events are indexed as tags with the key "<type>.<attribute key>", so that
+// clients can search for them, e.g. with "transfer.recipient='<address>'".
+type Event struct {
+	Type       string      `json:"type"`
+	Attributes []Attribute `json:"attributes"`
+}
+
+// NewEvent creates an event of the given type
+func NewEvent(ty string, attrs ...Attribute) Event {
+	return Event{Type: ty, Attributes: attrs}
+}
+
+// AppendAttributes returns the event with the attributes added
+func (e Event) AppendAttributes(attrs ...Attribute) Event {
+	e.Attributes = append(e.Attributes[:len(e.Attributes):len(e.Attributes)], attrs...)
+	return e
+}
+
+// Events is a list of events, in the order they were emitted
+type Events []Event
+
+// AppendEvent returns the events with the event added
+func (e Events) AppendEvent(event Event) Events {
+	return append(e, event)
+}
+
+// AppendEvents returns the events with the other events added
+func (e Events) AppendEvents(events Events) Events {
+	return append(e, events...)
+}
+
+// ToTags turns the events into tags for indexing, one per attribute,
+// with the key "<type>.<attribute key>"
+func (e Events) ToTags() Tags {
+	tags := EmptyTags()
+	for _, event := range e {
+		for _, attr := range event.Attributes {
+			tags = tags.AppendTag(EventKey(event.Type, attr.Key), []byte(attr.Value))
+		}
+	}
+	return tags
+}
+
+// EventKey returns the tag key of an event attribute, e.g. "message.action"
+func EventKey(ty, attrKey string) string {
+	return ty + "." + attrKey
+}
+
+// SplitEventKey splits a tag key into the event type and attribute key.
+// It returns false if the key is not an event key.
+func SplitEventKey(key string) (ty, attrKey string, ok bool) {
+	i := strings.Index(key, ".")
+	if i <= 0 || i == len(key)-1 {
+		return "", "", false
+	}
+	return key[:i], key[i+1:], true
+}
+
+//__________________________________________________
+
+// EventManager collects the events emitted while processing a message or a
+// block. The context holds a pointer to it, so that the events emitted in
+// the handlers and keepers reach BaseApp.
+type EventManager struct {
+	events Events
+}
+
+// NewEventManager creates an empty event manager
+func NewEventManager() *EventManager {
+	return &EventManager{EmptyEvents()}
+}
+
+// EmptyEvents returns a new empty list of events
+func EmptyEvents() Events {
+	return make(Events, 0)
+}
+
+// Events returns the events emitted so far
+func (em *EventManager) Events() Events {
+	return em.events
+}
+
+// EmitEvent adds an event to the manager
+func (em *EventManager) EmitEvent(event Event) {
+	em.events = em.events.AppendEvent(event)
+}
+
+// EmitEvents adds events to the manager
+func (em *EventManager) EmitEvents(events Events) {
+	em.events = em.events.AppendEvents(events)
+}
+
+//__________________________________________________
+
+// common event types and attribute keys
+const (
+	// EventTypeMessage is emitted by each handler, with the module and the
+	// action of the message
+	EventTypeMessage = "message"
+
+	AttributeKeyAction = "action"
+	AttributeKeyModule = "module"
+	AttributeKeySender = "sender"
+)
@@ -0,0 +1,75 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/tepleton/tmlibs/log"
+
+	wrsp "github.com/tepleton/tepleton/wrsp/types"
+)
+
+func TestEventsToTags(t *testing.T) {
+	events := EmptyEvents().
+		AppendEvent(NewEvent(EventTypeMessage,
+			NewAttribute(AttributeKeyModule, "bank"),
+			NewAttribute(AttributeKeyAction, "send"),
+		)).
+		AppendEvents(Events{NewEvent("transfer", NewAttribute("recipient", "addr"))})
+
+	require.Equal(t, Tags{
+		MakeTag("message.module", []byte("bank")),
+		MakeTag("message.action", []byte("send")),
+		MakeTag("transfer.recipient", []byte("addr")),
+	}, events.ToTags())
+	require.Equal(t, Tags{}, EmptyEvents().ToTags())
+}
+
+func TestAppendAttributes(t *testing.T) {
+	base := NewEvent("transfer", NewAttribute("sender", "a"))
+	e1 := base.AppendAttributes(NewAttribute("recipient", "b"))
+	e2 := base.AppendAttributes(NewAttribute("recipient", "c"))
+
+	// appending does not alter the event appended to
+	require.Equal(t, []Attribute{NewAttribute("sender", "a")}, base.Attributes)
+	require.Equal(t, "recipient=b", e1.Attributes[1].String())
+	require.Equal(t, "recipient=c", e2.Attributes[1].String())
+}
+
+func TestEventManager(t *testing.T) {
+	em := NewEventManager()
+	require.Empty(t, em.Events())
+
+	em.EmitEvent(NewEvent("a"))
+	em.EmitEvents(Events{NewEvent("b"), NewEvent("c")})
+	require.Equal(t, Events{NewEvent("a"), NewEvent("b"), NewEvent("c")}, em.Events())
+
+	// the context shares its event manager with the contexts derived from it
+	ctx := NewContext(nil, wrsp.Header{}, false, log.NewNopLogger()).WithEventManager(em)
+	ctx.WithTxBytes([]byte("tx")).EventManager().EmitEvent(NewEvent("d"))
+	require.Equal(t, 4, len(em.Events()))
+}
+
+func TestSplitEventKey(t *testing.T) {
+	cases := []struct {
+		key         string
+		ty, attrKey string
+		ok          bool
+	}{
+		{"message.action", "message", "action", true},
+		{"transfer.recipient_bech32", "transfer", "recipient_bech32", true},
+		{"tx.hash", "tx", "hash", true},
+		{"a.b.c", "a", "b.c", true},
+		{"action", "", "", false},
+		{".action", "", "", false},
+		{"message.", "", "", false},
+	}
+	for tcIndex, tc := range cases {
+		ty, attrKey, ok := SplitEventKey(tc.key)
+		require.Equal(t, tc.ok, ok, "tc #%d", tcIndex)
+		require.Equal(t, tc.ty, ty, "tc #%d", tcIndex)
+		require.Equal(t, tc.attrKey, attrKey, "tc #%d", tcIndex)
+	}
+	require.Equal(t, "message.action", EventKey(EventTypeMessage, AttributeKeyAction))
+}
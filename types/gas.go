@@ -8,6 +8,34 @@ type ErrorOutOfGas struct {
 	Descriptor string
 }
 
+// GasConfig defines the gas costs of the operations on a KVStore
+type GasConfig struct {
+	HasCost          Gas
+	ReadCostFlat     Gas
+	ReadCostPerByte  Gas
+	WriteCostFlat    Gas
+	WriteCostPerByte Gas
+	KeyCostFlat      Gas
+	ValueCostFlat    Gas
+	ValueCostPerByte Gas
+	IterNextCostFlat Gas
+}
+
+// DefaultGasConfig returns the gas costs used unless the app sets its own
+func DefaultGasConfig() GasConfig {
+	return GasConfig{
+		HasCost:          10,
+		ReadCostFlat:     10,
+		ReadCostPerByte:  1,
+		WriteCostFlat:    10,
+		WriteCostPerByte: 10,
+		KeyCostFlat:      5,
+		ValueCostFlat:    10,
+		ValueCostPerByte: 1,
+		IterNextCostFlat: 30,
+	}
+}
+
 // GasMeter interface to track gas consumption
 type GasMeter interface {
 	GasConsumed() Gas
@@ -6,3 +6,40 @@ type Handler func(ctx Context, msg Msg) Result
 // AnteHandler authenticates transactions, before their internal messages are handled.
 // If newCtx.IsZero(), ctx is used instead.
 type AnteHandler func(ctx Context, tx Tx) (newCtx Context, result Result, abort bool)
+
+// AnteDecorator is one step of an AnteHandler. It either aborts, or calls next
+// with the context it has prepared to run the rest of the chain.
+type AnteDecorator func(ctx Context, tx Tx, next AnteHandler) (newCtx Context, result Result, abort bool)
+
+// ChainAnteDecorators returns an AnteHandler running the decorators in order,
+// each one wrapping the rest of the chain.
+func ChainAnteDecorators(decorators ...AnteDecorator) AnteHandler {
+	if len(decorators) == 0 {
+		return func(ctx Context, _ Tx) (Context, Result, bool) {
+			return ctx, Result{}, false
+		}
+	}
+	next := ChainAnteDecorators(decorators[1:]...)
+	return func(ctx Context, tx Tx) (Context, Result, bool) {
+		return decorators[0](ctx, tx, next)
+	}
+}
+
+// FeeRefundHandler runs once the msgs of a tx have been handled, with the gas
+// the tx consumed, to refund the fees paid for the gas it did not use.
+type FeeRefundHandler func(ctx Context, tx Tx, gasUsed Gas)
+
+// PostHandler runs after the Handler of each message with its result, and
+// returns the result of the message, which it may amend.
+type PostHandler func(ctx Context, msg Msg, result Result) Result
+
+// ChainPostHandlers returns a PostHandler running the post handlers in order,
+// each one being given the result returned by the previous one.
+func ChainPostHandlers(postHandlers ...PostHandler) PostHandler {
+	return func(ctx Context, msg Msg, result Result) Result {
+		for _, ph := range postHandlers {
+			result = ph(ctx, msg, result)
+		}
+		return result
+	}
+}
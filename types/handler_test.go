@@ -0,0 +1,61 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/tepleton/tmlibs/log"
+
+	wrsp "github.com/tepleton/tepleton/wrsp/types"
+)
+
+func TestChainAnteDecorators(t *testing.T) {
+	var called []string
+	decorator := func(name string, abort bool) AnteDecorator {
+		return func(ctx Context, tx Tx, next AnteHandler) (Context, Result, bool) {
+			called = append(called, name)
+			if abort {
+				return ctx, ErrUnauthorized(name).Result(), true
+			}
+			return next(ctx.WithValue(name, true), tx)
+		}
+	}
+
+	// an empty chain lets every tx through
+	ctx := NewContext(nil, wrsp.Header{}, false, log.NewNopLogger())
+	_, res, abort := ChainAnteDecorators()(ctx, nil)
+	require.True(t, res.IsOK())
+	require.False(t, abort)
+
+	// the decorators run in order, handing their context down the chain
+	newCtx, res, abort := ChainAnteDecorators(decorator("a", false), decorator("b", false))(ctx, nil)
+	require.True(t, res.IsOK())
+	require.False(t, abort)
+	require.Equal(t, []string{"a", "b"}, called)
+	require.Equal(t, true, newCtx.Value("a"))
+	require.Equal(t, true, newCtx.Value("b"))
+
+	// and the chain stops at the first one to abort
+	called = nil
+	_, res, abort = ChainAnteDecorators(decorator("a", true), decorator("b", false))(ctx, nil)
+	require.Equal(t, ToWRSPCode(CodespaceRoot, CodeUnauthorized), res.Code)
+	require.True(t, abort)
+	require.Equal(t, []string{"a"}, called)
+}
+
+func TestChainPostHandlers(t *testing.T) {
+	appendLog := func(log string) PostHandler {
+		return func(ctx Context, msg Msg, result Result) Result {
+			result.Log += log
+			return result
+		}
+	}
+
+	var ctx Context
+	res := ChainPostHandlers()(ctx, nil, Result{Log: "handler"})
+	require.Equal(t, "handler", res.Log)
+
+	res = ChainPostHandlers(appendLog(",a"), appendLog(",b"))(ctx, nil, Result{Log: "handler"})
+	require.Equal(t, "handler,a,b", res.Log)
+}
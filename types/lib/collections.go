@@ -0,0 +1,277 @@
+package lib
+
+import (
+	"encoding/binary"
+
+	sdk "github.com/tepleton/tepleton-sdk/types"
+	wire "github.com/tepleton/tepleton-sdk/wire"
+)
+
+// The collections store their values encoded by the codec and
+// panic when the value type cannot be (un/)marshalled by it.
+// The keys are raw bytes; use the key encoders below to build them
+// so that they iterate in the order of the values they encode.
+
+// Uint64Key encodes the integer big-endian, so the keys sort like the integers
+func Uint64Key(i uint64) []byte {
+	bz := make([]byte, 8)
+	binary.BigEndian.PutUint64(bz, i)
+	return bz
+}
+
+// Int64Key encodes the integer big-endian with the sign bit flipped,
+// so the keys sort like the integers, negative ones included
+func Int64Key(i int64) []byte {
+	return Uint64Key(uint64(i) ^ (1 << 63))
+}
+
+// Uint64FromKey decodes a key built by Uint64Key
+func Uint64FromKey(key []byte) uint64 {
+	return binary.BigEndian.Uint64(key)
+}
+
+// Int64FromKey decodes a key built by Int64Key
+func Int64FromKey(key []byte) int64 {
+	return int64(Uint64FromKey(key) ^ (1 << 63))
+}
+
+// PairKey joins two keys, length-prefixing the first one so that all the
+// keys of a pair with the same first key share the prefix PairPrefix(first)
+func PairKey(first, second []byte) []byte {
+	return append(PairPrefix(first), second...)
+}
+
+// PairPrefix is the prefix of all the pair keys with the given first key
+func PairPrefix(first []byte) []byte {
+	if len(first) > 255 {
+		panic("the first key of a pair must not be longer than 255 bytes")
+	}
+	bz := make([]byte, 0, 1+len(first))
+	bz = append(bz, byte(len(first)))
+	return append(bz, first...)
+}
+
+// SplitPairKey splits a key built by PairKey
+func SplitPairKey(key []byte) (first, second []byte) {
+	n := int(key[0])
+	return key[1 : 1+n], key[1+n:]
+}
+
+// MapKey is the store key of the map entry with the key under the prefix
+func MapKey(prefix, key []byte) []byte {
+	bz := make([]byte, 0, len(prefix)+len(key))
+	bz = append(bz, prefix...)
+	return append(bz, key...)
+}
+
+// Value of the Set entries and the index entries
+var presentValue = []byte{0x01}
+
+//----------------------------------------
+// Item
+
+// Item is a single value stored under a key
+type Item struct {
+	cdc   *wire.Codec
+	store sdk.KVStore
+	key   []byte
+}
+
+// NewItem constructs new Item
+func NewItem(cdc *wire.Codec, store sdk.KVStore, key []byte) Item {
+	return Item{
+		cdc:   cdc,
+		store: store,
+		key:   key,
+	}
+}
+
+// Has returns whether the item is set
+func (i Item) Has() bool {
+	return i.store.Has(i.key)
+}
+
+// Get unmarshals the item into ptr, returning false if it is not set
+func (i Item) Get(ptr interface{}) bool {
+	bz := i.store.Get(i.key)
+	if bz == nil {
+		return false
+	}
+	i.cdc.MustUnmarshalBinary(bz, ptr)
+	return true
+}
+
+// Set stores the item
+func (i Item) Set(value interface{}) {
+	i.store.Set(i.key, i.cdc.MustMarshalBinary(value))
+}
+
+// Delete removes the item
+func (i Item) Delete() {
+	i.store.Delete(i.key)
+}
+
+//----------------------------------------
+// Sequence
+
+// Sequence is a counter stored under a key, e.g. to assign IDs
+type Sequence struct {
+	item Item
+}
+
+// NewSequence constructs new Sequence
+func NewSequence(cdc *wire.Codec, store sdk.KVStore, key []byte) Sequence {
+	return Sequence{NewItem(cdc, store, key)}
+}
+
+// Has returns whether the sequence was ever set or incremented
+func (s Sequence) Has() bool {
+	return s.item.Has()
+}
+
+// Peek returns the next value of the sequence without incrementing it,
+// 0 if it was never set
+func (s Sequence) Peek() (res uint64) {
+	s.item.Get(&res)
+	return
+}
+
+// Next returns the next value of the sequence and increments it
+func (s Sequence) Next() uint64 {
+	next := s.Peek()
+	s.Set(next + 1)
+	return next
+}
+
+// Set sets the next value of the sequence
+func (s Sequence) Set(next uint64) {
+	s.item.Set(next)
+}
+
+//----------------------------------------
+// Map
+
+// Map stores values by key under a prefix
+type Map struct {
+	cdc    *wire.Codec
+	store  sdk.KVStore
+	prefix []byte
+}
+
+// NewMap constructs new Map, storing the value of key under MapKey(prefix, key)
+func NewMap(cdc *wire.Codec, store sdk.KVStore, prefix []byte) Map {
+	return Map{
+		cdc:    cdc,
+		store:  store,
+		prefix: prefix,
+	}
+}
+
+// Has returns whether there is a value for the key
+func (m Map) Has(key []byte) bool {
+	return m.store.Has(MapKey(m.prefix, key))
+}
+
+// Get unmarshals the value of the key into ptr, returning false if there is none
+func (m Map) Get(key []byte, ptr interface{}) bool {
+	bz := m.store.Get(MapKey(m.prefix, key))
+	if bz == nil {
+		return false
+	}
+	m.cdc.MustUnmarshalBinary(bz, ptr)
+	return true
+}
+
+// Set stores the value of the key
+func (m Map) Set(key []byte, value interface{}) {
+	m.store.Set(MapKey(m.prefix, key), m.cdc.MustMarshalBinary(value))
+}
+
+// Delete removes the value of the key
+func (m Map) Delete(key []byte) {
+	m.store.Delete(MapKey(m.prefix, key))
+}
+
+// Iterate over all the values in the order of their keys
+// The value is unmarshalled into ptr before the continuation is called
+// Return true in the continuation to break
+// CONTRACT: No writes may happen within a domain while iterating over it.
+func (m Map) Iterate(ptr interface{}, fn func(key []byte) bool) {
+	m.IteratePrefix(nil, ptr, fn)
+}
+
+// IteratePrefix iterates like Iterate over the values of the keys with the prefix
+func (m Map) IteratePrefix(prefix []byte, ptr interface{}, fn func(key []byte) bool) {
+	iter := prefixIterator(m.store, MapKey(m.prefix, prefix))
+	defer iter.Close()
+	for ; iter.Valid(); iter.Next() {
+		m.cdc.MustUnmarshalBinary(iter.Value(), ptr)
+		if fn(iter.Key()[len(m.prefix):]) {
+			break
+		}
+	}
+}
+
+// Keys returns the keys with the prefix, in order
+// Unlike while iterating, the map may be written while going over them.
+func (m Map) Keys(prefix []byte) (keys [][]byte) {
+	iter := prefixIterator(m.store, MapKey(m.prefix, prefix))
+	defer iter.Close()
+	for ; iter.Valid(); iter.Next() {
+		keys = append(keys, iter.Key()[len(m.prefix):])
+	}
+	return
+}
+
+//----------------------------------------
+// Set
+
+// Set stores a set of keys under a prefix
+type Set struct {
+	store  sdk.KVStore
+	prefix []byte
+}
+
+// NewSet constructs new Set
+func NewSet(store sdk.KVStore, prefix []byte) Set {
+	return Set{
+		store:  store,
+		prefix: prefix,
+	}
+}
+
+// Has returns whether the key is in the set
+func (s Set) Has(key []byte) bool {
+	return s.store.Has(MapKey(s.prefix, key))
+}
+
+// Add adds the key to the set
+func (s Set) Add(key []byte) {
+	s.store.Set(MapKey(s.prefix, key), presentValue)
+}
+
+// Remove removes the key from the set
+func (s Set) Remove(key []byte) {
+	s.store.Delete(MapKey(s.prefix, key))
+}
+
+// Iterate over the keys of the set with the prefix, in order
+// Return true in the continuation to break
+// CONTRACT: No writes may happen within a domain while iterating over it.
+func (s Set) Iterate(prefix []byte, fn func(key []byte) bool) {
+	iter := prefixIterator(s.store, MapKey(s.prefix, prefix))
+	defer iter.Close()
+	for ; iter.Valid(); iter.Next() {
+		if fn(iter.Key()[len(s.prefix):]) {
+			break
+		}
+	}
+}
+
+// iterator over the keys with the prefix, which may be empty
+func prefixIterator(store sdk.KVStore, prefix []byte) sdk.Iterator {
+	if len(prefix) == 0 {
+		return store.Iterator(nil, nil)
+	}
+	return sdk.KVStorePrefixIterator(store, prefix)
+}
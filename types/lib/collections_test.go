@@ -0,0 +1,153 @@
+package lib
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	sdk "github.com/tepleton/tepleton-sdk/types"
+)
+
+func TestKeys(t *testing.T) {
+	require.Equal(t, []byte{0, 0, 0, 0, 0, 0, 1, 2}, Uint64Key(0x102))
+	require.True(t, string(Int64Key(-2)) < string(Int64Key(-1)))
+	require.True(t, string(Int64Key(-1)) < string(Int64Key(0)))
+	require.True(t, string(Int64Key(0)) < string(Int64Key(1)))
+	require.Equal(t, uint64(0x102), Uint64FromKey(Uint64Key(0x102)))
+	require.Equal(t, int64(-5), Int64FromKey(Int64Key(-5)))
+
+	key := PairKey([]byte("ab"), []byte("cd"))
+	require.Equal(t, []byte("\x02abcd"), key)
+	first, second := SplitPairKey(key)
+	require.Equal(t, []byte("ab"), first)
+	require.Equal(t, []byte("cd"), second)
+	require.False(t, bytes.HasPrefix(PairKey([]byte("ab"), nil), PairPrefix([]byte("a"))))
+}
+
+func TestItemAndSequence(t *testing.T) {
+	key := sdk.NewKVStoreKey("test")
+	ctx, cdc := defaultComponents(key)
+	store := ctx.KVStore(key)
+
+	item := NewItem(cdc, store, []byte{0x00})
+	var res S
+	require.False(t, item.Has())
+	require.False(t, item.Get(&res))
+	item.Set(S{1, true})
+	require.True(t, item.Get(&res))
+	require.Equal(t, S{1, true}, res)
+	item.Delete()
+	require.False(t, item.Has())
+
+	seq := NewSequence(cdc, store, []byte{0x01})
+	require.False(t, seq.Has())
+	require.Equal(t, uint64(0), seq.Peek())
+	require.Equal(t, uint64(0), seq.Next())
+	require.Equal(t, uint64(1), seq.Next())
+	require.Equal(t, uint64(2), seq.Peek())
+	seq.Set(10)
+	require.True(t, seq.Has())
+	require.Equal(t, uint64(10), seq.Next())
+}
+
+func TestMapAndSet(t *testing.T) {
+	key := sdk.NewKVStoreKey("test")
+	ctx, cdc := defaultComponents(key)
+	store := ctx.KVStore(key)
+
+	m := NewMap(cdc, store, []byte{0x00})
+	var res S
+	require.False(t, m.Get([]byte("a"), &res))
+	m.Set(PairKey([]byte("a"), []byte("2")), S{2, false})
+	m.Set(PairKey([]byte("a"), []byte("1")), S{1, true})
+	m.Set(PairKey([]byte("b"), []byte("3")), S{3, true})
+	require.True(t, m.Has(PairKey([]byte("a"), []byte("1"))))
+	require.True(t, m.Get(PairKey([]byte("b"), []byte("3")), &res))
+	require.Equal(t, S{3, true}, res)
+	require.Equal(t, m.cdc.MustMarshalBinary(S{3, true}), store.Get(MapKey([]byte{0x00}, PairKey([]byte("b"), []byte("3")))))
+
+	// iterate over the prefix of the pairs, in order
+	var values []S
+	m.IteratePrefix(PairPrefix([]byte("a")), &res, func(key []byte) bool {
+		_, second := SplitPairKey(key)
+		require.Equal(t, []byte{byte('0' + res.I)}, second)
+		values = append(values, res)
+		return false
+	})
+	require.Equal(t, []S{{1, true}, {2, false}}, values)
+
+	// break the iteration
+	values = nil
+	m.Iterate(&res, func(key []byte) bool {
+		values = append(values, res)
+		return len(values) == 2
+	})
+	require.Equal(t, []S{{1, true}, {2, false}}, values)
+
+	for _, key := range m.Keys(nil) {
+		m.Delete(key)
+	}
+	require.Nil(t, m.Keys(nil))
+
+	set := NewSet(store, []byte{0x01})
+	set.Add([]byte("y"))
+	set.Add([]byte("x"))
+	require.True(t, set.Has([]byte("x")))
+	require.False(t, set.Has([]byte("z")))
+	set.Remove([]byte("y"))
+	var keys [][]byte
+	set.Iterate(nil, func(key []byte) bool {
+		keys = append(keys, key)
+		return false
+	})
+	require.Equal(t, [][]byte{[]byte("x")}, keys)
+}
+
+func TestIndexedMap(t *testing.T) {
+	key := sdk.NewKVStoreKey("test")
+	ctx, cdc := defaultComponents(key)
+	store := ctx.KVStore(key)
+
+	byFlag := NewIndex([]byte{0x01}, func(value interface{}) []byte {
+		if value.(S).B {
+			return []byte{0x01}
+		}
+		return []byte{0x00}
+	})
+	// only indexes the values above 10
+	byLarge := NewIndex([]byte{0x02}, func(value interface{}) []byte {
+		if value.(S).I <= 10 {
+			return nil
+		}
+		return []byte{0x01}
+	})
+	m := NewIndexedMap(cdc, store, []byte{0x00}, func() interface{} { return &S{} }, byFlag, byLarge)
+
+	m.Set(Uint64Key(1), S{1, true})
+	m.Set(Uint64Key(2), S{20, false})
+	m.Set(Uint64Key(3), S{3, true})
+	require.Equal(t, [][]byte{Uint64Key(1), Uint64Key(3)}, m.IndexKeys(byFlag, []byte{0x01}))
+	require.Equal(t, [][]byte{Uint64Key(2)}, m.IndexKeys(byFlag, []byte{0x00}))
+	require.Equal(t, [][]byte{Uint64Key(2)}, m.IndexKeys(byLarge, []byte{0x01}))
+
+	// replacing a value moves it in the indexes
+	m.Set(Uint64Key(1), S{100, false})
+	require.Equal(t, [][]byte{Uint64Key(3)}, m.IndexKeys(byFlag, []byte{0x01}))
+	require.Equal(t, [][]byte{Uint64Key(1), Uint64Key(2)}, m.IndexKeys(byFlag, []byte{0x00}))
+	require.Equal(t, [][]byte{Uint64Key(1), Uint64Key(2)}, m.IndexKeys(byLarge, []byte{0x01}))
+
+	var res S
+	var values []S
+	m.IterateIndex(byFlag, []byte{0x00}, &res, func(key []byte) bool {
+		values = append(values, res)
+		return false
+	})
+	require.Equal(t, []S{{100, false}, {20, false}}, values)
+
+	// deleting a value removes it from the indexes
+	m.Delete(Uint64Key(2))
+	require.False(t, m.Has(Uint64Key(2)))
+	require.Equal(t, [][]byte{Uint64Key(1)}, m.IndexKeys(byFlag, []byte{0x00}))
+	require.Equal(t, [][]byte{Uint64Key(1)}, m.IndexKeys(byLarge, []byte{0x01}))
+}
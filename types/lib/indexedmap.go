@@ -0,0 +1,107 @@
+package lib
+
+import (
+	"reflect"
+
+	sdk "github.com/tepleton/tepleton-sdk/types"
+	wire "github.com/tepleton/tepleton-sdk/wire"
+)
+
+// IndexFunc returns the index key of a value, or nil to leave it out of the index
+// The value is of the type passed to IndexedMap.Set.
+type IndexFunc func(value interface{}) []byte
+
+// Index is a secondary index of an IndexedMap
+// It stores the keys of the values under their index key, as
+// MapKey(prefix, PairKey(indexKey, key)).
+type Index struct {
+	prefix []byte
+	fn     IndexFunc
+}
+
+// NewIndex constructs new Index, the prefix must not be shared with the map
+// nor the other indexes
+func NewIndex(prefix []byte, fn IndexFunc) Index {
+	return Index{
+		prefix: prefix,
+		fn:     fn,
+	}
+}
+
+// IndexedMap is a Map which keeps secondary indexes of its values
+// up to date on Set and Delete.
+type IndexedMap struct {
+	Map
+	newValue func() interface{}
+	indexes  []Index
+}
+
+// NewIndexedMap constructs new IndexedMap
+// newValue returns a pointer to unmarshal a value into, to remove the
+// index entries of the value being replaced or deleted.
+func NewIndexedMap(cdc *wire.Codec, store sdk.KVStore, prefix []byte, newValue func() interface{}, indexes ...Index) IndexedMap {
+	return IndexedMap{
+		Map:      NewMap(cdc, store, prefix),
+		newValue: newValue,
+		indexes:  indexes,
+	}
+}
+
+// Set stores the value of the key and indexes it
+func (m IndexedMap) Set(key []byte, value interface{}) {
+	m.unindex(key)
+	m.Map.Set(key, value)
+	for _, index := range m.indexes {
+		indexKey := index.fn(value)
+		if indexKey != nil {
+			m.store.Set(MapKey(index.prefix, PairKey(indexKey, key)), presentValue)
+		}
+	}
+}
+
+// Delete removes the value of the key and its index entries
+func (m IndexedMap) Delete(key []byte) {
+	m.unindex(key)
+	m.Map.Delete(key)
+}
+
+// remove the index entries of the current value of the key, if any
+func (m IndexedMap) unindex(key []byte) {
+	if len(m.indexes) == 0 {
+		return
+	}
+	ptr := m.newValue()
+	if !m.Map.Get(key, ptr) {
+		return
+	}
+	value := reflect.ValueOf(ptr).Elem().Interface()
+	for _, index := range m.indexes {
+		indexKey := index.fn(value)
+		if indexKey != nil {
+			m.store.Delete(MapKey(index.prefix, PairKey(indexKey, key)))
+		}
+	}
+}
+
+// IterateIndex iterates over the values with the index key, in the order of their keys
+// The value is unmarshalled into ptr before the continuation is called
+// Return true in the continuation to break
+func (m IndexedMap) IterateIndex(index Index, indexKey []byte, ptr interface{}, fn func(key []byte) bool) {
+	for _, key := range m.IndexKeys(index, indexKey) {
+		m.Map.Get(key, ptr)
+		if fn(key) {
+			break
+		}
+	}
+}
+
+// IndexKeys returns the keys of the values with the index key, in order
+func (m IndexedMap) IndexKeys(index Index, indexKey []byte) (keys [][]byte) {
+	prefix := MapKey(index.prefix, PairPrefix(indexKey))
+	iter := sdk.KVStorePrefixIterator(m.store, prefix)
+	defer iter.Close()
+	for ; iter.Valid(); iter.Next() {
+		keys = append(keys, iter.Key()[len(prefix):])
+	}
+	return
+}
@@ -0,0 +1,8 @@
+package types
+
+import wrsp "github.com/tepleton/tepleton/wrsp/types"
+
+// Querier defines a function type that a module querier must implement to
+// handle custom client queries. The path is the remainder of the request
+// path after "/custom/<route>".
+type Querier = func(ctx Context, path []string, req wrsp.RequestQuery) (res []byte, err Error)
@@ -1,5 +1,9 @@
 package types
 
+import (
+	"encoding/json"
+)
+
 // Result is the union of ResponseDeliverTx and ResponseCheckTx.
 type Result struct {
 
@@ -30,3 +34,43 @@ type Result struct {
 func (res Result) IsOK() bool {
 	return res.Code.IsOK()
 }
+
+// MsgResult is the result of a single message of a tx.
+type MsgResult struct {
+	// MsgIndex is the position of the message in the tx, starting from 0.
+	MsgIndex int          `json:"msg_index"`
+	Code     WRSPCodeType `json:"code"`
+	Data     []byte       `json:"data"`
+	Log      string       `json:"log"`
+	GasUsed  int64        `json:"gas_used"`
+	Tags     Tags         `json:"tags"`
+	Events   Events       `json:"events,omitempty"`
+}
+
+// IsOK returns true if the message succeeded.
+func (mr MsgResult) IsOK() bool {
+	return mr.Code.IsOK()
+}
+
+// MsgResults are the results of the messages of a tx, up to the first failed one.
+// They are encoded as the log of the tx result.
+type MsgResults []MsgResult
+
+// String returns the JSON encoding used as the log of the tx result.
+func (mrs MsgResults) String() string {
+	bz, err := json.Marshal(mrs)
+	if err != nil {
+		panic(err)
+	}
+	return string(bz)
+}
+
+// ParseMsgResults decodes the message results from the log of a tx result.
+func ParseMsgResults(log string) (MsgResults, error) {
+	var mrs MsgResults
+	err := json.Unmarshal([]byte(log), &mrs)
+	if err != nil {
+		return nil, err
+	}
+	return mrs, nil
+}
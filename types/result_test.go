@@ -0,0 +1,24 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMsgResults(t *testing.T) {
+	mrs := MsgResults{
+		{MsgIndex: 0, Data: []byte("data"), GasUsed: 10, Tags: EmptyTags().AppendTag("key", []byte("value"))},
+		{MsgIndex: 1, Code: ToWRSPCode(CodespaceRoot, CodeUnauthorized), Log: "failed"},
+	}
+	require.True(t, mrs[0].IsOK())
+	require.False(t, mrs[1].IsOK())
+
+	parsed, err := ParseMsgResults(mrs.String())
+	require.Nil(t, err)
+	require.Equal(t, mrs, parsed)
+
+	// plain text logs are not msg results
+	_, err = ParseMsgResults("Msg 1: ")
+	require.NotNil(t, err)
+}
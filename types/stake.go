@@ -37,8 +37,8 @@ type Validator interface {
 	GetStatus() BondStatus    // status of the validator
 	GetOwner() Address        // owner address to receive/return validators coins
 	GetPubKey() crypto.PubKey // validation pubkey
-	GetPower() Rat            // validation power
-	GetDelegatorShares() Rat  // Total out standing delegator shares
+	GetPower() Dec            // validation power
+	GetDelegatorShares() Dec  // Total out standing delegator shares
 	GetBondHeight() int64     // height in which the validator became active
 }
 
@@ -61,10 +61,10 @@ type ValidatorSet interface {
 		func(index int64, validator Validator) (stop bool))
 
 	Validator(Context, Address) Validator // get a particular validator by owner address
-	TotalPower(Context) Rat               // total power of the validator set
+	TotalPower(Context) Dec               // total power of the validator set
 
 	// slash the validator and delegators of the validator, specifying offence height, offence power, and slash fraction
-	Slash(Context, crypto.PubKey, int64, int64, Rat)
+	Slash(Context, crypto.PubKey, int64, int64, Dec)
 	Revoke(Context, crypto.PubKey)   // revoke a validator
 	Unrevoke(Context, crypto.PubKey) // unrevoke a validator
 }
@@ -75,7 +75,7 @@ type ValidatorSet interface {
 type Delegation interface {
 	GetDelegator() Address // delegator address for the bond
 	GetValidator() Address // validator owner address for the bond
-	GetBondShares() Rat    // amount of validator's shares
+	GetBondShares() Dec    // amount of validator's shares
 }
 
 // properties for the set of all delegations for a particular
@@ -35,6 +35,40 @@ type Queryable interface {
 	Query(wrsp.RequestQuery) wrsp.ResponseQuery
 }
 
+//----------------------------------------
+// PruningStrategy
+
+// PruningStrategy sets which old versions of the stores are kept: the
+// KeepRecent latest ones and every KeepEvery-th one. The other versions are
+// deleted as new ones are committed. A KeepRecent of 0 keeps every version.
+type PruningStrategy struct {
+	KeepRecent int64
+	KeepEvery  int64
+}
+
+var (
+	// Keep every version
+	PruneNothing = PruningStrategy{}
+	// Keep the latest version only
+	PruneEverything = PruningStrategy{KeepRecent: 1}
+	// Keep the recent versions, and enough old ones for new nodes to sync
+	PruneSyncable = PruningStrategy{KeepRecent: 100, KeepEvery: 10000}
+)
+
+// NewPruningStrategy keeps the keepRecent latest versions and every
+// keepEvery-th one.
+func NewPruningStrategy(keepRecent, keepEvery int64) PruningStrategy {
+	return PruningStrategy{KeepRecent: keepRecent, KeepEvery: keepEvery}
+}
+
+// Keep returns true if version is kept once the latest one is committed.
+func (ps PruningStrategy) Keep(version, latest int64) bool {
+	if ps.KeepRecent <= 0 || version > latest-ps.KeepRecent {
+		return true
+	}
+	return ps.KeepEvery > 0 && version%ps.KeepEvery == 0
+}
+
 //----------------------------------------
 // MultiStore
 
@@ -49,13 +83,31 @@ type MultiStore interface { //nolint
 	// Convenience for fetching substores.
 	GetStore(StoreKey) Store
 	GetKVStore(StoreKey) KVStore
-	GetKVStoreWithGas(GasMeter, StoreKey) KVStore
+	GetKVStoreWithGas(GasMeter, GasConfig, StoreKey) KVStore
 }
 
 // From MultiStore.CacheMultiStore()....
 type CacheMultiStore interface {
 	MultiStore
 	Write() // Writes operations to underlying KVStore
+
+	// Notify the listener of the writes to the stores, either direct
+	// or from the Write of their cache-wraps.
+	SetListener(listener WriteListener)
+}
+
+// WriteListener is notified of the writes to the stores of a CacheMultiStore.
+type WriteListener interface {
+	// A Set has delete false, and a Delete has a nil value.
+	OnWrite(storeKey StoreKey, key []byte, value []byte, delete bool)
+}
+
+// StoreKVPair is a write to a store, as seen by a WriteListener.
+type StoreKVPair struct {
+	StoreKey string `json:"store_key"` // the name of the store
+	Delete   bool   `json:"delete"`
+	Key      []byte `json:"key"`
+	Value    []byte `json:"value"`
 }
 
 // A non-cache MultiStore.
@@ -82,6 +134,23 @@ type CommitMultiStore interface {
 	// the next commit after loading must be idempotent (return the
 	// same commit id).  Otherwise the behavior is undefined.
 	LoadVersion(ver int64) error
+
+	// Set the pruning strategy of all the stores.
+	SetPruning(pruning PruningStrategy)
+
+	// Cache-wrap the stores as they were at a committed version, to
+	// query the historical state. Fails if the version was pruned.
+	CacheMultiStoreWithVersion(version int64) (CacheMultiStore, error)
+
+	// Pin the last committed version of the stores, and return a func
+	// exporting it into chunk files of about chunkSize bytes in
+	// <dir>/<version>/. The version isn't pruned until the export is done,
+	// which may run concurrently with the next commits.
+	Snapshot(dir string, chunkSize int) (export func() error, err error)
+
+	// Rebuild the empty stores from the snapshot at height in dir
+	// and load that version, if it matches the trusted app hash.
+	Restore(dir string, height int64, appHash []byte) error
 }
 
 //---------subsp-------------------------------
@@ -213,6 +282,7 @@ const (
 	StoreTypeDB
 	StoreTypeIAVL
 	StoreTypePrefix
+	StoreTypeTransient
 )
 
 //----------------------------------------
@@ -251,6 +321,29 @@ func (key *KVStoreKey) KVStore(ctx Context) KVStore {
 	return ctx.KVStore(key)
 }
 
+// TransientStoreKey is used for accessing transient substores, whose
+// content only lasts until the next commit.
+// Only the pointer value should ever be used - it functions as a capabilities key.
+type TransientStoreKey struct {
+	name string
+}
+
+// NewTransientStoreKey returns a new pointer to a TransientStoreKey.
+// Use a pointer so keys don't collide.
+func NewTransientStoreKey(name string) *TransientStoreKey {
+	return &TransientStoreKey{
+		name: name,
+	}
+}
+
+func (key *TransientStoreKey) Name() string {
+	return key.name
+}
+
+func (key *TransientStoreKey) String() string {
+	return fmt.Sprintf("TransientStoreKey{%p, %s}", key, key.name)
+}
+
 // PrefixEndBytes returns the []byte that would end a
 // range query for all []byte with a certain prefix
 // Deals with last byte of prefix being FF without overflowing
@@ -25,3 +25,25 @@ func TestPrefixEndBytes(t *testing.T) {
 		require.Equal(t, test.expected, end)
 	}
 }
+
+func TestPruningStrategy(t *testing.T) {
+	var testCases = []struct {
+		pruning PruningStrategy
+		version int64
+		kept    bool
+	}{
+		{PruneNothing, 1, true},
+		{PruneEverything, 99, false},
+		{PruneEverything, 100, true},
+		{NewPruningStrategy(10, 0), 90, false},
+		{NewPruningStrategy(10, 0), 91, true},
+		{NewPruningStrategy(10, 30), 60, true},
+		{NewPruningStrategy(10, 30), 61, false},
+		{PruneSyncable, 10000, true},
+		{PruneSyncable, 1, false},
+	}
+
+	for _, test := range testCases {
+		require.Equal(t, test.kept, test.pruning.Keep(test.version, 100), "%v %d", test.pruning, test.version)
+	}
+}
@@ -17,12 +17,27 @@ const (
 // NewAnteHandler returns an AnteHandler that checks
 // and increments sequence numbers, checks signatures & account numbers,
 // and deducts fees from the first signer.
+// It chains the DefaultAnteDecorators.
 func NewAnteHandler(am AccountMapper, fck FeeCollectionKeeper) sdk.AnteHandler {
+	return sdk.ChainAnteDecorators(DefaultAnteDecorators(am, fck)...)
+}
 
-	return func(
-		ctx sdk.Context, tx sdk.Tx,
-	) (_ sdk.Context, _ sdk.Result, abort bool) {
+// DefaultAnteDecorators returns the steps of the auth AnteHandler in the
+// order they run, for apps to chain along with their own checks.
+func DefaultAnteDecorators(am AccountMapper, fck FeeCollectionKeeper) []sdk.AnteDecorator {
+	return []sdk.AnteDecorator{
+		NewSetUpContextDecorator(),
+		NewValidateMemoDecorator(),
+		NewSigVerificationDecorator(am),
+		NewDeductFeeDecorator(am, fck),
+		NewIncrementSequenceDecorator(am),
+	}
+}
 
+// NewSetUpContextDecorator requires the tx to be a signed StdTx, and sets the
+// gas meter to the gas limit of its fee.
+func NewSetUpContextDecorator() sdk.AnteDecorator {
+	return func(ctx sdk.Context, tx sdk.Tx, next sdk.AnteHandler) (sdk.Context, sdk.Result, bool) {
 		// This AnteHandler requires Txs to be StdTxs
 		stdTx, ok := tx.(StdTx)
 		if !ok {
@@ -30,14 +45,24 @@ func NewAnteHandler(am AccountMapper, fck FeeCollectionKeeper) sdk.AnteHandler {
 		}
 
 		// Assert that there are signatures.
-		var sigs = stdTx.GetSignatures()
-		if len(sigs) == 0 {
+		if len(stdTx.GetSignatures()) == 0 {
 			return ctx,
 				sdk.ErrUnauthorized("no signers").Result(),
 				true
 		}
 
-		memo := stdTx.GetMemo()
+		// set the gas meter
+		ctx = ctx.WithGasMeter(sdk.NewGasMeter(stdTx.Fee.Gas))
+
+		return next(ctx, tx)
+	}
+}
+
+// NewValidateMemoDecorator limits the length of the memo, and charges gas
+// for it.
+func NewValidateMemoDecorator() sdk.AnteDecorator {
+	return func(ctx sdk.Context, tx sdk.Tx, next sdk.AnteHandler) (sdk.Context, sdk.Result, bool) {
+		memo := tx.(StdTx).GetMemo()
 
 		if len(memo) > maxMemoCharacters {
 			return ctx,
@@ -45,13 +70,20 @@ func NewAnteHandler(am AccountMapper, fck FeeCollectionKeeper) sdk.AnteHandler {
 				true
 		}
 
-		// set the gas meter
-		ctx = ctx.WithGasMeter(sdk.NewGasMeter(stdTx.Fee.Gas))
-
 		// charge gas for the memo
 		ctx.GasMeter().ConsumeGas(memoCostPerByte*sdk.Gas(len(memo)), "memo")
 
-		msgs := tx.GetMsgs()
+		return next(ctx, tx)
+	}
+}
+
+// NewSigVerificationDecorator checks the account numbers, sequences and
+// signatures of the signers, setting the pubkey of accounts which have none,
+// and caches the signer accounts in the context.
+func NewSigVerificationDecorator(am AccountMapper) sdk.AnteDecorator {
+	return func(ctx sdk.Context, tx sdk.Tx, next sdk.AnteHandler) (sdk.Context, sdk.Result, bool) {
+		stdTx := tx.(StdTx)
+		sigs := stdTx.GetSignatures()
 
 		// Assert that number of signatures is correct.
 		var signerAddrs = stdTx.GetSigners()
@@ -61,24 +93,13 @@ func NewAnteHandler(am AccountMapper, fck FeeCollectionKeeper) sdk.AnteHandler {
 				true
 		}
 
-		// Get the sign bytes (requires all account & sequence numbers and the fee)
-		sequences := make([]int64, len(signerAddrs))
-		for i := 0; i < len(signerAddrs); i++ {
-			sequences[i] = sigs[i].Sequence
-		}
-		accNums := make([]int64, len(signerAddrs))
-		for i := 0; i < len(signerAddrs); i++ {
-			accNums[i] = sigs[i].AccountNumber
-		}
-		fee := stdTx.Fee
-
 		// Check sig and nonce and collect signer accounts.
 		var signerAccs = make([]Account, len(signerAddrs))
 		for i := 0; i < len(sigs); i++ {
 			signerAddr, sig := signerAddrs[i], sigs[i]
 
-			// check signature, return account with incremented nonce
-			signBytes := StdSignBytes(ctx.ChainID(), accNums[i], sequences[i], fee, msgs, stdTx.GetMemo())
+			// the sign bytes require all account & sequence numbers and the fee
+			signBytes := StdSignBytes(ctx.ChainID(), sig.AccountNumber, sig.Sequence, stdTx.Fee, tx.GetMsgs(), stdTx.GetMemo())
 			signerAcc, res := processSig(
 				ctx, am,
 				signerAddr, sig, signBytes,
@@ -86,35 +107,68 @@ func NewAnteHandler(am AccountMapper, fck FeeCollectionKeeper) sdk.AnteHandler {
 			if !res.IsOK() {
 				return ctx, res, true
 			}
+			signerAccs[i] = signerAcc
+		}
+
+		// cache the signer accounts in the context
+		ctx = WithSigners(ctx, signerAccs)
+
+		return next(ctx, tx)
+	}
+}
+
+// NewDeductFeeDecorator deducts the fee from the first signer, and adds it to
+// the collected fees.
+func NewDeductFeeDecorator(am AccountMapper, fck FeeCollectionKeeper) sdk.AnteDecorator {
+	return func(ctx sdk.Context, tx sdk.Tx, next sdk.AnteHandler) (sdk.Context, sdk.Result, bool) {
+		fee := tx.(StdTx).Fee
+
+		// TODO: min fee
+		if !fee.Amount.IsZero() {
+			signerAccs := GetSigners(ctx)
+			if len(signerAccs) == 0 {
+				return ctx, sdk.ErrInternal("fee payer has not been verified").Result(), true
+			}
 
 			// first sig pays the fees
-			if i == 0 {
-				// TODO: min fee
-				if !fee.Amount.IsZero() {
-					ctx.GasMeter().ConsumeGas(deductFeesCost, "deductFees")
-					signerAcc, res = deductFees(signerAcc, fee)
-					if !res.IsOK() {
-						return ctx, res, true
-					}
-					fck.addCollectedFees(ctx, fee.Amount)
-				}
+			ctx.GasMeter().ConsumeGas(deductFeesCost, "deductFees")
+			signerAcc, res := deductFees(signerAccs[0], fee)
+			if !res.IsOK() {
+				return ctx, res, true
 			}
+			fck.addCollectedFees(ctx, fee.Amount)
 
 			// Save the account.
 			am.SetAccount(ctx, signerAcc)
-			signerAccs[i] = signerAcc
+			signerAccs[0] = signerAcc
 		}
 
-		// cache the signer accounts in the context
-		ctx = WithSigners(ctx, signerAccs)
+		return next(ctx, tx)
+	}
+}
+
+// NewIncrementSequenceDecorator increments the sequences of the signer
+// accounts cached in the context, and saves them.
+func NewIncrementSequenceDecorator(am AccountMapper) sdk.AnteDecorator {
+	return func(ctx sdk.Context, tx sdk.Tx, next sdk.AnteHandler) (sdk.Context, sdk.Result, bool) {
+		for _, signerAcc := range GetSigners(ctx) {
+			err := signerAcc.SetSequence(signerAcc.GetSequence() + 1)
+			if err != nil {
+				// Handle w/ #870
+				panic(err)
+			}
+
+			// Save the account.
+			am.SetAccount(ctx, signerAcc)
+		}
 
 		// TODO: tx tags (?)
 
-		return ctx, sdk.Result{}, false // continue...
+		return next(ctx, tx)
 	}
 }
 
-// verify the signature and increment the sequence.
+// verify the account number, sequence and signature.
 // if the account doesn't have a pubkey, set it.
 func processSig(
 	ctx sdk.Context, am AccountMapper,
@@ -134,17 +188,12 @@ func processSig(
 			fmt.Sprintf("Invalid account number. Got %d, expected %d", sig.AccountNumber, accnum)).Result()
 	}
 
-	// Check and increment sequence number.
+	// Check sequence number.
 	seq := acc.GetSequence()
 	if seq != sig.Sequence {
 		return nil, sdk.ErrInvalidSequence(
 			fmt.Sprintf("Invalid sequence. Got %d, expected %d", sig.Sequence, seq)).Result()
 	}
-	err := acc.SetSequence(seq + 1)
-	if err != nil {
-		// Handle w/ #870
-		panic(err)
-	}
 	// If pubkey is not known for account,
 	// set it from the StdSignature.
 	pubKey := acc.GetPubKey()
@@ -157,7 +206,7 @@ func processSig(
 			return nil, sdk.ErrInvalidPubKey(
 				fmt.Sprintf("PubKey does not match Signer address %v", addr)).Result()
 		}
-		err = acc.SetPubKey(pubKey)
+		err := acc.SetPubKey(pubKey)
 		if err != nil {
 			return nil, sdk.ErrInternal("setting PubKey on signer's account").Result()
 		}
@@ -192,5 +241,46 @@ func deductFees(acc Account, fee StdFee) (Account, sdk.Result) {
 	return acc, sdk.Result{}
 }
 
+// NewFeeRefundHandler returns a FeeRefundHandler crediting back to the first
+// signer, who paid the fee, the share of it paying for the gas left unused.
+func NewFeeRefundHandler(am AccountMapper, fck FeeCollectionKeeper) sdk.FeeRefundHandler {
+	return func(ctx sdk.Context, tx sdk.Tx, gasUsed sdk.Gas) {
+		stdTx, ok := tx.(StdTx)
+		if !ok {
+			return
+		}
+
+		refund := unusedGasFees(stdTx.Fee, gasUsed)
+		if refund.IsZero() {
+			return
+		}
+
+		// the account is read again, as the msgs may have changed it
+		payer := am.GetAccount(ctx, stdTx.GetSigners()[0])
+		err := payer.SetCoins(payer.GetCoins().Plus(refund))
+		if err != nil {
+			// Handle w/ #870
+			panic(err)
+		}
+		am.SetAccount(ctx, payer)
+		fck.subtractCollectedFees(ctx, refund)
+	}
+}
+
+// The share of the fee paying for the gas left unused, rounded down.
+func unusedGasFees(fee StdFee, gasUsed sdk.Gas) sdk.Coins {
+	if fee.Gas <= 0 || gasUsed >= fee.Gas {
+		return nil
+	}
+	var refund sdk.Coins
+	for _, coin := range fee.Amount {
+		amount := coin.Amount.MulRaw(fee.Gas - gasUsed).DivRaw(fee.Gas)
+		if !amount.IsZero() {
+			refund = append(refund, sdk.Coin{Denom: coin.Denom, Amount: amount})
+		}
+	}
+	return refund
+}
+
 // BurnFeeHandler burns all fees (decreasing total supply)
 func BurnFeeHandler(_ sdk.Context, _ sdk.Tx, _ sdk.Coins) {}
@@ -382,6 +382,53 @@ func TestAnteHandlerMemoGas(t *testing.T) {
 	checkValidTx(t, anteHandler, ctx, tx)
 }
 
+// Test the refund of the fees of unused gas.
+func TestFeeRefundHandler(t *testing.T) {
+	// setup
+	ms, capKey, capKey2 := setupMultiStore()
+	cdc := wire.NewCodec()
+	RegisterBaseAccount(cdc)
+	mapper := NewAccountMapper(cdc, capKey, &BaseAccount{})
+	feeCollector := NewFeeCollectionKeeper(cdc, capKey2)
+	anteHandler := NewAnteHandler(mapper, feeCollector)
+	refundHandler := NewFeeRefundHandler(mapper, feeCollector)
+	ctx := sdk.NewContext(ms, wrsp.Header{ChainID: "mychainid"}, false, log.NewNopLogger())
+
+	// keys and addresses
+	priv1, addr1 := privAndAddr()
+
+	// set the accounts
+	acc1 := mapper.NewAccountWithAddress(ctx, addr1)
+	acc1.SetCoins(sdk.Coins{sdk.NewCoin("atom", 150)})
+	mapper.SetAccount(ctx, acc1)
+
+	// msg and signatures
+	msgs := []sdk.Msg{newTestMsg(addr1)}
+	privs, accnums := []crypto.PrivKey{priv1}, []int64{0}
+	fee := newStdFee()
+
+	// the fee of the 4000 unused gas out of 5000 is refunded
+	tx := newTestTx(ctx, msgs, privs, accnums, []int64{0}, fee)
+	checkValidTx(t, anteHandler, ctx, tx)
+	refundHandler(ctx, tx, 1000)
+	require.Equal(t, sdk.Coins{sdk.NewCoin("atom", 120)}, mapper.GetAccount(ctx, addr1).GetCoins())
+	require.True(t, feeCollector.GetCollectedFees(ctx).IsEqual(sdk.Coins{sdk.NewCoin("atom", 30)}))
+
+	// refunds are rounded down
+	tx = newTestTx(ctx, msgs, privs, accnums, []int64{1}, NewStdFee(5000, sdk.NewCoin("atom", 100)))
+	checkValidTx(t, anteHandler, ctx, tx)
+	refundHandler(ctx, tx, 4999)
+	require.Equal(t, sdk.Coins{sdk.NewCoin("atom", 20)}, mapper.GetAccount(ctx, addr1).GetCoins())
+	require.True(t, feeCollector.GetCollectedFees(ctx).IsEqual(sdk.Coins{sdk.NewCoin("atom", 130)}))
+
+	// nothing is refunded once all the gas is used
+	tx = newTestTx(ctx, msgs, privs, accnums, []int64{2}, NewStdFee(5000, sdk.NewCoin("atom", 20)))
+	checkValidTx(t, anteHandler, ctx, tx)
+	refundHandler(ctx, tx, 5000)
+	require.True(t, mapper.GetAccount(ctx, addr1).GetCoins().IsZero())
+	require.True(t, feeCollector.GetCollectedFees(ctx).IsEqual(sdk.Coins{sdk.NewCoin("atom", 150)}))
+}
+
 func TestAnteHandlerMultiSigner(t *testing.T) {
 	// setup
 	ms, capKey, capKey2 := setupMultiStore()
@@ -567,3 +614,47 @@ func TestAnteHandlerSetPubKey(t *testing.T) {
 	acc2 = mapper.GetAccount(ctx, addr2)
 	require.Nil(t, acc2.GetPubKey())
 }
+
+// Test that apps can chain their own decorators with the default ones
+func TestAnteHandlerDecorators(t *testing.T) {
+	// setup
+	ms, capKey, capKey2 := setupMultiStore()
+	cdc := wire.NewCodec()
+	RegisterBaseAccount(cdc)
+	mapper := NewAccountMapper(cdc, capKey, &BaseAccount{})
+	feeCollector := NewFeeCollectionKeeper(cdc, capKey2)
+	ctx := sdk.NewContext(ms, wrsp.Header{ChainID: "mychainid"}, false, log.NewNopLogger())
+
+	// reject the txs with a memo before checking their signatures
+	rejectMemo := func(ctx sdk.Context, tx sdk.Tx, next sdk.AnteHandler) (sdk.Context, sdk.Result, bool) {
+		if tx.(StdTx).GetMemo() != "" {
+			return ctx, sdk.ErrUnauthorized("memo").Result(), true
+		}
+		return next(ctx, tx)
+	}
+	anteHandler := sdk.ChainAnteDecorators(append([]sdk.AnteDecorator{rejectMemo},
+		DefaultAnteDecorators(mapper, feeCollector)...)...)
+
+	// keys and addresses
+	priv1, addr1 := privAndAddr()
+
+	// set the accounts
+	acc1 := mapper.NewAccountWithAddress(ctx, addr1)
+	acc1.SetCoins(newCoins())
+	mapper.SetAccount(ctx, acc1)
+
+	msgs := []sdk.Msg{newTestMsg(addr1)}
+	fee := newStdFee()
+	privs, accnums, seqs := []crypto.PrivKey{priv1}, []int64{0}, []int64{0}
+
+	// the custom decorator aborts the chain
+	tx := newTestTxWithMemo(ctx, msgs, privs, accnums, seqs, fee, "memo")
+	checkInvalidTx(t, anteHandler, ctx, tx, sdk.CodeUnauthorized)
+	require.Equal(t, int64(0), mapper.GetAccount(ctx, addr1).GetSequence())
+
+	// and lets the default decorators run otherwise
+	tx = newTestTx(ctx, msgs, privs, accnums, seqs, fee)
+	checkValidTx(t, anteHandler, ctx, tx)
+	require.Equal(t, int64(1), mapper.GetAccount(ctx, addr1).GetSequence())
+	require.Equal(t, newCoins().Minus(fee.Amount), mapper.GetAccount(ctx, addr1).GetCoins())
+}
@@ -56,6 +56,14 @@ func (fck FeeCollectionKeeper) addCollectedFees(ctx sdk.Context, coins sdk.Coins
 	return newCoins
 }
 
+// Subtracts from Collected Fee Pool
+func (fck FeeCollectionKeeper) subtractCollectedFees(ctx sdk.Context, coins sdk.Coins) sdk.Coins {
+	newCoins := fck.GetCollectedFees(ctx).Minus(coins)
+	fck.setCollectedFees(ctx, newCoins)
+
+	return newCoins
+}
+
 // Clears the collected Fee Pool
 func (fck FeeCollectionKeeper) ClearCollectedFees(ctx sdk.Context) {
 	fck.setCollectedFees(ctx, sdk.Coins{})
@@ -57,6 +57,26 @@ func TestFeeCollectionKeeperAdd(t *testing.T) {
 	require.True(t, fck.GetCollectedFees(ctx).IsEqual(twoCoins))
 }
 
+func TestFeeCollectionKeeperSubtract(t *testing.T) {
+	ms, _, capKey2 := setupMultiStore()
+	cdc := wire.NewCodec()
+
+	// make context and keeper
+	ctx := sdk.NewContext(ms, wrsp.Header{}, false, log.NewNopLogger())
+	fck := NewFeeCollectionKeeper(cdc, capKey2)
+
+	// set coins initially
+	fck.setCollectedFees(ctx, twoCoins)
+
+	// subtract oneCoin and check that pool is now oneCoin
+	fck.subtractCollectedFees(ctx, oneCoin)
+	require.True(t, fck.GetCollectedFees(ctx).IsEqual(oneCoin))
+
+	// subtract oneCoin again and check that pool is now empty
+	fck.subtractCollectedFees(ctx, oneCoin)
+	require.True(t, fck.GetCollectedFees(ctx).IsEqual(emptyCoins))
+}
+
 func TestFeeCollectionKeeperClear(t *testing.T) {
 	ms, _, capKey2 := setupMultiStore()
 	cdc := wire.NewCodec()
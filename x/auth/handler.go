@@ -28,7 +28,12 @@ func handleMsgChangeKey(ctx sdk.Context, am AccountMapper, msg MsgChangeKey) sdk
 		return err.Result()
 	}
 
-	return sdk.Result{
-		Tags: sdk.NewTags("action", []byte("changePubkey"), "address", msg.Address.Bytes(), "pubkey", msg.NewPubKey.Bytes()),
-	}
+	ctx.EventManager().EmitEvent(sdk.NewEvent(sdk.EventTypeMessage,
+		sdk.NewAttribute(sdk.AttributeKeyModule, msg.Type()),
+		sdk.NewAttribute(sdk.AttributeKeyAction, "changePubkey"),
+		sdk.NewAttribute(sdk.AttributeKeySender, msg.Address.String()),
+		sdk.NewAttribute("pubkey", sdk.MustBech32ifyAccPub(msg.NewPubKey)),
+	))
+
+	return sdk.Result{}
 }
@@ -7,6 +7,7 @@ import (
 	"github.com/tepleton/tepleton-sdk/crypto/keys"
 	"github.com/gorilla/mux"
 
+	sdkclient "github.com/tepleton/tepleton-sdk/client"
 	"github.com/tepleton/tepleton-sdk/client/context"
 	sdk "github.com/tepleton/tepleton-sdk/types"
 	"github.com/tepleton/tepleton-sdk/wire"
@@ -28,7 +29,7 @@ type sendBody struct {
 	ChainID          string    `json:"chain_id"`
 	AccountNumber    int64     `json:"account_number"`
 	Sequence         int64     `json:"sequence"`
-	Gas              int64     `json:"gas"`
+	Gas              string    `json:"gas"`
 }
 
 var msgCdc = wire.NewCodec()
@@ -88,13 +89,25 @@ func SendRequestHandlerFn(cdc *wire.Codec, kb keys.Keybase, ctx context.CoreCont
 		}
 
 		// add gas to context
-		ctx = ctx.WithGas(m.Gas)
+		simulate, gas, err := sdkclient.ParseGas(m.Gas)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(err.Error()))
+			return
+		}
+		ctx = ctx.WithGas(gas).WithSimulate(simulate)
 		// add chain-id to context
 		ctx = ctx.WithChainID(m.ChainID)
 
 		// sign
 		ctx = ctx.WithAccountNumber(m.AccountNumber)
 		ctx = ctx.WithSequence(m.Sequence)
+		ctx, err = ctx.EnsureGas(m.LocalAccountName, m.Password, []sdk.Msg{msg}, cdc)
+		if err != nil {
+			w.WriteHeader(http.StatusUnauthorized)
+			w.Write([]byte(err.Error()))
+			return
+		}
 		txBytes, err := ctx.SignAndBuild(m.LocalAccountName, m.Password, []sdk.Msg{msg}, cdc)
 		if err != nil {
 			w.WriteHeader(http.StatusUnauthorized)
@@ -0,0 +1,13 @@
+// nolint
+package bank
+
+// bank events
+const (
+	ActionSend = "send"
+
+	// EventTypeTransfer is emitted for each output of a send
+	EventTypeTransfer = "transfer"
+
+	AttributeKeyRecipient = "recipient"
+	AttributeKeyAmount    = "amount"
+)
@@ -25,14 +25,27 @@ func NewHandler(k Keeper) sdk.Handler {
 func handleMsgSend(ctx sdk.Context, k Keeper, msg MsgSend) sdk.Result {
 	// NOTE: totalIn == totalOut should already have been checked
 
-	tags, err := k.InputOutputCoins(ctx, msg.Inputs, msg.Outputs)
+	_, err := k.InputOutputCoins(ctx, msg.Inputs, msg.Outputs)
 	if err != nil {
 		return err.Result()
 	}
 
-	return sdk.Result{
-		Tags: tags,
+	event := sdk.NewEvent(sdk.EventTypeMessage,
+		sdk.NewAttribute(sdk.AttributeKeyModule, msg.Type()),
+		sdk.NewAttribute(sdk.AttributeKeyAction, ActionSend),
+	)
+	for _, in := range msg.Inputs {
+		event = event.AppendAttributes(sdk.NewAttribute(sdk.AttributeKeySender, in.Address.String()))
 	}
+	ctx.EventManager().EmitEvent(event)
+	for _, out := range msg.Outputs {
+		ctx.EventManager().EmitEvent(sdk.NewEvent(EventTypeTransfer,
+			sdk.NewAttribute(AttributeKeyRecipient, out.Address.String()),
+			sdk.NewAttribute(AttributeKeyAmount, out.Coins.String()),
+		))
+	}
+
+	return sdk.Result{}
 }
 
 // Handle MsgIssue.
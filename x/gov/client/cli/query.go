@@ -0,0 +1,274 @@
+package cli
+
+import (
+	"fmt"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/tepleton/tepleton-sdk/client/context"
+	sdk "github.com/tepleton/tepleton-sdk/types"
+	"github.com/tepleton/tepleton-sdk/wire"
+	"github.com/tepleton/tepleton-sdk/x/gov"
+)
+
+// Command to Get a Proposal Information
+func GetCmdQueryProposal(queryRoute string, cdc *wire.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "query-proposal",
+		Short: "query proposal details",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			params := gov.QueryProposalParams{
+				ProposalID: viper.GetInt64(flagProposalID),
+			}
+
+			var proposal gov.Proposal
+			err := queryGov(cdc, queryRoute, gov.QueryProposal, params, &proposal)
+			if err != nil {
+				return err
+			}
+
+			return printJSON(cdc, gov.ProposalToRest(proposal))
+		},
+	}
+
+	cmd.Flags().String(flagProposalID, "", "proposalID of proposal being queried")
+
+	return cmd
+}
+
+// Command to Query Proposals, optionally filtered by status, voter and depositer
+func GetCmdQueryProposals(queryRoute string, cdc *wire.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "query-proposals",
+		Short: "query proposals with optional filters",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			params := gov.QueryProposalsParams{
+				NumLatest: viper.GetInt64(flagLatestN),
+			}
+
+			if bechVoter := viper.GetString(flagVoter); len(bechVoter) != 0 {
+				voterAddr, err := sdk.GetAccAddressBech32(bechVoter)
+				if err != nil {
+					return err
+				}
+				params.Voter = voterAddr
+			}
+
+			if bechDepositer := viper.GetString(flagDepositer); len(bechDepositer) != 0 {
+				depositerAddr, err := sdk.GetAccAddressBech32(bechDepositer)
+				if err != nil {
+					return err
+				}
+				params.Depositer = depositerAddr
+			}
+
+			if strStatus := viper.GetString(flagStatus); len(strStatus) != 0 {
+				status := gov.StringToStatus(strStatus)
+				if status == gov.VoteStatus(0xff) {
+					return errors.Errorf("'%s' is not a valid proposal status, options: DepositPeriod/VotingPeriod/Passed/Rejected", strStatus)
+				}
+				params.ProposalStatus = status
+			}
+
+			var proposals []gov.Proposal
+			err := queryGov(cdc, queryRoute, gov.QueryProposals, params, &proposals)
+			if err != nil {
+				return err
+			}
+
+			if len(proposals) == 0 {
+				fmt.Println("No matching proposals found")
+				return nil
+			}
+
+			proposalsRest := make([]gov.ProposalRest, 0, len(proposals))
+			for _, proposal := range proposals {
+				proposalsRest = append(proposalsRest, gov.ProposalToRest(proposal))
+			}
+			return printJSON(cdc, proposalsRest)
+		},
+	}
+
+	cmd.Flags().String(flagLatestN, "", "(optional) limit to latest [number] proposals. Defaults to all proposals")
+	cmd.Flags().String(flagDepositer, "", "(optional) filter by proposals deposited on by depositer")
+	cmd.Flags().String(flagVoter, "", "(optional) filter by proposals voted on by voter")
+	cmd.Flags().String(flagStatus, "", "(optional) filter proposals by proposal status, options: DepositPeriod/VotingPeriod/Passed/Rejected")
+
+	return cmd
+}
+
+// Command to Get a Vote Information
+func GetCmdQueryVote(queryRoute string, cdc *wire.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "query-vote",
+		Short: "query vote",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			voterAddr, err := sdk.GetAccAddressBech32(viper.GetString(flagVoter))
+			if err != nil {
+				return err
+			}
+
+			params := gov.QueryVoteParams{
+				ProposalID: viper.GetInt64(flagProposalID),
+				Voter:      voterAddr,
+			}
+
+			var vote gov.Vote
+			err = queryGov(cdc, queryRoute, gov.QueryVote, params, &vote)
+			if err != nil {
+				return err
+			}
+
+			return printJSON(cdc, gov.VoteToRest(vote))
+		},
+	}
+
+	cmd.Flags().String(flagProposalID, "", "proposalID of proposal voting on")
+	cmd.Flags().String(flagVoter, "", "bech32 voter address")
+
+	return cmd
+}
+
+// Command to Get Votes on a Proposal
+func GetCmdQueryVotes(queryRoute string, cdc *wire.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "query-votes",
+		Short: "query votes on a proposal",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			params := gov.QueryProposalParams{
+				ProposalID: viper.GetInt64(flagProposalID),
+			}
+
+			var votes []gov.Vote
+			err := queryGov(cdc, queryRoute, gov.QueryVotes, params, &votes)
+			if err != nil {
+				return err
+			}
+
+			votesRest := make([]gov.VoteRest, 0, len(votes))
+			for _, vote := range votes {
+				votesRest = append(votesRest, gov.VoteToRest(vote))
+			}
+			return printJSON(cdc, votesRest)
+		},
+	}
+
+	cmd.Flags().String(flagProposalID, "", "proposalID of which proposal's votes are being queried")
+
+	return cmd
+}
+
+// Command to Get a Deposit Information
+func GetCmdQueryDeposit(queryRoute string, cdc *wire.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "query-deposit",
+		Short: "query deposit",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			depositerAddr, err := sdk.GetAccAddressBech32(viper.GetString(flagDepositer))
+			if err != nil {
+				return err
+			}
+
+			params := gov.QueryDepositParams{
+				ProposalID: viper.GetInt64(flagProposalID),
+				Depositer:  depositerAddr,
+			}
+
+			var deposit gov.Deposit
+			err = queryGov(cdc, queryRoute, gov.QueryDeposit, params, &deposit)
+			if err != nil {
+				return err
+			}
+
+			return printJSON(cdc, gov.DepositToRest(deposit))
+		},
+	}
+
+	cmd.Flags().String(flagProposalID, "", "proposalID of proposal deposited on")
+	cmd.Flags().String(flagDepositer, "", "bech32 depositer address")
+
+	return cmd
+}
+
+// Command to Get Deposits on a Proposal
+func GetCmdQueryDeposits(queryRoute string, cdc *wire.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "query-deposits",
+		Short: "query deposits on a proposal",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			params := gov.QueryProposalParams{
+				ProposalID: viper.GetInt64(flagProposalID),
+			}
+
+			var deposits []gov.Deposit
+			err := queryGov(cdc, queryRoute, gov.QueryDeposits, params, &deposits)
+			if err != nil {
+				return err
+			}
+
+			depositsRest := make([]gov.DepositRest, 0, len(deposits))
+			for _, deposit := range deposits {
+				depositsRest = append(depositsRest, gov.DepositToRest(deposit))
+			}
+			return printJSON(cdc, depositsRest)
+		},
+	}
+
+	cmd.Flags().String(flagProposalID, "", "proposalID of which proposal's deposits are being queried")
+
+	return cmd
+}
+
+// Command to Get the Tally of a Proposal
+func GetCmdQueryTally(queryRoute string, cdc *wire.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "query-tally",
+		Short: "get the tally of a proposal vote",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			params := gov.QueryProposalParams{
+				ProposalID: viper.GetInt64(flagProposalID),
+			}
+
+			var tally gov.TallyResult
+			err := queryGov(cdc, queryRoute, gov.QueryTally, params, &tally)
+			if err != nil {
+				return err
+			}
+
+			return printJSON(cdc, tally)
+		},
+	}
+
+	cmd.Flags().String(flagProposalID, "", "proposalID of which proposal is being tallied")
+
+	return cmd
+}
+
+// queryGov sends the JSON encoded params to the gov querier endpoint and
+// decodes the JSON response into ptr
+func queryGov(cdc *wire.Codec, queryRoute, endpoint string, params interface{}, ptr interface{}) error {
+	bz, err := cdc.MarshalJSON(params)
+	if err != nil {
+		return err
+	}
+
+	ctx := context.NewCoreContextFromViper()
+
+	res, err := ctx.QueryWithData(fmt.Sprintf("/custom/%s/%s", queryRoute, endpoint), bz)
+	if err != nil {
+		return err
+	}
+
+	return cdc.UnmarshalJSON(res, ptr)
+}
+
+func printJSON(cdc *wire.Codec, obj interface{}) error {
+	output, err := wire.MarshalJSONIndent(cdc, obj)
+	if err != nil {
+		return err
+	}
+	fmt.Println(string(output))
+	return nil
+}
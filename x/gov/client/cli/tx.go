@@ -11,7 +11,6 @@ import (
 	"github.com/tepleton/tepleton-sdk/wire"
 	authcmd "github.com/tepleton/tepleton-sdk/x/auth/client/cli"
 	"github.com/tepleton/tepleton-sdk/x/gov"
-	"github.com/pkg/errors"
 )
 
 const (
@@ -24,6 +23,9 @@ const (
 	flagDepositer    = "depositer"
 	flagVoter        = "voter"
 	flagOption       = "option"
+	flagOptions      = "options"
+	flagStatus       = "status"
+	flagLatestN      = "limit"
 )
 
 // submit a proposal tx
@@ -180,72 +182,51 @@ func GetCmdVote(cdc *wire.Codec) *cobra.Command {
 	return cmd
 }
 
-// Command to Get a Proposal Information
-func GetCmdQueryProposal(storeName string, cdc *wire.Codec) *cobra.Command {
+// set a new weighted Vote transaction
+func GetCmdVoteWeighted(cdc *wire.Codec) *cobra.Command {
 	cmd := &cobra.Command{
-		Use:   "query-proposal",
-		Short: "query proposal details",
+		Use:   "vote-weighted",
+		Short: "split a vote for an active proposal between options, e.g. Yes=0.6,No=0.4",
 		RunE: func(cmd *cobra.Command, args []string) error {
-			proposalID := viper.GetInt64(flagProposalID)
-
-			ctx := context.NewCoreContextFromViper()
-
-			res, err := ctx.QueryStore(gov.KeyProposal(proposalID), storeName)
-			if len(res) == 0 || err != nil {
-				return errors.Errorf("proposalID [%d] is not existed", proposalID)
-			}
 
-			var proposal gov.Proposal
-			cdc.MustUnmarshalBinary(res, &proposal)
-			proposalRest := gov.ProposalToRest(proposal)
-			output, err := wire.MarshalJSONIndent(cdc, proposalRest)
+			bechVoter := viper.GetString(flagVoter)
+			voter, err := sdk.GetAccAddressBech32(bechVoter)
 			if err != nil {
 				return err
 			}
-			fmt.Println(string(output))
-			return nil
-		},
-	}
-
-	cmd.Flags().String(flagProposalID, "", "proposalID of proposal being queried")
-
-	return cmd
-}
 
-// Command to Get a Proposal Information
-func GetCmdQueryVote(storeName string, cdc *wire.Codec) *cobra.Command {
-	cmd := &cobra.Command{
-		Use:   "query-vote",
-		Short: "query vote",
-		RunE: func(cmd *cobra.Command, args []string) error {
 			proposalID := viper.GetInt64(flagProposalID)
 
-			voterAddr, err := sdk.GetAccAddressBech32(viper.GetString(flagVoter))
+			options, err := gov.StringToWeightedVoteOptions(viper.GetString(flagOptions))
 			if err != nil {
 				return err
 			}
 
-			ctx := context.NewCoreContextFromViper()
+			// create the message
+			msg := gov.NewMsgVoteWeighted(voter, proposalID, options)
 
-			res, err := ctx.QueryStore(gov.KeyVote(proposalID, voterAddr), storeName)
-			if len(res) == 0 || err != nil {
-				return errors.Errorf("proposalID [%d] does not exist", proposalID)
+			err = msg.ValidateBasic()
+			if err != nil {
+				return err
 			}
 
-			var vote gov.Vote
-			cdc.MustUnmarshalBinary(res, &vote)
-			voteRest := gov.VoteToRest(vote)
-			output, err := wire.MarshalJSONIndent(cdc, voteRest)
+			fmt.Printf("Vote[Voter:%s,ProposalID:%d,Options:%s]", bechVoter, msg.ProposalID, msg.Options)
+
+			// build and sign the transaction, then broadcast to Tendermint
+			ctx := context.NewCoreContextFromViper().WithDecoder(authcmd.GetAccountDecoder(cdc))
+
+			res, err := ctx.EnsureSignBuildBroadcast(ctx.FromAddressName, []sdk.Msg{msg}, cdc)
 			if err != nil {
 				return err
 			}
-			fmt.Println(string(output))
+			fmt.Printf("Committed at block %d. Hash: %s\n", res.Height, res.Hash.String())
 			return nil
 		},
 	}
 
 	cmd.Flags().String(flagProposalID, "", "proposalID of proposal voting on")
 	cmd.Flags().String(flagVoter, "", "bech32 voter address")
+	cmd.Flags().String(flagOptions, "", "weighted vote options summing to 1, e.g. Yes=0.6,No=0.4")
 
 	return cmd
 }
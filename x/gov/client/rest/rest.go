@@ -16,10 +16,13 @@ import (
 // REST Variable names
 // nolint
 const (
-	RestProposalID = "proposalID"
-	RestDepositer  = "depositer"
-	RestVoter      = "voter"
-	storeName      = "gov"
+	RestProposalID     = "proposalID"
+	RestDepositer      = "depositer"
+	RestVoter          = "voter"
+	RestProposalStatus = "status"
+	RestNumLatest      = "limit"
+	storeName          = "gov"
+	queryRoute         = "gov"
 )
 
 // RegisterRoutes - Central function to define routes that get registered by the main application
@@ -52,8 +55,9 @@ type depositReq struct {
 
 type voteReq struct {
 	BaseReq baseReq `json:"base_req"`
-	Voter   string  `json:"voter"`  //  address of the voter
-	Option  string  `json:"option"` //  option from OptionSet chosen by the voter
+	Voter   string  `json:"voter"`   //  address of the voter
+	Option  string  `json:"option"`  //  option from OptionSet chosen by the voter
+	Options string  `json:"options"` //  weighted options, e.g. "Yes=0.6,No=0.4", used instead of option for split votes
 }
 
 func postProposalHandlerFn(cdc *wire.Codec, ctx context.CoreContext) http.HandlerFunc {
@@ -176,14 +180,24 @@ func voteHandlerFn(cdc *wire.Codec, ctx context.CoreContext) http.HandlerFunc {
 			return
 		}
 
-		voteOptionByte, err := gov.StringToVoteOption(req.Option)
-		if err != nil {
-			writeErr(&w, http.StatusBadRequest, err.Error())
-			return
+		var msg sdk.Msg
+		if len(req.Options) != 0 {
+			options, err := gov.StringToWeightedVoteOptions(req.Options)
+			if err != nil {
+				writeErr(&w, http.StatusBadRequest, err.Error())
+				return
+			}
+			msg = gov.NewMsgVoteWeighted(voter, proposalID, options)
+		} else {
+			voteOptionByte, err := gov.StringToVoteOption(req.Option)
+			if err != nil {
+				writeErr(&w, http.StatusBadRequest, err.Error())
+				return
+			}
+			msg = gov.NewMsgVote(voter, proposalID, voteOptionByte)
 		}
 
 		// create the message
-		msg := gov.NewMsgVote(voter, proposalID, voteOptionByte)
 		err = msg.ValidateBasic()
 		if err != nil {
 			writeErr(&w, http.StatusBadRequest, err.Error())
@@ -214,7 +228,12 @@ func queryProposalHandlerFn(cdc *wire.Codec) http.HandlerFunc {
 			return
 		}
 
-		ctx := context.NewCoreContextFromViper()
+		ctx, err := context.NewCoreContextFromViper().WithHeightFromRequest(r)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(err.Error()))
+			return
+		}
 
 		res, err := ctx.QueryStore(gov.KeyProposal(proposalID), storeName)
 		if err != nil || len(res) == 0 {
@@ -272,7 +291,12 @@ func queryDepositHandlerFn(cdc *wire.Codec) http.HandlerFunc {
 			return
 		}
 
-		ctx := context.NewCoreContextFromViper()
+		ctx, err := context.NewCoreContextFromViper().WithHeightFromRequest(r)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(err.Error()))
+			return
+		}
 
 		res, err := ctx.QueryStore(gov.KeyDeposit(proposalID, depositerAddr), storeName)
 		if err != nil || len(res) == 0 {
@@ -338,7 +362,12 @@ func queryVoteHandlerFn(cdc *wire.Codec) http.HandlerFunc {
 			return
 		}
 
-		ctx := context.NewCoreContextFromViper()
+		ctx, err := context.NewCoreContextFromViper().WithHeightFromRequest(r)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(err.Error()))
+			return
+		}
 
 		res, err := ctx.QueryStore(gov.KeyVote(proposalID, voterAddr), storeName)
 		if err != nil || len(res) == 0 {
@@ -373,70 +402,90 @@ func queryProposalsWithParameterFn(cdc *wire.Codec) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		bechVoterAddr := r.URL.Query().Get(RestVoter)
 		bechDepositerAddr := r.URL.Query().Get(RestDepositer)
+		strProposalStatus := r.URL.Query().Get(RestProposalStatus)
+		strNumLatest := r.URL.Query().Get(RestNumLatest)
 
-		var err error
-		var voterAddr sdk.Address
-		var depositerAddr sdk.Address
+		params := gov.QueryProposalsParams{}
 
 		if len(bechVoterAddr) != 0 {
-			voterAddr, err = sdk.GetAccAddressBech32(bechVoterAddr)
+			voterAddr, err := sdk.GetAccAddressBech32(bechVoterAddr)
 			if err != nil {
 				w.WriteHeader(http.StatusBadRequest)
 				err := errors.Errorf("'%s' needs to be bech32 encoded", RestVoter)
 				w.Write([]byte(err.Error()))
 				return
 			}
+			params.Voter = voterAddr
 		}
 
 		if len(bechDepositerAddr) != 0 {
-			depositerAddr, err = sdk.GetAccAddressBech32(bechDepositerAddr)
+			depositerAddr, err := sdk.GetAccAddressBech32(bechDepositerAddr)
 			if err != nil {
 				w.WriteHeader(http.StatusBadRequest)
 				err := errors.Errorf("'%s' needs to be bech32 encoded", RestDepositer)
 				w.Write([]byte(err.Error()))
 				return
 			}
+			params.Depositer = depositerAddr
 		}
 
-		ctx := context.NewCoreContextFromViper()
+		if len(strProposalStatus) != 0 {
+			proposalStatus := gov.StringToStatus(strProposalStatus)
+			if proposalStatus == gov.VoteStatus(0xff) {
+				w.WriteHeader(http.StatusBadRequest)
+				err := errors.Errorf("'%s' is not a valid proposal status", strProposalStatus)
+				w.Write([]byte(err.Error()))
+				return
+			}
+			params.ProposalStatus = proposalStatus
+		}
+
+		if len(strNumLatest) != 0 {
+			numLatest, err := strconv.ParseInt(strNumLatest, 10, 64)
+			if err != nil {
+				w.WriteHeader(http.StatusBadRequest)
+				err := errors.Errorf("'%s' is not a valid int", RestNumLatest)
+				w.Write([]byte(err.Error()))
+				return
+			}
+			params.NumLatest = numLatest
+		}
 
-		res, err := ctx.QueryStore(gov.KeyNextProposalID, storeName)
+		bz, err := cdc.MarshalJSON(params)
 		if err != nil {
-			err = errors.New("no proposals exist yet and proposalID has not been set")
+			w.WriteHeader(http.StatusBadRequest)
 			w.Write([]byte(err.Error()))
 			return
 		}
-		var maxProposalID int64
-		cdc.MustUnmarshalBinary(res, &maxProposalID)
 
-		matchingProposals := []gov.ProposalRest{}
-
-		for proposalID := int64(0); proposalID < maxProposalID; proposalID++ {
-			if voterAddr != nil {
-				res, err = ctx.QueryStore(gov.KeyVote(proposalID, voterAddr), storeName)
-				if err != nil || len(res) == 0 {
-					continue
-				}
-			}
+		ctx, err := context.NewCoreContextFromViper().WithHeightFromRequest(r)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(err.Error()))
+			return
+		}
 
-			if depositerAddr != nil {
-				res, err = ctx.QueryStore(gov.KeyDeposit(proposalID, depositerAddr), storeName)
-				if err != nil || len(res) == 0 {
-					continue
-				}
-			}
+		res, err := ctx.QueryWithData(fmt.Sprintf("/custom/%s/%s", queryRoute, gov.QueryProposals), bz)
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			w.Write([]byte(err.Error()))
+			return
+		}
 
-			res, err = ctx.QueryStore(gov.KeyProposal(proposalID), storeName)
-			if err != nil || len(res) == 0 {
-				continue
-			}
-			var proposal gov.Proposal
-			cdc.MustUnmarshalBinary(res, &proposal)
+		var matchingProposals []gov.Proposal
+		err = cdc.UnmarshalJSON(res, &matchingProposals)
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			w.Write([]byte(err.Error()))
+			return
+		}
 
-			matchingProposals = append(matchingProposals, gov.ProposalToRest(proposal))
+		proposalsRest := []gov.ProposalRest{}
+		for _, proposal := range matchingProposals {
+			proposalsRest = append(proposalsRest, gov.ProposalToRest(proposal))
 		}
 
-		output, err := wire.MarshalJSONIndent(cdc, matchingProposals)
+		output, err := wire.MarshalJSONIndent(cdc, proposalsRest)
 		if err != nil {
 			w.WriteHeader(http.StatusBadRequest)
 			w.Write([]byte(err.Error()))
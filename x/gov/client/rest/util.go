@@ -4,6 +4,7 @@ import (
 	"io/ioutil"
 	"net/http"
 
+	"github.com/tepleton/tepleton-sdk/client"
 	"github.com/tepleton/tepleton-sdk/client/context"
 	sdk "github.com/tepleton/tepleton-sdk/types"
 	"github.com/tepleton/tepleton-sdk/wire"
@@ -16,7 +17,7 @@ type baseReq struct {
 	ChainID       string `json:"chain_id"`
 	AccountNumber int64  `json:"account_number"`
 	Sequence      int64  `json:"sequence"`
-	Gas           int64  `json:"gas"`
+	Gas           string `json:"gas"`
 }
 
 func buildReq(w http.ResponseWriter, r *http.Request, cdc *wire.Codec, req interface{}) error {
@@ -74,7 +75,18 @@ func signAndBuild(w http.ResponseWriter, ctx context.CoreContext, baseReq baseRe
 	ctx = ctx.WithChainID(baseReq.ChainID)
 
 	// add gas to context
-	ctx = ctx.WithGas(baseReq.Gas)
+	simulate, gas, err := client.ParseGas(baseReq.Gas)
+	if err != nil {
+		writeErr(&w, http.StatusBadRequest, err.Error())
+		return
+	}
+	ctx = ctx.WithGas(gas).WithSimulate(simulate)
+
+	ctx, err = ctx.EnsureGas(baseReq.Name, baseReq.Password, []sdk.Msg{msg}, cdc)
+	if err != nil {
+		writeErr(&w, http.StatusUnauthorized, err.Error())
+		return
+	}
 
 	txBytes, err := ctx.SignAndBuild(baseReq.Name, baseReq.Password, []sdk.Msg{msg}, cdc)
 	if err != nil {
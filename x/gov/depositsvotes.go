@@ -1,6 +1,9 @@
 package gov
 
 import (
+	"fmt"
+	"strings"
+
 	sdk "github.com/tepleton/tepleton-sdk/types"
 )
 
@@ -16,11 +19,82 @@ const (
 	OptionNoWithVeto VoteOption = 0x04
 )
 
+// WeightedVoteOption is a vote option together with the fraction of the
+// voter's voting power assigned to it
+type WeightedVoteOption struct {
+	Option VoteOption `json:"option"` //  option from OptionSet chosen by the voter
+	Weight sdk.Dec    `json:"weight"` //  fraction of the voting power given to the option
+}
+
+// NewWeightedVoteOption creates a WeightedVoteOption
+func NewWeightedVoteOption(option VoteOption, weight sdk.Dec) WeightedVoteOption {
+	return WeightedVoteOption{
+		Option: option,
+		Weight: weight,
+	}
+}
+
+// WeightedVoteOptions splits a vote between one or more options
+type WeightedVoteOptions []WeightedVoteOption
+
+// NewNonSplitVoteOption returns options giving the full voting power to a single option
+func NewNonSplitVoteOption(option VoteOption) WeightedVoteOptions {
+	return WeightedVoteOptions{NewWeightedVoteOption(option, sdk.OneDec())}
+}
+
+// checks that every option is valid, appears only once, has a positive
+// weight and that the weights sum up to exactly one
+func (options WeightedVoteOptions) ValidateBasic() sdk.Error {
+	if len(options) == 0 {
+		return ErrInvalidVote(DefaultCodespace, options.String())
+	}
+	totalWeight := sdk.ZeroDec()
+	usedOptions := make(map[VoteOption]bool)
+	for _, option := range options {
+		if !validVoteOption(option.Option) {
+			return ErrInvalidVote(DefaultCodespace, VoteOptionToString(option.Option))
+		}
+		if usedOptions[option.Option] {
+			return ErrInvalidVote(DefaultCodespace, options.String())
+		}
+		if option.Weight.IsNil() || !option.Weight.GT(sdk.ZeroDec()) || option.Weight.GT(sdk.OneDec()) {
+			return ErrInvalidVote(DefaultCodespace, options.String())
+		}
+		usedOptions[option.Option] = true
+		totalWeight = totalWeight.Add(option.Weight)
+	}
+	if !totalWeight.Equal(sdk.OneDec()) {
+		return ErrInvalidVote(DefaultCodespace, options.String())
+	}
+	return nil
+}
+
+// returns the single option if the vote is not split, otherwise OptionEmpty
+func (options WeightedVoteOptions) NonSplitOption() VoteOption {
+	if len(options) == 1 && options[0].Weight.Equal(sdk.OneDec()) {
+		return options[0].Option
+	}
+	return OptionEmpty
+}
+
+// String returns the options in the format "Yes=0.6,No=0.4"
+func (options WeightedVoteOptions) String() string {
+	strs := make([]string, 0, len(options))
+	for _, option := range options {
+		weight := "<nil>"
+		if !option.Weight.IsNil() {
+			weight = option.Weight.String()
+		}
+		strs = append(strs, fmt.Sprintf("%s=%s", VoteOptionToString(option.Option), weight))
+	}
+	return strings.Join(strs, ",")
+}
+
 // Vote
 type Vote struct {
-	Voter      sdk.Address `json:"voter"`       //  address of the voter
-	ProposalID int64       `json:"proposal_id"` //  proposalID of the proposal
-	Option     VoteOption  `json:"option"`      //  option from OptionSet chosen by the voter
+	Voter      sdk.Address         `json:"voter"`       //  address of the voter
+	ProposalID int64               `json:"proposal_id"` //  proposalID of the proposal
+	Options    WeightedVoteOptions `json:"options"`     //  weighted options from OptionSet chosen by the voter
 }
 
 // Deposit
@@ -72,6 +146,27 @@ func StringToVoteOption(str string) (VoteOption, sdk.Error) {
 	}
 }
 
+// Parses weighted vote options in the format "Yes=0.6,No=0.4"
+func StringToWeightedVoteOptions(str string) (WeightedVoteOptions, sdk.Error) {
+	options := WeightedVoteOptions{}
+	for _, strOption := range strings.Split(str, ",") {
+		fields := strings.Split(strings.TrimSpace(strOption), "=")
+		if len(fields) != 2 || len(fields[1]) == 0 {
+			return nil, ErrInvalidVote(DefaultCodespace, strOption)
+		}
+		option, err := StringToVoteOption(fields[0])
+		if err != nil {
+			return nil, err
+		}
+		weight, err := sdk.NewDecFromStr(fields[1])
+		if err != nil {
+			return nil, ErrInvalidVote(DefaultCodespace, strOption)
+		}
+		options = append(options, NewWeightedVoteOption(option, weight))
+	}
+	return options, nil
+}
+
 //-----------------------------------------------------------
 // REST
 
@@ -92,19 +187,34 @@ func DepositToRest(deposit Deposit) DepositRest {
 	}
 }
 
+// Rest Weighted Vote Options
+type WeightedVoteOptionRest struct {
+	Option string `json:"option"`
+	Weight string `json:"weight"`
+}
+
 // Rest Votes
 type VoteRest struct {
-	Voter      string `json:"voter"`       //  address of the voter
-	ProposalID int64  `json:"proposal_id"` //  proposalID of the proposal
-	Option     string `json:"option"`
+	Voter      string                   `json:"voter"`       //  address of the voter
+	ProposalID int64                    `json:"proposal_id"` //  proposalID of the proposal
+	Option     string                   `json:"option"`      //  set only if the vote is not split
+	Options    []WeightedVoteOptionRest `json:"options"`
 }
 
 // Turn any Vote to a VoteRest
 func VoteToRest(vote Vote) VoteRest {
 	bechAddr, _ := sdk.Bech32ifyAcc(vote.Voter)
+	options := make([]WeightedVoteOptionRest, 0, len(vote.Options))
+	for _, option := range vote.Options {
+		options = append(options, WeightedVoteOptionRest{
+			Option: VoteOptionToString(option.Option),
+			Weight: option.Weight.String(),
+		})
+	}
 	return VoteRest{
 		Voter:      bechAddr,
 		ProposalID: vote.ProposalID,
-		Option:     VoteOptionToString(vote.Option),
+		Option:     VoteOptionToString(vote.Options.NonSplitOption()),
+		Options:    options,
 	}
 }
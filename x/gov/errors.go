@@ -20,6 +20,8 @@ const (
 	CodeInvalidProposalType     sdk.CodeType = 8
 	CodeInvalidVote             sdk.CodeType = 9
 	CodeInvalidGenesis          sdk.CodeType = 10
+	CodeUnknownDeposit          sdk.CodeType = 11
+	CodeUnknownVote             sdk.CodeType = 12
 )
 
 //----------------------------------------
@@ -65,3 +67,13 @@ func ErrInvalidVote(codespace sdk.CodespaceType, strOption string) sdk.Error {
 func ErrInvalidGenesis(codespace sdk.CodespaceType, msg string) sdk.Error {
 	return sdk.NewError(codespace, CodeInvalidVote, msg)
 }
+
+func ErrUnknownDeposit(codespace sdk.CodespaceType, proposalID int64, depositer sdk.Address) sdk.Error {
+	bechAddr, _ := sdk.Bech32ifyAcc(depositer)
+	return sdk.NewError(codespace, CodeUnknownDeposit, fmt.Sprintf("Address %s has not deposited on proposal %d", bechAddr, proposalID))
+}
+
+func ErrUnknownVote(codespace sdk.CodespaceType, proposalID int64, voter sdk.Address) sdk.Error {
+	bechAddr, _ := sdk.Bech32ifyAcc(voter)
+	return sdk.NewError(codespace, CodeUnknownVote, fmt.Sprintf("Address %s has not voted on proposal %d", bechAddr, proposalID))
+}
@@ -0,0 +1,22 @@
+// nolint
+package gov
+
+// gov events
+const (
+	ActionSubmitProposal = "submitProposal"
+	ActionDeposit        = "deposit"
+	ActionVote           = "vote"
+
+	// emitted by the end blocker
+	EventTypeProposalDropped  = "proposalDropped"
+	EventTypeProposalPassed   = "proposalPassed"
+	EventTypeProposalRejected = "proposalRejected"
+
+	// emitted when a deposit starts the voting period of a proposal
+	EventTypeVotingPeriodStart = "votingPeriodStart"
+
+	AttributeKeyProposalID = "proposalId"
+	AttributeKeyProposer   = "proposer"
+	AttributeKeyDepositer  = "depositer"
+	AttributeKeyVoter      = "voter"
+)
@@ -1,6 +1,8 @@
 package gov
 
 import (
+	"strconv"
+
 	sdk "github.com/tepleton/tepleton-sdk/types"
 )
 
@@ -14,6 +16,8 @@ func NewHandler(keeper Keeper) sdk.Handler {
 			return handleMsgSubmitProposal(ctx, keeper, msg)
 		case MsgVote:
 			return handleMsgVote(ctx, keeper, msg)
+		case MsgVoteWeighted:
+			return handleMsgVoteWeighted(ctx, keeper, msg)
 		default:
 			errMsg := "Unrecognized gov msg type"
 			return sdk.ErrUnknownRequest(errMsg).Result()
@@ -30,21 +34,19 @@ func handleMsgSubmitProposal(ctx sdk.Context, keeper Keeper, msg MsgSubmitPropos
 		return err.Result()
 	}
 
-	proposalIDBytes := keeper.cdc.MustMarshalBinaryBare(proposal.GetProposalID())
-
-	tags := sdk.NewTags(
-		"action", []byte("submitProposal"),
-		"proposer", []byte(msg.Proposer.String()),
-		"proposalId", proposalIDBytes,
+	proposalID := strconv.FormatInt(proposal.GetProposalID(), 10)
+	emitMsgEvent(ctx, ActionSubmitProposal, msg.Proposer,
+		sdk.NewAttribute(AttributeKeyProposer, msg.Proposer.String()),
+		sdk.NewAttribute(AttributeKeyProposalID, proposalID),
 	)
-
 	if votingStarted {
-		tags.AppendTag("votingPeriodStart", proposalIDBytes)
+		ctx.EventManager().EmitEvent(sdk.NewEvent(EventTypeVotingPeriodStart,
+			sdk.NewAttribute(AttributeKeyProposalID, proposalID),
+		))
 	}
 
 	return sdk.Result{
-		Data: proposalIDBytes,
-		Tags: tags,
+		Data: keeper.cdc.MustMarshalBinaryBare(proposal.GetProposalID()),
 	}
 }
 
@@ -55,22 +57,18 @@ func handleMsgDeposit(ctx sdk.Context, keeper Keeper, msg MsgDeposit) sdk.Result
 		return err.Result()
 	}
 
-	proposalIDBytes := keeper.cdc.MustMarshalBinaryBare(msg.ProposalID)
-
-	// TODO: Add tag for if voting period started
-	tags := sdk.NewTags(
-		"action", []byte("deposit"),
-		"depositer", []byte(msg.Depositer.String()),
-		"proposalId", proposalIDBytes,
+	proposalID := strconv.FormatInt(msg.ProposalID, 10)
+	emitMsgEvent(ctx, ActionDeposit, msg.Depositer,
+		sdk.NewAttribute(AttributeKeyDepositer, msg.Depositer.String()),
+		sdk.NewAttribute(AttributeKeyProposalID, proposalID),
 	)
-
 	if votingStarted {
-		tags.AppendTag("votingPeriodStart", proposalIDBytes)
+		ctx.EventManager().EmitEvent(sdk.NewEvent(EventTypeVotingPeriodStart,
+			sdk.NewAttribute(AttributeKeyProposalID, proposalID),
+		))
 	}
 
-	return sdk.Result{
-		Tags: tags,
-	}
+	return sdk.Result{}
 }
 
 func handleMsgVote(ctx sdk.Context, keeper Keeper, msg MsgVote) sdk.Result {
@@ -80,61 +78,82 @@ func handleMsgVote(ctx sdk.Context, keeper Keeper, msg MsgVote) sdk.Result {
 		return err.Result()
 	}
 
-	proposalIDBytes := keeper.cdc.MustMarshalBinaryBare(msg.ProposalID)
-
-	tags := sdk.NewTags(
-		"action", []byte("vote"),
-		"voter", []byte(msg.Voter.String()),
-		"proposalId", proposalIDBytes,
+	emitMsgEvent(ctx, ActionVote, msg.Voter,
+		sdk.NewAttribute(AttributeKeyVoter, msg.Voter.String()),
+		sdk.NewAttribute(AttributeKeyProposalID, strconv.FormatInt(msg.ProposalID, 10)),
 	)
-	return sdk.Result{
-		Tags: tags,
+	return sdk.Result{}
+}
+
+func handleMsgVoteWeighted(ctx sdk.Context, keeper Keeper, msg MsgVoteWeighted) sdk.Result {
+
+	err := keeper.AddWeightedVote(ctx, msg.ProposalID, msg.Voter, msg.Options)
+	if err != nil {
+		return err.Result()
 	}
+
+	emitMsgEvent(ctx, ActionVote, msg.Voter,
+		sdk.NewAttribute(AttributeKeyVoter, msg.Voter.String()),
+		sdk.NewAttribute(AttributeKeyProposalID, strconv.FormatInt(msg.ProposalID, 10)),
+	)
+	return sdk.Result{}
 }
 
-// Called every block, process inflation, update validator set
-func EndBlocker(ctx sdk.Context, keeper Keeper) (tags sdk.Tags, nonVotingVals []sdk.Address) {
+// emit the message event of a gov msg, with the attributes of its action
+func emitMsgEvent(ctx sdk.Context, action string, sender sdk.Address, attrs ...sdk.Attribute) {
+	ctx.EventManager().EmitEvent(sdk.NewEvent(sdk.EventTypeMessage,
+		sdk.NewAttribute(sdk.AttributeKeyModule, MsgType),
+		sdk.NewAttribute(sdk.AttributeKeyAction, action),
+		sdk.NewAttribute(sdk.AttributeKeySender, sender.String()),
+	).AppendAttributes(attrs...))
+}
 
-	tags = sdk.NewTags()
+// Called every block, process inflation, update validator set
+func EndBlocker(ctx sdk.Context, keeper Keeper) (nonVotingVals []sdk.Address) {
 
 	// Delete proposals that haven't met minDeposit
 	for shouldPopInactiveProposalQueue(ctx, keeper) {
 		inactiveProposal := keeper.InactiveProposalQueuePop(ctx)
 		if inactiveProposal.GetStatus() == StatusDepositPeriod {
-			proposalIDBytes := keeper.cdc.MustMarshalBinaryBare(inactiveProposal.GetProposalID())
 			keeper.DeleteProposal(ctx, inactiveProposal)
-			tags.AppendTag("action", []byte("proposalDropped"))
-			tags.AppendTag("proposalId", proposalIDBytes)
+			emitProposalEvent(ctx, EventTypeProposalDropped, inactiveProposal)
 		}
 	}
 
 	var passes bool
+	var tallyResults TallyResult
 
 	// Check if earliest Active Proposal ended voting period yet
 	for shouldPopActiveProposalQueue(ctx, keeper) {
 		activeProposal := keeper.ActiveProposalQueuePop(ctx)
 
 		if ctx.BlockHeight() >= activeProposal.GetVotingStartBlock()+keeper.GetVotingProcedure().VotingPeriod {
-			passes, nonVotingVals = tally(ctx, keeper, activeProposal)
-			proposalIDBytes := keeper.cdc.MustMarshalBinaryBare(activeProposal.GetProposalID())
+			passes, tallyResults, nonVotingVals = tally(ctx, keeper, activeProposal)
+			activeProposal.SetTallyResult(tallyResults)
 			if passes {
 				keeper.RefundDeposits(ctx, activeProposal.GetProposalID())
 				activeProposal.SetStatus(StatusPassed)
-				tags.AppendTag("action", []byte("proposalPassed"))
-				tags.AppendTag("proposalId", proposalIDBytes)
+				emitProposalEvent(ctx, EventTypeProposalPassed, activeProposal)
 			} else {
 				keeper.DeleteDeposits(ctx, activeProposal.GetProposalID())
 				activeProposal.SetStatus(StatusRejected)
-				tags.AppendTag("action", []byte("proposalRejected"))
-				tags.AppendTag("proposalId", proposalIDBytes)
+				emitProposalEvent(ctx, EventTypeProposalRejected, activeProposal)
 			}
 
 			keeper.SetProposal(ctx, activeProposal)
 		}
 	}
 
-	return tags, nonVotingVals
+	return nonVotingVals
 }
+
+// emit an event of the end blocker about a proposal
+func emitProposalEvent(ctx sdk.Context, ty string, proposal Proposal) {
+	ctx.EventManager().EmitEvent(sdk.NewEvent(ty,
+		sdk.NewAttribute(AttributeKeyProposalID, strconv.FormatInt(proposal.GetProposalID(), 10)),
+	))
+}
+
 func shouldPopInactiveProposalQueue(ctx sdk.Context, keeper Keeper) bool {
 	depositProcedure := keeper.GetDepositProcedure()
 	peekProposal := keeper.InactiveProposalQueuePeek(ctx)
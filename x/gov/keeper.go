@@ -2,6 +2,7 @@ package gov
 
 import (
 	sdk "github.com/tepleton/tepleton-sdk/types"
+	"github.com/tepleton/tepleton-sdk/types/lib"
 	wire "github.com/tepleton/tepleton-sdk/wire"
 	"github.com/tepleton/tepleton-sdk/x/bank"
 )
@@ -44,6 +45,32 @@ func (keeper Keeper) WireCodec() *wire.Codec {
 	return keeper.cdc
 }
 
+// =====================================================
+// Collections
+
+// Index of the proposals by their status
+var proposalsByStatus = lib.NewIndex(ProposalsByStatusKey, func(value interface{}) []byte {
+	return []byte{value.(Proposal).GetStatus()}
+})
+
+func (keeper Keeper) proposals(ctx sdk.Context) lib.IndexedMap {
+	newProposal := func() interface{} { return new(Proposal) }
+	return lib.NewIndexedMap(keeper.cdc, ctx.KVStore(keeper.storeKey), ProposalsKey, newProposal, proposalsByStatus)
+}
+
+func (keeper Keeper) proposalIDs(ctx sdk.Context) lib.Sequence {
+	return lib.NewSequence(keeper.cdc, ctx.KVStore(keeper.storeKey), KeyNextProposalID)
+}
+
+// votes and deposits are keyed by lib.PairKey(lib.Int64Key(proposalID), address)
+func (keeper Keeper) votes(ctx sdk.Context) lib.Map {
+	return lib.NewMap(keeper.cdc, ctx.KVStore(keeper.storeKey), VotesKey)
+}
+
+func (keeper Keeper) deposits(ctx sdk.Context) lib.Map {
+	return lib.NewMap(keeper.cdc, ctx.KVStore(keeper.storeKey), DepositsKey)
+}
+
 // =====================================================
 // Proposals
 
@@ -59,6 +86,7 @@ func (keeper Keeper) NewTextProposal(ctx sdk.Context, title string, description
 		Description:      description,
 		ProposalType:     proposalType,
 		Status:           StatusDepositPeriod,
+		TallyResult:      EmptyTallyResult(),
 		TotalDeposit:     sdk.Coins{},
 		SubmitBlock:      ctx.BlockHeight(),
 		VotingStartBlock: -1, // TODO: Make Time
@@ -70,52 +98,106 @@ func (keeper Keeper) NewTextProposal(ctx sdk.Context, title string, description
 
 // Get Proposal from store by ProposalID
 func (keeper Keeper) GetProposal(ctx sdk.Context, proposalID int64) Proposal {
-	store := ctx.KVStore(keeper.storeKey)
-	bz := store.Get(KeyProposal(proposalID))
-	if bz == nil {
+	var proposal Proposal
+	if !keeper.proposals(ctx).Get(lib.Int64Key(proposalID), &proposal) {
 		return nil
 	}
-
-	var proposal Proposal
-	keeper.cdc.MustUnmarshalBinary(bz, &proposal)
-
 	return proposal
 }
 
 // Implements sdk.AccountMapper.
 func (keeper Keeper) SetProposal(ctx sdk.Context, proposal Proposal) {
-	store := ctx.KVStore(keeper.storeKey)
-	bz := keeper.cdc.MustMarshalBinary(proposal)
-	store.Set(KeyProposal(proposal.GetProposalID()), bz)
+	keeper.proposals(ctx).Set(lib.Int64Key(proposal.GetProposalID()), proposal)
 }
 
 // Implements sdk.AccountMapper.
 func (keeper Keeper) DeleteProposal(ctx sdk.Context, proposal Proposal) {
-	store := ctx.KVStore(keeper.storeKey)
-	store.Delete(KeyProposal(proposal.GetProposalID()))
+	keeper.proposals(ctx).Delete(lib.Int64Key(proposal.GetProposalID()))
+}
+
+// Get Proposal from store by ProposalID
+// voterAddr will filter proposals by whether or not that address has voted on them
+// depositerAddr will filter proposals by whether or not that address has deposited to them
+// status will filter proposals by status
+// numLatest will fetch a specified number of the most recent proposals, or 0 for all proposals
+func (keeper Keeper) GetProposalsFiltered(ctx sdk.Context, voterAddr sdk.Address, depositerAddr sdk.Address, status VoteStatus, numLatest int64) []Proposal {
+
+	maxProposalID, err := keeper.peekCurrentProposalID(ctx)
+	if err != nil {
+		return nil
+	}
+
+	matchingProposals := []Proposal{}
+
+	if numLatest <= 0 {
+		numLatest = maxProposalID
+	}
+
+	// only go over the proposals with the status, from the index, if filtering by status
+	proposalIDs := []int64{}
+	if validProposalStatus(status) {
+		for _, key := range keeper.proposals(ctx).IndexKeys(proposalsByStatus, []byte{status}) {
+			proposalIDs = append(proposalIDs, lib.Int64FromKey(key))
+		}
+	} else {
+		for proposalID := maxProposalID - numLatest; proposalID < maxProposalID; proposalID++ {
+			proposalIDs = append(proposalIDs, proposalID)
+		}
+	}
+
+	for _, proposalID := range proposalIDs {
+		if proposalID < 0 || proposalID < maxProposalID-numLatest {
+			continue
+		}
+
+		if voterAddr != nil && len(voterAddr) != 0 {
+			_, found := keeper.GetVote(ctx, proposalID, voterAddr)
+			if !found {
+				continue
+			}
+		}
+
+		if depositerAddr != nil && len(depositerAddr) != 0 {
+			_, found := keeper.GetDeposit(ctx, proposalID, depositerAddr)
+			if !found {
+				continue
+			}
+		}
+
+		proposal := keeper.GetProposal(ctx, proposalID)
+		if proposal == nil {
+			continue
+		}
+
+		matchingProposals = append(matchingProposals, proposal)
+	}
+	return matchingProposals
 }
 
 func (keeper Keeper) setInitialProposalID(ctx sdk.Context, proposalID int64) sdk.Error {
-	store := ctx.KVStore(keeper.storeKey)
-	bz := store.Get(KeyNextProposalID)
-	if bz != nil {
+	proposalIDs := keeper.proposalIDs(ctx)
+	if proposalIDs.Has() {
 		return ErrInvalidGenesis(keeper.codespace, "Initial ProposalID already set")
 	}
-	bz = keeper.cdc.MustMarshalBinary(proposalID)
-	store.Set(KeyNextProposalID, bz)
+	proposalIDs.Set(uint64(proposalID))
 	return nil
 }
 
 func (keeper Keeper) getNewProposalID(ctx sdk.Context) (proposalID int64, err sdk.Error) {
-	store := ctx.KVStore(keeper.storeKey)
-	bz := store.Get(KeyNextProposalID)
-	if bz == nil {
+	proposalIDs := keeper.proposalIDs(ctx)
+	if !proposalIDs.Has() {
+		return -1, ErrInvalidGenesis(keeper.codespace, "InitialProposalID never set")
+	}
+	return int64(proposalIDs.Next()), nil
+}
+
+// Peeks the next available ProposalID without incrementing it
+func (keeper Keeper) peekCurrentProposalID(ctx sdk.Context) (proposalID int64, err sdk.Error) {
+	proposalIDs := keeper.proposalIDs(ctx)
+	if !proposalIDs.Has() {
 		return -1, ErrInvalidGenesis(keeper.codespace, "InitialProposalID never set")
 	}
-	keeper.cdc.MustUnmarshalBinary(bz, &proposalID)
-	bz = keeper.cdc.MustMarshalBinary(proposalID + 1)
-	store.Set(KeyNextProposalID, bz)
-	return proposalID, nil
+	return int64(proposalIDs.Peek()), nil
 }
 
 func (keeper Keeper) activateVotingPeriod(ctx sdk.Context, proposal Proposal) {
@@ -146,17 +228,22 @@ func (keeper Keeper) GetVotingProcedure() VotingProcedure {
 // Gets procedure from store. TODO: move to global param store and allow for updating of this
 func (keeper Keeper) GetTallyingProcedure() TallyingProcedure {
 	return TallyingProcedure{
-		Threshold:         sdk.NewRat(1, 2),
-		Veto:              sdk.NewRat(1, 3),
-		GovernancePenalty: sdk.NewRat(1, 100),
+		Threshold:         sdk.NewDecWithPrec(5, 1),
+		Veto:              sdk.NewDec(1).Quo(sdk.NewDec(3)),
+		GovernancePenalty: sdk.NewDecWithPrec(1, 2),
 	}
 }
 
 // =====================================================
 // Votes
 
-// Adds a vote on a specific proposal
+// Adds a vote on a specific proposal, giving the voter's full voting power to a single option
 func (keeper Keeper) AddVote(ctx sdk.Context, proposalID int64, voterAddr sdk.Address, option VoteOption) sdk.Error {
+	return keeper.AddWeightedVote(ctx, proposalID, voterAddr, NewNonSplitVoteOption(option))
+}
+
+// Adds a vote on a specific proposal, splitting the voter's voting power between the options
+func (keeper Keeper) AddWeightedVote(ctx sdk.Context, proposalID int64, voterAddr sdk.Address, options WeightedVoteOptions) sdk.Error {
 	proposal := keeper.GetProposal(ctx, proposalID)
 	if proposal == nil {
 		return ErrUnknownProposal(keeper.codespace, proposalID)
@@ -165,14 +252,15 @@ func (keeper Keeper) AddVote(ctx sdk.Context, proposalID int64, voterAddr sdk.Ad
 		return ErrInactiveProposal(keeper.codespace, proposalID)
 	}
 
-	if option != OptionYes && option != OptionAbstain && option != OptionNo && option != OptionNoWithVeto {
-		return ErrInvalidVote(keeper.codespace, VoteOptionToString(option))
+	err := options.ValidateBasic()
+	if err != nil {
+		return ErrInvalidVote(keeper.codespace, options.String())
 	}
 
 	vote := Vote{
 		ProposalID: proposalID,
 		Voter:      voterAddr,
-		Option:     option,
+		Options:    options,
 	}
 	keeper.setVote(ctx, proposalID, voterAddr, vote)
 
@@ -181,20 +269,15 @@ func (keeper Keeper) AddVote(ctx sdk.Context, proposalID int64, voterAddr sdk.Ad
 
 // Gets the vote of a specific voter on a specific proposal
 func (keeper Keeper) GetVote(ctx sdk.Context, proposalID int64, voterAddr sdk.Address) (Vote, bool) {
-	store := ctx.KVStore(keeper.storeKey)
-	bz := store.Get(KeyVote(proposalID, voterAddr))
-	if bz == nil {
+	var vote Vote
+	if !keeper.votes(ctx).Get(lib.PairKey(lib.Int64Key(proposalID), voterAddr), &vote) {
 		return Vote{}, false
 	}
-	var vote Vote
-	keeper.cdc.MustUnmarshalBinary(bz, &vote)
 	return vote, true
 }
 
 func (keeper Keeper) setVote(ctx sdk.Context, proposalID int64, voterAddr sdk.Address, vote Vote) {
-	store := ctx.KVStore(keeper.storeKey)
-	bz := keeper.cdc.MustMarshalBinary(vote)
-	store.Set(KeyVote(proposalID, voterAddr), bz)
+	keeper.votes(ctx).Set(lib.PairKey(lib.Int64Key(proposalID), voterAddr), vote)
 }
 
 // Gets all the votes on a specific proposal
@@ -204,8 +287,7 @@ func (keeper Keeper) GetVotes(ctx sdk.Context, proposalID int64) sdk.Iterator {
 }
 
 func (keeper Keeper) deleteVote(ctx sdk.Context, proposalID int64, voterAddr sdk.Address) {
-	store := ctx.KVStore(keeper.storeKey)
-	store.Delete(KeyVote(proposalID, voterAddr))
+	keeper.votes(ctx).Delete(lib.PairKey(lib.Int64Key(proposalID), voterAddr))
 }
 
 // =====================================================
@@ -213,20 +295,15 @@ func (keeper Keeper) deleteVote(ctx sdk.Context, proposalID int64, voterAddr sdk
 
 // Gets the deposit of a specific depositer on a specific proposal
 func (keeper Keeper) GetDeposit(ctx sdk.Context, proposalID int64, depositerAddr sdk.Address) (Deposit, bool) {
-	store := ctx.KVStore(keeper.storeKey)
-	bz := store.Get(KeyDeposit(proposalID, depositerAddr))
-	if bz == nil {
+	var deposit Deposit
+	if !keeper.deposits(ctx).Get(lib.PairKey(lib.Int64Key(proposalID), depositerAddr), &deposit) {
 		return Deposit{}, false
 	}
-	var deposit Deposit
-	keeper.cdc.MustUnmarshalBinary(bz, &deposit)
 	return deposit, true
 }
 
 func (keeper Keeper) setDeposit(ctx sdk.Context, proposalID int64, depositerAddr sdk.Address, deposit Deposit) {
-	store := ctx.KVStore(keeper.storeKey)
-	bz := keeper.cdc.MustMarshalBinary(deposit)
-	store.Set(KeyDeposit(proposalID, depositerAddr), bz)
+	keeper.deposits(ctx).Set(lib.PairKey(lib.Int64Key(proposalID), depositerAddr), deposit)
 }
 
 // Adds or updates a deposit of a specific depositer on a specific proposal
@@ -282,56 +359,40 @@ func (keeper Keeper) GetDeposits(ctx sdk.Context, proposalID int64) sdk.Iterator
 
 // Returns and deletes all the deposits on a specific proposal
 func (keeper Keeper) RefundDeposits(ctx sdk.Context, proposalID int64) {
-	store := ctx.KVStore(keeper.storeKey)
-	depositsIterator := keeper.GetDeposits(ctx, proposalID)
+	deposits := keeper.deposits(ctx)
 
-	for ; depositsIterator.Valid(); depositsIterator.Next() {
-		deposit := &Deposit{}
-		keeper.cdc.MustUnmarshalBinary(depositsIterator.Value(), deposit)
+	for _, key := range deposits.Keys(lib.PairPrefix(lib.Int64Key(proposalID))) {
+		var deposit Deposit
+		deposits.Get(key, &deposit)
 
 		_, _, err := keeper.ck.AddCoins(ctx, deposit.Depositer, deposit.Amount)
 		if err != nil {
 			panic("should not happen")
 		}
 
-		store.Delete(depositsIterator.Key())
+		deposits.Delete(key)
 	}
-
-	depositsIterator.Close()
 }
 
 // Deletes all the deposits on a specific proposal without refunding them
 func (keeper Keeper) DeleteDeposits(ctx sdk.Context, proposalID int64) {
-	store := ctx.KVStore(keeper.storeKey)
-	depositsIterator := keeper.GetDeposits(ctx, proposalID)
+	deposits := keeper.deposits(ctx)
 
-	for ; depositsIterator.Valid(); depositsIterator.Next() {
-		store.Delete(depositsIterator.Key())
+	for _, key := range deposits.Keys(lib.PairPrefix(lib.Int64Key(proposalID))) {
+		deposits.Delete(key)
 	}
-
-	depositsIterator.Close()
 }
 
 // =====================================================
 // ProposalQueues
 
-func (keeper Keeper) getActiveProposalQueue(ctx sdk.Context) ProposalQueue {
-	store := ctx.KVStore(keeper.storeKey)
-	bz := store.Get(KeyActiveProposalQueue)
-	if bz == nil {
-		return nil
-	}
-
-	var proposalQueue ProposalQueue
-	keeper.cdc.MustUnmarshalBinary(bz, &proposalQueue)
-
-	return proposalQueue
+func (keeper Keeper) getActiveProposalQueue(ctx sdk.Context) (proposalQueue ProposalQueue) {
+	lib.NewItem(keeper.cdc, ctx.KVStore(keeper.storeKey), KeyActiveProposalQueue).Get(&proposalQueue)
+	return
 }
 
 func (keeper Keeper) setActiveProposalQueue(ctx sdk.Context, proposalQueue ProposalQueue) {
-	store := ctx.KVStore(keeper.storeKey)
-	bz := keeper.cdc.MustMarshalBinary(proposalQueue)
-	store.Set(KeyActiveProposalQueue, bz)
+	lib.NewItem(keeper.cdc, ctx.KVStore(keeper.storeKey), KeyActiveProposalQueue).Set(proposalQueue)
 }
 
 // Return the Proposal at the front of the ProposalQueue
@@ -360,24 +421,13 @@ func (keeper Keeper) ActiveProposalQueuePush(ctx sdk.Context, proposal Proposal)
 	keeper.setActiveProposalQueue(ctx, proposalQueue)
 }
 
-func (keeper Keeper) getInactiveProposalQueue(ctx sdk.Context) ProposalQueue {
-	store := ctx.KVStore(keeper.storeKey)
-	bz := store.Get(KeyInactiveProposalQueue)
-	if bz == nil {
-		return nil
-	}
-
-	var proposalQueue ProposalQueue
-
-	keeper.cdc.MustUnmarshalBinary(bz, &proposalQueue)
-
-	return proposalQueue
+func (keeper Keeper) getInactiveProposalQueue(ctx sdk.Context) (proposalQueue ProposalQueue) {
+	lib.NewItem(keeper.cdc, ctx.KVStore(keeper.storeKey), KeyInactiveProposalQueue).Get(&proposalQueue)
+	return
 }
 
 func (keeper Keeper) setInactiveProposalQueue(ctx sdk.Context, proposalQueue ProposalQueue) {
-	store := ctx.KVStore(keeper.storeKey)
-	bz := keeper.cdc.MustMarshalBinary(proposalQueue)
-	store.Set(KeyInactiveProposalQueue, bz)
+	lib.NewItem(keeper.cdc, ctx.KVStore(keeper.storeKey), KeyInactiveProposalQueue).Set(proposalQueue)
 }
 
 // Return the Proposal at the front of the ProposalQueue
@@ -1,41 +1,45 @@
 package gov
 
 import (
-	"fmt"
-
 	sdk "github.com/tepleton/tepleton-sdk/types"
+	"github.com/tepleton/tepleton-sdk/types/lib"
 )
 
 // TODO remove some of these prefixes once have working multistore
 
 // Key for getting a the next available proposalID from the store
 var (
-	KeyNextProposalID        = []byte("newProposalID")
-	KeyActiveProposalQueue   = []byte("activeProposalQueue")
-	KeyInactiveProposalQueue = []byte("inactiveProposalQueue")
+	KeyNextProposalID        = []byte{0x00}
+	KeyActiveProposalQueue   = []byte{0x01}
+	KeyInactiveProposalQueue = []byte{0x02}
+
+	ProposalsKey         = []byte{0x03} // prefix for each key to a proposal
+	ProposalsByStatusKey = []byte{0x04} // prefix for the index of the proposals by status
+	DepositsKey          = []byte{0x05} // prefix for each key to a deposit
+	VotesKey             = []byte{0x06} // prefix for each key to a vote
 )
 
 // Key for getting a specific proposal from the store
 func KeyProposal(proposalID int64) []byte {
-	return []byte(fmt.Sprintf("proposals:%d", proposalID))
+	return lib.MapKey(ProposalsKey, lib.Int64Key(proposalID))
 }
 
 // Key for getting a specific deposit from the store
 func KeyDeposit(proposalID int64, depositerAddr sdk.Address) []byte {
-	return []byte(fmt.Sprintf("deposits:%d:%d", proposalID, depositerAddr))
+	return lib.MapKey(DepositsKey, lib.PairKey(lib.Int64Key(proposalID), depositerAddr))
 }
 
 // Key for getting a specific vote from the store
 func KeyVote(proposalID int64, voterAddr sdk.Address) []byte {
-	return []byte(fmt.Sprintf("votes:%d:%d", proposalID, voterAddr))
+	return lib.MapKey(VotesKey, lib.PairKey(lib.Int64Key(proposalID), voterAddr))
 }
 
 // Key for getting all deposits on a proposal from the store
 func KeyDepositsSubspace(proposalID int64) []byte {
-	return []byte(fmt.Sprintf("deposits:%d:", proposalID))
+	return lib.MapKey(DepositsKey, lib.PairPrefix(lib.Int64Key(proposalID)))
 }
 
 // Key for getting all votes on a proposal from the store
 func KeyVotesSubspace(proposalID int64) []byte {
-	return []byte(fmt.Sprintf("votes:%d:", proposalID))
+	return lib.MapKey(VotesKey, lib.PairPrefix(lib.Int64Key(proposalID)))
 }
@@ -130,6 +130,7 @@ func TestDeposits(t *testing.T) {
 	require.Equal(t, fourSteak, deposit.Amount)
 	depositsIterator.Next()
 	require.False(t, depositsIterator.Valid())
+	depositsIterator.Close()
 
 	// Test Refund Deposits
 	deposit, found = keeper.GetDeposit(ctx, proposalID, addrs[1])
@@ -161,7 +162,7 @@ func TestVotes(t *testing.T) {
 	require.True(t, found)
 	require.Equal(t, addrs[0], vote.Voter)
 	require.Equal(t, proposalID, vote.ProposalID)
-	require.Equal(t, OptionAbstain, vote.Option)
+	require.Equal(t, OptionAbstain, vote.Options.NonSplitOption())
 
 	// Test change of vote
 	keeper.AddVote(ctx, proposalID, addrs[0], OptionYes)
@@ -169,7 +170,7 @@ func TestVotes(t *testing.T) {
 	require.True(t, found)
 	require.Equal(t, addrs[0], vote.Voter)
 	require.Equal(t, proposalID, vote.ProposalID)
-	require.Equal(t, OptionYes, vote.Option)
+	require.Equal(t, OptionYes, vote.Options.NonSplitOption())
 
 	// Test second vote
 	keeper.AddVote(ctx, proposalID, addrs[1], OptionNoWithVeto)
@@ -177,7 +178,7 @@ func TestVotes(t *testing.T) {
 	require.True(t, found)
 	require.Equal(t, addrs[1], vote.Voter)
 	require.Equal(t, proposalID, vote.ProposalID)
-	require.Equal(t, OptionNoWithVeto, vote.Option)
+	require.Equal(t, OptionNoWithVeto, vote.Options.NonSplitOption())
 
 	// Test vote iterator
 	votesIterator := keeper.GetVotes(ctx, proposalID)
@@ -186,16 +187,17 @@ func TestVotes(t *testing.T) {
 	require.True(t, votesIterator.Valid())
 	require.Equal(t, addrs[0], vote.Voter)
 	require.Equal(t, proposalID, vote.ProposalID)
-	require.Equal(t, OptionYes, vote.Option)
+	require.Equal(t, OptionYes, vote.Options.NonSplitOption())
 	votesIterator.Next()
 	require.True(t, votesIterator.Valid())
 	keeper.cdc.MustUnmarshalBinary(votesIterator.Value(), &vote)
 	require.True(t, votesIterator.Valid())
 	require.Equal(t, addrs[1], vote.Voter)
 	require.Equal(t, proposalID, vote.ProposalID)
-	require.Equal(t, OptionNoWithVeto, vote.Option)
+	require.Equal(t, OptionNoWithVeto, vote.Options.NonSplitOption())
 	votesIterator.Next()
 	require.False(t, votesIterator.Valid())
+	votesIterator.Close()
 }
 
 func TestProposalQueues(t *testing.T) {
@@ -245,3 +247,40 @@ func TestProposalQueues(t *testing.T) {
 	require.Equal(t, keeper.ActiveProposalQueuePeek(ctx).GetProposalID(), proposal4.GetProposalID())
 	require.Equal(t, keeper.ActiveProposalQueuePop(ctx).GetProposalID(), proposal4.GetProposalID())
 }
+
+func TestGetProposalsFiltered(t *testing.T) {
+	mapp, keeper, _, _, _, _ := getMockApp(t, 0)
+	mapp.BeginBlock(wrsp.RequestBeginBlock{})
+	ctx := mapp.BaseApp.NewContext(false, wrsp.Header{})
+
+	proposal1 := keeper.NewTextProposal(ctx, "Test1", "description", ProposalTypeText)
+	proposal2 := keeper.NewTextProposal(ctx, "Test2", "description", ProposalTypeText)
+	proposal3 := keeper.NewTextProposal(ctx, "Test3", "description", ProposalTypeText)
+	keeper.activateVotingPeriod(ctx, proposal1)
+	keeper.activateVotingPeriod(ctx, proposal3)
+
+	proposalIDs := func(proposals []Proposal) (ids []int64) {
+		for _, proposal := range proposals {
+			ids = append(ids, proposal.GetProposalID())
+		}
+		return
+	}
+	require.Equal(t, []int64{proposal1.GetProposalID(), proposal2.GetProposalID(), proposal3.GetProposalID()},
+		proposalIDs(keeper.GetProposalsFiltered(ctx, nil, nil, StatusNil, 0)))
+	require.Equal(t, []int64{proposal1.GetProposalID(), proposal3.GetProposalID()},
+		proposalIDs(keeper.GetProposalsFiltered(ctx, nil, nil, StatusVotingPeriod, 0)))
+	require.Equal(t, []int64{proposal2.GetProposalID()},
+		proposalIDs(keeper.GetProposalsFiltered(ctx, nil, nil, StatusDepositPeriod, 0)))
+
+	// only the latest proposals
+	require.Equal(t, []int64{proposal3.GetProposalID()},
+		proposalIDs(keeper.GetProposalsFiltered(ctx, nil, nil, StatusVotingPeriod, 1)))
+
+	// the index follows the status of the proposals
+	proposal3.SetStatus(StatusPassed)
+	keeper.SetProposal(ctx, proposal3)
+	require.Equal(t, []int64{proposal1.GetProposalID()},
+		proposalIDs(keeper.GetProposalsFiltered(ctx, nil, nil, StatusVotingPeriod, 0)))
+	keeper.DeleteProposal(ctx, proposal3)
+	require.Empty(t, keeper.GetProposalsFiltered(ctx, nil, nil, StatusPassed, 0))
+}
@@ -220,3 +220,72 @@ func (msg MsgVote) GetSignBytes() []byte {
 func (msg MsgVote) GetSigners() []sdk.Address {
 	return []sdk.Address{msg.Voter}
 }
+
+//-----------------------------------------------------------
+// MsgVoteWeighted
+type MsgVoteWeighted struct {
+	ProposalID int64               //  proposalID of the proposal
+	Voter      sdk.Address         //  address of the voter
+	Options    WeightedVoteOptions //  weighted options from OptionSet chosen by the voter, summing to one
+}
+
+func NewMsgVoteWeighted(voter sdk.Address, proposalID int64, options WeightedVoteOptions) MsgVoteWeighted {
+	return MsgVoteWeighted{
+		ProposalID: proposalID,
+		Voter:      voter,
+		Options:    options,
+	}
+}
+
+// Implements Msg.
+func (msg MsgVoteWeighted) Type() string { return MsgType }
+
+// Implements Msg.
+func (msg MsgVoteWeighted) ValidateBasic() sdk.Error {
+	if len(msg.Voter.Bytes()) == 0 {
+		return sdk.ErrInvalidAddress(msg.Voter.String())
+	}
+	if msg.ProposalID < 0 {
+		return ErrUnknownProposal(DefaultCodespace, msg.ProposalID)
+	}
+	return msg.Options.ValidateBasic()
+}
+
+func (msg MsgVoteWeighted) String() string {
+	return fmt.Sprintf("MsgVoteWeighted{%v - %v}", msg.ProposalID, msg.Options)
+}
+
+// Implements Msg.
+func (msg MsgVoteWeighted) Get(key interface{}) (value interface{}) {
+	return nil
+}
+
+// Implements Msg.
+func (msg MsgVoteWeighted) GetSignBytes() []byte {
+	type signOption struct {
+		Option string `json:"option"`
+		Weight string `json:"weight"`
+	}
+	options := make([]signOption, 0, len(msg.Options))
+	for _, option := range msg.Options {
+		options = append(options, signOption{VoteOptionToString(option.Option), option.Weight.String()})
+	}
+	b, err := msgCdc.MarshalJSON(struct {
+		ProposalID int64        `json:"proposalID"`
+		Voter      string       `json:"voter"`
+		Options    []signOption `json:"options"`
+	}{
+		ProposalID: msg.ProposalID,
+		Voter:      sdk.MustBech32ifyVal(msg.Voter),
+		Options:    options,
+	})
+	if err != nil {
+		panic(err)
+	}
+	return b
+}
+
+// Implements Msg.
+func (msg MsgVoteWeighted) GetSigners() []sdk.Address {
+	return []sdk.Address{msg.Voter}
+}
@@ -103,3 +103,59 @@ func TestMsgVote(t *testing.T) {
 		}
 	}
 }
+
+// test ValidateBasic for MsgVoteWeighted
+func TestMsgVoteWeighted(t *testing.T) {
+	_, addrs, _, _ := mock.CreateGenAccounts(1, sdk.Coins{})
+	tests := []struct {
+		proposalID int64
+		voterAddr  sdk.Address
+		options    WeightedVoteOptions
+		expectPass bool
+	}{
+		{0, addrs[0], NewNonSplitVoteOption(OptionYes), true},
+		{-1, addrs[0], NewNonSplitVoteOption(OptionYes), false},
+		{0, sdk.Address{}, NewNonSplitVoteOption(OptionYes), false},
+		{0, addrs[0], NewNonSplitVoteOption(VoteOption(0x13)), false},
+		{0, addrs[0], WeightedVoteOptions{}, false},
+		{0, addrs[0], WeightedVoteOptions{
+			NewWeightedVoteOption(OptionYes, sdk.NewDecWithPrec(6, 1)),
+			NewWeightedVoteOption(OptionNo, sdk.NewDecWithPrec(4, 1)),
+		}, true},
+		{0, addrs[0], WeightedVoteOptions{
+			NewWeightedVoteOption(OptionYes, sdk.NewDecWithPrec(6, 1)),
+			NewWeightedVoteOption(OptionNo, sdk.NewDecWithPrec(2, 1)),
+		}, false},
+		{0, addrs[0], WeightedVoteOptions{
+			NewWeightedVoteOption(OptionYes, sdk.NewDecWithPrec(5, 1)),
+			NewWeightedVoteOption(OptionYes, sdk.NewDecWithPrec(5, 1)),
+		}, false},
+		{0, addrs[0], WeightedVoteOptions{
+			NewWeightedVoteOption(OptionYes, sdk.NewDecWithPrec(15, 1)),
+			NewWeightedVoteOption(OptionNo, sdk.NewDecWithPrec(-5, 1)),
+		}, false},
+	}
+
+	for i, tc := range tests {
+		msg := NewMsgVoteWeighted(tc.voterAddr, tc.proposalID, tc.options)
+		if tc.expectPass {
+			require.Nil(t, msg.ValidateBasic(), "test: %v", i)
+		} else {
+			require.NotNil(t, msg.ValidateBasic(), "test: %v", i)
+		}
+	}
+}
+
+func TestStringToWeightedVoteOptions(t *testing.T) {
+	options, err := StringToWeightedVoteOptions("Yes=0.6,No=0.4")
+	require.Nil(t, err)
+	require.Nil(t, options.ValidateBasic())
+	require.Equal(t, 2, len(options))
+	require.Equal(t, OptionYes, options[0].Option)
+	require.True(t, options[0].Weight.Equal(sdk.NewDecWithPrec(6, 1)))
+
+	_, err = StringToWeightedVoteOptions("Yes")
+	require.NotNil(t, err)
+	_, err = StringToWeightedVoteOptions("Maybe=1")
+	require.NotNil(t, err)
+}
@@ -12,6 +12,7 @@ type ProposalKind = byte
 
 //nolint
 const (
+	StatusNil           VoteStatus = 0x00
 	StatusDepositPeriod VoteStatus = 0x01
 	StatusVotingPeriod  VoteStatus = 0x02
 	StatusPassed        VoteStatus = 0x03
@@ -40,6 +41,9 @@ type Proposal interface {
 	GetStatus() VoteStatus
 	SetStatus(VoteStatus)
 
+	GetTallyResult() TallyResult
+	SetTallyResult(TallyResult)
+
 	GetSubmitBlock() int64
 	SetSubmitBlock(int64)
 
@@ -57,6 +61,7 @@ func ProposalEqual(proposalA Proposal, proposalB Proposal) bool {
 		proposalA.GetDescription() != proposalB.GetDescription() ||
 		proposalA.GetProposalType() != proposalB.GetProposalType() ||
 		proposalA.GetStatus() != proposalB.GetStatus() ||
+		!proposalA.GetTallyResult().Equals(proposalB.GetTallyResult()) ||
 		proposalA.GetSubmitBlock() != proposalB.GetSubmitBlock() ||
 		!(proposalA.GetTotalDeposit().IsEqual(proposalB.GetTotalDeposit())) ||
 		proposalA.GetVotingStartBlock() != proposalB.GetVotingStartBlock() {
@@ -73,7 +78,8 @@ type TextProposal struct {
 	Description  string       `json:"description"`   //  Description of the proposal
 	ProposalType ProposalKind `json:"proposal_type"` //  Type of proposal. Initial set {PlainTextProposal, SoftwareUpgradeProposal}
 
-	Status VoteStatus `json:"string"` //  Status of the Proposal {Pending, Active, Passed, Rejected}
+	Status      VoteStatus  `json:"string"`       //  Status of the Proposal {Pending, Active, Passed, Rejected}
+	TallyResult TallyResult `json:"tally_result"` //  Result of Tallys
 
 	SubmitBlock  int64     `json:"submit_block"`  //  Height of the block where TxGovSubmitProposal was included
 	TotalDeposit sdk.Coins `json:"total_deposit"` //  Current deposit on this proposal. Initial value is set at InitialDeposit
@@ -95,6 +101,8 @@ func (tp TextProposal) GetProposalType() ProposalKind              { return tp.P
 func (tp *TextProposal) SetProposalType(proposalType ProposalKind) { tp.ProposalType = proposalType }
 func (tp TextProposal) GetStatus() VoteStatus                      { return tp.Status }
 func (tp *TextProposal) SetStatus(status VoteStatus)               { tp.Status = status }
+func (tp TextProposal) GetTallyResult() TallyResult                { return tp.TallyResult }
+func (tp *TextProposal) SetTallyResult(tallyResult TallyResult)    { tp.TallyResult = tallyResult }
 func (tp TextProposal) GetSubmitBlock() int64                      { return tp.SubmitBlock }
 func (tp *TextProposal) SetSubmitBlock(submitBlock int64)          { tp.SubmitBlock = submitBlock }
 func (tp TextProposal) GetTotalDeposit() sdk.Coins                 { return tp.TotalDeposit }
@@ -160,6 +168,16 @@ func StatusToString(status VoteStatus) string {
 	}
 }
 
+func validProposalStatus(status VoteStatus) bool {
+	if status == StatusDepositPeriod ||
+		status == StatusVotingPeriod ||
+		status == StatusPassed ||
+		status == StatusRejected {
+		return true
+	}
+	return false
+}
+
 // StatusToString for pretty prints of Status
 func StringToStatus(status string) VoteStatus {
 	switch status {
@@ -176,17 +194,45 @@ func StringToStatus(status string) VoteStatus {
 	}
 }
 
+//-----------------------------------------------------------
+// Tally Results
+type TallyResult struct {
+	Yes        sdk.Dec `json:"yes"`
+	Abstain    sdk.Dec `json:"abstain"`
+	No         sdk.Dec `json:"no"`
+	NoWithVeto sdk.Dec `json:"no_with_veto"`
+}
+
+// returns a TallyResult with all options set to zero
+func EmptyTallyResult() TallyResult {
+	return TallyResult{
+		Yes:        sdk.ZeroDec(),
+		Abstain:    sdk.ZeroDec(),
+		No:         sdk.ZeroDec(),
+		NoWithVeto: sdk.ZeroDec(),
+	}
+}
+
+// checks if two tally results are equal
+func (resultA TallyResult) Equals(resultB TallyResult) bool {
+	return resultA.Yes.Equal(resultB.Yes) &&
+		resultA.Abstain.Equal(resultB.Abstain) &&
+		resultA.No.Equal(resultB.No) &&
+		resultA.NoWithVeto.Equal(resultB.NoWithVeto)
+}
+
 //-----------------------------------------------------------
 // Rest Proposals
 type ProposalRest struct {
-	ProposalID       int64     `json:"proposal_id"`        //  ID of the proposal
-	Title            string    `json:"title"`              //  Title of the proposal
-	Description      string    `json:"description"`        //  Description of the proposal
-	ProposalType     string    `json:"proposal_type"`      //  Type of proposal. Initial set {PlainTextProposal, SoftwareUpgradeProposal}
-	Status           string    `json:"string"`             //  Status of the Proposal {Pending, Active, Passed, Rejected}
-	SubmitBlock      int64     `json:"submit_block"`       //  Height of the block where TxGovSubmitProposal was included
-	TotalDeposit     sdk.Coins `json:"total_deposit"`      //  Current deposit on this proposal. Initial value is set at InitialDeposit
-	VotingStartBlock int64     `json:"voting_start_block"` //  Height of the block where MinDeposit was reached. -1 if MinDeposit is not reached
+	ProposalID       int64       `json:"proposal_id"`        //  ID of the proposal
+	Title            string      `json:"title"`              //  Title of the proposal
+	Description      string      `json:"description"`        //  Description of the proposal
+	ProposalType     string      `json:"proposal_type"`      //  Type of proposal. Initial set {PlainTextProposal, SoftwareUpgradeProposal}
+	Status           string      `json:"string"`             //  Status of the Proposal {Pending, Active, Passed, Rejected}
+	TallyResult      TallyResult `json:"tally_result"`       //  Result of Tallys
+	SubmitBlock      int64       `json:"submit_block"`       //  Height of the block where TxGovSubmitProposal was included
+	TotalDeposit     sdk.Coins   `json:"total_deposit"`      //  Current deposit on this proposal. Initial value is set at InitialDeposit
+	VotingStartBlock int64       `json:"voting_start_block"` //  Height of the block where MinDeposit was reached. -1 if MinDeposit is not reached
 }
 
 // Turn any Proposal to a ProposalRest
@@ -197,6 +243,7 @@ func ProposalToRest(proposal Proposal) ProposalRest {
 		Description:      proposal.GetDescription(),
 		ProposalType:     ProposalTypeToString(proposal.GetProposalType()),
 		Status:           StatusToString(proposal.GetStatus()),
+		TallyResult:      proposal.GetTallyResult(),
 		SubmitBlock:      proposal.GetSubmitBlock(),
 		TotalDeposit:     proposal.GetTotalDeposit(),
 		VotingStartBlock: proposal.GetVotingStartBlock(),
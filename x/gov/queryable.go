@@ -0,0 +1,209 @@
+package gov
+
+import (
+	wrsp "github.com/tepleton/tepleton/wrsp/types"
+
+	sdk "github.com/tepleton/tepleton-sdk/types"
+	"github.com/tepleton/tepleton-sdk/wire"
+)
+
+// query endpoints supported by the governance Querier
+const (
+	QueryProposals = "proposals"
+	QueryProposal  = "proposal"
+	QueryDeposits  = "deposits"
+	QueryDeposit   = "deposit"
+	QueryVotes     = "votes"
+	QueryVote      = "vote"
+	QueryTally     = "tally"
+)
+
+// NewQuerier returns the governance querier, to be registered on the app's
+// QueryRouter under the gov route
+func NewQuerier(keeper Keeper) sdk.Querier {
+	return func(ctx sdk.Context, path []string, req wrsp.RequestQuery) (res []byte, err sdk.Error) {
+		if len(path) == 0 {
+			return nil, sdk.ErrUnknownRequest("no gov query endpoint specified")
+		}
+		switch path[0] {
+		case QueryProposals:
+			return queryProposals(ctx, path[1:], req, keeper)
+		case QueryProposal:
+			return queryProposal(ctx, path[1:], req, keeper)
+		case QueryDeposits:
+			return queryDeposits(ctx, path[1:], req, keeper)
+		case QueryDeposit:
+			return queryDeposit(ctx, path[1:], req, keeper)
+		case QueryVotes:
+			return queryVotes(ctx, path[1:], req, keeper)
+		case QueryVote:
+			return queryVote(ctx, path[1:], req, keeper)
+		case QueryTally:
+			return queryTally(ctx, path[1:], req, keeper)
+		default:
+			return nil, sdk.ErrUnknownRequest("unknown gov query endpoint")
+		}
+	}
+}
+
+// Params for query 'custom/gov/proposal'
+type QueryProposalParams struct {
+	ProposalID int64
+}
+
+// Params for query 'custom/gov/deposit'
+type QueryDepositParams struct {
+	ProposalID int64
+	Depositer  sdk.Address
+}
+
+// Params for query 'custom/gov/vote'
+type QueryVoteParams struct {
+	ProposalID int64
+	Voter      sdk.Address
+}
+
+// Params for query 'custom/gov/proposals'
+type QueryProposalsParams struct {
+	Voter          sdk.Address
+	Depositer      sdk.Address
+	ProposalStatus VoteStatus
+	NumLatest      int64
+}
+
+// nolint: unparam
+func queryProposal(ctx sdk.Context, path []string, req wrsp.RequestQuery, keeper Keeper) (res []byte, err sdk.Error) {
+	var params QueryProposalParams
+	err2 := keeper.cdc.UnmarshalJSON(req.Data, &params)
+	if err2 != nil {
+		return nil, sdk.ErrUnknownRequest("incorrectly formatted request data - " + err2.Error())
+	}
+
+	proposal := keeper.GetProposal(ctx, params.ProposalID)
+	if proposal == nil {
+		return nil, ErrUnknownProposal(keeper.codespace, params.ProposalID)
+	}
+
+	return marshalQueryResult(keeper.cdc, proposal)
+}
+
+// nolint: unparam
+func queryDeposit(ctx sdk.Context, path []string, req wrsp.RequestQuery, keeper Keeper) (res []byte, err sdk.Error) {
+	var params QueryDepositParams
+	err2 := keeper.cdc.UnmarshalJSON(req.Data, &params)
+	if err2 != nil {
+		return nil, sdk.ErrUnknownRequest("incorrectly formatted request data - " + err2.Error())
+	}
+
+	deposit, found := keeper.GetDeposit(ctx, params.ProposalID, params.Depositer)
+	if !found {
+		return nil, ErrUnknownDeposit(keeper.codespace, params.ProposalID, params.Depositer)
+	}
+
+	return marshalQueryResult(keeper.cdc, deposit)
+}
+
+// nolint: unparam
+func queryVote(ctx sdk.Context, path []string, req wrsp.RequestQuery, keeper Keeper) (res []byte, err sdk.Error) {
+	var params QueryVoteParams
+	err2 := keeper.cdc.UnmarshalJSON(req.Data, &params)
+	if err2 != nil {
+		return nil, sdk.ErrUnknownRequest("incorrectly formatted request data - " + err2.Error())
+	}
+
+	vote, found := keeper.GetVote(ctx, params.ProposalID, params.Voter)
+	if !found {
+		return nil, ErrUnknownVote(keeper.codespace, params.ProposalID, params.Voter)
+	}
+
+	return marshalQueryResult(keeper.cdc, vote)
+}
+
+// nolint: unparam
+func queryDeposits(ctx sdk.Context, path []string, req wrsp.RequestQuery, keeper Keeper) (res []byte, err sdk.Error) {
+	var params QueryProposalParams
+	err2 := keeper.cdc.UnmarshalJSON(req.Data, &params)
+	if err2 != nil {
+		return nil, sdk.ErrUnknownRequest("incorrectly formatted request data - " + err2.Error())
+	}
+
+	var deposits []Deposit
+	depositsIterator := keeper.GetDeposits(ctx, params.ProposalID)
+	for ; depositsIterator.Valid(); depositsIterator.Next() {
+		deposit := Deposit{}
+		keeper.cdc.MustUnmarshalBinary(depositsIterator.Value(), &deposit)
+		deposits = append(deposits, deposit)
+	}
+	depositsIterator.Close()
+
+	return marshalQueryResult(keeper.cdc, deposits)
+}
+
+// nolint: unparam
+func queryVotes(ctx sdk.Context, path []string, req wrsp.RequestQuery, keeper Keeper) (res []byte, err sdk.Error) {
+	var params QueryProposalParams
+	err2 := keeper.cdc.UnmarshalJSON(req.Data, &params)
+	if err2 != nil {
+		return nil, sdk.ErrUnknownRequest("incorrectly formatted request data - " + err2.Error())
+	}
+
+	var votes []Vote
+	votesIterator := keeper.GetVotes(ctx, params.ProposalID)
+	for ; votesIterator.Valid(); votesIterator.Next() {
+		vote := Vote{}
+		keeper.cdc.MustUnmarshalBinary(votesIterator.Value(), &vote)
+		votes = append(votes, vote)
+	}
+	votesIterator.Close()
+
+	return marshalQueryResult(keeper.cdc, votes)
+}
+
+// nolint: unparam
+func queryProposals(ctx sdk.Context, path []string, req wrsp.RequestQuery, keeper Keeper) (res []byte, err sdk.Error) {
+	var params QueryProposalsParams
+	err2 := keeper.cdc.UnmarshalJSON(req.Data, &params)
+	if err2 != nil {
+		return nil, sdk.ErrUnknownRequest("incorrectly formatted request data - " + err2.Error())
+	}
+
+	proposals := keeper.GetProposalsFiltered(ctx, params.Voter, params.Depositer, params.ProposalStatus, params.NumLatest)
+
+	return marshalQueryResult(keeper.cdc, proposals)
+}
+
+// nolint: unparam
+func queryTally(ctx sdk.Context, path []string, req wrsp.RequestQuery, keeper Keeper) (res []byte, err sdk.Error) {
+	var params QueryProposalParams
+	err2 := keeper.cdc.UnmarshalJSON(req.Data, &params)
+	if err2 != nil {
+		return nil, sdk.ErrUnknownRequest("incorrectly formatted request data - " + err2.Error())
+	}
+
+	proposal := keeper.GetProposal(ctx, params.ProposalID)
+	if proposal == nil {
+		return nil, ErrUnknownProposal(keeper.codespace, params.ProposalID)
+	}
+
+	var tallyResult TallyResult
+	switch proposal.GetStatus() {
+	case StatusDepositPeriod:
+		tallyResult = EmptyTallyResult()
+	case StatusVotingPeriod:
+		// tally removes the votes it counts, so run it on a throwaway cache
+		cacheCtx, _ := ctx.CacheContext()
+		_, tallyResult, _ = tally(cacheCtx, keeper, proposal)
+	default:
+		tallyResult = proposal.GetTallyResult()
+	}
+
+	return marshalQueryResult(keeper.cdc, tallyResult)
+}
+
+func marshalQueryResult(cdc *wire.Codec, obj interface{}) (res []byte, err sdk.Error) {
+	bz, err2 := wire.MarshalJSONIndent(cdc, obj)
+	if err2 != nil {
+		return nil, sdk.ErrInternal("could not marshal result to JSON - " + err2.Error())
+	}
+	return bz, nil
+}
@@ -0,0 +1,89 @@
+package gov
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	wrsp "github.com/tepleton/tepleton/wrsp/types"
+
+	sdk "github.com/tepleton/tepleton-sdk/types"
+)
+
+func TestQueryProposals(t *testing.T) {
+	mapp, keeper, _, addrs, _, _ := getMockApp(t, 2)
+	mapp.BeginBlock(wrsp.RequestBeginBlock{})
+	ctx := mapp.BaseApp.NewContext(false, wrsp.Header{})
+	querier := NewQuerier(keeper)
+
+	proposal1 := keeper.NewTextProposal(ctx, "Test", "description", ProposalTypeText)
+	proposal2 := keeper.NewTextProposal(ctx, "Test", "description", ProposalTypeText)
+	keeper.NewTextProposal(ctx, "Test", "description", ProposalTypeText)
+
+	keeper.activateVotingPeriod(ctx, proposal2)
+	err := keeper.AddVote(ctx, proposal2.GetProposalID(), addrs[0], OptionYes)
+	require.Nil(t, err)
+	err, _ = keeper.AddDeposit(ctx, proposal1.GetProposalID(), addrs[1], sdk.Coins{sdk.NewCoin("steak", 4)})
+	require.Nil(t, err)
+
+	queryProposals := func(params QueryProposalsParams) []Proposal {
+		bz, err := keeper.cdc.MarshalJSON(params)
+		require.Nil(t, err)
+		res, sdkErr := querier(ctx, []string{QueryProposals}, wrsp.RequestQuery{Data: bz})
+		require.Nil(t, sdkErr)
+		var proposals []Proposal
+		require.Nil(t, keeper.cdc.UnmarshalJSON(res, &proposals))
+		return proposals
+	}
+
+	require.Len(t, queryProposals(QueryProposalsParams{}), 3)
+	require.Len(t, queryProposals(QueryProposalsParams{NumLatest: 2}), 2)
+
+	proposals := queryProposals(QueryProposalsParams{ProposalStatus: StatusVotingPeriod})
+	require.Len(t, proposals, 1)
+	require.Equal(t, proposal2.GetProposalID(), proposals[0].GetProposalID())
+
+	proposals = queryProposals(QueryProposalsParams{Voter: addrs[0]})
+	require.Len(t, proposals, 1)
+	require.Equal(t, proposal2.GetProposalID(), proposals[0].GetProposalID())
+
+	proposals = queryProposals(QueryProposalsParams{Depositer: addrs[1]})
+	require.Len(t, proposals, 1)
+	require.Equal(t, proposal1.GetProposalID(), proposals[0].GetProposalID())
+
+	require.Len(t, queryProposals(QueryProposalsParams{Voter: addrs[1]}), 0)
+}
+
+func TestQueryVoteAndTally(t *testing.T) {
+	mapp, keeper, _, addrs, _, _ := getMockApp(t, 1)
+	mapp.BeginBlock(wrsp.RequestBeginBlock{})
+	ctx := mapp.BaseApp.NewContext(false, wrsp.Header{})
+	querier := NewQuerier(keeper)
+
+	proposal := keeper.NewTextProposal(ctx, "Test", "description", ProposalTypeText)
+	proposalID := proposal.GetProposalID()
+	keeper.activateVotingPeriod(ctx, proposal)
+	err := keeper.AddVote(ctx, proposalID, addrs[0], OptionNo)
+	require.Nil(t, err)
+
+	bz, err2 := keeper.cdc.MarshalJSON(QueryVoteParams{proposalID, addrs[0]})
+	require.Nil(t, err2)
+	res, err := querier(ctx, []string{QueryVote}, wrsp.RequestQuery{Data: bz})
+	require.Nil(t, err)
+	var vote Vote
+	require.Nil(t, keeper.cdc.UnmarshalJSON(res, &vote))
+	require.Equal(t, OptionNo, vote.Options.NonSplitOption())
+
+	// querying the tally must not remove the votes it counts
+	bz, err2 = keeper.cdc.MarshalJSON(QueryProposalParams{proposalID})
+	require.Nil(t, err2)
+	_, err = querier(ctx, []string{QueryTally}, wrsp.RequestQuery{Data: bz})
+	require.Nil(t, err)
+	_, found := keeper.GetVote(ctx, proposalID, addrs[0])
+	require.True(t, found)
+
+	bz, err2 = keeper.cdc.MarshalJSON(QueryProposalParams{proposalID + 1})
+	require.Nil(t, err2)
+	_, err = querier(ctx, []string{QueryTally}, wrsp.RequestQuery{Data: bz})
+	require.NotNil(t, err)
+}
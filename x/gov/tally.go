@@ -6,21 +6,21 @@ import (
 
 // validatorGovInfo used for tallying
 type validatorGovInfo struct {
-	Address         sdk.Address // sdk.Address of the validator owner
-	Power           sdk.Rat     // Power of a Validator
-	DelegatorShares sdk.Rat     // Total outstanding delegator shares
-	Minus           sdk.Rat     // Minus of validator, used to compute validator's voting power
-	Vote            VoteOption  // Vote of the validator
+	Address         sdk.Address         // sdk.Address of the validator owner
+	Power           sdk.Dec             // Power of a Validator
+	DelegatorShares sdk.Dec             // Total outstanding delegator shares
+	Minus           sdk.Dec             // Minus of validator, used to compute validator's voting power
+	Vote            WeightedVoteOptions // Vote of the validator
 }
 
-func tally(ctx sdk.Context, keeper Keeper, proposal Proposal) (passes bool, nonVoting []sdk.Address) {
-	results := make(map[VoteOption]sdk.Rat)
-	results[OptionYes] = sdk.ZeroRat()
-	results[OptionAbstain] = sdk.ZeroRat()
-	results[OptionNo] = sdk.ZeroRat()
-	results[OptionNoWithVeto] = sdk.ZeroRat()
+func tally(ctx sdk.Context, keeper Keeper, proposal Proposal) (passes bool, tallyResults TallyResult, nonVoting []sdk.Address) {
+	results := make(map[VoteOption]sdk.Dec)
+	results[OptionYes] = sdk.ZeroDec()
+	results[OptionAbstain] = sdk.ZeroDec()
+	results[OptionNo] = sdk.ZeroDec()
+	results[OptionNoWithVeto] = sdk.ZeroDec()
 
-	totalVotingPower := sdk.ZeroRat()
+	totalVotingPower := sdk.ZeroDec()
 	currValidators := make(map[string]validatorGovInfo)
 
 	keeper.vs.IterateValidatorsBonded(ctx, func(index int64, validator sdk.Validator) (stop bool) {
@@ -28,8 +28,8 @@ func tally(ctx sdk.Context, keeper Keeper, proposal Proposal) (passes bool, nonV
 			Address:         validator.GetOwner(),
 			Power:           validator.GetPower(),
 			DelegatorShares: validator.GetDelegatorShares(),
-			Minus:           sdk.ZeroRat(),
-			Vote:            OptionEmpty,
+			Minus:           sdk.ZeroDec(),
+			Vote:            nil,
 		}
 		return false
 	})
@@ -43,7 +43,7 @@ func tally(ctx sdk.Context, keeper Keeper, proposal Proposal) (passes bool, nonV
 		// if validator, just record it in the map
 		// if delegator tally voting power
 		if val, ok := currValidators[vote.Voter.String()]; ok {
-			val.Vote = vote.Option
+			val.Vote = vote.Options
 			currValidators[vote.Voter.String()] = val
 		} else {
 
@@ -55,7 +55,10 @@ func tally(ctx sdk.Context, keeper Keeper, proposal Proposal) (passes bool, nonV
 				delegatorShare := delegation.GetBondShares().Quo(val.DelegatorShares)
 				votingPower := val.Power.Mul(delegatorShare)
 
-				results[vote.Option] = results[vote.Option].Add(votingPower)
+				for _, option := range vote.Options {
+					subPower := votingPower.Mul(option.Weight)
+					results[option.Option] = results[option.Option].Add(subPower)
+				}
 				totalVotingPower = totalVotingPower.Add(votingPower)
 
 				return false
@@ -69,7 +72,7 @@ func tally(ctx sdk.Context, keeper Keeper, proposal Proposal) (passes bool, nonV
 	// Iterate over the validators again to tally their voting power and see who didn't vote
 	nonVoting = []sdk.Address{}
 	for _, val := range currValidators {
-		if val.Vote == OptionEmpty {
+		if len(val.Vote) == 0 {
 			nonVoting = append(nonVoting, val.Address)
 			continue
 		}
@@ -77,24 +80,34 @@ func tally(ctx sdk.Context, keeper Keeper, proposal Proposal) (passes bool, nonV
 		percentAfterMinus := sharesAfterMinus.Quo(val.DelegatorShares)
 		votingPower := val.Power.Mul(percentAfterMinus)
 
-		results[val.Vote] = results[val.Vote].Add(votingPower)
+		for _, option := range val.Vote {
+			subPower := votingPower.Mul(option.Weight)
+			results[option.Option] = results[option.Option].Add(subPower)
+		}
 		totalVotingPower = totalVotingPower.Add(votingPower)
 	}
 
 	tallyingProcedure := keeper.GetTallyingProcedure()
 
+	tallyResults = TallyResult{
+		Yes:        results[OptionYes],
+		Abstain:    results[OptionAbstain],
+		No:         results[OptionNo],
+		NoWithVeto: results[OptionNoWithVeto],
+	}
+
 	// If no one votes, proposal fails
-	if totalVotingPower.Sub(results[OptionAbstain]).Equal(sdk.ZeroRat()) {
-		return false, nonVoting
+	if totalVotingPower.Sub(results[OptionAbstain]).Equal(sdk.ZeroDec()) {
+		return false, tallyResults, nonVoting
 	}
 	// If more than 1/3 of voters veto, proposal fails
 	if results[OptionNoWithVeto].Quo(totalVotingPower).GT(tallyingProcedure.Veto) {
-		return false, nonVoting
+		return false, tallyResults, nonVoting
 	}
 	// If more than 1/2 of non-abstaining voters vote Yes, proposal passes
 	if results[OptionYes].Quo(totalVotingPower.Sub(results[OptionAbstain])).GT(tallyingProcedure.Threshold) {
-		return true, nonVoting
+		return true, tallyResults, nonVoting
 	}
 	// If more than 1/2 of non-abstaining voters vote No, proposal fails
-	return false, nonVoting
+	return false, tallyResults, nonVoting
 }
@@ -25,13 +25,15 @@ func getMockApp(t *testing.T, numGenAccs int64) (*mock.App, Keeper, stake.Keeper
 	RegisterWire(mapp.Cdc)
 
 	keyStake := sdk.NewKVStoreKey("stake")
+	tkeyStake := sdk.NewTransientStoreKey("transient_stake")
 	keyGov := sdk.NewKVStoreKey("gov")
 
 	ck := bank.NewKeeper(mapp.AccountMapper)
-	sk := stake.NewKeeper(mapp.Cdc, keyStake, ck, mapp.RegisterCodespace(stake.DefaultCodespace))
+	sk := stake.NewKeeper(mapp.Cdc, keyStake, tkeyStake, ck, mapp.RegisterCodespace(stake.DefaultCodespace))
 	keeper := NewKeeper(mapp.Cdc, keyGov, ck, sk, DefaultCodespace)
 	mapp.Router().AddRoute("gov", NewHandler(keeper))
 
+	mapp.MountStoresTransient(tkeyStake)
 	require.NoError(t, mapp.CompleteSetup([]*sdk.KVStoreKey{keyStake, keyGov}))
 
 	mapp.SetEndBlocker(getEndBlocker(keeper))
@@ -46,10 +48,8 @@ func getMockApp(t *testing.T, numGenAccs int64) (*mock.App, Keeper, stake.Keeper
 // gov and stake endblocker
 func getEndBlocker(keeper Keeper) sdk.EndBlocker {
 	return func(ctx sdk.Context, req wrsp.RequestEndBlock) wrsp.ResponseEndBlock {
-		tags, _ := EndBlocker(ctx, keeper)
-		return wrsp.ResponseEndBlock{
-			Tags: tags,
-		}
+		EndBlocker(ctx, keeper)
+		return wrsp.ResponseEndBlock{}
 	}
 }
 
@@ -10,6 +10,7 @@ func RegisterWire(cdc *wire.Codec) {
 	cdc.RegisterConcrete(MsgSubmitProposal{}, "tepleton-sdk/MsgSubmitProposal", nil)
 	cdc.RegisterConcrete(MsgDeposit{}, "tepleton-sdk/MsgDeposit", nil)
 	cdc.RegisterConcrete(MsgVote{}, "tepleton-sdk/MsgVote", nil)
+	cdc.RegisterConcrete(MsgVoteWeighted{}, "tepleton-sdk/MsgVoteWeighted", nil)
 
 	cdc.RegisterInterface((*Proposal)(nil), nil)
 	cdc.RegisterConcrete(&TextProposal{}, "gov/TextProposal", nil)
@@ -22,7 +22,10 @@ func getMockApp(t *testing.T) *mock.App {
 	keyIBC := sdk.NewKVStoreKey("ibc")
 	ibcMapper := NewMapper(mapp.Cdc, keyIBC, mapp.RegisterCodespace(DefaultCodespace))
 	coinKeeper := bank.NewKeeper(mapp.AccountMapper)
-	mapp.Router().AddRoute("ibc", NewHandler(ibcMapper, coinKeeper))
+	transfer := NewTransferModule(ibcMapper, coinKeeper)
+	mapp.Router().
+		AddRoute("ibc", NewHandler(ibcMapper, NewRouter().AddRoute(PortTransfer, transfer))).
+		AddRoute("transfer", NewTransferHandler(transfer))
 
 	require.NoError(t, mapp.CompleteSetup([]*sdk.KVStoreKey{keyIBC}))
 	return mapp
@@ -37,7 +40,6 @@ func TestIBCMsgs(t *testing.T) {
 	priv1 := crypto.GenPrivKeyEd25519()
 	addr1 := priv1.PubKey().Address()
 	coins := sdk.Coins{sdk.NewCoin("foocoin", 10)}
-	var emptyCoins sdk.Coins
 
 	acc := &auth.BaseAccount{
 		Address: addr1,
@@ -52,28 +54,31 @@ func TestIBCMsgs(t *testing.T) {
 	res1 := mapp.AccountMapper.GetAccount(ctxCheck, addr1)
 	require.Equal(t, acc, res1)
 
-	packet := IBCPacket{
-		SrcAddr:   addr1,
-		DestAddr:  addr1,
-		Coins:     coins,
-		SrcChain:  sourceChain,
-		DestChain: destChain,
-	}
-
 	transferMsg := IBCTransferMsg{
-		IBCPacket: packet,
+		SrcAddr:    addr1,
+		DestAddr:   addr1,
+		Coins:      coins,
+		SrcChannel: "channel-0",
+		DestChain:  destChain,
 	}
 
+	data := marshalBinaryPanic(mapp.Cdc, TransferPacketData{addr1, addr1, coins})
+	packet := NewIBCPacket(sourceChain, PortTransfer, "channel-0", destChain, PortTransfer, "channel-0", data, 0)
 	receiveMsg := IBCReceiveMsg{
 		IBCPacket: packet,
 		Relayer:   addr1,
 		Sequence:  0,
 	}
 
-	mock.SignCheckDeliver(t, mapp.BaseApp, []sdk.Msg{transferMsg}, []int64{0}, []int64{0}, true, priv1)
-	mock.CheckBalance(t, mapp, addr1, emptyCoins)
-	mock.SignCheckDeliver(t, mapp.BaseApp, []sdk.Msg{transferMsg}, []int64{0}, []int64{1}, false, priv1)
-	mock.SignCheckDeliver(t, mapp.BaseApp, []sdk.Msg{receiveMsg}, []int64{0}, []int64{2}, true, priv1)
+	// coins can only be sent over an open channel
+	mock.SignCheckDeliver(t, mapp.BaseApp, []sdk.Msg{transferMsg}, []int64{0}, []int64{0}, false, priv1)
+	mock.CheckBalance(t, mapp, addr1, coins)
+
+	// a relayer cannot credit a packet without proving it against a tracked header
+	mock.SignCheckDeliver(t, mapp.BaseApp, []sdk.Msg{receiveMsg}, []int64{0}, []int64{1}, false, priv1)
+	mock.CheckBalance(t, mapp, addr1, coins)
+	receiveMsg.Proof = []byte("proof")
+	receiveMsg.Height = 1
+	mock.SignCheckDeliver(t, mapp.BaseApp, []sdk.Msg{receiveMsg}, []int64{0}, []int64{1}, false, priv1)
 	mock.CheckBalance(t, mapp, addr1, coins)
-	mock.SignCheckDeliver(t, mapp.BaseApp, []sdk.Msg{receiveMsg}, []int64{0}, []int64{2}, false, priv1)
 }
@@ -0,0 +1,245 @@
+package ibc
+
+import (
+	sdk "github.com/tepleton/tepleton-sdk/types"
+)
+
+// ------------------------------
+// Channel
+
+// Channel is an ordered stream of packets between a port on this chain and a
+// port on the counterparty chain of its connection. Each channel has its own
+// sequences, so modules owning different ports never block each other.
+type Channel struct {
+	Port                  string
+	ID                    string
+	ConnectionID          string
+	CounterpartyPort      string
+	CounterpartyChannelID string
+	State                 State
+}
+
+// ----------------------------------
+// MsgChannelOpenInit
+
+// MsgChannelOpenInit starts the handshake of a channel over an open
+// connection.
+type MsgChannelOpenInit struct {
+	Port                  string
+	ChannelID             string
+	ConnectionID          string
+	CounterpartyPort      string
+	CounterpartyChannelID string
+	Signer                sdk.Address
+}
+
+// nolint
+func (msg MsgChannelOpenInit) Type() string              { return "ibc" }
+func (msg MsgChannelOpenInit) GetSigners() []sdk.Address { return []sdk.Address{msg.Signer} }
+
+// get the sign bytes for channel open init message
+func (msg MsgChannelOpenInit) GetSignBytes() []byte {
+	b, err := msgCdc.MarshalJSON(struct {
+		Port                  string
+		ChannelID             string
+		ConnectionID          string
+		CounterpartyPort      string
+		CounterpartyChannelID string
+		Signer                string
+	}{
+		Port:                  msg.Port,
+		ChannelID:             msg.ChannelID,
+		ConnectionID:          msg.ConnectionID,
+		CounterpartyPort:      msg.CounterpartyPort,
+		CounterpartyChannelID: msg.CounterpartyChannelID,
+		Signer:                sdk.MustBech32ifyAcc(msg.Signer),
+	})
+	if err != nil {
+		panic(err)
+	}
+	return b
+}
+
+// validate channel open init message
+func (msg MsgChannelOpenInit) ValidateBasic() sdk.Error {
+	return validateChannelMsg(msg.Port, msg.ChannelID, msg.ConnectionID,
+		msg.CounterpartyPort, msg.CounterpartyChannelID, msg.Signer)
+}
+
+// ----------------------------------
+// MsgChannelOpenTry
+
+// MsgChannelOpenTry answers the handshake started on the counterparty chain.
+// Proof proves the counterparty channel in StateInit against the app hash of
+// the counterparty header at Height.
+type MsgChannelOpenTry struct {
+	Port                  string
+	ChannelID             string
+	ConnectionID          string
+	CounterpartyPort      string
+	CounterpartyChannelID string
+	Proof                 []byte
+	Height                int64
+	Signer                sdk.Address
+}
+
+// nolint
+func (msg MsgChannelOpenTry) Type() string              { return "ibc" }
+func (msg MsgChannelOpenTry) GetSigners() []sdk.Address { return []sdk.Address{msg.Signer} }
+
+// get the sign bytes for channel open try message
+func (msg MsgChannelOpenTry) GetSignBytes() []byte {
+	b, err := msgCdc.MarshalJSON(struct {
+		Port                  string
+		ChannelID             string
+		ConnectionID          string
+		CounterpartyPort      string
+		CounterpartyChannelID string
+		Proof                 []byte
+		Height                int64
+		Signer                string
+	}{
+		Port:                  msg.Port,
+		ChannelID:             msg.ChannelID,
+		ConnectionID:          msg.ConnectionID,
+		CounterpartyPort:      msg.CounterpartyPort,
+		CounterpartyChannelID: msg.CounterpartyChannelID,
+		Proof:                 msg.Proof,
+		Height:                msg.Height,
+		Signer:                sdk.MustBech32ifyAcc(msg.Signer),
+	})
+	if err != nil {
+		panic(err)
+	}
+	return b
+}
+
+// validate channel open try message
+func (msg MsgChannelOpenTry) ValidateBasic() sdk.Error {
+	err := validateChannelMsg(msg.Port, msg.ChannelID, msg.ConnectionID,
+		msg.CounterpartyPort, msg.CounterpartyChannelID, msg.Signer)
+	if err != nil {
+		return err
+	}
+	return validateHandshakeProof(msg.Proof, msg.Height)
+}
+
+// ----------------------------------
+// MsgChannelOpenAck
+
+// MsgChannelOpenAck opens a channel in StateInit once the counterparty has
+// answered the handshake. Proof proves the counterparty channel in
+// StateTryOpen against the app hash of the counterparty header at Height.
+type MsgChannelOpenAck struct {
+	Port      string
+	ChannelID string
+	Proof     []byte
+	Height    int64
+	Signer    sdk.Address
+}
+
+// nolint
+func (msg MsgChannelOpenAck) Type() string              { return "ibc" }
+func (msg MsgChannelOpenAck) GetSigners() []sdk.Address { return []sdk.Address{msg.Signer} }
+
+// get the sign bytes for channel open ack message
+func (msg MsgChannelOpenAck) GetSignBytes() []byte {
+	return channelProofSignBytes(msg.Port, msg.ChannelID, msg.Proof, msg.Height, msg.Signer)
+}
+
+// validate channel open ack message
+func (msg MsgChannelOpenAck) ValidateBasic() sdk.Error {
+	return validateChannelProofMsg(msg.Port, msg.ChannelID, msg.Proof, msg.Height, msg.Signer)
+}
+
+// ----------------------------------
+// MsgChannelOpenConfirm
+
+// MsgChannelOpenConfirm opens a channel in StateTryOpen once the
+// counterparty has opened its end. Proof proves the counterparty channel in
+// StateOpen against the app hash of the counterparty header at Height.
+type MsgChannelOpenConfirm struct {
+	Port      string
+	ChannelID string
+	Proof     []byte
+	Height    int64
+	Signer    sdk.Address
+}
+
+// nolint
+func (msg MsgChannelOpenConfirm) Type() string              { return "ibc" }
+func (msg MsgChannelOpenConfirm) GetSigners() []sdk.Address { return []sdk.Address{msg.Signer} }
+
+// get the sign bytes for channel open confirm message
+func (msg MsgChannelOpenConfirm) GetSignBytes() []byte {
+	return channelProofSignBytes(msg.Port, msg.ChannelID, msg.Proof, msg.Height, msg.Signer)
+}
+
+// validate channel open confirm message
+func (msg MsgChannelOpenConfirm) ValidateBasic() sdk.Error {
+	return validateChannelProofMsg(msg.Port, msg.ChannelID, msg.Proof, msg.Height, msg.Signer)
+}
+
+// ----------------------------------
+// Helpers
+
+func channelProofSignBytes(port, channelID string, proof []byte, height int64, signer sdk.Address) []byte {
+	b, err := msgCdc.MarshalJSON(struct {
+		Port      string
+		ChannelID string
+		Proof     []byte
+		Height    int64
+		Signer    string
+	}{
+		Port:      port,
+		ChannelID: channelID,
+		Proof:     proof,
+		Height:    height,
+		Signer:    sdk.MustBech32ifyAcc(signer),
+	})
+	if err != nil {
+		panic(err)
+	}
+	return b
+}
+
+func validateChannelMsg(port, channelID, connectionID, counterpartyPort, counterpartyChannelID string,
+	signer sdk.Address) sdk.Error {
+
+	ids := []struct{ kind, id string }{
+		{"port", port},
+		{"channel", channelID},
+		{"connection", connectionID},
+		{"port", counterpartyPort},
+		{"channel", counterpartyChannelID},
+	}
+	for _, id := range ids {
+		err := validateIdentifier(id.kind, id.id)
+		if err != nil {
+			return err
+		}
+	}
+	if len(signer) == 0 {
+		return sdk.ErrInvalidAddress("signer address is empty")
+	}
+	return nil
+}
+
+func validateChannelProofMsg(port, channelID string, proof []byte, height int64, signer sdk.Address) sdk.Error {
+	err := validateIdentifier("port", port)
+	if err != nil {
+		return err
+	}
+	err = validateIdentifier("channel", channelID)
+	if err != nil {
+		return err
+	}
+	err = validateHandshakeProof(proof, height)
+	if err != nil {
+		return err
+	}
+	if len(signer) == 0 {
+		return sdk.ErrInvalidAddress("signer address is empty")
+	}
+	return nil
+}
@@ -0,0 +1,151 @@
+package ibc
+
+import (
+	"bytes"
+	"fmt"
+
+	tmtypes "github.com/tepleton/tepleton/types"
+
+	sdk "github.com/tepleton/tepleton-sdk/types"
+)
+
+// ------------------------------
+// ConsensusState
+
+// ConsensusState is what the light client of a counterparty chain remembers
+// about a verified header: the app hash it commits to and the validator set
+// that signed it, which must sign off on any later header.
+type ConsensusState struct {
+	ChainID    string
+	Height     int64
+	AppHash    []byte
+	Validators *tmtypes.ValidatorSet
+}
+
+// verifyHeader checks that the header belongs to chainID, that commit is a
+// commit for it signed by more than 2/3 of vals and, if trusted is set, also
+// by more than 2/3 of the trusted validator set.
+func verifyHeader(chainID string, trusted *tmtypes.ValidatorSet, header tmtypes.Header,
+	commit *tmtypes.Commit, vals *tmtypes.ValidatorSet) error {
+
+	if header.ChainID != chainID {
+		return fmt.Errorf("header is for chain %s, expected %s", header.ChainID, chainID)
+	}
+	if commit.Height() != header.Height {
+		return fmt.Errorf("commit is for height %d, header has height %d", commit.Height(), header.Height)
+	}
+	if !bytes.Equal(commit.BlockID.Hash, header.Hash()) {
+		return fmt.Errorf("commit is not for this header")
+	}
+	if !bytes.Equal(header.ValidatorsHash, vals.Hash()) {
+		return fmt.Errorf("validator set does not match header")
+	}
+
+	if trusted == nil || bytes.Equal(trusted.Hash(), vals.Hash()) {
+		return vals.VerifyCommit(chainID, commit.BlockID, header.Height, commit)
+	}
+	return trusted.VerifyCommitAny(vals, chainID, commit.BlockID, header.Height, commit)
+}
+
+// ----------------------------------
+// MsgCreateClient
+
+// MsgCreateClient starts tracking a counterparty chain from a header, the
+// commit signing it and the validator set of the chain at that height. The
+// result data holds the ID of the new light client.
+type MsgCreateClient struct {
+	Header     tmtypes.Header
+	Commit     *tmtypes.Commit
+	Validators *tmtypes.ValidatorSet
+	Signer     sdk.Address
+}
+
+// nolint
+func (msg MsgCreateClient) Type() string              { return "ibc" }
+func (msg MsgCreateClient) GetSigners() []sdk.Address { return []sdk.Address{msg.Signer} }
+
+// get the sign bytes for create client message
+func (msg MsgCreateClient) GetSignBytes() []byte {
+	return clientSignBytes("", msg.Header, msg.Commit, msg.Validators, msg.Signer)
+}
+
+// validate create client message
+func (msg MsgCreateClient) ValidateBasic() sdk.Error {
+	return validateClientMsg(msg.Header, msg.Commit, msg.Validators, msg.Signer)
+}
+
+// ----------------------------------
+// MsgUpdateClient
+
+// MsgUpdateClient moves the light client ClientID forward to a newer header.
+// Validators is the validator set of the chain at the height of the new
+// header.
+type MsgUpdateClient struct {
+	ClientID   string
+	Header     tmtypes.Header
+	Commit     *tmtypes.Commit
+	Validators *tmtypes.ValidatorSet
+	Signer     sdk.Address
+}
+
+// nolint
+func (msg MsgUpdateClient) Type() string              { return "ibc" }
+func (msg MsgUpdateClient) GetSigners() []sdk.Address { return []sdk.Address{msg.Signer} }
+
+// get the sign bytes for update client message
+func (msg MsgUpdateClient) GetSignBytes() []byte {
+	return clientSignBytes(msg.ClientID, msg.Header, msg.Commit, msg.Validators, msg.Signer)
+}
+
+// validate update client message
+func (msg MsgUpdateClient) ValidateBasic() sdk.Error {
+	err := validateIdentifier("client", msg.ClientID)
+	if err != nil {
+		return err
+	}
+	return validateClientMsg(msg.Header, msg.Commit, msg.Validators, msg.Signer)
+}
+
+// ----------------------------------
+// Helpers
+
+func clientSignBytes(clientID string, header tmtypes.Header, commit *tmtypes.Commit,
+	vals *tmtypes.ValidatorSet, signer sdk.Address) []byte {
+
+	b, err := msgCdc.MarshalJSON(struct {
+		ClientID   string
+		Header     tmtypes.Header
+		Commit     *tmtypes.Commit
+		Validators *tmtypes.ValidatorSet
+		Signer     string
+	}{
+		ClientID:   clientID,
+		Header:     header,
+		Commit:     commit,
+		Validators: vals,
+		Signer:     sdk.MustBech32ifyAcc(signer),
+	})
+	if err != nil {
+		panic(err)
+	}
+	return b
+}
+
+func validateClientMsg(header tmtypes.Header, commit *tmtypes.Commit,
+	vals *tmtypes.ValidatorSet, signer sdk.Address) sdk.Error {
+
+	err := validateIdentifier("chain", header.ChainID)
+	if err != nil {
+		return err
+	}
+	if commit == nil || len(commit.Precommits) == 0 {
+		return ErrInvalidHeader(DefaultCodespace, "header has no commit")
+	}
+	if vals == nil || vals.Size() == 0 {
+		return ErrInvalidHeader(DefaultCodespace, "header has no validator set")
+	}
+	if len(signer) == 0 {
+		return sdk.ErrInvalidAddress("signer address is empty")
+	}
+	return nil
+}
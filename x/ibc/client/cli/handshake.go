@@ -0,0 +1,328 @@
+package cli
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/tepleton/tepleton-sdk/client/context"
+	sdk "github.com/tepleton/tepleton-sdk/types"
+	wire "github.com/tepleton/tepleton-sdk/wire"
+	authcmd "github.com/tepleton/tepleton-sdk/x/auth/client/cli"
+	"github.com/tepleton/tepleton-sdk/x/ibc"
+)
+
+const (
+	flagConnection             = "connection"
+	flagClient                 = "client"
+	flagCounterpartyClient     = "counterparty-client"
+	flagCounterpartyNode       = "counterparty-node"
+	flagCounterpartyConnection = "counterparty-connection"
+	flagCounterpartyPort       = "counterparty-port"
+	flagCounterpartyChannel    = "counterparty-channel"
+)
+
+// handshakeMsgs builds the messages of a handshake step, signed by signer
+type handshakeMsgs func(ctx context.CoreContext, signer sdk.Address, args []string) ([]sdk.Msg, error)
+
+// handshakeCmd returns a command signing and broadcasting a handshake step
+func handshakeCmd(cdc *wire.Codec, use, short string, build handshakeMsgs) *cobra.Command {
+	return &cobra.Command{
+		Use:   use,
+		Short: short,
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := context.NewCoreContextFromViper().WithDecoder(authcmd.GetAccountDecoder(cdc))
+
+			signer, err := ctx.GetFromAddress()
+			if err != nil {
+				return err
+			}
+
+			msgs, err := build(ctx, signer, args)
+			if err != nil {
+				return err
+			}
+
+			res, err := ctx.EnsureSignBuildBroadcast(ctx.FromAddressName, msgs, cdc)
+			if err != nil {
+				return err
+			}
+
+			fmt.Printf("Committed at block %d. Hash: %s\n", res.Height, res.Hash.String())
+			return nil
+		},
+	}
+}
+
+// proveCounterparty returns the messages moving the light client of the
+// counterparty chain forward to its latest header, along with the proof of
+// key in the counterparty state that header commits to and its height.
+func proveCounterparty(cdc *wire.Codec, ctx context.CoreContext, signer sdk.Address,
+	clientID string, key []byte) (msgs []sdk.Msg, proof []byte, height int64, err error) {
+
+	cs, err := queryClient(ctx, cdc, "ibc", clientID)
+	if err != nil {
+		return
+	}
+	counterparty := newChain(cdc, cs.ChainID, viper.GetString(flagCounterpartyNode))
+	header, commit, valset, err := getHeader(counterparty)
+	if err != nil {
+		return
+	}
+
+	c := relayCommander{cdc: cdc, address: signer, ibcStore: "ibc"}
+	msgs, err = c.clientMsgs(&chain{id: ctx.ChainID, ctx: ctx}, clientID, header, commit, valset)
+	if err != nil {
+		return
+	}
+
+	// the header commits to the state of the previous height
+	_, proof, err = queryWithProof(counterparty, key, c.ibcStore, header.Height-1)
+	return msgs, proof, header.Height, err
+}
+
+// IBC create client command
+func CreateClientCmd(cdc *wire.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "create-client",
+		Short: "create a light client of the counterparty chain from its latest header",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := context.NewCoreContextFromViper().WithDecoder(authcmd.GetAccountDecoder(cdc))
+
+			signer, err := ctx.GetFromAddress()
+			if err != nil {
+				return err
+			}
+
+			// the header names the chain the client tracks
+			counterparty := newChain(cdc, "", viper.GetString(flagCounterpartyNode))
+			header, commit, valset, err := getHeader(counterparty)
+			if err != nil {
+				return err
+			}
+
+			msg := ibc.MsgCreateClient{
+				Header:     header,
+				Commit:     commit,
+				Validators: valset,
+				Signer:     signer,
+			}
+			res, err := ctx.EnsureSignBuildBroadcast(ctx.FromAddressName, []sdk.Msg{msg}, cdc)
+			if err != nil {
+				return err
+			}
+
+			var clientID string
+			err = cdc.UnmarshalBinaryBare(res.DeliverTx.Data, &clientID)
+			if err != nil {
+				return err
+			}
+			fmt.Printf("Committed at block %d. Hash: %s\n", res.Height, res.Hash.String())
+			fmt.Printf("Created light client %s of chain %s\n", clientID, header.ChainID)
+			return nil
+		},
+	}
+
+	cmd.Flags().String(flagCounterpartyNode, "tcp://localhost:36657", "<host>:<port> to tepleton rpc interface for the counterparty chain")
+	return cmd
+}
+
+// IBC connection open init command
+func ConnectionOpenInitCmd(cdc *wire.Codec) *cobra.Command {
+	cmd := handshakeCmd(cdc, "connection-open-init [connection-id]",
+		"start the handshake of a connection to a chain tracked by a light client",
+		func(ctx context.CoreContext, signer sdk.Address, args []string) ([]sdk.Msg, error) {
+			return []sdk.Msg{ibc.MsgConnectionOpenInit{
+				ConnectionID:             args[0],
+				ClientID:                 viper.GetString(flagClient),
+				CounterpartyClientID:     viper.GetString(flagCounterpartyClient),
+				CounterpartyConnectionID: viper.GetString(flagCounterpartyConnection),
+				Signer:                   signer,
+			}}, nil
+		})
+
+	addConnectionFlags(cmd)
+	return cmd
+}
+
+// IBC connection open try command
+func ConnectionOpenTryCmd(cdc *wire.Codec) *cobra.Command {
+	cmd := handshakeCmd(cdc, "connection-open-try [connection-id]",
+		"answer the handshake of a connection started on the counterparty chain",
+		func(ctx context.CoreContext, signer sdk.Address, args []string) ([]sdk.Msg, error) {
+			clientID := viper.GetString(flagClient)
+			counterpartyConnection := viper.GetString(flagCounterpartyConnection)
+
+			msgs, proof, height, err := proveCounterparty(cdc, ctx, signer, clientID,
+				ibc.ConnectionKey(counterpartyConnection))
+			if err != nil {
+				return nil, err
+			}
+
+			return append(msgs, ibc.MsgConnectionOpenTry{
+				ConnectionID:             args[0],
+				ClientID:                 clientID,
+				CounterpartyClientID:     viper.GetString(flagCounterpartyClient),
+				CounterpartyConnectionID: counterpartyConnection,
+				Proof:                    proof,
+				Height:                   height,
+				Signer:                   signer,
+			}), nil
+		})
+
+	addConnectionFlags(cmd)
+	cmd.Flags().String(flagCounterpartyNode, "tcp://localhost:36657", "<host>:<port> to tepleton rpc interface for the counterparty chain")
+	return cmd
+}
+
+// IBC connection open ack command
+func ConnectionOpenAckCmd(cdc *wire.Codec) *cobra.Command {
+	return connectionOpenCmd(cdc, "connection-open-ack [connection-id]",
+		"open a connection once the counterparty chain has answered its handshake",
+		func(connectionID string, proof []byte, height int64, signer sdk.Address) sdk.Msg {
+			return ibc.MsgConnectionOpenAck{ConnectionID: connectionID, Proof: proof, Height: height, Signer: signer}
+		})
+}
+
+// IBC connection open confirm command
+func ConnectionOpenConfirmCmd(cdc *wire.Codec) *cobra.Command {
+	return connectionOpenCmd(cdc, "connection-open-confirm [connection-id]",
+		"open a connection once the counterparty chain has opened its end",
+		func(connectionID string, proof []byte, height int64, signer sdk.Address) sdk.Msg {
+			return ibc.MsgConnectionOpenConfirm{ConnectionID: connectionID, Proof: proof, Height: height, Signer: signer}
+		})
+}
+
+func connectionOpenCmd(cdc *wire.Codec, use, short string,
+	newMsg func(connectionID string, proof []byte, height int64, signer sdk.Address) sdk.Msg) *cobra.Command {
+
+	cmd := handshakeCmd(cdc, use, short,
+		func(ctx context.CoreContext, signer sdk.Address, args []string) ([]sdk.Msg, error) {
+			conn, err := queryConnection(ctx, cdc, "ibc", args[0])
+			if err != nil {
+				return nil, err
+			}
+
+			msgs, proof, height, err := proveCounterparty(cdc, ctx, signer, conn.ClientID,
+				ibc.ConnectionKey(conn.CounterpartyConnectionID))
+			if err != nil {
+				return nil, err
+			}
+
+			return append(msgs, newMsg(conn.ID, proof, height, signer)), nil
+		})
+
+	cmd.Flags().String(flagCounterpartyNode, "tcp://localhost:36657", "<host>:<port> to tepleton rpc interface for the counterparty chain")
+	return cmd
+}
+
+// IBC channel open init command
+func ChannelOpenInitCmd(cdc *wire.Codec) *cobra.Command {
+	cmd := handshakeCmd(cdc, "channel-open-init [channel-id]",
+		"start the handshake of a channel over an open connection",
+		func(ctx context.CoreContext, signer sdk.Address, args []string) ([]sdk.Msg, error) {
+			return []sdk.Msg{ibc.MsgChannelOpenInit{
+				Port:                  viper.GetString(flagPort),
+				ChannelID:             args[0],
+				ConnectionID:          viper.GetString(flagConnection),
+				CounterpartyPort:      viper.GetString(flagCounterpartyPort),
+				CounterpartyChannelID: viper.GetString(flagCounterpartyChannel),
+				Signer:                signer,
+			}}, nil
+		})
+
+	addChannelFlags(cmd)
+	return cmd
+}
+
+// IBC channel open try command
+func ChannelOpenTryCmd(cdc *wire.Codec) *cobra.Command {
+	cmd := handshakeCmd(cdc, "channel-open-try [channel-id]",
+		"answer the handshake of a channel started on the counterparty chain",
+		func(ctx context.CoreContext, signer sdk.Address, args []string) ([]sdk.Msg, error) {
+			conn, err := queryConnection(ctx, cdc, "ibc", viper.GetString(flagConnection))
+			if err != nil {
+				return nil, err
+			}
+			counterpartyPort := viper.GetString(flagCounterpartyPort)
+			counterpartyChannel := viper.GetString(flagCounterpartyChannel)
+
+			msgs, proof, height, err := proveCounterparty(cdc, ctx, signer, conn.ClientID,
+				ibc.ChannelKey(counterpartyPort, counterpartyChannel))
+			if err != nil {
+				return nil, err
+			}
+
+			return append(msgs, ibc.MsgChannelOpenTry{
+				Port:                  viper.GetString(flagPort),
+				ChannelID:             args[0],
+				ConnectionID:          conn.ID,
+				CounterpartyPort:      counterpartyPort,
+				CounterpartyChannelID: counterpartyChannel,
+				Proof:                 proof,
+				Height:                height,
+				Signer:                signer,
+			}), nil
+		})
+
+	addChannelFlags(cmd)
+	cmd.Flags().String(flagCounterpartyNode, "tcp://localhost:36657", "<host>:<port> to tepleton rpc interface for the counterparty chain")
+	return cmd
+}
+
+// IBC channel open ack command
+func ChannelOpenAckCmd(cdc *wire.Codec) *cobra.Command {
+	return channelOpenCmd(cdc, "channel-open-ack [channel-id]",
+		"open a channel once the counterparty chain has answered its handshake",
+		func(port, channelID string, proof []byte, height int64, signer sdk.Address) sdk.Msg {
+			return ibc.MsgChannelOpenAck{Port: port, ChannelID: channelID, Proof: proof, Height: height, Signer: signer}
+		})
+}
+
+// IBC channel open confirm command
+func ChannelOpenConfirmCmd(cdc *wire.Codec) *cobra.Command {
+	return channelOpenCmd(cdc, "channel-open-confirm [channel-id]",
+		"open a channel once the counterparty chain has opened its end",
+		func(port, channelID string, proof []byte, height int64, signer sdk.Address) sdk.Msg {
+			return ibc.MsgChannelOpenConfirm{Port: port, ChannelID: channelID, Proof: proof, Height: height, Signer: signer}
+		})
+}
+
+func channelOpenCmd(cdc *wire.Codec, use, short string,
+	newMsg func(port, channelID string, proof []byte, height int64, signer sdk.Address) sdk.Msg) *cobra.Command {
+
+	cmd := handshakeCmd(cdc, use, short,
+		func(ctx context.CoreContext, signer sdk.Address, args []string) ([]sdk.Msg, error) {
+			channel, conn, err := queryChannel(ctx, cdc, "ibc", viper.GetString(flagPort), args[0])
+			if err != nil {
+				return nil, err
+			}
+
+			msgs, proof, height, err := proveCounterparty(cdc, ctx, signer, conn.ClientID,
+				ibc.ChannelKey(channel.CounterpartyPort, channel.CounterpartyChannelID))
+			if err != nil {
+				return nil, err
+			}
+
+			return append(msgs, newMsg(channel.Port, channel.ID, proof, height, signer)), nil
+		})
+
+	cmd.Flags().String(flagPort, ibc.PortTransfer, "Port the channel is bound to")
+	cmd.Flags().String(flagCounterpartyNode, "tcp://localhost:36657", "<host>:<port> to tepleton rpc interface for the counterparty chain")
+	return cmd
+}
+
+func addConnectionFlags(cmd *cobra.Command) {
+	cmd.Flags().String(flagClient, "", "Light client of the counterparty chain")
+	cmd.Flags().String(flagCounterpartyClient, "", "Light client of this chain on the counterparty chain")
+	cmd.Flags().String(flagCounterpartyConnection, "", "Identifier of the connection on the counterparty chain")
+}
+
+func addChannelFlags(cmd *cobra.Command) {
+	cmd.Flags().String(flagPort, ibc.PortTransfer, "Port the channel is bound to")
+	cmd.Flags().String(flagConnection, "", "Connection the channel runs over")
+	cmd.Flags().String(flagCounterpartyPort, ibc.PortTransfer, "Port of the channel on the counterparty chain")
+	cmd.Flags().String(flagCounterpartyChannel, "", "Identifier of the channel on the counterparty chain")
+}
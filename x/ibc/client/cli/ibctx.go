@@ -7,7 +7,6 @@ import (
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 
-	"github.com/tepleton/tepleton-sdk/client"
 	"github.com/tepleton/tepleton-sdk/client/context"
 
 	sdk "github.com/tepleton/tepleton-sdk/types"
@@ -18,9 +17,11 @@ import (
 )
 
 const (
-	flagTo     = "to"
-	flagAmount = "amount"
-	flagChain  = "chain"
+	flagTo      = "to"
+	flagAmount  = "amount"
+	flagPort    = "port"
+	flagChannel = "channel"
+	flagTimeout = "timeout"
 )
 
 // IBC transfer command
@@ -37,7 +38,7 @@ func IBCTransferCmd(cdc *wire.Codec) *cobra.Command {
 			}
 
 			// build the message
-			msg, err := buildMsg(from)
+			msg, err := buildMsg(ctx, cdc, from)
 			if err != nil {
 				return err
 			}
@@ -55,11 +56,12 @@ func IBCTransferCmd(cdc *wire.Codec) *cobra.Command {
 
 	cmd.Flags().String(flagTo, "", "Address to send coins")
 	cmd.Flags().String(flagAmount, "", "Amount of coins to send")
-	cmd.Flags().String(flagChain, "", "Destination chain to send coins")
+	cmd.Flags().String(flagChannel, "", "Channel of the transfer port to send the coins over")
+	cmd.Flags().Int64(flagTimeout, 0, "Height of the destination chain after which the coins are refunded if not received, 0 for no timeout")
 	return cmd
 }
 
-func buildMsg(from sdk.Address) (sdk.Msg, error) {
+func buildMsg(ctx context.CoreContext, cdc *wire.Codec, from sdk.Address) (sdk.Msg, error) {
 	amount := viper.GetString(flagAmount)
 	coins, err := sdk.ParseCoins(amount)
 	if err != nil {
@@ -73,11 +75,20 @@ func buildMsg(from sdk.Address) (sdk.Msg, error) {
 	}
 	to := sdk.Address(bz)
 
-	packet := ibc.NewIBCPacket(from, to, coins, viper.GetString(client.FlagChainID),
-		viper.GetString(flagChain))
+	// the destination is the chain at the other end of the channel
+	channelID := viper.GetString(flagChannel)
+	_, conn, err := queryChannel(ctx, cdc, "ibc", ibc.PortTransfer, channelID)
+	if err != nil {
+		return nil, err
+	}
 
 	msg := ibc.IBCTransferMsg{
-		IBCPacket: packet,
+		SrcAddr:    from,
+		DestAddr:   to,
+		Coins:      coins,
+		SrcChannel: channelID,
+		DestChain:  conn.CounterpartyChain,
+		Timeout:    viper.GetInt64(flagTimeout),
 	}
 
 	return msg, nil
@@ -0,0 +1,174 @@
+package cli
+
+import (
+	"fmt"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/tepleton/tepleton-sdk/client/context"
+	"github.com/tepleton/tepleton-sdk/wire"
+	"github.com/tepleton/tepleton-sdk/x/ibc"
+)
+
+// Command to Query the coins escrowed for the coins sent over a channel
+func GetCmdQueryEscrow(queryRoute string, cdc *wire.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "escrow [channel-id]",
+		Short: "query the coins locked in escrow for the coins sent over a channel",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			params := ibc.QueryEscrowParams{
+				Port:    viper.GetString(flagPort),
+				Channel: args[0],
+			}
+
+			bz, err := cdc.MarshalJSON(params)
+			if err != nil {
+				return err
+			}
+
+			ctx := context.NewCoreContextFromViper()
+			res, err := ctx.QueryWithData(fmt.Sprintf("/custom/%s/%s", queryRoute, ibc.QueryEscrow), bz)
+			if err != nil {
+				return err
+			}
+
+			fmt.Println(string(res))
+			return nil
+		},
+	}
+
+	cmd.Flags().String(flagPort, ibc.PortTransfer, "Port the channel is bound to")
+
+	return cmd
+}
+
+// Command to Query a light client
+func GetCmdQueryClient(storeName string, cdc *wire.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "client [client-id]",
+		Short: "query the latest state verified by an IBC light client",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := context.NewCoreContextFromViper()
+			cs, err := queryClient(ctx, cdc, storeName, args[0])
+			if err != nil {
+				return err
+			}
+
+			output, err := wire.MarshalJSONIndent(cdc, cs)
+			if err != nil {
+				return err
+			}
+			fmt.Println(string(output))
+			return nil
+		},
+	}
+}
+
+// Command to Query a connection
+func GetCmdQueryConnection(storeName string, cdc *wire.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "connection [connection-id]",
+		Short: "query an IBC connection",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := context.NewCoreContextFromViper()
+			conn, err := queryConnection(ctx, cdc, storeName, args[0])
+			if err != nil {
+				return err
+			}
+
+			output, err := wire.MarshalJSONIndent(cdc, conn)
+			if err != nil {
+				return err
+			}
+			fmt.Println(string(output))
+			return nil
+		},
+	}
+}
+
+// Command to Query a channel
+func GetCmdQueryChannel(storeName string, cdc *wire.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "channel [channel-id]",
+		Short: "query an IBC channel",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := context.NewCoreContextFromViper()
+			channel, _, err := queryChannel(ctx, cdc, storeName, viper.GetString(flagPort), args[0])
+			if err != nil {
+				return err
+			}
+
+			output, err := wire.MarshalJSONIndent(cdc, channel)
+			if err != nil {
+				return err
+			}
+			fmt.Println(string(output))
+			return nil
+		},
+	}
+
+	cmd.Flags().String(flagPort, ibc.PortTransfer, "Port the channel is bound to")
+
+	return cmd
+}
+
+// queryClient returns the latest state verified by the light client
+func queryClient(ctx context.CoreContext, cdc *wire.Codec, storeName string, clientID string) (cs ibc.ConsensusState, err error) {
+	bz, err := ctx.QueryStore(ibc.LatestClientHeightKey(clientID), storeName)
+	if err != nil {
+		return cs, err
+	}
+	if bz == nil {
+		return cs, errors.Errorf("no light client %s", clientID)
+	}
+	var height int64
+	err = cdc.UnmarshalBinary(bz, &height)
+	if err != nil {
+		return cs, err
+	}
+
+	bz, err = ctx.QueryStore(ibc.ConsensusStateKey(clientID, height), storeName)
+	if err != nil {
+		return cs, err
+	}
+	err = cdc.UnmarshalBinary(bz, &cs)
+	return cs, err
+}
+
+func queryConnection(ctx context.CoreContext, cdc *wire.Codec, storeName string, connectionID string) (conn ibc.Connection, err error) {
+	bz, err := ctx.QueryStore(ibc.ConnectionKey(connectionID), storeName)
+	if err != nil {
+		return conn, err
+	}
+	if bz == nil {
+		return conn, errors.Errorf("no connection %s", connectionID)
+	}
+	err = cdc.UnmarshalBinary(bz, &conn)
+	return conn, err
+}
+
+// queryChannel returns the channel and the connection it runs over
+func queryChannel(ctx context.CoreContext, cdc *wire.Codec, storeName string, port, channelID string) (
+	channel ibc.Channel, conn ibc.Connection, err error) {
+
+	bz, err := ctx.QueryStore(ibc.ChannelKey(port, channelID), storeName)
+	if err != nil {
+		return channel, conn, err
+	}
+	if bz == nil {
+		return channel, conn, errors.Errorf("no channel %s/%s", port, channelID)
+	}
+	err = cdc.UnmarshalBinary(bz, &channel)
+	if err != nil {
+		return channel, conn, err
+	}
+
+	conn, err = queryConnection(ctx, cdc, storeName, channel.ConnectionID)
+	return channel, conn, err
+}
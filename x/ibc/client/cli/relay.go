@@ -1,37 +1,87 @@
 package cli
 
 import (
+	gocontext "context"
+	"fmt"
 	"os"
+	"path/filepath"
 	"time"
 
+	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 
+	rpcclient "github.com/tepleton/tepleton/rpc/client"
+	tmtypes "github.com/tepleton/tepleton/types"
+	"github.com/tepleton/tmlibs/cli"
 	"github.com/tepleton/tmlibs/log"
 
 	"github.com/tepleton/tepleton-sdk/client/context"
 	sdk "github.com/tepleton/tepleton-sdk/types"
 	wire "github.com/tepleton/tepleton-sdk/wire"
-	"github.com/tepleton/tepleton-sdk/x/auth"
 	authcmd "github.com/tepleton/tepleton-sdk/x/auth/client/cli"
 	"github.com/tepleton/tepleton-sdk/x/ibc"
 )
 
 // flags
 const (
-	FlagFromChainID   = "from-chain-id"
-	FlagFromChainNode = "from-chain-node"
-	FlagToChainID     = "to-chain-id"
-	FlagToChainNode   = "to-chain-node"
+	FlagChainAID      = "chain-a-id"
+	FlagChainANode    = "chain-a-node"
+	FlagChainAPort    = "chain-a-port"
+	FlagChainAChannel = "chain-a-channel"
+	FlagChainBID      = "chain-b-id"
+	FlagChainBNode    = "chain-b-node"
+	FlagStateFile     = "state-file"
+	FlagStatusAddr    = "status-addr"
+	FlagMaxMsgs       = "max-msgs"
 )
 
+// how often the relayer checks for work when no new block comes in
+const relayInterval = 30 * time.Second
+
+// chain is one of the two chains the relayer connects
+type chain struct {
+	id   string
+	node string
+	ctx  context.CoreContext
+}
+
+// end is one end of the channel the relayer relays over, with the light
+// client verifying the other chain on its chain
+type end struct {
+	*chain
+	port    string
+	channel string
+	client  string
+}
+
+func (e end) String() string {
+	return fmt.Sprintf("%s:%s/%s", e.id, e.port, e.channel)
+}
+
+// path relays the packets sent from src to dest, and settles them on src with
+// the receipts written by dest. Packets which time out are still relayed, and
+// refunded on src by the failed receipt they get on dest.
+type path struct {
+	src  end
+	dest end
+
+	failures int
+	retryAt  time.Time
+}
+
+func (p *path) String() string {
+	return fmt.Sprintf("%s->%s", p.src, p.dest)
+}
+
 type relayCommander struct {
-	cdc       *wire.Codec
-	address   sdk.Address
-	decoder   auth.AccountDecoder
-	mainStore string
-	ibcStore  string
-	accStore  string
+	cdc        *wire.Codec
+	address    sdk.Address
+	name       string
+	passphrase string
+	ibcStore   string
+	maxMsgs    int64
+	state      *relayState
 
 	logger log.Logger
 }
@@ -39,160 +89,454 @@ type relayCommander struct {
 // IBC relay command
 func IBCRelayCmd(cdc *wire.Codec) *cobra.Command {
 	cmdr := relayCommander{
-		cdc:       cdc,
-		decoder:   authcmd.GetAccountDecoder(cdc),
-		ibcStore:  "ibc",
-		mainStore: "main",
-		accStore:  "acc",
+		cdc:      cdc,
+		ibcStore: "ibc",
 
 		logger: log.NewTMLogger(log.NewSyncWriter(os.Stdout)),
 	}
 
 	cmd := &cobra.Command{
-		Use: "relay",
-		Run: cmdr.runIBCRelay,
+		Use:   "relay",
+		Short: "relay IBC packets and their receipts over a channel between two chains",
+		RunE:  cmdr.runIBCRelay,
 	}
 
-	cmd.Flags().String(FlagFromChainID, "", "Chain ID for ibc node to check outgoing packets")
-	cmd.Flags().String(FlagFromChainNode, "tcp://localhost:26657", "<host>:<port> to tepleton rpc interface for this chain")
-	cmd.Flags().String(FlagToChainID, "", "Chain ID for ibc node to broadcast incoming packets")
-	cmd.Flags().String(FlagToChainNode, "tcp://localhost:36657", "<host>:<port> to tepleton rpc interface for this chain")
-
-	cmd.MarkFlagRequired(FlagFromChainID)
-	cmd.MarkFlagRequired(FlagFromChainNode)
-	cmd.MarkFlagRequired(FlagToChainID)
-	cmd.MarkFlagRequired(FlagToChainNode)
-
-	viper.BindPFlag(FlagFromChainID, cmd.Flags().Lookup(FlagFromChainID))
-	viper.BindPFlag(FlagFromChainNode, cmd.Flags().Lookup(FlagFromChainNode))
-	viper.BindPFlag(FlagToChainID, cmd.Flags().Lookup(FlagToChainID))
-	viper.BindPFlag(FlagToChainNode, cmd.Flags().Lookup(FlagToChainNode))
+	cmd.Flags().String(FlagChainAID, "", "Chain ID of the first chain")
+	cmd.Flags().String(FlagChainANode, "tcp://localhost:26657", "<host>:<port> to tepleton rpc interface for the first chain")
+	cmd.Flags().String(FlagChainAPort, ibc.PortTransfer, "Port of the channel on the first chain")
+	cmd.Flags().String(FlagChainAChannel, "", "Channel to relay over, identified by its end on the first chain")
+	cmd.Flags().String(FlagChainBID, "", "Chain ID of the second chain")
+	cmd.Flags().String(FlagChainBNode, "tcp://localhost:36657", "<host>:<port> to tepleton rpc interface for the second chain")
+	cmd.Flags().String(FlagStateFile, "", "File to persist relaying progress in, defaults to relayer/<chain-a-id>_<chain-b-id>.json in the home directory")
+	cmd.Flags().String(FlagStatusAddr, "localhost:26680", "<host>:<port> to serve the relayer status on, empty to disable")
+	cmd.Flags().Int64(FlagMaxMsgs, 20, "Maximum number of packets to relay in a single transaction")
+
+	cmd.MarkFlagRequired(FlagChainAID)
+	cmd.MarkFlagRequired(FlagChainAChannel)
+	cmd.MarkFlagRequired(FlagChainBID)
+
+	viper.BindPFlag(FlagChainAID, cmd.Flags().Lookup(FlagChainAID))
+	viper.BindPFlag(FlagChainANode, cmd.Flags().Lookup(FlagChainANode))
+	viper.BindPFlag(FlagChainAPort, cmd.Flags().Lookup(FlagChainAPort))
+	viper.BindPFlag(FlagChainAChannel, cmd.Flags().Lookup(FlagChainAChannel))
+	viper.BindPFlag(FlagChainBID, cmd.Flags().Lookup(FlagChainBID))
+	viper.BindPFlag(FlagChainBNode, cmd.Flags().Lookup(FlagChainBNode))
+	viper.BindPFlag(FlagStateFile, cmd.Flags().Lookup(FlagStateFile))
+	viper.BindPFlag(FlagStatusAddr, cmd.Flags().Lookup(FlagStatusAddr))
+	viper.BindPFlag(FlagMaxMsgs, cmd.Flags().Lookup(FlagMaxMsgs))
 
 	return cmd
 }
 
 // nolint: unparam
-func (c relayCommander) runIBCRelay(cmd *cobra.Command, args []string) {
-	fromChainID := viper.GetString(FlagFromChainID)
-	fromChainNode := viper.GetString(FlagFromChainNode)
-	toChainID := viper.GetString(FlagToChainID)
-	toChainNode := viper.GetString(FlagToChainNode)
-	address, err := context.NewCoreContextFromViper().GetFromAddress()
+func (c relayCommander) runIBCRelay(cmd *cobra.Command, args []string) error {
+	ctx := context.NewCoreContextFromViper()
+	address, err := ctx.GetFromAddress()
 	if err != nil {
-		panic(err)
+		return err
 	}
 	c.address = address
+	c.name = ctx.FromAddressName
+
+	c.passphrase, err = ctx.GetPassphraseFromStdin(ctx.FromAddressName)
+	if err != nil {
+		return err
+	}
 
-	c.loop(fromChainID, fromChainNode, toChainID, toChainNode)
+	c.maxMsgs = viper.GetInt64(FlagMaxMsgs)
+	if c.maxMsgs <= 0 {
+		return errors.Errorf("--%s must be positive", FlagMaxMsgs)
+	}
+
+	chainA := newChain(c.cdc, viper.GetString(FlagChainAID), viper.GetString(FlagChainANode))
+	chainB := newChain(c.cdc, viper.GetString(FlagChainBID), viper.GetString(FlagChainBNode))
+	if chainA.id == chainB.id {
+		return errors.New("cannot relay between a chain and itself")
+	}
+
+	endA, endB, err := c.channelEnds(chainA, viper.GetString(FlagChainAPort), viper.GetString(FlagChainAChannel), chainB)
+	if err != nil {
+		return err
+	}
+
+	stateFile := viper.GetString(FlagStateFile)
+	if stateFile == "" {
+		stateFile = filepath.Join(viper.GetString(cli.HomeFlag), "relayer",
+			fmt.Sprintf("%s_%s_%s_%s.json", chainA.id, endA.port, endA.channel, chainB.id))
+	}
+	c.state, err = loadRelayState(stateFile)
+	if err != nil {
+		return err
+	}
+
+	if addr := viper.GetString(FlagStatusAddr); addr != "" {
+		go c.serveStatus(addr)
+	}
+
+	c.loop([]*path{{src: endA, dest: endB}, {src: endB, dest: endA}})
+	return nil
 }
 
-func (c relayCommander) loop(fromChainID, fromChainNode, toChainID,
-	toChainNode string) {
+func newChain(cdc *wire.Codec, id, node string) *chain {
+	return &chain{
+		id:   id,
+		node: node,
+		ctx: context.NewCoreContextFromViper().
+			WithChainID(id).
+			WithNodeURI(node).
+			WithDecoder(authcmd.GetAccountDecoder(cdc)),
+	}
+}
 
-	ctx := context.NewCoreContextFromViper()
-	// get password
-	passphrase, err := ctx.GetPassphraseFromStdin(ctx.FromAddressName)
+// channelEnds returns both ends of the channel on port of chain a, which must
+// be open and lead to chain b.
+func (c relayCommander) channelEnds(a *chain, port, channelID string, b *chain) (end, end, error) {
+	e := end{chain: a, port: port, channel: channelID}
+	channel, conn, err := queryChannel(a.ctx, c.cdc, c.ibcStore, port, channelID)
 	if err != nil {
-		panic(err)
+		return end{}, end{}, err
+	}
+	if channel.State != ibc.StateOpen {
+		return end{}, end{}, errors.Errorf("channel %s is in state %s, finish its handshake first", e, channel.State)
+	}
+	if conn.CounterpartyChain != b.id {
+		return end{}, end{}, errors.Errorf("channel %s leads to chain %s, not %s", e, conn.CounterpartyChain, b.id)
+	}
+
+	e.client = conn.ClientID
+	return e, end{b, channel.CounterpartyPort, channel.CounterpartyChannelID, conn.CounterpartyClientID}, nil
+}
+
+// loop relays every path whenever one of the chains commits a block
+func (c relayCommander) loop(paths []*path) {
+	newBlock := make(chan struct{}, 1)
+	for _, p := range paths {
+		go c.subscribe(p.src.chain, newBlock)
 	}
 
-	ingressKey := ibc.IngressSequenceKey(fromChainID)
+	ticker := time.NewTicker(relayInterval)
+	defer ticker.Stop()
 
-OUTER:
 	for {
-		time.Sleep(5 * time.Second)
+		select {
+		case <-newBlock:
+		case <-ticker.C:
+		}
+
+		for _, p := range paths {
+			if time.Now().Before(p.retryAt) {
+				continue
+			}
 
-		processedbz, err := query(toChainNode, ingressKey, c.ibcStore)
+			err := c.relayPath(p)
+			if err != nil {
+				p.failures++
+				p.retryAt = time.Now().Add(backoff(p.failures))
+				c.logger.Error("error relaying", "path", p, "err", err, "retry", p.retryAt)
+				c.state.update(p.String(), func(status *pathStatus) { status.LastError = err.Error() })
+				continue
+			}
+			p.failures = 0
+		}
+
+		err := c.state.save()
 		if err != nil {
-			panic(err)
+			c.logger.Error("error saving relayer state", "err", err)
 		}
+	}
+}
+
+// subscribe signals newBlock whenever the chain commits a block, subscribing
+// again with backoff if the websocket connection fails
+func (c relayCommander) subscribe(ch *chain, newBlock chan<- struct{}) {
+	for failures := 1; ; failures++ {
+		err := subscribeNewBlocks(ch.node, newBlock)
+		c.logger.Error("error subscribing to new blocks", "chain", ch.id, "err", err)
+		time.Sleep(backoff(failures))
+	}
+}
 
-		var processed int64
-		if processedbz == nil {
-			processed = 0
-		} else if err = c.cdc.UnmarshalBinary(processedbz, &processed); err != nil {
-			panic(err)
+func subscribeNewBlocks(node string, newBlock chan<- struct{}) error {
+	client := rpcclient.NewHTTP(node, "/websocket")
+	err := client.Start()
+	if err != nil {
+		return err
+	}
+	defer client.Stop()
+
+	events := make(chan interface{})
+	err = client.Subscribe(gocontext.Background(), "ibc-relayer", tmtypes.EventQueryNewBlockHeader, events)
+	if err != nil {
+		return err
+	}
+	for range events {
+		// one pending signal is enough, the loop relays everything at once
+		select {
+		case newBlock <- struct{}{}:
+		default:
 		}
+	}
+	return errors.New("new block subscription closed")
+}
+
+// backoff returns how long to wait after consecutive failures
+func backoff(failures int) time.Duration {
+	if failures > 6 {
+		failures = 6
+	}
+	return time.Second << uint(failures)
+}
+
+// relayPath relays the pending packets of the path to its destination chain,
+// then settles the packets the destination chain has processed on its source
+// chain. Headers commit to the state of the previous height, so only what is
+// stored at that height can be proven.
+func (c relayCommander) relayPath(p *path) error {
+	srcHeader, srcCommit, srcValset, err := getHeader(p.src.chain)
+	if err != nil {
+		return err
+	}
+	sent, err := c.queryInt64(p.src.chain, ibc.EgressLengthKey(p.src.port, p.src.channel), srcHeader.Height-1)
+	if err != nil {
+		return err
+	}
+	received, err := c.queryInt64(p.dest.chain, ibc.IngressSequenceKey(p.dest.port, p.dest.channel), 0)
+	if err != nil {
+		return err
+	}
 
-		lengthKey := ibc.EgressLengthKey(toChainID)
-		egressLengthbz, err := query(fromChainNode, lengthKey, c.ibcStore)
+	if received < sent {
+		to := min(sent, received+c.maxMsgs)
+		err = c.relayPackets(p, srcHeader, srcCommit, srcValset, received, to)
 		if err != nil {
-			c.logger.Error("error querying outgoing packet list length", "err", err)
-			continue OUTER //TODO replace with continue (I think it should just to the correct place where OUTER is now)
+			return err
 		}
-		var egressLength int64
-		if egressLengthbz == nil {
-			egressLength = 0
-		} else if err = c.cdc.UnmarshalBinary(egressLengthbz, &egressLength); err != nil {
-			panic(err)
-		}
-		if egressLength > processed {
-			c.logger.Info("Detected IBC packet", "number", egressLength-1)
+		c.logger.Info("Relayed IBC packets", "path", p, "from", received, "to", to-1)
+		received = to
+	}
+
+	// the receipts of the packets just relayed are acknowledged once they
+	// can be proven
+	destHeader, destCommit, destValset, err := getHeader(p.dest.chain)
+	if err != nil {
+		return err
+	}
+	provable, err := c.queryInt64(p.dest.chain, ibc.IngressSequenceKey(p.dest.port, p.dest.channel), destHeader.Height-1)
+	if err != nil {
+		return err
+	}
+	acknowledged := c.state.get(p.String()).Acknowledged
+	if acknowledged < provable {
+		to := min(provable, acknowledged+c.maxMsgs)
+		err = c.relayAcknowledgements(p, destHeader, destCommit, destValset, acknowledged, to)
+		if err != nil {
+			return err
 		}
+		c.logger.Info("Acknowledged IBC packets", "path", p, "from", acknowledged, "to", to-1)
+		acknowledged = to
+	}
 
-		seq := c.getSequence(toChainNode)
+	c.state.update(p.String(), func(status *pathStatus) {
+		status.Sent = sent
+		status.Received = received
+		status.Acknowledged = acknowledged
+		status.Lag = sent - received
+		status.LastError = ""
+		status.UpdatedAt = time.Now()
+	})
+	return nil
+}
 
-		for i := processed; i < egressLength; i++ {
-			egressbz, err := query(fromChainNode, ibc.EgressKey(toChainID, i), c.ibcStore)
-			if err != nil {
-				c.logger.Error("error querying egress packet", "err", err)
-				continue OUTER // TODO replace to break, will break first loop then send back to the beginning (aka OUTER)
-			}
+// relayPackets posts the packets with sequences [from, to) to the
+// destination chain in a single transaction, with their proofs against the
+// header of the source chain
+func (c relayCommander) relayPackets(p *path, header tmtypes.Header, commit *tmtypes.Commit,
+	valset *tmtypes.ValidatorSet, from, to int64) error {
 
-			err = c.broadcastTx(seq, toChainNode, c.refine(egressbz, i, passphrase))
-			seq++
-			if err != nil {
-				c.logger.Error("error broadcasting ingress packet", "err", err)
-				continue OUTER // TODO replace to break, will break first loop then send back to the beginning (aka OUTER)
-			}
+	msgs, err := c.clientMsgs(p.dest.chain, p.dest.client, header, commit, valset)
+	if err != nil {
+		return err
+	}
 
-			c.logger.Info("Relayed IBC packet", "number", i)
+	// the header commits to the state of the previous height
+	for seq := from; seq < to; seq++ {
+		bz, proof, err := queryWithProof(p.src.chain, ibc.EgressKey(p.src.port, p.src.channel, seq), c.ibcStore, header.Height-1)
+		if err != nil {
+			return err
 		}
+		var packet ibc.IBCPacket
+		err = c.cdc.UnmarshalBinary(bz, &packet)
+		if err != nil {
+			return errors.Wrapf(err, "decoding packet %d", seq)
+		}
+
+		msgs = append(msgs, ibc.IBCReceiveMsg{
+			IBCPacket: packet,
+			Relayer:   c.address,
+			Sequence:  seq,
+			Proof:     proof,
+			Height:    header.Height,
+		})
 	}
-}
 
-func query(node string, key []byte, storeName string) (res []byte, err error) {
-	return context.NewCoreContextFromViper().WithNodeURI(node).QueryStore(key, storeName)
+	return c.broadcast(p.dest.chain, msgs)
 }
 
-func (c relayCommander) broadcastTx(seq int64, node string, tx []byte) error {
-	_, err := context.NewCoreContextFromViper().WithNodeURI(node).WithSequence(seq + 1).BroadcastTx(tx)
-	return err
-}
+// relayAcknowledgements posts the receipts of the packets with sequences
+// [from, to) to the source chain in a single transaction, with their proofs
+// against the header of the destination chain
+func (c relayCommander) relayAcknowledgements(p *path, header tmtypes.Header, commit *tmtypes.Commit,
+	valset *tmtypes.ValidatorSet, from, to int64) error {
 
-func (c relayCommander) getSequence(node string) int64 {
-	res, err := query(node, c.address, c.accStore)
+	msgs, err := c.clientMsgs(p.src.chain, p.src.client, header, commit, valset)
 	if err != nil {
-		panic(err)
+		return err
+	}
+	nClientMsgs := len(msgs)
+
+	for seq := from; seq < to; seq++ {
+		// packets which already timed out on the source chain are settled
+		settled, err := query(p.src.chain, ibc.SettledKey(p.src.port, p.src.channel, seq), c.ibcStore)
+		if err != nil {
+			return err
+		}
+		if settled != nil {
+			continue
+		}
+
+		bz, proof, err := queryWithProof(p.dest.chain, ibc.ReceiptKey(p.dest.port, p.dest.channel, seq), c.ibcStore, header.Height-1)
+		if err != nil {
+			return err
+		}
+		var receipt ibc.Receipt
+		err = c.cdc.UnmarshalBinary(bz, &receipt)
+		if err != nil {
+			return errors.Wrapf(err, "decoding receipt %d", seq)
+		}
+
+		msgs = append(msgs, ibc.MsgAcknowledgement{
+			Port:      p.src.port,
+			ChannelID: p.src.channel,
+			Sequence:  seq,
+			Receipt:   receipt,
+			Proof:     proof,
+			Height:    header.Height,
+			Relayer:   c.address,
+		})
 	}
-	if nil != res {
-		account, err := c.decoder(res)
+
+	if len(msgs) > nClientMsgs {
+		err = c.broadcast(p.src.chain, msgs)
 		if err != nil {
-			panic(err)
+			return err
 		}
+	}
+
+	c.state.update(p.String(), func(status *pathStatus) { status.Acknowledged = to })
+	return nil
+}
+
+// clientMsgs returns the message moving the light client of the header's
+// chain on target forward to header, if needed
+func (c relayCommander) clientMsgs(target *chain, clientID string, header tmtypes.Header, commit *tmtypes.Commit,
+	valset *tmtypes.ValidatorSet) ([]sdk.Msg, error) {
+
+	cs, err := queryClient(target.ctx, c.cdc, c.ibcStore, clientID)
+	if err != nil {
+		return nil, err
+	}
+	if cs.ChainID != header.ChainID {
+		return nil, errors.Errorf("light client %s on %s tracks chain %s, not %s", clientID, target.id, cs.ChainID, header.ChainID)
+	}
+	switch {
+	case cs.Height == header.Height:
+		return nil, nil
+	case cs.Height > header.Height:
+		return nil, errors.Errorf("light client %s on %s is ahead of height %d", clientID, target.id, header.Height)
+	}
+
+	return []sdk.Msg{ibc.MsgUpdateClient{
+		ClientID:   clientID,
+		Header:     header,
+		Commit:     commit,
+		Validators: valset,
+		Signer:     c.address,
+	}}, nil
+}
+
+// broadcast signs msgs into a single transaction and broadcasts it to target
+func (c relayCommander) broadcast(target *chain, msgs []sdk.Msg) error {
+	accnum, err := target.ctx.GetAccountNumber(c.address)
+	if err != nil {
+		return err
+	}
+	seq, err := target.ctx.NextSequence(c.address)
+	if err != nil {
+		return err
+	}
 
-		return account.GetSequence()
+	ctx := target.ctx.WithAccountNumber(accnum).WithSequence(seq)
+	ctx, err = ctx.EnsureGas(c.name, c.passphrase, msgs, c.cdc)
+	if err != nil {
+		return err
+	}
+	txBytes, err := ctx.SignAndBuild(c.name, c.passphrase, msgs, c.cdc)
+	if err != nil {
+		return err
 	}
 
-	return 0
+	_, err = ctx.BroadcastTx(txBytes)
+	return err
 }
 
-func (c relayCommander) refine(bz []byte, sequence int64, passphrase string) []byte {
-	var packet ibc.IBCPacket
-	if err := c.cdc.UnmarshalBinary(bz, &packet); err != nil {
-		panic(err)
+func query(ch *chain, key []byte, storeName string) (res []byte, err error) {
+	return ch.ctx.QueryStore(key, storeName)
+}
+
+// queryInt64 reads an int64 at height, or at the latest height if it is 0
+func (c relayCommander) queryInt64(ch *chain, key []byte, height int64) (res int64, err error) {
+	bz, err := ch.ctx.WithHeight(height).QueryStore(key, c.ibcStore)
+	if err != nil || bz == nil {
+		return 0, err
 	}
+	err = c.cdc.UnmarshalBinary(bz, &res)
+	return res, err
+}
 
-	msg := ibc.IBCReceiveMsg{
-		IBCPacket: packet,
-		Relayer:   c.address,
-		Sequence:  sequence,
+func queryWithProof(ch *chain, key []byte, storeName string, height int64) (res []byte, proof []byte, err error) {
+	res, proof, _, err = ch.ctx.WithHeight(height).QueryStoreWithProof(key, storeName)
+	if err == nil && res == nil {
+		err = errors.Errorf("no value for key %s at height %d on %s", key, height, ch.id)
 	}
+	return
+}
 
-	ctx := context.NewCoreContextFromViper().WithSequence(sequence)
-	res, err := ctx.SignAndBuild(ctx.FromAddressName, passphrase, []sdk.Msg{msg}, c.cdc)
+// getHeader returns the latest header of the chain along with its commit and
+// the validator set which signed it
+func getHeader(ch *chain) (header tmtypes.Header, commit *tmtypes.Commit, valset *tmtypes.ValidatorSet, err error) {
+	rpc, err := ch.ctx.GetNode()
 	if err != nil {
-		panic(err)
+		return
+	}
+
+	resCommit, err := rpc.Commit(nil)
+	if err != nil {
+		return
+	}
+	header = *resCommit.Header
+	commit = resCommit.Commit
+
+	resVals, err := rpc.Validators(&header.Height)
+	if err != nil {
+		return
+	}
+	valset = tmtypes.NewValidatorSet(resVals.Validators)
+	return
+}
+
+func min(a, b int64) int64 {
+	if a < b {
+		return a
 	}
-	return res
+	return b
 }
@@ -0,0 +1,110 @@
+package cli
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	cmn "github.com/tepleton/tmlibs/common"
+)
+
+// pathStatus is the relaying progress of one direction, e.g. "chainA->chainB"
+type pathStatus struct {
+	Sent         int64     `json:"sent"`         // packets sent by the source chain
+	Received     int64     `json:"received"`     // packets received by the destination chain
+	Acknowledged int64     `json:"acknowledged"` // packets settled on the source chain
+	Lag          int64     `json:"lag"`          // packets waiting to be relayed
+	LastError    string    `json:"last_error,omitempty"`
+	UpdatedAt    time.Time `json:"updated_at"`
+}
+
+// relayState is the progress of the relayer, persisted to disk between runs
+// and served over HTTP
+type relayState struct {
+	mtx   sync.Mutex
+	file  string
+	Paths map[string]*pathStatus `json:"paths"`
+}
+
+func loadRelayState(file string) (*relayState, error) {
+	state := &relayState{
+		file:  file,
+		Paths: make(map[string]*pathStatus),
+	}
+
+	bz, err := ioutil.ReadFile(file)
+	if os.IsNotExist(err) {
+		return state, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	err = json.Unmarshal(bz, state)
+	if err != nil {
+		return nil, err
+	}
+	return state, nil
+}
+
+// get returns a copy of the status of the path
+func (s *relayState) get(path string) pathStatus {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	if status, ok := s.Paths[path]; ok {
+		return *status
+	}
+	return pathStatus{}
+}
+
+func (s *relayState) update(path string, fn func(*pathStatus)) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	status, ok := s.Paths[path]
+	if !ok {
+		status = &pathStatus{}
+		s.Paths[path] = status
+	}
+	fn(status)
+}
+
+func (s *relayState) save() error {
+	s.mtx.Lock()
+	bz, err := json.MarshalIndent(s, "", "  ")
+	s.mtx.Unlock()
+	if err != nil {
+		return err
+	}
+
+	err = os.MkdirAll(filepath.Dir(s.file), 0700)
+	if err != nil {
+		return err
+	}
+	return cmn.WriteFileAtomic(s.file, bz, 0600)
+}
+
+// serveStatus serves the progress of every path as JSON under /status
+func (c relayCommander) serveStatus(addr string) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/status", func(w http.ResponseWriter, r *http.Request) {
+		c.state.mtx.Lock()
+		bz, err := json.MarshalIndent(c.state, "", "  ")
+		c.state.mtx.Unlock()
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			w.Write([]byte(err.Error()))
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(bz)
+	})
+
+	c.logger.Info("Serving relayer status", "addr", addr)
+	err := http.ListenAndServe(addr, mux)
+	if err != nil {
+		c.logger.Error("error serving relayer status", "err", err)
+	}
+}
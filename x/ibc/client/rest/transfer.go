@@ -8,6 +8,7 @@ import (
 	"github.com/tepleton/tepleton-sdk/crypto/keys"
 	"github.com/gorilla/mux"
 
+	"github.com/tepleton/tepleton-sdk/client"
 	"github.com/tepleton/tepleton-sdk/client/context"
 	sdk "github.com/tepleton/tepleton-sdk/types"
 	"github.com/tepleton/tepleton-sdk/wire"
@@ -17,6 +18,7 @@ import (
 // RegisterRoutes - Central function to define routes that get registered by the main application
 func RegisterRoutes(ctx context.CoreContext, r *mux.Router, cdc *wire.Codec, kb keys.Keybase) {
 	r.HandleFunc("/ibc/{destchain}/{address}/send", TransferRequestHandlerFn(cdc, kb, ctx)).Methods("POST")
+	r.HandleFunc("/ibc/escrow/{port}/{channel}", EscrowRequestHandlerFn(cdc, ctx)).Methods("GET")
 }
 
 type transferBody struct {
@@ -24,10 +26,11 @@ type transferBody struct {
 	Amount           sdk.Coins `json:"amount"`
 	LocalAccountName string    `json:"name"`
 	Password         string    `json:"password"`
-	SrcChainID       string    `json:"src_chain_id"`
+	SrcChannel       string    `json:"src_channel"`
 	AccountNumber    int64     `json:"account_number"`
 	Sequence         int64     `json:"sequence"`
-	Gas              int64     `json:"gas"`
+	Gas              string    `json:"gas"`
+	Timeout          int64     `json:"timeout"`
 }
 
 // TransferRequestHandler - http request handler to transfer coins to a address
@@ -76,15 +79,33 @@ func TransferRequestHandlerFn(cdc *wire.Codec, kb keys.Keybase, ctx context.Core
 		to := sdk.Address(bz)
 
 		// build message
-		packet := ibc.NewIBCPacket(info.GetPubKey().Address(), to, m.Amount, m.SrcChainID, destChainID)
-		msg := ibc.IBCTransferMsg{packet}
+		msg := ibc.IBCTransferMsg{
+			SrcAddr:    info.GetPubKey().Address(),
+			DestAddr:   to,
+			Coins:      m.Amount,
+			SrcChannel: m.SrcChannel,
+			DestChain:  destChainID,
+			Timeout:    m.Timeout,
+		}
 
 		// add gas to context
-		ctx = ctx.WithGas(m.Gas)
+		simulate, gas, err := client.ParseGas(m.Gas)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(err.Error()))
+			return
+		}
+		ctx = ctx.WithGas(gas).WithSimulate(simulate)
 
 		// sign
 		ctx = ctx.WithAccountNumber(m.AccountNumber)
 		ctx = ctx.WithSequence(m.Sequence)
+		ctx, err = ctx.EnsureGas(m.LocalAccountName, m.Password, []sdk.Msg{msg}, cdc)
+		if err != nil {
+			w.WriteHeader(http.StatusUnauthorized)
+			w.Write([]byte(err.Error()))
+			return
+		}
 		txBytes, err := ctx.SignAndBuild(m.LocalAccountName, m.Password, []sdk.Msg{msg}, cdc)
 		if err != nil {
 			w.WriteHeader(http.StatusUnauthorized)
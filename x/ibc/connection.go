@@ -0,0 +1,284 @@
+package ibc
+
+import (
+	"regexp"
+
+	sdk "github.com/tepleton/tepleton-sdk/types"
+)
+
+// State is the stage of the opening handshake a connection or channel is at.
+// The chain which starts the handshake goes from StateInit to StateOpen, its
+// counterparty from StateTryOpen to StateOpen.
+type State byte
+
+// nolint
+const (
+	StateInit    State = 0x01
+	StateTryOpen State = 0x02
+	StateOpen    State = 0x03
+)
+
+// String implements the Stringer interface.
+func (s State) String() string {
+	switch s {
+	case StateInit:
+		return "Init"
+	case StateTryOpen:
+		return "TryOpen"
+	case StateOpen:
+		return "Open"
+	default:
+		return ""
+	}
+}
+
+var reIdentifier = regexp.MustCompile(`^[[:alnum:]\-_.]{1,64}$`)
+
+// validateIdentifier checks a chain, client, connection, channel or port
+// identifier, which is used in store keys and must not contain a separator.
+func validateIdentifier(kind string, id string) sdk.Error {
+	if !reIdentifier.MatchString(id) {
+		return ErrInvalidIdentifier(DefaultCodespace, kind, id)
+	}
+	return nil
+}
+
+// ------------------------------
+// Connection
+
+// Connection links this chain to a counterparty chain, whose light client
+// verifies the proofs of everything sent over the connection. Both ends of a
+// connection know the identifiers of the other end and of the light client
+// it verifies this chain with.
+type Connection struct {
+	ID                       string
+	ClientID                 string
+	CounterpartyChain        string
+	CounterpartyClientID     string
+	CounterpartyConnectionID string
+	State                    State
+}
+
+// ----------------------------------
+// MsgConnectionOpenInit
+
+// MsgConnectionOpenInit starts the handshake of a connection to the
+// counterparty chain tracked by the light client ClientID. The counterparty
+// chain tracks this chain with CounterpartyClientID.
+type MsgConnectionOpenInit struct {
+	ConnectionID             string
+	ClientID                 string
+	CounterpartyClientID     string
+	CounterpartyConnectionID string
+	Signer                   sdk.Address
+}
+
+// nolint
+func (msg MsgConnectionOpenInit) Type() string              { return "ibc" }
+func (msg MsgConnectionOpenInit) GetSigners() []sdk.Address { return []sdk.Address{msg.Signer} }
+
+// get the sign bytes for connection open init message
+func (msg MsgConnectionOpenInit) GetSignBytes() []byte {
+	b, err := msgCdc.MarshalJSON(struct {
+		ConnectionID             string
+		ClientID                 string
+		CounterpartyClientID     string
+		CounterpartyConnectionID string
+		Signer                   string
+	}{
+		ConnectionID:             msg.ConnectionID,
+		ClientID:                 msg.ClientID,
+		CounterpartyClientID:     msg.CounterpartyClientID,
+		CounterpartyConnectionID: msg.CounterpartyConnectionID,
+		Signer:                   sdk.MustBech32ifyAcc(msg.Signer),
+	})
+	if err != nil {
+		panic(err)
+	}
+	return b
+}
+
+// validate connection open init message
+func (msg MsgConnectionOpenInit) ValidateBasic() sdk.Error {
+	return validateConnectionMsg(msg.ConnectionID, msg.ClientID, msg.CounterpartyClientID, msg.CounterpartyConnectionID, msg.Signer)
+}
+
+// ----------------------------------
+// MsgConnectionOpenTry
+
+// MsgConnectionOpenTry answers the handshake started on the counterparty
+// chain tracked by the light client ClientID. Proof proves the counterparty
+// connection in StateInit against the app hash of the counterparty header at
+// Height.
+type MsgConnectionOpenTry struct {
+	ConnectionID             string
+	ClientID                 string
+	CounterpartyClientID     string
+	CounterpartyConnectionID string
+	Proof                    []byte
+	Height                   int64
+	Signer                   sdk.Address
+}
+
+// nolint
+func (msg MsgConnectionOpenTry) Type() string              { return "ibc" }
+func (msg MsgConnectionOpenTry) GetSigners() []sdk.Address { return []sdk.Address{msg.Signer} }
+
+// get the sign bytes for connection open try message
+func (msg MsgConnectionOpenTry) GetSignBytes() []byte {
+	b, err := msgCdc.MarshalJSON(struct {
+		ConnectionID             string
+		ClientID                 string
+		CounterpartyClientID     string
+		CounterpartyConnectionID string
+		Proof                    []byte
+		Height                   int64
+		Signer                   string
+	}{
+		ConnectionID:             msg.ConnectionID,
+		ClientID:                 msg.ClientID,
+		CounterpartyClientID:     msg.CounterpartyClientID,
+		CounterpartyConnectionID: msg.CounterpartyConnectionID,
+		Proof:                    msg.Proof,
+		Height:                   msg.Height,
+		Signer:                   sdk.MustBech32ifyAcc(msg.Signer),
+	})
+	if err != nil {
+		panic(err)
+	}
+	return b
+}
+
+// validate connection open try message
+func (msg MsgConnectionOpenTry) ValidateBasic() sdk.Error {
+	err := validateConnectionMsg(msg.ConnectionID, msg.ClientID, msg.CounterpartyClientID, msg.CounterpartyConnectionID, msg.Signer)
+	if err != nil {
+		return err
+	}
+	return validateHandshakeProof(msg.Proof, msg.Height)
+}
+
+// ----------------------------------
+// MsgConnectionOpenAck
+
+// MsgConnectionOpenAck opens a connection in StateInit once the counterparty
+// has answered the handshake. Proof proves the counterparty connection in
+// StateTryOpen against the app hash of the counterparty header at Height.
+type MsgConnectionOpenAck struct {
+	ConnectionID string
+	Proof        []byte
+	Height       int64
+	Signer       sdk.Address
+}
+
+// nolint
+func (msg MsgConnectionOpenAck) Type() string              { return "ibc" }
+func (msg MsgConnectionOpenAck) GetSigners() []sdk.Address { return []sdk.Address{msg.Signer} }
+
+// get the sign bytes for connection open ack message
+func (msg MsgConnectionOpenAck) GetSignBytes() []byte {
+	return connectionProofSignBytes(msg.ConnectionID, msg.Proof, msg.Height, msg.Signer)
+}
+
+// validate connection open ack message
+func (msg MsgConnectionOpenAck) ValidateBasic() sdk.Error {
+	return validateConnectionProofMsg(msg.ConnectionID, msg.Proof, msg.Height, msg.Signer)
+}
+
+// ----------------------------------
+// MsgConnectionOpenConfirm
+
+// MsgConnectionOpenConfirm opens a connection in StateTryOpen once the
+// counterparty has opened its end. Proof proves the counterparty connection
+// in StateOpen against the app hash of the counterparty header at Height.
+type MsgConnectionOpenConfirm struct {
+	ConnectionID string
+	Proof        []byte
+	Height       int64
+	Signer       sdk.Address
+}
+
+// nolint
+func (msg MsgConnectionOpenConfirm) Type() string              { return "ibc" }
+func (msg MsgConnectionOpenConfirm) GetSigners() []sdk.Address { return []sdk.Address{msg.Signer} }
+
+// get the sign bytes for connection open confirm message
+func (msg MsgConnectionOpenConfirm) GetSignBytes() []byte {
+	return connectionProofSignBytes(msg.ConnectionID, msg.Proof, msg.Height, msg.Signer)
+}
+
+// validate connection open confirm message
+func (msg MsgConnectionOpenConfirm) ValidateBasic() sdk.Error {
+	return validateConnectionProofMsg(msg.ConnectionID, msg.Proof, msg.Height, msg.Signer)
+}
+
+// ----------------------------------
+// Helpers
+
+func connectionProofSignBytes(connectionID string, proof []byte, height int64, signer sdk.Address) []byte {
+	b, err := msgCdc.MarshalJSON(struct {
+		ConnectionID string
+		Proof        []byte
+		Height       int64
+		Signer       string
+	}{
+		ConnectionID: connectionID,
+		Proof:        proof,
+		Height:       height,
+		Signer:       sdk.MustBech32ifyAcc(signer),
+	})
+	if err != nil {
+		panic(err)
+	}
+	return b
+}
+
+func validateConnectionMsg(connectionID, clientID, counterpartyClientID, counterpartyConnectionID string,
+	signer sdk.Address) sdk.Error {
+
+	err := validateIdentifier("connection", connectionID)
+	if err != nil {
+		return err
+	}
+	err = validateIdentifier("client", clientID)
+	if err != nil {
+		return err
+	}
+	err = validateIdentifier("client", counterpartyClientID)
+	if err != nil {
+		return err
+	}
+	err = validateIdentifier("connection", counterpartyConnectionID)
+	if err != nil {
+		return err
+	}
+	if len(signer) == 0 {
+		return sdk.ErrInvalidAddress("signer address is empty")
+	}
+	return nil
+}
+
+func validateConnectionProofMsg(connectionID string, proof []byte, height int64, signer sdk.Address) sdk.Error {
+	err := validateIdentifier("connection", connectionID)
+	if err != nil {
+		return err
+	}
+	err = validateHandshakeProof(proof, height)
+	if err != nil {
+		return err
+	}
+	if len(signer) == 0 {
+		return sdk.ErrInvalidAddress("signer address is empty")
+	}
+	return nil
+}
+
+func validateHandshakeProof(proof []byte, height int64) sdk.Error {
+	if len(proof) == 0 {
+		return ErrInvalidProof(DefaultCodespace, "missing IBC handshake proof")
+	}
+	if height <= 0 {
+		return ErrInvalidProof(DefaultCodespace, "IBC handshake proof has no height")
+	}
+	return nil
+}
@@ -0,0 +1,92 @@
+package ibc
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	sdk "github.com/tepleton/tepleton-sdk/types"
+)
+
+func TestValidateIdentifier(t *testing.T) {
+	cases := []struct {
+		valid bool
+		id    string
+	}{
+		{true, "channel-0"},
+		{true, "conn_a.1"},
+		{false, ""},
+		{false, "channel/0"},
+		{false, "channel 0"},
+		{false, string(make([]byte, 65))},
+	}
+
+	for i, tc := range cases {
+		err := validateIdentifier("channel", tc.id)
+		if tc.valid {
+			require.Nil(t, err, "%d: %+v", i, err)
+		} else {
+			require.NotNil(t, err, "%d", i)
+		}
+	}
+}
+
+func TestMsgConnectionOpenValidation(t *testing.T) {
+	signer := sdk.Address([]byte("signer"))
+	proof := []byte("proof")
+	cases := []struct {
+		valid bool
+		msg   sdk.Msg
+	}{
+		{true, MsgConnectionOpenInit{"conn-a", "client-0", "client-1", "conn-b", signer}},
+		{false, MsgConnectionOpenInit{"conn-a", "", "client-1", "conn-b", signer}},
+		{false, MsgConnectionOpenInit{"conn-a", "client-0", "client/1", "conn-b", signer}},
+		{false, MsgConnectionOpenInit{"conn-a", "client-0", "client-1", "", signer}},
+		{false, MsgConnectionOpenInit{"conn-a", "client-0", "client-1", "conn-b", nil}},
+		{true, MsgConnectionOpenTry{"conn-a", "client-0", "client-1", "conn-b", proof, 1, signer}},
+		{false, MsgConnectionOpenTry{"conn-a", "client-0", "client-1", "conn-b", nil, 1, signer}},
+		{false, MsgConnectionOpenTry{"conn-a", "client-0", "client-1", "conn-b", proof, 0, signer}},
+		{true, MsgConnectionOpenAck{"conn-a", proof, 1, signer}},
+		{false, MsgConnectionOpenAck{"conn/a", proof, 1, signer}},
+		{true, MsgConnectionOpenConfirm{"conn-a", proof, 1, signer}},
+		{false, MsgConnectionOpenConfirm{"conn-a", proof, 1, nil}},
+	}
+
+	for i, tc := range cases {
+		err := tc.msg.ValidateBasic()
+		if tc.valid {
+			require.Nil(t, err, "%d: %+v", i, err)
+		} else {
+			require.NotNil(t, err, "%d", i)
+		}
+	}
+}
+
+func TestMsgChannelOpenValidation(t *testing.T) {
+	signer := sdk.Address([]byte("signer"))
+	proof := []byte("proof")
+	cases := []struct {
+		valid bool
+		msg   sdk.Msg
+	}{
+		{true, MsgChannelOpenInit{PortTransfer, "channel-0", "conn-a", PortTransfer, "channel-1", signer}},
+		{false, MsgChannelOpenInit{"", "channel-0", "conn-a", PortTransfer, "channel-1", signer}},
+		{false, MsgChannelOpenInit{PortTransfer, "channel-0", "", PortTransfer, "channel-1", signer}},
+		{false, MsgChannelOpenInit{PortTransfer, "channel-0", "conn-a", PortTransfer, "", signer}},
+		{true, MsgChannelOpenTry{PortTransfer, "channel-0", "conn-a", PortTransfer, "channel-1", proof, 1, signer}},
+		{false, MsgChannelOpenTry{PortTransfer, "channel-0", "conn-a", PortTransfer, "channel-1", proof, 0, signer}},
+		{true, MsgChannelOpenAck{PortTransfer, "channel-0", proof, 1, signer}},
+		{false, MsgChannelOpenAck{PortTransfer, "channel-0", nil, 1, signer}},
+		{true, MsgChannelOpenConfirm{PortTransfer, "channel-0", proof, 1, signer}},
+		{false, MsgChannelOpenConfirm{PortTransfer, "channel-0", proof, 1, nil}},
+	}
+
+	for i, tc := range cases {
+		err := tc.msg.ValidateBasic()
+		if tc.valid {
+			require.Nil(t, err, "%d: %+v", i, err)
+		} else {
+			require.NotNil(t, err, "%d", i)
+		}
+	}
+}
@@ -1,6 +1,8 @@
 package ibc
 
 import (
+	"fmt"
+
 	sdk "github.com/tepleton/tepleton-sdk/types"
 )
 
@@ -9,9 +11,28 @@ const (
 	DefaultCodespace sdk.CodespaceType = 3
 
 	// IBC errors reserve 200 - 299.
-	CodeInvalidSequence sdk.CodeType = 200
-	CodeIdenticalChains sdk.CodeType = 201
-	CodeUnknownRequest  sdk.CodeType = sdk.CodeUnknownRequest
+	CodeInvalidSequence   sdk.CodeType = 200
+	CodeIdenticalChains   sdk.CodeType = 201
+	CodeInvalidHeader     sdk.CodeType = 202
+	CodeUnknownClient     sdk.CodeType = 204
+	CodeUnknownHeight     sdk.CodeType = 205
+	CodeInvalidProof      sdk.CodeType = 206
+	CodeWrongDestChain    sdk.CodeType = 207
+	CodeInvalidTimeout    sdk.CodeType = 208
+	CodeUnknownPacket     sdk.CodeType = 209
+	CodePacketSettled     sdk.CodeType = 210
+	CodePacketTimedOut    sdk.CodeType = 211
+	CodeNotTimedOut       sdk.CodeType = 212
+	CodeInvalidIdentifier sdk.CodeType = 213
+	CodeUnknownConnection sdk.CodeType = 214
+	CodeConnectionExists  sdk.CodeType = 215
+	CodeUnknownChannel    sdk.CodeType = 216
+	CodeChannelExists     sdk.CodeType = 217
+	CodeInvalidState      sdk.CodeType = 218
+	CodeInvalidChannel    sdk.CodeType = 219
+	CodeUnknownPort       sdk.CodeType = 220
+	CodeInvalidPacketData sdk.CodeType = 221
+	CodeUnknownRequest    sdk.CodeType = sdk.CodeUnknownRequest
 )
 
 func codeToDefaultMsg(code sdk.CodeType) string {
@@ -20,6 +41,44 @@ func codeToDefaultMsg(code sdk.CodeType) string {
 		return "invalid IBC packet sequence"
 	case CodeIdenticalChains:
 		return "source and destination chain cannot be identical"
+	case CodeInvalidHeader:
+		return "invalid counterparty chain header"
+	case CodeUnknownClient:
+		return "unknown IBC light client"
+	case CodeUnknownHeight:
+		return "no header verified by the light client at height"
+	case CodeInvalidProof:
+		return "invalid IBC packet proof"
+	case CodeWrongDestChain:
+		return "IBC packet is not addressed to this chain"
+	case CodeInvalidTimeout:
+		return "invalid IBC packet timeout"
+	case CodeUnknownPacket:
+		return "unknown IBC packet"
+	case CodePacketSettled:
+		return "IBC packet has already been settled"
+	case CodePacketTimedOut:
+		return "IBC packet has timed out"
+	case CodeNotTimedOut:
+		return "IBC packet has not timed out"
+	case CodeInvalidIdentifier:
+		return "invalid IBC identifier"
+	case CodeUnknownConnection:
+		return "unknown IBC connection"
+	case CodeConnectionExists:
+		return "IBC connection already exists"
+	case CodeUnknownChannel:
+		return "unknown IBC channel"
+	case CodeChannelExists:
+		return "IBC channel already exists"
+	case CodeInvalidState:
+		return "IBC connection or channel is not in the expected state"
+	case CodeInvalidChannel:
+		return "IBC packet does not match its channel"
+	case CodeUnknownPort:
+		return "no IBC module bound to port"
+	case CodeInvalidPacketData:
+		return "invalid IBC packet data"
 	default:
 		return sdk.CodeToDefaultMsg(code)
 	}
@@ -32,6 +91,63 @@ func ErrInvalidSequence(codespace sdk.CodespaceType) sdk.Error {
 func ErrIdenticalChains(codespace sdk.CodespaceType) sdk.Error {
 	return newError(codespace, CodeIdenticalChains, "")
 }
+func ErrInvalidHeader(codespace sdk.CodespaceType, msg string) sdk.Error {
+	return newError(codespace, CodeInvalidHeader, msg)
+}
+func ErrUnknownClient(codespace sdk.CodespaceType, clientID string) sdk.Error {
+	return newError(codespace, CodeUnknownClient, fmt.Sprintf("unknown IBC light client %s", clientID))
+}
+func ErrUnknownHeight(codespace sdk.CodespaceType, clientID string, height int64) sdk.Error {
+	return newError(codespace, CodeUnknownHeight, fmt.Sprintf("no header verified by light client %s at height %d", clientID, height))
+}
+func ErrInvalidProof(codespace sdk.CodespaceType, msg string) sdk.Error {
+	return newError(codespace, CodeInvalidProof, msg)
+}
+func ErrWrongDestChain(codespace sdk.CodespaceType, destChain string) sdk.Error {
+	return newError(codespace, CodeWrongDestChain, fmt.Sprintf("IBC packet is addressed to chain %s", destChain))
+}
+func ErrInvalidTimeout(codespace sdk.CodespaceType, timeout int64) sdk.Error {
+	return newError(codespace, CodeInvalidTimeout, fmt.Sprintf("invalid IBC packet timeout %d", timeout))
+}
+func ErrUnknownPacket(codespace sdk.CodespaceType, port, channelID string, sequence int64) sdk.Error {
+	return newError(codespace, CodeUnknownPacket, fmt.Sprintf("no IBC packet %d sent on channel %s/%s", sequence, port, channelID))
+}
+func ErrPacketSettled(codespace sdk.CodespaceType, port, channelID string, sequence int64) sdk.Error {
+	return newError(codespace, CodePacketSettled, fmt.Sprintf("IBC packet %d sent on channel %s/%s has already been settled", sequence, port, channelID))
+}
+func ErrPacketTimedOut(codespace sdk.CodespaceType, timeout int64) sdk.Error {
+	return newError(codespace, CodePacketTimedOut, fmt.Sprintf("IBC packet timed out at height %d", timeout))
+}
+func ErrNotTimedOut(codespace sdk.CodespaceType, timeout int64, height int64) sdk.Error {
+	return newError(codespace, CodeNotTimedOut, fmt.Sprintf("IBC packet times out at height %d, proof is for height %d", timeout, height))
+}
+func ErrInvalidIdentifier(codespace sdk.CodespaceType, kind string, id string) sdk.Error {
+	return newError(codespace, CodeInvalidIdentifier, fmt.Sprintf("invalid %s identifier %q", kind, id))
+}
+func ErrUnknownConnection(codespace sdk.CodespaceType, connectionID string) sdk.Error {
+	return newError(codespace, CodeUnknownConnection, fmt.Sprintf("unknown IBC connection %s", connectionID))
+}
+func ErrConnectionExists(codespace sdk.CodespaceType, connectionID string) sdk.Error {
+	return newError(codespace, CodeConnectionExists, fmt.Sprintf("IBC connection %s already exists", connectionID))
+}
+func ErrUnknownChannel(codespace sdk.CodespaceType, port, channelID string) sdk.Error {
+	return newError(codespace, CodeUnknownChannel, fmt.Sprintf("unknown IBC channel %s/%s", port, channelID))
+}
+func ErrChannelExists(codespace sdk.CodespaceType, port, channelID string) sdk.Error {
+	return newError(codespace, CodeChannelExists, fmt.Sprintf("IBC channel %s/%s already exists", port, channelID))
+}
+func ErrInvalidState(codespace sdk.CodespaceType, what string, state State, expected State) sdk.Error {
+	return newError(codespace, CodeInvalidState, fmt.Sprintf("%s is in state %s, expected %s", what, state, expected))
+}
+func ErrInvalidChannel(codespace sdk.CodespaceType, msg string) sdk.Error {
+	return newError(codespace, CodeInvalidChannel, msg)
+}
+func ErrUnknownPort(codespace sdk.CodespaceType, port string) sdk.Error {
+	return newError(codespace, CodeUnknownPort, fmt.Sprintf("no IBC module bound to port %s", port))
+}
+func ErrInvalidPacketData(codespace sdk.CodespaceType, msg string) sdk.Error {
+	return newError(codespace, CodeInvalidPacketData, msg)
+}
 
 // -------------------------
 // Helpers
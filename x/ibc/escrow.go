@@ -0,0 +1,115 @@
+package ibc
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/tepleton/tepleton/crypto/tmhash"
+
+	sdk "github.com/tepleton/tepleton-sdk/types"
+	"github.com/tepleton/tepleton-sdk/x/bank"
+)
+
+// Coins sent over a channel are locked in the escrow account of the channel,
+// and minted on the other chain as vouchers whose denomination is prefixed
+// with the port and channel they were received on, e.g.
+// "transfer/channel-0/steak". Vouchers sent back over the channel they came
+// from are burned, and the escrowed coins released on arrival. Keying both by
+// the channel keeps the coins of two channels to the same chain apart, so
+// that neither can release what was locked for the other.
+
+// EscrowAddress returns the address of the account holding the coins sent
+// over the channel on port until they come back.
+func EscrowAddress(port, channelID string) sdk.Address {
+	return sdk.Address(tmhash.Sum([]byte(fmt.Sprintf("ibc/escrow/%s/%s", port, channelID))))
+}
+
+// VoucherDenom returns the denomination of the vouchers minted for denom
+// received over the channel on port.
+func VoucherDenom(port, channelID string, denom string) string {
+	return fmt.Sprintf("%s/%s/%s", port, channelID, denom)
+}
+
+// sendCoins takes the coins sent over the channel from the sender, locking
+// them in escrow unless they are vouchers going back over the channel they
+// came from.
+func sendCoins(ctx sdk.Context, ck bank.Keeper, data TransferPacketData, port, channelID string) sdk.Error {
+	_, _, err := ck.SubtractCoins(ctx, data.SrcAddr, data.Coins)
+	if err != nil {
+		return err
+	}
+
+	escrowed, _ := splitVouchers(data.Coins, port, channelID)
+	if escrowed.IsZero() {
+		return nil
+	}
+	_, _, err = ck.AddCoins(ctx, EscrowAddress(port, channelID), escrowed)
+	return err
+}
+
+// receiveCoins credits the coins of the packet to the receiver, releasing our
+// own coins coming back from escrow and minting vouchers for any other coins.
+// Our coins come back as the vouchers the other end of the channel minted.
+func receiveCoins(ctx sdk.Context, ck bank.Keeper, data TransferPacketData, packet IBCPacket) sdk.Error {
+	foreign, returning := splitVouchers(data.Coins, packet.SrcPort, packet.SrcChannel)
+
+	released := mapDenoms(returning, func(denom string) string {
+		return strings.TrimPrefix(denom, VoucherDenom(packet.SrcPort, packet.SrcChannel, ""))
+	})
+	if !released.IsZero() {
+		_, _, err := ck.SubtractCoins(ctx, EscrowAddress(packet.DestPort, packet.DestChannel), released)
+		if err != nil {
+			return err
+		}
+	}
+
+	vouchers := mapDenoms(foreign, func(denom string) string {
+		return VoucherDenom(packet.DestPort, packet.DestChannel, denom)
+	})
+	// Refuse vouchers nested too deep, which are refunded to the sender
+	for _, coin := range vouchers {
+		if !sdk.IsValidDenom(coin.Denom) {
+			return sdk.ErrInvalidCoins(fmt.Sprintf("invalid voucher denomination %s", coin.Denom))
+		}
+	}
+	_, _, err := ck.AddCoins(ctx, data.DestAddr, released.Plus(vouchers))
+	return err
+}
+
+// refundCoins gives the coins sent over the channel which were not received
+// back to the sender, reversing sendCoins.
+func refundCoins(ctx sdk.Context, ck bank.Keeper, data TransferPacketData, port, channelID string) sdk.Error {
+	escrowed, _ := splitVouchers(data.Coins, port, channelID)
+	if !escrowed.IsZero() {
+		_, _, err := ck.SubtractCoins(ctx, EscrowAddress(port, channelID), escrowed)
+		if err != nil {
+			return err
+		}
+	}
+
+	_, _, err := ck.AddCoins(ctx, data.SrcAddr, data.Coins)
+	return err
+}
+
+// splitVouchers splits coins into the vouchers received over the channel on
+// port and the rest.
+func splitVouchers(coins sdk.Coins, port, channelID string) (others sdk.Coins, vouchers sdk.Coins) {
+	prefix := VoucherDenom(port, channelID, "")
+	for _, coin := range coins {
+		if strings.HasPrefix(coin.Denom, prefix) {
+			vouchers = append(vouchers, coin)
+		} else {
+			others = append(others, coin)
+		}
+	}
+	return
+}
+
+// mapDenoms renames the denominations of coins, keeping them sorted.
+func mapDenoms(coins sdk.Coins, rename func(string) string) sdk.Coins {
+	res := make(sdk.Coins, 0, len(coins))
+	for _, coin := range coins {
+		res = append(res, sdk.Coin{Denom: rename(coin.Denom), Amount: coin.Amount})
+	}
+	return res.Sort()
+}
@@ -1,19 +1,43 @@
 package ibc
 
 import (
+	"fmt"
 	"reflect"
 
 	sdk "github.com/tepleton/tepleton-sdk/types"
-	"github.com/tepleton/tepleton-sdk/x/bank"
 )
 
-func NewHandler(ibcm Mapper, ck bank.Keeper) sdk.Handler {
+// NewHandler returns the IBC handler, which hands the packets over to the
+// modules bound to their ports by router.
+func NewHandler(ibcm Mapper, router Router) sdk.Handler {
 	return func(ctx sdk.Context, msg sdk.Msg) sdk.Result {
 		switch msg := msg.(type) {
-		case IBCTransferMsg:
-			return handleIBCTransferMsg(ctx, ibcm, ck, msg)
 		case IBCReceiveMsg:
-			return handleIBCReceiveMsg(ctx, ibcm, ck, msg)
+			return handleIBCReceiveMsg(ctx, ibcm, router, msg)
+		case MsgAcknowledgement:
+			return handleMsgAcknowledgement(ctx, ibcm, router, msg)
+		case MsgTimeout:
+			return handleMsgTimeout(ctx, ibcm, router, msg)
+		case MsgCreateClient:
+			return handleMsgCreateClient(ctx, ibcm, msg)
+		case MsgUpdateClient:
+			return handleMsgUpdateClient(ctx, ibcm, msg)
+		case MsgConnectionOpenInit:
+			return handleMsgConnectionOpenInit(ctx, ibcm, msg)
+		case MsgConnectionOpenTry:
+			return handleMsgConnectionOpenTry(ctx, ibcm, msg)
+		case MsgConnectionOpenAck:
+			return handleMsgConnectionOpenAck(ctx, ibcm, msg)
+		case MsgConnectionOpenConfirm:
+			return handleMsgConnectionOpenConfirm(ctx, ibcm, msg)
+		case MsgChannelOpenInit:
+			return handleMsgChannelOpenInit(ctx, ibcm, router, msg)
+		case MsgChannelOpenTry:
+			return handleMsgChannelOpenTry(ctx, ibcm, router, msg)
+		case MsgChannelOpenAck:
+			return handleMsgChannelOpenAck(ctx, ibcm, msg)
+		case MsgChannelOpenConfirm:
+			return handleMsgChannelOpenConfirm(ctx, ibcm, msg)
 		default:
 			errMsg := "Unrecognized IBC Msg type: " + reflect.TypeOf(msg).Name()
 			return sdk.ErrUnknownRequest(errMsg).Result()
@@ -21,38 +45,379 @@ func NewHandler(ibcm Mapper, ck bank.Keeper) sdk.Handler {
 	}
 }
 
-// IBCTransferMsg deducts coins from the account and creates an egress IBC packet.
-func handleIBCTransferMsg(ctx sdk.Context, ibcm Mapper, ck bank.Keeper, msg IBCTransferMsg) sdk.Result {
+// IBCReceiveMsg verifies the packet against the light client of the source
+// chain, then executes it with the module bound to the destination port and
+// writes the receipt the source chain settles the packet with. A packet which
+// timed out or failed to execute is still consumed, with a failed receipt.
+func handleIBCReceiveMsg(ctx sdk.Context, ibcm Mapper, router Router, msg IBCReceiveMsg) sdk.Result {
 	packet := msg.IBCPacket
 
-	_, _, err := ck.SubtractCoins(ctx, packet.SrcAddr, packet.Coins)
+	if packet.DestChain != ctx.ChainID() {
+		return ErrWrongDestChain(ibcm.codespace, packet.DestChain).Result()
+	}
+	module := router.Route(packet.DestPort)
+	if module == nil {
+		return ErrUnknownPort(ibcm.codespace, packet.DestPort).Result()
+	}
+	_, conn, err := ibcm.getOpenChannel(ctx, packet.DestPort, packet.DestChannel,
+		packet.SrcChain, packet.SrcPort, packet.SrcChannel)
 	if err != nil {
 		return err.Result()
 	}
 
-	err = ibcm.PostIBCPacket(ctx, packet)
+	seq := ibcm.GetIngressSequence(ctx, packet.DestPort, packet.DestChannel)
+	if msg.Sequence != seq {
+		return ErrInvalidSequence(ibcm.codespace).Result()
+	}
+
+	err = ibcm.verifyPacket(ctx, conn, packet, msg.Sequence, msg.Proof, msg.Height)
 	if err != nil {
 		return err.Result()
 	}
 
+	receipt := Receipt{}
+	if packet.TimedOut(ctx.BlockHeight()) {
+		receipt.Code = ErrPacketTimedOut(ibcm.codespace, packet.Timeout).WRSPCode()
+	} else {
+		cacheCtx, write := ctx.CacheContext()
+		err = module.OnRecvPacket(cacheCtx, packet)
+		if err != nil {
+			receipt.Code = err.WRSPCode()
+		} else {
+			write()
+		}
+	}
+
+	ibcm.setReceipt(ctx, packet.DestPort, packet.DestChannel, seq, receipt)
+	ibcm.SetIngressSequence(ctx, packet.DestPort, packet.DestChannel, seq+1)
+
 	return sdk.Result{}
 }
 
-// IBCReceiveMsg adds coins to the destination address and creates an ingress IBC packet.
-func handleIBCReceiveMsg(ctx sdk.Context, ibcm Mapper, ck bank.Keeper, msg IBCReceiveMsg) sdk.Result {
-	packet := msg.IBCPacket
+// MsgAcknowledgement settles a sent packet with the proven receipt of the
+// destination chain, which is handed to the module bound to the source port.
+func handleMsgAcknowledgement(ctx sdk.Context, ibcm Mapper, router Router, msg MsgAcknowledgement) sdk.Result {
+	packet, conn, module, err := getUnsettledPacket(ctx, ibcm, router, msg.Port, msg.ChannelID, msg.Sequence)
+	if err != nil {
+		return err.Result()
+	}
 
-	seq := ibcm.GetIngressSequence(ctx, packet.SrcChain)
-	if msg.Sequence != seq {
-		return ErrInvalidSequence(ibcm.codespace).Result()
+	key := ReceiptKey(packet.DestPort, packet.DestChannel, msg.Sequence)
+	value := marshalBinaryPanic(ibcm.cdc, msg.Receipt)
+	err = ibcm.verifyMembership(ctx, conn.ClientID, msg.Height, key, value, msg.Proof)
+	if err != nil {
+		return err.Result()
+	}
+
+	err = module.OnAcknowledgePacket(ctx, packet, msg.Receipt)
+	if err != nil {
+		return err.Result()
+	}
+
+	ibcm.setSettled(ctx, msg.Port, msg.ChannelID, msg.Sequence)
+
+	return sdk.Result{}
+}
+
+// MsgTimeout settles a sent packet which timed out without being received on
+// the destination chain, notifying the module bound to the source port.
+func handleMsgTimeout(ctx sdk.Context, ibcm Mapper, router Router, msg MsgTimeout) sdk.Result {
+	packet, conn, module, err := getUnsettledPacket(ctx, ibcm, router, msg.Port, msg.ChannelID, msg.Sequence)
+	if err != nil {
+		return err.Result()
+	}
+
+	// the header at msg.Height commits to the state before that height, in
+	// which the packet must be missing while no later block may receive it
+	if !packet.TimedOut(msg.Height) {
+		return ErrNotTimedOut(ibcm.codespace, packet.Timeout, msg.Height).Result()
+	}
+
+	key := ReceiptKey(packet.DestPort, packet.DestChannel, msg.Sequence)
+	err = ibcm.verifyNonMembership(ctx, conn.ClientID, msg.Height, key, msg.Proof)
+	if err != nil {
+		return err.Result()
+	}
+
+	err = module.OnTimeoutPacket(ctx, packet)
+	if err != nil {
+		return err.Result()
+	}
+
+	ibcm.setSettled(ctx, msg.Port, msg.ChannelID, msg.Sequence)
+
+	return sdk.Result{}
+}
+
+// getUnsettledPacket returns the packet sent with sequence on the channel,
+// which must not be settled yet, the connection of the channel and the module
+// bound to its port.
+func getUnsettledPacket(ctx sdk.Context, ibcm Mapper, router Router,
+	port, channelID string, sequence int64) (IBCPacket, Connection, Module, sdk.Error) {
+
+	packet, found := ibcm.GetEgressPacket(ctx, port, channelID, sequence)
+	if !found {
+		return packet, Connection{}, nil, ErrUnknownPacket(ibcm.codespace, port, channelID, sequence)
+	}
+	if ibcm.IsSettled(ctx, port, channelID, sequence) {
+		return packet, Connection{}, nil, ErrPacketSettled(ibcm.codespace, port, channelID, sequence)
+	}
+	module := router.Route(port)
+	if module == nil {
+		return packet, Connection{}, nil, ErrUnknownPort(ibcm.codespace, port)
+	}
+	// packets are only sent over open channels, which are never removed
+	channel, _ := ibcm.GetChannel(ctx, port, channelID)
+	conn, _ := ibcm.GetConnection(ctx, channel.ConnectionID)
+	return packet, conn, module, nil
+}
+
+// MsgCreateClient starts tracking a counterparty chain from a header signed
+// by its validator set, with a light client under a new identifier. Anyone
+// can create a client for any chain, the connections pick the one they trust.
+func handleMsgCreateClient(ctx sdk.Context, ibcm Mapper, msg MsgCreateClient) sdk.Result {
+	chainID := msg.Header.ChainID
+	if chainID == ctx.ChainID() {
+		return ErrIdenticalChains(ibcm.codespace).Result()
+	}
+
+	err := verifyHeader(chainID, nil, msg.Header, msg.Commit, msg.Validators)
+	if err != nil {
+		return ErrInvalidHeader(ibcm.codespace, err.Error()).Result()
+	}
+
+	clientID := ibcm.newClientID(ctx)
+	ibcm.setConsensusState(ctx, clientID, ConsensusState{
+		ChainID:    chainID,
+		Height:     msg.Header.Height,
+		AppHash:    msg.Header.AppHash,
+		Validators: msg.Validators,
+	})
+
+	return sdk.Result{
+		Data: ibcm.cdc.MustMarshalBinaryBare(clientID),
+	}
+}
+
+// MsgUpdateClient moves a light client forward to a newer header of the chain
+// it tracks, which must be signed by more than 2/3 of the last trusted
+// validator set.
+func handleMsgUpdateClient(ctx sdk.Context, ibcm Mapper, msg MsgUpdateClient) sdk.Result {
+	trusted, found := ibcm.getLatestConsensusState(ctx, msg.ClientID)
+	if !found {
+		return ErrUnknownClient(ibcm.codespace, msg.ClientID).Result()
+	}
+	if msg.Header.Height <= trusted.Height {
+		return ErrInvalidHeader(ibcm.codespace,
+			fmt.Sprintf("header height %d is not above latest height %d", msg.Header.Height, trusted.Height)).Result()
+	}
+
+	chainID := trusted.ChainID
+	err := verifyHeader(chainID, trusted.Validators, msg.Header, msg.Commit, msg.Validators)
+	if err != nil {
+		return ErrInvalidHeader(ibcm.codespace, err.Error()).Result()
+	}
+
+	ibcm.setConsensusState(ctx, msg.ClientID, ConsensusState{
+		ChainID:    chainID,
+		Height:     msg.Header.Height,
+		AppHash:    msg.Header.AppHash,
+		Validators: msg.Validators,
+	})
+
+	return sdk.Result{}
+}
+
+// MsgConnectionOpenInit starts the handshake of a connection to the chain
+// tracked by a light client.
+func handleMsgConnectionOpenInit(ctx sdk.Context, ibcm Mapper, msg MsgConnectionOpenInit) sdk.Result {
+	conn, err := newConnection(ctx, ibcm, msg.ConnectionID, msg.ClientID)
+	if err != nil {
+		return err.Result()
+	}
+
+	conn.CounterpartyClientID = msg.CounterpartyClientID
+	conn.CounterpartyConnectionID = msg.CounterpartyConnectionID
+	conn.State = StateInit
+	ibcm.setConnection(ctx, conn)
+
+	return sdk.Result{}
+}
+
+// MsgConnectionOpenTry creates the end of a connection the counterparty chain
+// has started the handshake of.
+func handleMsgConnectionOpenTry(ctx sdk.Context, ibcm Mapper, msg MsgConnectionOpenTry) sdk.Result {
+	conn, err := newConnection(ctx, ibcm, msg.ConnectionID, msg.ClientID)
+	if err != nil {
+		return err.Result()
+	}
+
+	conn.CounterpartyClientID = msg.CounterpartyClientID
+	conn.CounterpartyConnectionID = msg.CounterpartyConnectionID
+	conn.State = StateTryOpen
+	err = ibcm.verifyCounterpartyConnection(ctx, conn, StateInit, msg.Proof, msg.Height)
+	if err != nil {
+		return err.Result()
+	}
+
+	ibcm.setConnection(ctx, conn)
+
+	return sdk.Result{}
+}
+
+// MsgConnectionOpenAck opens a connection once the counterparty chain has
+// created its end.
+func handleMsgConnectionOpenAck(ctx sdk.Context, ibcm Mapper, msg MsgConnectionOpenAck) sdk.Result {
+	return openConnection(ctx, ibcm, msg.ConnectionID, StateInit, StateTryOpen, msg.Proof, msg.Height)
+}
+
+// MsgConnectionOpenConfirm opens a connection once the counterparty chain has
+// opened its end.
+func handleMsgConnectionOpenConfirm(ctx sdk.Context, ibcm Mapper, msg MsgConnectionOpenConfirm) sdk.Result {
+	return openConnection(ctx, ibcm, msg.ConnectionID, StateTryOpen, StateOpen, msg.Proof, msg.Height)
+}
+
+// newConnection checks that a connection can be created with the identifier,
+// to the chain tracked by the light client, and returns it without its
+// counterparty end and state.
+func newConnection(ctx sdk.Context, ibcm Mapper, connectionID, clientID string) (Connection, sdk.Error) {
+	if _, found := ibcm.GetConnection(ctx, connectionID); found {
+		return Connection{}, ErrConnectionExists(ibcm.codespace, connectionID)
+	}
+	cs, found := ibcm.getLatestConsensusState(ctx, clientID)
+	if !found {
+		return Connection{}, ErrUnknownClient(ibcm.codespace, clientID)
+	}
+	return Connection{
+		ID:                connectionID,
+		ClientID:          clientID,
+		CounterpartyChain: cs.ChainID,
+	}, nil
+}
+
+// openConnection opens the connection in state once its counterparty end is
+// proven to be in counterpartyState.
+func openConnection(ctx sdk.Context, ibcm Mapper, connectionID string, state State,
+	counterpartyState State, proof []byte, height int64) sdk.Result {
+
+	conn, found := ibcm.GetConnection(ctx, connectionID)
+	if !found {
+		return ErrUnknownConnection(ibcm.codespace, connectionID).Result()
+	}
+	if conn.State != state {
+		return ErrInvalidState(ibcm.codespace, "connection "+connectionID, conn.State, state).Result()
+	}
+
+	err := ibcm.verifyCounterpartyConnection(ctx, conn, counterpartyState, proof, height)
+	if err != nil {
+		return err.Result()
+	}
+
+	conn.State = StateOpen
+	ibcm.setConnection(ctx, conn)
+
+	return sdk.Result{}
+}
+
+// MsgChannelOpenInit starts the handshake of a channel over an open
+// connection.
+func handleMsgChannelOpenInit(ctx sdk.Context, ibcm Mapper, router Router, msg MsgChannelOpenInit) sdk.Result {
+	_, err := newChannel(ctx, ibcm, router, msg.Port, msg.ChannelID, msg.ConnectionID)
+	if err != nil {
+		return err.Result()
+	}
+
+	ibcm.setChannel(ctx, Channel{
+		Port:                  msg.Port,
+		ID:                    msg.ChannelID,
+		ConnectionID:          msg.ConnectionID,
+		CounterpartyPort:      msg.CounterpartyPort,
+		CounterpartyChannelID: msg.CounterpartyChannelID,
+		State:                 StateInit,
+	})
+
+	return sdk.Result{}
+}
+
+// MsgChannelOpenTry creates the end of a channel the counterparty chain has
+// started the handshake of.
+func handleMsgChannelOpenTry(ctx sdk.Context, ibcm Mapper, router Router, msg MsgChannelOpenTry) sdk.Result {
+	conn, err := newChannel(ctx, ibcm, router, msg.Port, msg.ChannelID, msg.ConnectionID)
+	if err != nil {
+		return err.Result()
+	}
+
+	channel := Channel{
+		Port:                  msg.Port,
+		ID:                    msg.ChannelID,
+		ConnectionID:          msg.ConnectionID,
+		CounterpartyPort:      msg.CounterpartyPort,
+		CounterpartyChannelID: msg.CounterpartyChannelID,
+		State:                 StateTryOpen,
+	}
+	err = ibcm.verifyCounterpartyChannel(ctx, conn, channel, StateInit, msg.Proof, msg.Height)
+	if err != nil {
+		return err.Result()
+	}
+
+	ibcm.setChannel(ctx, channel)
+
+	return sdk.Result{}
+}
+
+// MsgChannelOpenAck opens a channel once the counterparty chain has created
+// its end.
+func handleMsgChannelOpenAck(ctx sdk.Context, ibcm Mapper, msg MsgChannelOpenAck) sdk.Result {
+	return openChannel(ctx, ibcm, msg.Port, msg.ChannelID, StateInit, StateTryOpen, msg.Proof, msg.Height)
+}
+
+// MsgChannelOpenConfirm opens a channel once the counterparty chain has
+// opened its end.
+func handleMsgChannelOpenConfirm(ctx sdk.Context, ibcm Mapper, msg MsgChannelOpenConfirm) sdk.Result {
+	return openChannel(ctx, ibcm, msg.Port, msg.ChannelID, StateTryOpen, StateOpen, msg.Proof, msg.Height)
+}
+
+// newChannel checks that a channel can be created on port, which must be
+// bound to a module, over the connection, which must be open, and returns the
+// connection.
+func newChannel(ctx sdk.Context, ibcm Mapper, router Router, port, channelID, connectionID string) (Connection, sdk.Error) {
+	if router.Route(port) == nil {
+		return Connection{}, ErrUnknownPort(ibcm.codespace, port)
+	}
+	if _, found := ibcm.GetChannel(ctx, port, channelID); found {
+		return Connection{}, ErrChannelExists(ibcm.codespace, port, channelID)
+	}
+	conn, found := ibcm.GetConnection(ctx, connectionID)
+	if !found {
+		return conn, ErrUnknownConnection(ibcm.codespace, connectionID)
+	}
+	if conn.State != StateOpen {
+		return conn, ErrInvalidState(ibcm.codespace, "connection "+connectionID, conn.State, StateOpen)
+	}
+	return conn, nil
+}
+
+// openChannel opens the channel in state once its counterparty end is proven
+// to be in counterpartyState.
+func openChannel(ctx sdk.Context, ibcm Mapper, port, channelID string, state State,
+	counterpartyState State, proof []byte, height int64) sdk.Result {
+
+	channel, found := ibcm.GetChannel(ctx, port, channelID)
+	if !found {
+		return ErrUnknownChannel(ibcm.codespace, port, channelID).Result()
+	}
+	if channel.State != state {
+		return ErrInvalidState(ibcm.codespace, "channel "+port+"/"+channelID, channel.State, state).Result()
 	}
 
-	_, _, err := ck.AddCoins(ctx, packet.DestAddr, packet.Coins)
+	conn, _ := ibcm.GetConnection(ctx, channel.ConnectionID)
+	err := ibcm.verifyCounterpartyChannel(ctx, conn, channel, counterpartyState, proof, height)
 	if err != nil {
 		return err.Result()
 	}
 
-	ibcm.SetIngressSequence(ctx, packet.SrcChain, seq+1)
+	channel.State = StateOpen
+	ibcm.setChannel(ctx, channel)
 
 	return sdk.Result{}
 }
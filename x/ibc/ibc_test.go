@@ -2,11 +2,13 @@ package ibc
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 
 	wrsp "github.com/tepleton/tepleton/wrsp/types"
 	"github.com/tepleton/tepleton/crypto"
+	tmtypes "github.com/tepleton/tepleton/types"
 	dbm "github.com/tepleton/tmlibs/db"
 	"github.com/tepleton/tmlibs/log"
 
@@ -19,13 +21,13 @@ import (
 
 // AccountMapper(/Keeper) and IBCMapper should use different StoreKey later
 
-func defaultContext(key sdk.StoreKey) sdk.Context {
+func defaultContext(key sdk.StoreKey, chainID string) (sdk.CommitMultiStore, sdk.Context) {
 	db := dbm.NewMemDB()
 	cms := store.NewCommitMultiStore(db)
 	cms.MountStoreWithDB(key, sdk.StoreTypeIAVL, db)
 	cms.LoadLatestVersion()
-	ctx := sdk.NewContext(cms, wrsp.Header{}, false, log.NewNopLogger())
-	return ctx
+	ctx := sdk.NewContext(cms, wrsp.Header{ChainID: chainID}, false, log.NewNopLogger())
+	return cms, ctx
 }
 
 func newAddress() crypto.Address {
@@ -47,6 +49,18 @@ func makeCodec() *wire.Codec {
 	cdc.RegisterConcrete(bank.MsgIssue{}, "test/ibc/Issue", nil)
 	cdc.RegisterConcrete(IBCTransferMsg{}, "test/ibc/IBCTransferMsg", nil)
 	cdc.RegisterConcrete(IBCReceiveMsg{}, "test/ibc/IBCReceiveMsg", nil)
+	cdc.RegisterConcrete(MsgAcknowledgement{}, "test/ibc/MsgAcknowledgement", nil)
+	cdc.RegisterConcrete(MsgTimeout{}, "test/ibc/MsgTimeout", nil)
+	cdc.RegisterConcrete(MsgCreateClient{}, "test/ibc/MsgCreateClient", nil)
+	cdc.RegisterConcrete(MsgUpdateClient{}, "test/ibc/MsgUpdateClient", nil)
+	cdc.RegisterConcrete(MsgConnectionOpenInit{}, "test/ibc/MsgConnectionOpenInit", nil)
+	cdc.RegisterConcrete(MsgConnectionOpenTry{}, "test/ibc/MsgConnectionOpenTry", nil)
+	cdc.RegisterConcrete(MsgConnectionOpenAck{}, "test/ibc/MsgConnectionOpenAck", nil)
+	cdc.RegisterConcrete(MsgConnectionOpenConfirm{}, "test/ibc/MsgConnectionOpenConfirm", nil)
+	cdc.RegisterConcrete(MsgChannelOpenInit{}, "test/ibc/MsgChannelOpenInit", nil)
+	cdc.RegisterConcrete(MsgChannelOpenTry{}, "test/ibc/MsgChannelOpenTry", nil)
+	cdc.RegisterConcrete(MsgChannelOpenAck{}, "test/ibc/MsgChannelOpenAck", nil)
+	cdc.RegisterConcrete(MsgChannelOpenConfirm{}, "test/ibc/MsgChannelOpenConfirm", nil)
 
 	// Register AppAccount
 	cdc.RegisterInterface((*auth.Account)(nil), nil)
@@ -56,79 +70,696 @@ func makeCodec() *wire.Codec {
 	return cdc
 }
 
-func TestIBC(t *testing.T) {
-	cdc := makeCodec()
+// signedHeader returns a header of chainID at height committing to appHash,
+// the validator set made of privs and a commit signed by the first nSigners
+func signedHeader(t *testing.T, chainID string, height int64, appHash []byte,
+	privs []crypto.PrivKey, nSigners int) (tmtypes.Header, *tmtypes.Commit, *tmtypes.ValidatorSet) {
 
-	key := sdk.NewKVStoreKey("ibc")
-	ctx := defaultContext(key)
+	vals := make([]*tmtypes.Validator, len(privs))
+	for i, priv := range privs {
+		vals[i] = tmtypes.NewValidator(priv.PubKey(), 10)
+	}
+	valset := tmtypes.NewValidatorSet(vals)
 
-	am := auth.NewAccountMapper(cdc, key, &auth.BaseAccount{})
-	ck := bank.NewKeeper(am)
+	header := tmtypes.Header{
+		ChainID:        chainID,
+		Height:         height,
+		Time:           time.Now(),
+		AppHash:        appHash,
+		ValidatorsHash: valset.Hash(),
+	}
+	blockID := tmtypes.BlockID{Hash: header.Hash()}
 
-	src := newAddress()
-	dest := newAddress()
-	chainid := "ibcchain"
-	zero := sdk.Coins(nil)
-	mycoins := sdk.Coins{sdk.NewCoin("mycoin", 10)}
+	precommits := make([]*tmtypes.Vote, valset.Size())
+	for _, priv := range privs[:nSigners] {
+		idx, _ := valset.GetByAddress(priv.PubKey().Address())
+		vote := &tmtypes.Vote{
+			ValidatorAddress: priv.PubKey().Address(),
+			ValidatorIndex:   idx,
+			Height:           height,
+			Timestamp:        time.Now(),
+			Type:             tmtypes.VoteTypePrecommit,
+			BlockID:          blockID,
+		}
+		sig, err := priv.Sign(vote.SignBytes(chainID))
+		require.Nil(t, err)
+		vote.Signature = sig
+		precommits[idx] = vote
+	}
 
-	coins, _, err := ck.AddCoins(ctx, src, mycoins)
-	require.Nil(t, err)
-	require.Equal(t, mycoins, coins)
+	return header, &tmtypes.Commit{BlockID: blockID, Precommits: precommits}, valset
+}
 
+// testChain is a chain with the ibc module, whose committed state can be
+// proven to the other test chains
+type testChain struct {
+	chainID string
+	cms     sdk.CommitMultiStore
+	ctx     sdk.Context
+	ck      bank.Keeper
+	ibcm    Mapper
+	router  Router
+	handler sdk.Handler
+	version int64
+	hash    []byte
+	height  int64             // height of the latest header tracked by other chains
+	clients map[string]string // light client of each chain tracked
+}
+
+func newTestChain(cdc *wire.Codec, chainID string) *testChain {
+	key := sdk.NewKVStoreKey("ibc")
+	cms, ctx := defaultContext(key, chainID)
+	ck := bank.NewKeeper(auth.NewAccountMapper(cdc, key, &auth.BaseAccount{}))
 	ibcm := NewMapper(cdc, key, DefaultCodespace)
-	h := NewHandler(ibcm, ck)
-	packet := IBCPacket{
-		SrcAddr:   src,
-		DestAddr:  dest,
-		Coins:     mycoins,
-		SrcChain:  chainid,
-		DestChain: chainid,
+	transfer := NewTransferModule(ibcm, ck)
+	router := NewRouter().AddRoute(PortTransfer, transfer)
+
+	// route the messages by type like the app router does
+	ibcHandler := NewHandler(ibcm, router)
+	transferHandler := NewTransferHandler(transfer)
+	handler := func(ctx sdk.Context, msg sdk.Msg) sdk.Result {
+		if msg.Type() == PortTransfer {
+			return transferHandler(ctx, msg)
+		}
+		return ibcHandler(ctx, msg)
 	}
 
-	store := ctx.KVStore(key)
+	return &testChain{
+		chainID: chainID,
+		cms:     cms,
+		ctx:     ctx,
+		ck:      ck,
+		ibcm:    ibcm,
+		router:  router,
+		handler: handler,
+		clients: make(map[string]string),
+	}
+}
 
-	var msg sdk.Msg
-	var res sdk.Result
-	var egl int64
-	var igs int64
+// sent returns the packet sent with sequence on the channel of the transfer port
+func (c *testChain) sent(t *testing.T, channelID string, sequence int64) IBCPacket {
+	packet, found := c.ibcm.GetEgressPacket(c.ctx, PortTransfer, channelID, sequence)
+	require.True(t, found)
+	return packet
+}
 
-	egl = ibcm.getEgressLength(store, chainid)
-	require.Equal(t, egl, int64(0))
+// commit commits the chain state and returns its app hash
+func (c *testChain) commit() []byte {
+	cid := c.cms.Commit()
+	c.version = cid.Version
+	c.hash = cid.Hash
+	return cid.Hash
+}
 
-	msg = IBCTransferMsg{
-		IBCPacket: packet,
+// prove returns the proof of key in the last committed state
+func (c *testChain) prove(t *testing.T, key []byte) []byte {
+	qres := c.cms.(sdk.Queryable).Query(wrsp.RequestQuery{
+		Path:   "/ibc/key",
+		Data:   key,
+		Height: c.version,
+		Prove:  true,
+	})
+	require.True(t, sdk.WRSPCodeType(qres.Code).IsOK())
+	return qres.Proof
+}
+
+// track creates or updates the light client of chainID with a header at
+// height committing to appHash
+func (c *testChain) track(t *testing.T, chainID string, height int64, appHash []byte, privs []crypto.PrivKey) {
+	header, commit, valset := signedHeader(t, chainID, height, appHash, privs, len(privs))
+	clientID, found := c.clients[chainID]
+	if !found {
+		c.clients[chainID] = createClient(t, c.ctx, c.handler, MsgCreateClient{header, commit, valset, newAddress()})
+		return
 	}
-	res = h(ctx, msg)
+	c.deliver(t, MsgUpdateClient{clientID, header, commit, valset, newAddress()})
+}
+
+// createClient delivers msg and returns the identifier of the client created
+func createClient(t *testing.T, ctx sdk.Context, handler sdk.Handler, msg MsgCreateClient) string {
+	res := handler(ctx, msg)
+	require.True(t, res.IsOK(), res.Log)
+	var clientID string
+	require.Nil(t, msgCdc.UnmarshalBinaryBare(res.Data, &clientID))
+	return clientID
+}
+
+// trackAt tracks the last committed state of src with its header at height
+func (c *testChain) trackAt(t *testing.T, src *testChain, height int64, privs []crypto.PrivKey) {
+	src.height = height
+	c.track(t, src.chainID, height, src.hash, privs)
+}
+
+// update commits the state of src and tracks it with the next header of src,
+// returning the height of that header
+func (c *testChain) update(t *testing.T, src *testChain, privs []crypto.PrivKey) int64 {
+	src.commit()
+	c.trackAt(t, src, src.height+1, privs)
+	return src.height
+}
+
+func (c *testChain) deliver(t *testing.T, msg sdk.Msg) {
+	res := c.handler(c.ctx, msg)
+	require.True(t, res.IsOK(), res.Log)
+}
+
+// openConnection runs the handshake of a connection between chains a and b,
+// returning the identifiers of both its ends
+func openConnection(t *testing.T, a, b *testChain, privs []crypto.PrivKey) (connA, connB string) {
+	connA, connB = "conn-"+b.chainID, "conn-"+a.chainID
+	signer := newAddress()
+
+	a.update(t, b, privs)
+	b.update(t, a, privs)
+	clientA, clientB := a.clients[b.chainID], b.clients[a.chainID]
+	a.deliver(t, MsgConnectionOpenInit{connA, clientA, clientB, connB, signer})
+	h := b.update(t, a, privs)
+	b.deliver(t, MsgConnectionOpenTry{connB, clientB, clientA, connA, a.prove(t, ConnectionKey(connA)), h, signer})
+	h = a.update(t, b, privs)
+	a.deliver(t, MsgConnectionOpenAck{connA, b.prove(t, ConnectionKey(connB)), h, signer})
+	h = b.update(t, a, privs)
+	b.deliver(t, MsgConnectionOpenConfirm{connB, a.prove(t, ConnectionKey(connA)), h, signer})
+	return
+}
+
+// openChannel runs the handshake of a channel between port on chains a and
+// b, over the connection with ends connA and connB
+func openChannel(t *testing.T, a, b *testChain, port, connA, connB, chanA, chanB string, privs []crypto.PrivKey) {
+	signer := newAddress()
+
+	a.deliver(t, MsgChannelOpenInit{port, chanA, connA, port, chanB, signer})
+	h := b.update(t, a, privs)
+	b.deliver(t, MsgChannelOpenTry{port, chanB, connB, port, chanA,
+		a.prove(t, ChannelKey(port, chanA)), h, signer})
+	h = a.update(t, b, privs)
+	a.deliver(t, MsgChannelOpenAck{port, chanA, b.prove(t, ChannelKey(port, chanB)), h, signer})
+	h = b.update(t, a, privs)
+	b.deliver(t, MsgChannelOpenConfirm{port, chanB, a.prove(t, ChannelKey(port, chanA)), h, signer})
+}
+
+func TestIBCClients(t *testing.T) {
+	srcChain := "src-chain"
+	destChain := "dest-chain"
+
+	key := sdk.NewKVStoreKey("ibc")
+	_, ctx := defaultContext(key, destChain)
+	ibcm := NewMapper(makeCodec(), key, DefaultCodespace)
+	handler := NewHandler(ibcm, NewRouter())
+
+	relayer := newAddress()
+	appHash := []byte("app hash")
+	height := int64(5)
+	privs := []crypto.PrivKey{crypto.GenPrivKeyEd25519(), crypto.GenPrivKeyEd25519(), crypto.GenPrivKeyEd25519()}
+	header, commit, valset := signedHeader(t, srcChain, height, appHash, privs, len(privs))
+
+	// a header which is not signed by enough of its validators is rejected
+	weakHeader, weakCommit, _ := signedHeader(t, srcChain, height, appHash, privs, 2)
+	res := handler(ctx, MsgCreateClient{weakHeader, weakCommit, valset, relayer})
+	require.Equal(t, sdk.ToWRSPCode(DefaultCodespace, CodeInvalidHeader), res.Code)
+
+	// so is a chain ID which could clash with the store keys
+	slashHeader, slashCommit, _ := signedHeader(t, "src/5", height, appHash, privs, len(privs))
+	require.NotNil(t, MsgCreateClient{slashHeader, slashCommit, valset, relayer}.ValidateBasic())
+
+	clientID := createClient(t, ctx, handler, MsgCreateClient{header, commit, valset, relayer})
+	require.Equal(t, "client-0", clientID)
+
+	cs, found := ibcm.GetConsensusState(ctx, clientID, height)
+	require.True(t, found)
+	require.Equal(t, srcChain, cs.ChainID)
+	require.Equal(t, appHash, cs.AppHash)
+
+	// anyone can create another client of the chain, even from a validator set
+	// the chain never had, without taking over the first one
+	otherPrivs := []crypto.PrivKey{crypto.GenPrivKeyEd25519()}
+	otherHeader, otherCommit, otherValset := signedHeader(t, srcChain, height, []byte("other app hash"), otherPrivs, 1)
+	otherClientID := createClient(t, ctx, handler, MsgCreateClient{otherHeader, otherCommit, otherValset, relayer})
+	require.Equal(t, "client-1", otherClientID)
+	cs, _ = ibcm.GetConsensusState(ctx, clientID, height)
+	require.Equal(t, appHash, cs.AppHash)
+
+	// updating the client requires a newer header signed by the trusted validators
+	newHeader, newCommit, _ := signedHeader(t, srcChain, height+1, appHash, privs, 1)
+	res = handler(ctx, MsgUpdateClient{clientID, newHeader, newCommit, valset, relayer})
+	require.Equal(t, sdk.ToWRSPCode(DefaultCodespace, CodeInvalidHeader), res.Code)
+
+	newHeader, newCommit, _ = signedHeader(t, srcChain, height+1, appHash, privs, len(privs))
+	res = handler(ctx, MsgUpdateClient{"client-2", newHeader, newCommit, valset, relayer})
+	require.Equal(t, sdk.ToWRSPCode(DefaultCodespace, CodeUnknownClient), res.Code)
+	res = handler(ctx, MsgUpdateClient{clientID, newHeader, newCommit, valset, relayer})
 	require.True(t, res.IsOK())
+	res = handler(ctx, MsgUpdateClient{clientID, newHeader, newCommit, valset, relayer})
+	require.Equal(t, sdk.ToWRSPCode(DefaultCodespace, CodeInvalidHeader), res.Code)
+
+	latest, found := ibcm.GetLatestClientHeight(ctx, clientID)
+	require.True(t, found)
+	require.Equal(t, height+1, latest)
+	latest, _ = ibcm.GetLatestClientHeight(ctx, otherClientID)
+	require.Equal(t, height, latest)
+
+	// a validator set the source chain never had cannot take over the client
+	otherHeader, otherCommit, otherValset = signedHeader(t, srcChain, height+2, appHash, otherPrivs, 1)
+	res = handler(ctx, MsgUpdateClient{clientID, otherHeader, otherCommit, otherValset, relayer})
+	require.Equal(t, sdk.ToWRSPCode(DefaultCodespace, CodeInvalidHeader), res.Code)
+
+	// nor can a header of another chain
+	otherHeader, otherCommit, _ = signedHeader(t, "other-chain", height+2, appHash, privs, len(privs))
+	res = handler(ctx, MsgUpdateClient{clientID, otherHeader, otherCommit, valset, relayer})
+	require.Equal(t, sdk.ToWRSPCode(DefaultCodespace, CodeInvalidHeader), res.Code)
+}
+
+func TestIBCHandshake(t *testing.T) {
+	cdc := makeCodec()
+	chainA := newTestChain(cdc, "chain-a")
+	chainB := newTestChain(cdc, "chain-b")
+	privs := []crypto.PrivKey{crypto.GenPrivKeyEd25519()}
+	signer := newAddress()
+
+	// a connection needs a light client of its counterparty
+	res := chainA.handler(chainA.ctx, MsgConnectionOpenInit{"conn-b", "client-0", "client-0", "conn-a", signer})
+	require.Equal(t, sdk.ToWRSPCode(DefaultCodespace, CodeUnknownClient), res.Code)
+
+	chainA.update(t, chainB, privs)
+	chainB.update(t, chainA, privs)
+	clientA, clientB := chainA.clients[chainB.chainID], chainB.clients[chainA.chainID]
+	chainA.deliver(t, MsgConnectionOpenInit{"conn-b", clientA, clientB, "conn-a", signer})
+	res = chainA.handler(chainA.ctx, MsgConnectionOpenInit{"conn-b", clientA, clientB, "conn-a", signer})
+	require.Equal(t, sdk.ToWRSPCode(DefaultCodespace, CodeConnectionExists), res.Code)
+	conn, _ := chainA.ibcm.GetConnection(chainA.ctx, "conn-b")
+	require.Equal(t, chainB.chainID, conn.CounterpartyChain)
+
+	// the counterparty only answers a handshake which names it and its client
+	h := chainB.update(t, chainA, privs)
+	proof := chainA.prove(t, ConnectionKey("conn-b"))
+	res = chainB.handler(chainB.ctx, MsgConnectionOpenTry{"conn-x", clientB, clientA, "conn-b", proof, h, signer})
+	require.Equal(t, sdk.ToWRSPCode(DefaultCodespace, CodeInvalidProof), res.Code)
+	header, commit, valset := signedHeader(t, chainA.chainID, 1, chainA.hash, privs, len(privs))
+	otherClientB := createClient(t, chainB.ctx, chainB.handler, MsgCreateClient{header, commit, valset, signer})
+	res = chainB.handler(chainB.ctx, MsgConnectionOpenTry{"conn-a", otherClientB, clientA, "conn-b", proof, h, signer})
+	require.Equal(t, sdk.ToWRSPCode(DefaultCodespace, CodeUnknownHeight), res.Code)
+	chainB.deliver(t, MsgConnectionOpenTry{"conn-a", clientB, clientA, "conn-b", proof, h, signer})
+
+	// channels need an open connection
+	res = chainB.handler(chainB.ctx, MsgChannelOpenInit{PortTransfer, "channel-0", "conn-a", PortTransfer, "channel-0", signer})
+	require.Equal(t, sdk.ToWRSPCode(DefaultCodespace, CodeInvalidState), res.Code)
+	res = chainB.handler(chainB.ctx, MsgChannelOpenInit{PortTransfer, "channel-0", "conn-x", PortTransfer, "channel-0", signer})
+	require.Equal(t, sdk.ToWRSPCode(DefaultCodespace, CodeUnknownConnection), res.Code)
+
+	// the end which answered the handshake cannot acknowledge it
+	h = chainA.update(t, chainB, privs)
+	proof = chainB.prove(t, ConnectionKey("conn-a"))
+	res = chainB.handler(chainB.ctx, MsgConnectionOpenAck{"conn-a", proof, h, signer})
+	require.Equal(t, sdk.ToWRSPCode(DefaultCodespace, CodeInvalidState), res.Code)
+
+	// the connection opens on the end which started the handshake first
+	chainA.deliver(t, MsgConnectionOpenAck{"conn-b", proof, h, signer})
+	conn, found := chainA.ibcm.GetConnection(chainA.ctx, "conn-b")
+	require.True(t, found)
+	require.Equal(t, StateOpen, conn.State)
+	conn, _ = chainB.ibcm.GetConnection(chainB.ctx, "conn-a")
+	require.Equal(t, StateTryOpen, conn.State)
+
+	// it cannot be confirmed with a proof of a state the counterparty is not in
+	res = chainB.handler(chainB.ctx, MsgConnectionOpenConfirm{"conn-a", chainA.prove(t, ConnectionKey("conn-b")), chainA.height, signer})
+	require.Equal(t, sdk.ToWRSPCode(DefaultCodespace, CodeInvalidProof), res.Code)
+
+	h = chainB.update(t, chainA, privs)
+	chainB.deliver(t, MsgConnectionOpenConfirm{"conn-a", chainA.prove(t, ConnectionKey("conn-b")), h, signer})
+	conn, _ = chainB.ibcm.GetConnection(chainB.ctx, "conn-a")
+	require.Equal(t, StateOpen, conn.State)
 
-	coins, err = getCoins(ck, ctx, src)
+	openChannel(t, chainA, chainB, PortTransfer, "conn-b", "conn-a", "channel-0", "channel-1", privs)
+	channel, found := chainA.ibcm.GetChannel(chainA.ctx, PortTransfer, "channel-0")
+	require.True(t, found)
+	require.Equal(t, Channel{PortTransfer, "channel-0", "conn-b", PortTransfer, "channel-1", StateOpen}, channel)
+	channel, found = chainB.ibcm.GetChannel(chainB.ctx, PortTransfer, "channel-1")
+	require.True(t, found)
+	require.Equal(t, Channel{PortTransfer, "channel-1", "conn-a", PortTransfer, "channel-0", StateOpen}, channel)
+
+	res = chainA.handler(chainA.ctx, MsgChannelOpenInit{PortTransfer, "channel-0", "conn-b", PortTransfer, "channel-2", signer})
+	require.Equal(t, sdk.ToWRSPCode(DefaultCodespace, CodeChannelExists), res.Code)
+
+	// packets must be sent over an open channel and match its other end
+	sender := newAddress()
+	mycoins := sdk.Coins{sdk.NewCoin("mycoin", 10)}
+	_, _, err := chainA.ck.AddCoins(chainA.ctx, sender, mycoins)
+	require.Nil(t, err)
+
+	chainA.deliver(t, MsgChannelOpenInit{PortTransfer, "channel-3", "conn-b", PortTransfer, "channel-3", signer})
+	res = chainA.handler(chainA.ctx, IBCTransferMsg{sender, sender, mycoins, "channel-3", chainB.chainID, 0})
+	require.Equal(t, sdk.ToWRSPCode(DefaultCodespace, CodeInvalidState), res.Code)
+	res = chainA.handler(chainA.ctx, IBCTransferMsg{sender, sender, mycoins, "channel-4", chainB.chainID, 0})
+	require.Equal(t, sdk.ToWRSPCode(DefaultCodespace, CodeUnknownChannel), res.Code)
+	res = chainA.handler(chainA.ctx, IBCTransferMsg{sender, sender, mycoins, "channel-0", "chain-c", 0})
+	require.Equal(t, sdk.ToWRSPCode(DefaultCodespace, CodeInvalidChannel), res.Code)
+	require.Equal(t, mycoins, chainA.ck.GetCoins(chainA.ctx, sender))
+
+	chainA.deliver(t, IBCTransferMsg{sender, sender, mycoins, "channel-0", chainB.chainID, 0})
+	require.True(t, chainA.ck.GetCoins(chainA.ctx, sender).IsZero())
+
+	// the packet is addressed to the other end of the channel
+	packet := chainA.sent(t, "channel-0", 0)
+	require.Equal(t, PortTransfer, packet.DestPort)
+	require.Equal(t, "channel-1", packet.DestChannel)
+}
+
+func TestIBC(t *testing.T) {
+	cdc := makeCodec()
+	src := newTestChain(cdc, "src-chain")
+	dest := newTestChain(cdc, "dest-chain")
+	privs := []crypto.PrivKey{crypto.GenPrivKeyEd25519(), crypto.GenPrivKeyEd25519(), crypto.GenPrivKeyEd25519()}
+
+	connSrc, connDest := openConnection(t, src, dest, privs)
+	openChannel(t, src, dest, PortTransfer, connSrc, connDest, "channel-0", "channel-1", privs)
+
+	sender := newAddress()
+	receiver := newAddress()
+	relayer := newAddress()
+	zero := sdk.Coins(nil)
+	mycoins := sdk.Coins{sdk.NewCoin("mycoin", 10)}
+
+	coins, _, err := src.ck.AddCoins(src.ctx, sender, mycoins)
+	require.Nil(t, err)
+	require.Equal(t, mycoins, coins)
+
+	egl := src.ibcm.getEgressLength(src.ctx.KVStore(src.ibcm.key), PortTransfer, "channel-0")
+	require.Equal(t, egl, int64(0))
+
+	res := src.handler(src.ctx, IBCTransferMsg{sender, receiver, mycoins, "channel-0", dest.chainID, 0})
+	require.True(t, res.IsOK())
+	packet := src.sent(t, "channel-0", 0)
+
+	coins, err = getCoins(src.ck, src.ctx, sender)
 	require.Nil(t, err)
 	require.Equal(t, zero, coins)
 
-	egl = ibcm.getEgressLength(store, chainid)
-	require.Equal(t, egl, int64(1))
+	coins, err = getCoins(src.ck, src.ctx, EscrowAddress(PortTransfer, "channel-0"))
+	require.Nil(t, err)
+	require.Equal(t, mycoins, coins)
 
-	igs = ibcm.GetIngressSequence(ctx, chainid)
-	require.Equal(t, igs, int64(0))
+	egl = src.ibcm.getEgressLength(src.ctx.KVStore(src.ibcm.key), PortTransfer, "channel-0")
+	require.Equal(t, egl, int64(1))
 
-	msg = IBCReceiveMsg{
+	// commit the source chain and prove the packet
+	height := dest.update(t, src, privs)
+	msg := IBCReceiveMsg{
 		IBCPacket: packet,
-		Relayer:   src,
+		Relayer:   relayer,
 		Sequence:  0,
+		Proof:     src.prove(t, EgressKey(PortTransfer, "channel-0", 0)),
+		Height:    height,
 	}
-	res = h(ctx, msg)
-	require.True(t, res.IsOK())
 
-	coins, err = getCoins(ck, ctx, dest)
+	// a packet which does not match the proof is rejected
+	forged := msg
+	forged.Data = marshalBinaryPanic(cdc, TransferPacketData{sender, receiver, sdk.Coins{sdk.NewCoin("mycoin", 1000)}})
+	res = dest.handler(dest.ctx, forged)
+	require.Equal(t, sdk.ToWRSPCode(DefaultCodespace, CodeInvalidProof), res.Code)
+
+	// so is a proof against a height the client has not verified
+	forged = msg
+	forged.Height = height + 1
+	res = dest.handler(dest.ctx, forged)
+	require.Equal(t, sdk.ToWRSPCode(DefaultCodespace, CodeUnknownHeight), res.Code)
+
+	res = dest.handler(dest.ctx, msg)
+	require.True(t, res.IsOK(), res.Log)
+
+	coins, err = getCoins(dest.ck, dest.ctx, receiver)
 	require.Nil(t, err)
-	require.Equal(t, mycoins, coins)
+	require.Equal(t, sdk.Coins{sdk.NewCoin(VoucherDenom(PortTransfer, "channel-1", "mycoin"), 10)}, coins)
 
-	igs = ibcm.GetIngressSequence(ctx, chainid)
+	igs := dest.ibcm.GetIngressSequence(dest.ctx, PortTransfer, "channel-1")
 	require.Equal(t, igs, int64(1))
 
-	res = h(ctx, msg)
+	res = dest.handler(dest.ctx, msg)
 	require.False(t, res.IsOK())
 
-	igs = ibcm.GetIngressSequence(ctx, chainid)
+	igs = dest.ibcm.GetIngressSequence(dest.ctx, PortTransfer, "channel-1")
 	require.Equal(t, igs, int64(1))
 }
+
+func TestIBCChannelsAreIsolated(t *testing.T) {
+	cdc := makeCodec()
+	src := newTestChain(cdc, "src-chain")
+	dest := newTestChain(cdc, "dest-chain")
+	privs := []crypto.PrivKey{crypto.GenPrivKeyEd25519()}
+
+	connSrc, connDest := openConnection(t, src, dest, privs)
+	openChannel(t, src, dest, PortTransfer, connSrc, connDest, "channel-0", "channel-0", privs)
+	openChannel(t, src, dest, PortTransfer, connSrc, connDest, "channel-1", "channel-1", privs)
+
+	sender := newAddress()
+	receiver := newAddress()
+	relayer := newAddress()
+	mycoins := sdk.Coins{sdk.NewCoin("mycoin", 10)}
+	_, _, err := src.ck.AddCoins(src.ctx, sender, mycoins.Plus(mycoins).Plus(mycoins))
+	require.Nil(t, err)
+
+	// two packets on the first channel, one on the second
+	src.deliver(t, IBCTransferMsg{sender, receiver, mycoins, "channel-0", dest.chainID, 0})
+	src.deliver(t, IBCTransferMsg{sender, receiver, mycoins, "channel-0", dest.chainID, 0})
+	src.deliver(t, IBCTransferMsg{sender, receiver, mycoins, "channel-1", dest.chainID, 0})
+
+	first := src.sent(t, "channel-0", 0)
+	require.Equal(t, first, src.sent(t, "channel-0", 1))
+	second := src.sent(t, "channel-1", 0)
+	_, found := src.ibcm.GetEgressPacket(src.ctx, PortTransfer, "channel-1", 1)
+	require.False(t, found)
+
+	h := dest.update(t, src, privs)
+
+	// a packet cannot be received on another channel than it was sent to
+	proof := src.prove(t, EgressKey(PortTransfer, "channel-1", 0))
+	mismatched := second
+	mismatched.DestChannel = "channel-0"
+	res := dest.handler(dest.ctx, IBCReceiveMsg{mismatched, relayer, 0, proof, h})
+	require.Equal(t, sdk.ToWRSPCode(DefaultCodespace, CodeInvalidChannel), res.Code)
+
+	// the second channel does not wait for the first one
+	dest.deliver(t, IBCReceiveMsg{second, relayer, 0, proof, h})
+	require.Equal(t, int64(1), dest.ibcm.GetIngressSequence(dest.ctx, PortTransfer, "channel-1"))
+	require.Equal(t, int64(0), dest.ibcm.GetIngressSequence(dest.ctx, PortTransfer, "channel-0"))
+
+	// and each channel stays ordered
+	res = dest.handler(dest.ctx, IBCReceiveMsg{first, relayer, 1, src.prove(t, EgressKey(PortTransfer, "channel-0", 1)), h})
+	require.Equal(t, sdk.ToWRSPCode(DefaultCodespace, CodeInvalidSequence), res.Code)
+	dest.deliver(t, IBCReceiveMsg{first, relayer, 0, src.prove(t, EgressKey(PortTransfer, "channel-0", 0)), h})
+	dest.deliver(t, IBCReceiveMsg{first, relayer, 1, src.prove(t, EgressKey(PortTransfer, "channel-0", 1)), h})
+
+	coins, err := getCoins(dest.ck, dest.ctx, receiver)
+	require.Nil(t, err)
+	vouchers := sdk.Coins{
+		sdk.NewCoin(VoucherDenom(PortTransfer, "channel-0", "mycoin"), 20),
+		sdk.NewCoin(VoucherDenom(PortTransfer, "channel-1", "mycoin"), 10),
+	}
+	require.Equal(t, vouchers, coins)
+}
+
+func TestIBCAcknowledgementAndTimeout(t *testing.T) {
+	cdc := makeCodec()
+	src := newTestChain(cdc, "src-chain")
+	dest := newTestChain(cdc, "dest-chain")
+	privs := []crypto.PrivKey{crypto.GenPrivKeyEd25519(), crypto.GenPrivKeyEd25519()}
+
+	connSrc, connDest := openConnection(t, src, dest, privs)
+	openChannel(t, src, dest, PortTransfer, connSrc, connDest, "channel-0", "channel-1", privs)
+
+	sender := newAddress()
+	receiver := newAddress()
+	relayer := newAddress()
+	mycoins := sdk.Coins{sdk.NewCoin("mycoin", 10)}
+
+	_, _, err := src.ck.AddCoins(src.ctx, sender, mycoins.Plus(mycoins))
+	require.Nil(t, err)
+
+	// the first packet times out at height 10 of the destination chain,
+	// the second one never does
+	res := src.handler(src.ctx, IBCTransferMsg{sender, receiver, mycoins, "channel-0", dest.chainID, 10})
+	require.True(t, res.IsOK())
+	res = src.handler(src.ctx, IBCTransferMsg{sender, receiver, mycoins, "channel-0", dest.chainID, 0})
+	require.True(t, res.IsOK())
+	packet, noTimeoutPacket := src.sent(t, "channel-0", 0), src.sent(t, "channel-0", 1)
+
+	coins, err := getCoins(src.ck, src.ctx, sender)
+	require.Nil(t, err)
+	require.True(t, coins.IsZero())
+
+	srcHeight := dest.update(t, src, privs)
+
+	// the destination chain has not received anything
+	dest.commit()
+	absenceProof := dest.prove(t, ReceiptKey(PortTransfer, "channel-1", 0))
+
+	// which is not enough to time out before the timeout height
+	src.trackAt(t, dest, 5, privs)
+	res = src.handler(src.ctx, MsgTimeout{PortTransfer, "channel-0", 0, absenceProof, 5, relayer})
+	require.Equal(t, sdk.ToWRSPCode(DefaultCodespace, CodeNotTimedOut), res.Code)
+
+	// nor to time out a packet without timeout
+	src.trackAt(t, dest, 10, privs)
+	res = src.handler(src.ctx, MsgTimeout{PortTransfer, "channel-0", 1, dest.prove(t, ReceiptKey(PortTransfer, "channel-1", 1)), 10, relayer})
+	require.Equal(t, sdk.ToWRSPCode(DefaultCodespace, CodeNotTimedOut), res.Code)
+
+	// at the timeout height the sender is refunded, only once
+	res = src.handler(src.ctx, MsgTimeout{PortTransfer, "channel-0", 0, absenceProof, 10, relayer})
+	require.True(t, res.IsOK(), res.Log)
+	coins, err = getCoins(src.ck, src.ctx, sender)
+	require.Nil(t, err)
+	require.Equal(t, mycoins, coins)
+	coins, err = getCoins(src.ck, src.ctx, EscrowAddress(PortTransfer, "channel-0"))
+	require.Nil(t, err)
+	require.Equal(t, mycoins, coins)
+
+	res = src.handler(src.ctx, MsgTimeout{PortTransfer, "channel-0", 0, absenceProof, 10, relayer})
+	require.Equal(t, sdk.ToWRSPCode(DefaultCodespace, CodePacketSettled), res.Code)
+
+	// the timed out packet is still consumed on the destination chain, with a failed receipt
+	dest.ctx = dest.ctx.WithBlockHeight(11)
+	res = dest.handler(dest.ctx, IBCReceiveMsg{packet, relayer, 0, src.prove(t, EgressKey(PortTransfer, "channel-0", 0)), srcHeight})
+	require.True(t, res.IsOK(), res.Log)
+	receipt, found := dest.ibcm.GetReceipt(dest.ctx, PortTransfer, "channel-1", 0)
+	require.True(t, found)
+	require.Equal(t, sdk.ToWRSPCode(DefaultCodespace, CodePacketTimedOut), receipt.Code)
+
+	res = dest.handler(dest.ctx, IBCReceiveMsg{noTimeoutPacket, relayer, 1, src.prove(t, EgressKey(PortTransfer, "channel-0", 1)), srcHeight})
+	require.True(t, res.IsOK(), res.Log)
+	receipt, found = dest.ibcm.GetReceipt(dest.ctx, PortTransfer, "channel-1", 1)
+	require.True(t, found)
+	require.True(t, receipt.IsOK())
+
+	coins, err = getCoins(dest.ck, dest.ctx, receiver)
+	require.Nil(t, err)
+	require.Equal(t, sdk.Coins{sdk.NewCoin(VoucherDenom(PortTransfer, "channel-1", "mycoin"), 10)}, coins)
+
+	destHeight := src.update(t, dest, privs)
+
+	// the refunded packet cannot be acknowledged anymore
+	timedOut := Receipt{Code: sdk.ToWRSPCode(DefaultCodespace, CodePacketTimedOut)}
+	res = src.handler(src.ctx, MsgAcknowledgement{PortTransfer, "channel-0", 0, timedOut, dest.prove(t, ReceiptKey(PortTransfer, "channel-1", 0)), destHeight, relayer})
+	require.Equal(t, sdk.ToWRSPCode(DefaultCodespace, CodePacketSettled), res.Code)
+
+	// a receipt which does not match the proof is rejected
+	receiptProof := dest.prove(t, ReceiptKey(PortTransfer, "channel-1", 1))
+	forged := Receipt{Code: sdk.ToWRSPCode(DefaultCodespace, CodeUnknownPacket)}
+	res = src.handler(src.ctx, MsgAcknowledgement{PortTransfer, "channel-0", 1, forged, receiptProof, destHeight, relayer})
+	require.Equal(t, sdk.ToWRSPCode(DefaultCodespace, CodeInvalidProof), res.Code)
+
+	// the successful receipt settles the packet without refund
+	res = src.handler(src.ctx, MsgAcknowledgement{PortTransfer, "channel-0", 1, receipt, receiptProof, destHeight, relayer})
+	require.True(t, res.IsOK(), res.Log)
+	require.True(t, src.ibcm.IsSettled(src.ctx, PortTransfer, "channel-0", 1))
+	coins, err = getCoins(src.ck, src.ctx, sender)
+	require.Nil(t, err)
+	require.Equal(t, mycoins, coins)
+}
+
+func TestIBCEscrowAndVouchers(t *testing.T) {
+	cdc := makeCodec()
+	chainA := newTestChain(cdc, "chain-a")
+	chainB := newTestChain(cdc, "chain-b")
+	privs := []crypto.PrivKey{crypto.GenPrivKeyEd25519()}
+
+	connA, connB := openConnection(t, chainA, chainB, privs)
+	openChannel(t, chainA, chainB, PortTransfer, connA, connB, "channel-0", "channel-1", privs)
+	openChannel(t, chainA, chainB, PortTransfer, connA, connB, "channel-1", "channel-2", privs)
+
+	alice := newAddress()
+	bob := newAddress()
+	relayer := newAddress()
+	steak := sdk.Coins{sdk.NewCoin("steak", 10)}
+	voucher := VoucherDenom(PortTransfer, "channel-1", "steak")
+
+	_, _, err := chainA.ck.AddCoins(chainA.ctx, alice, steak)
+	require.Nil(t, err)
+	// chain b has its own native steak, which must not mix with the vouchers
+	_, _, err = chainB.ck.AddCoins(chainB.ctx, bob, steak)
+	require.Nil(t, err)
+
+	// native coins sent away are locked in the escrow of the channel
+	res := chainA.handler(chainA.ctx, IBCTransferMsg{alice, bob, steak, "channel-0", chainB.chainID, 0})
+	require.True(t, res.IsOK(), res.Log)
+	toB := chainA.sent(t, "channel-0", 0)
+	require.True(t, chainA.ck.GetCoins(chainA.ctx, alice).IsZero())
+	require.Equal(t, steak, chainA.ck.GetCoins(chainA.ctx, EscrowAddress(PortTransfer, "channel-0")))
+
+	// and minted as vouchers of the receiving channel on the other chain
+	h := chainB.update(t, chainA, privs)
+	proof := chainA.prove(t, EgressKey(PortTransfer, "channel-0", 0))
+	res = chainB.handler(chainB.ctx, IBCReceiveMsg{toB, relayer, 0, proof, h})
+	require.True(t, res.IsOK(), res.Log)
+	require.Equal(t, sdk.Coins{sdk.NewCoin("steak", 10), sdk.NewCoin(voucher, 10)}.Sort(), chainB.ck.GetCoins(chainB.ctx, bob))
+
+	// vouchers sent back over the channel they came from are burned
+	back := sdk.Coins{sdk.NewCoin(voucher, 4)}
+	res = chainB.handler(chainB.ctx, IBCTransferMsg{bob, alice, back, "channel-1", chainA.chainID, 0})
+	require.True(t, res.IsOK(), res.Log)
+	toA := chainB.sent(t, "channel-1", 0)
+	require.Equal(t, sdk.Coins{sdk.NewCoin("steak", 10), sdk.NewCoin(voucher, 6)}.Sort(), chainB.ck.GetCoins(chainB.ctx, bob))
+	require.True(t, chainB.ck.GetCoins(chainB.ctx, EscrowAddress(PortTransfer, "channel-1")).IsZero())
+
+	// and released from escrow on return
+	h = chainA.update(t, chainB, privs)
+	proof = chainB.prove(t, EgressKey(PortTransfer, "channel-1", 0))
+	res = chainA.handler(chainA.ctx, IBCReceiveMsg{toA, relayer, 0, proof, h})
+	require.True(t, res.IsOK(), res.Log)
+	require.Equal(t, sdk.Coins{sdk.NewCoin("steak", 4)}, chainA.ck.GetCoins(chainA.ctx, alice))
+	require.Equal(t, sdk.Coins{sdk.NewCoin("steak", 6)}, chainA.ck.GetCoins(chainA.ctx, EscrowAddress(PortTransfer, "channel-0")))
+
+	// vouchers sent over another channel are escrowed like any other coin,
+	// and cannot release what was locked for the channel they came from
+	other := sdk.Coins{sdk.NewCoin(voucher, 2)}
+	res = chainB.handler(chainB.ctx, IBCTransferMsg{bob, alice, other, "channel-2", chainA.chainID, 0})
+	require.True(t, res.IsOK(), res.Log)
+	toA = chainB.sent(t, "channel-2", 0)
+	require.Equal(t, other, chainB.ck.GetCoins(chainB.ctx, EscrowAddress(PortTransfer, "channel-2")))
+
+	h = chainA.update(t, chainB, privs)
+	proof = chainB.prove(t, EgressKey(PortTransfer, "channel-2", 0))
+	res = chainA.handler(chainA.ctx, IBCReceiveMsg{toA, relayer, 0, proof, h})
+	require.True(t, res.IsOK(), res.Log)
+	rewrapped := sdk.NewCoin(VoucherDenom(PortTransfer, "channel-1", voucher), 2)
+	require.Equal(t, sdk.Coins{rewrapped, sdk.NewCoin("steak", 4)}.Sort(), chainA.ck.GetCoins(chainA.ctx, alice))
+	require.Equal(t, sdk.Coins{sdk.NewCoin("steak", 6)}, chainA.ck.GetCoins(chainA.ctx, EscrowAddress(PortTransfer, "channel-0")))
+
+	// the escrow balance can be queried
+	querier := NewQuerier(chainA.ibcm, chainA.ck)
+	bz, err := cdc.MarshalJSON(QueryEscrowParams{PortTransfer, "channel-0"})
+	require.Nil(t, err)
+	res2, qerr := querier(chainA.ctx, []string{QueryEscrow}, wrsp.RequestQuery{Data: bz})
+	require.Nil(t, qerr)
+	var balance EscrowBalance
+	require.Nil(t, cdc.UnmarshalJSON(res2, &balance))
+	require.Equal(t, sdk.Coins{sdk.NewCoin("steak", 6)}, balance.Coins)
+	require.Equal(t, EscrowAddress(PortTransfer, "channel-0"), balance.Address)
+}
+
+func TestIBCVoucherDepth(t *testing.T) {
+	cdc := makeCodec()
+	chain := newTestChain(cdc, "chain-a")
+	alice := newAddress()
+	bob := newAddress()
+	packet := IBCPacket{
+		SrcChain:    "chain-b",
+		SrcPort:     PortTransfer,
+		SrcChannel:  "channel-1",
+		DestChain:   chain.chainID,
+		DestPort:    PortTransfer,
+		DestChannel: "channel-0",
+	}
+
+	// vouchers can be received through up to 4 hops
+	denom := "steak"
+	for hops := 1; hops <= 4; hops++ {
+		coins := sdk.Coins{sdk.NewCoin(denom, 1)}
+		err := receiveCoins(chain.ctx, chain.ck, TransferPacketData{alice, bob, coins}, packet)
+		require.Nil(t, err)
+		denom = VoucherDenom(PortTransfer, "channel-0", denom)
+		require.Equal(t, int64(1), chain.ck.GetCoins(chain.ctx, bob).AmountOf(denom).Int64())
+	}
+
+	// but no more, so the sender gets them back
+	coins := sdk.Coins{sdk.NewCoin(denom, 1)}
+	err := receiveCoins(chain.ctx, chain.ck, TransferPacketData{alice, bob, coins}, packet)
+	require.Equal(t, sdk.CodeInvalidCoins, err.Code())
+}
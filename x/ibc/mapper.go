@@ -3,6 +3,7 @@ package ibc
 import (
 	"fmt"
 
+	"github.com/tepleton/tepleton-sdk/store"
 	sdk "github.com/tepleton/tepleton-sdk/types"
 	wire "github.com/tepleton/tepleton-sdk/wire"
 )
@@ -25,36 +26,27 @@ func NewMapper(cdc *wire.Codec, key sdk.StoreKey, codespace sdk.CodespaceType) M
 	}
 }
 
-// XXX: This is not the public API. This will change in MVP2 and will henceforth
-// only be invoked from another module directly and not through a user
-// transaction.
-// TODO: Handle invalid IBC packets and return errors.
-func (ibcm Mapper) PostIBCPacket(ctx sdk.Context, packet IBCPacket) sdk.Error {
-	// write everything into the state
-	store := ctx.KVStore(ibcm.key)
-	index := ibcm.getEgressLength(store, packet.DestChain)
-	bz, err := ibcm.cdc.MarshalBinary(packet)
-	if err != nil {
-		panic(err)
+// SendPacket stores a packet sent by a module over an open channel of its
+// port, for relayers to prove to the destination chain, and returns its
+// sequence on the channel.
+func (ibcm Mapper) SendPacket(ctx sdk.Context, packet IBCPacket) (int64, sdk.Error) {
+	if packet.SrcChain != ctx.ChainID() {
+		return 0, ErrInvalidChannel(ibcm.codespace, fmt.Sprintf("IBC packet is sent from chain %s", packet.SrcChain))
 	}
-
-	store.Set(EgressKey(packet.DestChain, index), bz)
-	bz, err = ibcm.cdc.MarshalBinary(index + 1)
+	_, _, err := ibcm.getOpenChannel(ctx, packet.SrcPort, packet.SrcChannel,
+		packet.DestChain, packet.DestPort, packet.DestChannel)
 	if err != nil {
-		panic(err)
+		return 0, err
 	}
-	store.Set(EgressLengthKey(packet.DestChain), bz)
 
-	return nil
-}
+	// write everything into the state
+	store := ctx.KVStore(ibcm.key)
+	index := ibcm.getEgressLength(store, packet.SrcPort, packet.SrcChannel)
 
-// XXX: In the future every module is able to register it's own handler for
-// handling it's own IBC packets. The "ibc" handler will only route the packets
-// to the appropriate callbacks.
-// XXX: For now this handles all interactions with the CoinKeeper.
-// XXX: This needs to do some authentication checking.
-func (ibcm Mapper) ReceiveIBCPacket(ctx sdk.Context, packet IBCPacket) sdk.Error {
-	return nil
+	store.Set(EgressKey(packet.SrcPort, packet.SrcChannel, index), marshalBinaryPanic(ibcm.cdc, packet))
+	store.Set(EgressLengthKey(packet.SrcPort, packet.SrcChannel), marshalBinaryPanic(ibcm.cdc, index+1))
+
+	return index, nil
 }
 
 // --------------------------
@@ -75,10 +67,11 @@ func unmarshalBinaryPanic(cdc *wire.Codec, bz []byte, ptr interface{}) {
 	}
 }
 
-// TODO add description
-func (ibcm Mapper) GetIngressSequence(ctx sdk.Context, srcChain string) int64 {
+// GetIngressSequence returns the sequence of the next packet to be received
+// on the channel.
+func (ibcm Mapper) GetIngressSequence(ctx sdk.Context, port, channelID string) int64 {
 	store := ctx.KVStore(ibcm.key)
-	key := IngressSequenceKey(srcChain)
+	key := IngressSequenceKey(port, channelID)
 
 	bz := store.Get(key)
 	if bz == nil {
@@ -92,21 +85,254 @@ func (ibcm Mapper) GetIngressSequence(ctx sdk.Context, srcChain string) int64 {
 	return res
 }
 
-// TODO add description
-func (ibcm Mapper) SetIngressSequence(ctx sdk.Context, srcChain string, sequence int64) {
+// SetIngressSequence sets the sequence of the next packet to be received on
+// the channel.
+func (ibcm Mapper) SetIngressSequence(ctx sdk.Context, port, channelID string, sequence int64) {
 	store := ctx.KVStore(ibcm.key)
-	key := IngressSequenceKey(srcChain)
+	key := IngressSequenceKey(port, channelID)
 
 	bz := marshalBinaryPanic(ibcm.cdc, sequence)
 	store.Set(key, bz)
 }
 
+// GetConsensusState returns the state verified by the light client at height,
+// if it has been created or updated to that height.
+func (ibcm Mapper) GetConsensusState(ctx sdk.Context, clientID string, height int64) (cs ConsensusState, found bool) {
+	store := ctx.KVStore(ibcm.key)
+	bz := store.Get(ConsensusStateKey(clientID, height))
+	if bz == nil {
+		return cs, false
+	}
+	unmarshalBinaryPanic(ibcm.cdc, bz, &cs)
+	return cs, true
+}
+
+// GetLatestClientHeight returns the height of the most recent header verified
+// by the light client.
+func (ibcm Mapper) GetLatestClientHeight(ctx sdk.Context, clientID string) (height int64, found bool) {
+	store := ctx.KVStore(ibcm.key)
+	bz := store.Get(LatestClientHeightKey(clientID))
+	if bz == nil {
+		return 0, false
+	}
+	unmarshalBinaryPanic(ibcm.cdc, bz, &height)
+	return height, true
+}
+
+// getLatestConsensusState returns the most recent state verified by the
+// light client, if it exists.
+func (ibcm Mapper) getLatestConsensusState(ctx sdk.Context, clientID string) (cs ConsensusState, found bool) {
+	height, found := ibcm.GetLatestClientHeight(ctx, clientID)
+	if !found {
+		return cs, false
+	}
+	return ibcm.GetConsensusState(ctx, clientID, height)
+}
+
+// setConsensusState stores a state verified by the light client and makes it
+// the latest one.
+func (ibcm Mapper) setConsensusState(ctx sdk.Context, clientID string, cs ConsensusState) {
+	store := ctx.KVStore(ibcm.key)
+	store.Set(ConsensusStateKey(clientID, cs.Height), marshalBinaryPanic(ibcm.cdc, cs))
+	store.Set(LatestClientHeightKey(clientID), marshalBinaryPanic(ibcm.cdc, cs.Height))
+}
+
+// newClientID returns the identifier of a new light client. Identifiers are
+// generated, so that creating a client for a chain never takes over the
+// client somebody else created for it.
+func (ibcm Mapper) newClientID(ctx sdk.Context) string {
+	store := ctx.KVStore(ibcm.key)
+	var sequence int64
+	bz := store.Get(ClientSequenceKey())
+	if bz != nil {
+		unmarshalBinaryPanic(ibcm.cdc, bz, &sequence)
+	}
+	store.Set(ClientSequenceKey(), marshalBinaryPanic(ibcm.cdc, sequence+1))
+	return fmt.Sprintf("client-%d", sequence)
+}
+
+// GetConnection returns the connection with the given identifier.
+func (ibcm Mapper) GetConnection(ctx sdk.Context, connectionID string) (conn Connection, found bool) {
+	store := ctx.KVStore(ibcm.key)
+	bz := store.Get(ConnectionKey(connectionID))
+	if bz == nil {
+		return conn, false
+	}
+	unmarshalBinaryPanic(ibcm.cdc, bz, &conn)
+	return conn, true
+}
+
+func (ibcm Mapper) setConnection(ctx sdk.Context, conn Connection) {
+	store := ctx.KVStore(ibcm.key)
+	store.Set(ConnectionKey(conn.ID), marshalBinaryPanic(ibcm.cdc, conn))
+}
+
+// GetChannel returns the channel with the given identifier on port.
+func (ibcm Mapper) GetChannel(ctx sdk.Context, port, channelID string) (channel Channel, found bool) {
+	store := ctx.KVStore(ibcm.key)
+	bz := store.Get(ChannelKey(port, channelID))
+	if bz == nil {
+		return channel, false
+	}
+	unmarshalBinaryPanic(ibcm.cdc, bz, &channel)
+	return channel, true
+}
+
+func (ibcm Mapper) setChannel(ctx sdk.Context, channel Channel) {
+	store := ctx.KVStore(ibcm.key)
+	store.Set(ChannelKey(channel.Port, channel.ID), marshalBinaryPanic(ibcm.cdc, channel))
+}
+
+// getOpenChannel returns the open channel on port and the connection it runs
+// over, checking that its other end is counterpartyPort and
+// counterpartyChannelID on counterpartyChain.
+func (ibcm Mapper) getOpenChannel(ctx sdk.Context, port, channelID string,
+	counterpartyChain, counterpartyPort, counterpartyChannelID string) (Channel, Connection, sdk.Error) {
+
+	channel, found := ibcm.GetChannel(ctx, port, channelID)
+	if !found {
+		return channel, Connection{}, ErrUnknownChannel(ibcm.codespace, port, channelID)
+	}
+	if channel.State != StateOpen {
+		return channel, Connection{}, ErrInvalidState(ibcm.codespace, "channel "+port+"/"+channelID, channel.State, StateOpen)
+	}
+	conn, _ := ibcm.GetConnection(ctx, channel.ConnectionID)
+
+	if conn.CounterpartyChain != counterpartyChain ||
+		channel.CounterpartyPort != counterpartyPort || channel.CounterpartyChannelID != counterpartyChannelID {
+		return channel, conn, ErrInvalidChannel(ibcm.codespace, fmt.Sprintf(
+			"channel %s/%s leads to %s/%s on chain %s, not %s/%s on chain %s", port, channelID,
+			channel.CounterpartyPort, channel.CounterpartyChannelID, conn.CounterpartyChain,
+			counterpartyPort, counterpartyChannelID, counterpartyChain))
+	}
+	return channel, conn, nil
+}
+
+// verifyCounterpartyConnection checks the proof that the other end of conn is
+// stored on the counterparty chain in state, against the app hash of the
+// counterparty chain header at height.
+func (ibcm Mapper) verifyCounterpartyConnection(ctx sdk.Context, conn Connection, state State, proof []byte, height int64) sdk.Error {
+	counterparty := Connection{
+		ID:                       conn.CounterpartyConnectionID,
+		ClientID:                 conn.CounterpartyClientID,
+		CounterpartyChain:        ctx.ChainID(),
+		CounterpartyClientID:     conn.ClientID,
+		CounterpartyConnectionID: conn.ID,
+		State:                    state,
+	}
+	key := ConnectionKey(counterparty.ID)
+	value := marshalBinaryPanic(ibcm.cdc, counterparty)
+	return ibcm.verifyMembership(ctx, conn.ClientID, height, key, value, proof)
+}
+
+// verifyCounterpartyChannel checks the proof that the other end of channel,
+// running over conn, is stored on the counterparty chain in state, against the
+// app hash of the counterparty chain header at height.
+func (ibcm Mapper) verifyCounterpartyChannel(ctx sdk.Context, conn Connection, channel Channel,
+	state State, proof []byte, height int64) sdk.Error {
+
+	counterparty := Channel{
+		Port:                  channel.CounterpartyPort,
+		ID:                    channel.CounterpartyChannelID,
+		ConnectionID:          conn.CounterpartyConnectionID,
+		CounterpartyPort:      channel.Port,
+		CounterpartyChannelID: channel.ID,
+		State:                 state,
+	}
+	key := ChannelKey(counterparty.Port, counterparty.ID)
+	value := marshalBinaryPanic(ibcm.cdc, counterparty)
+	return ibcm.verifyMembership(ctx, conn.ClientID, height, key, value, proof)
+}
+
+// verifyPacket checks the proof that packet was stored under its egress key
+// with the given sequence on the source chain, against the app hash of the
+// source chain header at height, as verified by the light client of conn.
+func (ibcm Mapper) verifyPacket(ctx sdk.Context, conn Connection, packet IBCPacket, sequence int64,
+	proof []byte, height int64) sdk.Error {
+
+	key := EgressKey(packet.SrcPort, packet.SrcChannel, sequence)
+	value := marshalBinaryPanic(ibcm.cdc, packet)
+	return ibcm.verifyMembership(ctx, conn.ClientID, height, key, value, proof)
+}
+
+// verifyMembership checks the proof that value is stored under key in the
+// ibc store of the chain tracked by the light client, against the app hash of
+// its header at height.
+func (ibcm Mapper) verifyMembership(ctx sdk.Context, clientID string, height int64, key, value, proof []byte) sdk.Error {
+	cs, found := ibcm.GetConsensusState(ctx, clientID, height)
+	if !found {
+		return ErrUnknownHeight(ibcm.codespace, clientID, height)
+	}
+
+	err := store.VerifyMultiStoreProof(proof, ibcm.key.Name(), key, value, cs.AppHash)
+	if err != nil {
+		return ErrInvalidProof(ibcm.codespace, err.Error())
+	}
+	return nil
+}
+
+// verifyNonMembership checks the proof that nothing is stored under key in
+// the ibc store of the chain tracked by the light client, against the app
+// hash of its header at height.
+func (ibcm Mapper) verifyNonMembership(ctx sdk.Context, clientID string, height int64, key, proof []byte) sdk.Error {
+	cs, found := ibcm.GetConsensusState(ctx, clientID, height)
+	if !found {
+		return ErrUnknownHeight(ibcm.codespace, clientID, height)
+	}
+
+	err := store.VerifyMultiStoreAbsence(proof, ibcm.key.Name(), key, cs.AppHash)
+	if err != nil {
+		return ErrInvalidProof(ibcm.codespace, err.Error())
+	}
+	return nil
+}
+
+// GetEgressPacket returns the packet sent on the channel with the given sequence.
+func (ibcm Mapper) GetEgressPacket(ctx sdk.Context, port, channelID string, sequence int64) (packet IBCPacket, found bool) {
+	store := ctx.KVStore(ibcm.key)
+	bz := store.Get(EgressKey(port, channelID, sequence))
+	if bz == nil {
+		return packet, false
+	}
+	unmarshalBinaryPanic(ibcm.cdc, bz, &packet)
+	return packet, true
+}
+
+// IsSettled returns whether the packet sent on the channel with the given
+// sequence has been acknowledged or timed out.
+func (ibcm Mapper) IsSettled(ctx sdk.Context, port, channelID string, sequence int64) bool {
+	store := ctx.KVStore(ibcm.key)
+	return store.Has(SettledKey(port, channelID, sequence))
+}
+
+func (ibcm Mapper) setSettled(ctx sdk.Context, port, channelID string, sequence int64) {
+	store := ctx.KVStore(ibcm.key)
+	store.Set(SettledKey(port, channelID, sequence), []byte{0x01})
+}
+
+// GetReceipt returns the receipt written for the packet received on the
+// channel with the given sequence.
+func (ibcm Mapper) GetReceipt(ctx sdk.Context, port, channelID string, sequence int64) (receipt Receipt, found bool) {
+	store := ctx.KVStore(ibcm.key)
+	bz := store.Get(ReceiptKey(port, channelID, sequence))
+	if bz == nil {
+		return receipt, false
+	}
+	unmarshalBinaryPanic(ibcm.cdc, bz, &receipt)
+	return receipt, true
+}
+
+func (ibcm Mapper) setReceipt(ctx sdk.Context, port, channelID string, sequence int64, receipt Receipt) {
+	store := ctx.KVStore(ibcm.key)
+	store.Set(ReceiptKey(port, channelID, sequence), marshalBinaryPanic(ibcm.cdc, receipt))
+}
+
 // Retrieves the index of the currently stored outgoing IBC packets.
-func (ibcm Mapper) getEgressLength(store sdk.KVStore, destChain string) int64 {
-	bz := store.Get(EgressLengthKey(destChain))
+func (ibcm Mapper) getEgressLength(store sdk.KVStore, port, channelID string) int64 {
+	bz := store.Get(EgressLengthKey(port, channelID))
 	if bz == nil {
 		zero := marshalBinaryPanic(ibcm.cdc, int64(0))
-		store.Set(EgressLengthKey(destChain), zero)
+		store.Set(EgressLengthKey(port, channelID), zero)
 		return 0
 	}
 	var res int64
@@ -114,17 +340,52 @@ func (ibcm Mapper) getEgressLength(store sdk.KVStore, destChain string) int64 {
 	return res
 }
 
-// Stores an outgoing IBC packet under "egress/chain_id/index".
-func EgressKey(destChain string, index int64) []byte {
-	return []byte(fmt.Sprintf("egress/%s/%d", destChain, index))
+// Stores an outgoing IBC packet under "egress/port/channel_id/index".
+func EgressKey(port, channelID string, index int64) []byte {
+	return []byte(fmt.Sprintf("egress/%s/%s/%d", port, channelID, index))
+}
+
+// Stores the number of outgoing IBC packets under "egress/port/channel_id".
+func EgressLengthKey(port, channelID string) []byte {
+	return []byte(fmt.Sprintf("egress/%s/%s", port, channelID))
+}
+
+// Stores the sequence number of incoming IBC packet under "ingress/port/channel_id".
+func IngressSequenceKey(port, channelID string) []byte {
+	return []byte(fmt.Sprintf("ingress/%s/%s", port, channelID))
+}
+
+// Stores the sequence of the next light client under "client_sequence".
+func ClientSequenceKey() []byte {
+	return []byte("client_sequence")
+}
+
+// Stores a state verified by a light client under "consensus/client_id/height".
+func ConsensusStateKey(clientID string, height int64) []byte {
+	return []byte(fmt.Sprintf("consensus/%s/%d", clientID, height))
+}
+
+// Stores the height of the latest header verified by a light client under "client/client_id".
+func LatestClientHeightKey(clientID string) []byte {
+	return []byte(fmt.Sprintf("client/%s", clientID))
+}
+
+// Stores a connection under "connection/connection_id".
+func ConnectionKey(connectionID string) []byte {
+	return []byte(fmt.Sprintf("connection/%s", connectionID))
+}
+
+// Stores a channel under "channel/port/channel_id".
+func ChannelKey(port, channelID string) []byte {
+	return []byte(fmt.Sprintf("channel/%s/%s", port, channelID))
 }
 
-// Stores the number of outgoing IBC packets under "egress/index".
-func EgressLengthKey(destChain string) []byte {
-	return []byte(fmt.Sprintf("egress/%s", destChain))
+// Stores the receipt of an incoming IBC packet under "receipt/port/channel_id/index".
+func ReceiptKey(port, channelID string, index int64) []byte {
+	return []byte(fmt.Sprintf("receipt/%s/%s/%d", port, channelID, index))
 }
 
-// Stores the sequence number of incoming IBC packet under "ingress/index".
-func IngressSequenceKey(srcChain string) []byte {
-	return []byte(fmt.Sprintf("ingress/%s", srcChain))
+// Marks an outgoing IBC packet as settled under "settled/port/channel_id/index".
+func SettledKey(port, channelID string, index int64) []byte {
+	return []byte(fmt.Sprintf("settled/%s/%s/%d", port, channelID, index))
 }
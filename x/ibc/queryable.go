@@ -0,0 +1,67 @@
+package ibc
+
+import (
+	wrsp "github.com/tepleton/tepleton/wrsp/types"
+
+	sdk "github.com/tepleton/tepleton-sdk/types"
+	"github.com/tepleton/tepleton-sdk/wire"
+	"github.com/tepleton/tepleton-sdk/x/bank"
+)
+
+// query endpoints supported by the IBC Querier
+const (
+	QueryEscrow = "escrow"
+)
+
+// NewQuerier returns the IBC querier, to be registered on the app's
+// QueryRouter under the ibc route
+func NewQuerier(ibcm Mapper, ck bank.Keeper) sdk.Querier {
+	return func(ctx sdk.Context, path []string, req wrsp.RequestQuery) (res []byte, err sdk.Error) {
+		if len(path) == 0 {
+			return nil, sdk.ErrUnknownRequest("no ibc query endpoint specified")
+		}
+		switch path[0] {
+		case QueryEscrow:
+			return queryEscrow(ctx, path[1:], req, ibcm, ck)
+		default:
+			return nil, sdk.ErrUnknownRequest("unknown ibc query endpoint")
+		}
+	}
+}
+
+// Params for query 'custom/ibc/escrow'
+type QueryEscrowParams struct {
+	Port    string
+	Channel string
+}
+
+// EscrowBalance holds the coins locked for the coins sent over a channel
+type EscrowBalance struct {
+	Port    string      `json:"port"`
+	Channel string      `json:"channel"`
+	Address sdk.Address `json:"address"`
+	Coins   sdk.Coins   `json:"coins"`
+}
+
+// nolint: unparam
+func queryEscrow(ctx sdk.Context, path []string, req wrsp.RequestQuery, ibcm Mapper, ck bank.Keeper) (res []byte, err sdk.Error) {
+	var params QueryEscrowParams
+	err2 := ibcm.cdc.UnmarshalJSON(req.Data, &params)
+	if err2 != nil {
+		return nil, sdk.ErrUnknownRequest("incorrectly formatted request data - " + err2.Error())
+	}
+
+	addr := EscrowAddress(params.Port, params.Channel)
+	balance := EscrowBalance{
+		Port:    params.Port,
+		Channel: params.Channel,
+		Address: addr,
+		Coins:   ck.GetCoins(ctx, addr),
+	}
+
+	bz, err2 := wire.MarshalJSONIndent(ibcm.cdc, balance)
+	if err2 != nil {
+		return nil, sdk.ErrInternal("could not marshal result to JSON - " + err2.Error())
+	}
+	return bz, nil
+}
@@ -0,0 +1,60 @@
+package ibc
+
+import (
+	sdk "github.com/tepleton/tepleton-sdk/types"
+)
+
+// Module is an application bound to an IBC port. The IBC handler verifies
+// the packets sent to the port and settles the packets sent from it, and
+// hands them over to the module through these callbacks.
+type Module interface {
+	// OnRecvPacket executes a packet received on the port. An error does not
+	// fail the transaction: the packet is consumed with a failed receipt and
+	// the changes made by the callback are discarded.
+	OnRecvPacket(ctx sdk.Context, packet IBCPacket) sdk.Error
+
+	// OnAcknowledgePacket settles a packet sent from the port with the
+	// receipt the destination chain wrote for it.
+	OnAcknowledgePacket(ctx sdk.Context, packet IBCPacket, receipt Receipt) sdk.Error
+
+	// OnTimeoutPacket settles a packet sent from the port which timed out
+	// without being received.
+	OnTimeoutPacket(ctx sdk.Context, packet IBCPacket) sdk.Error
+}
+
+// Router binds the modules of the app to IBC ports.
+type Router interface {
+	AddRoute(port string, m Module) (rtr Router)
+	Route(port string) (m Module)
+}
+
+type router struct {
+	routes map[string]Module
+}
+
+// nolint
+// NewRouter - create new IBC router
+// TODO either make Function unexported or make return type (router) Exported
+func NewRouter() *router {
+	return &router{
+		routes: make(map[string]Module),
+	}
+}
+
+// AddRoute binds m to port, which must be a valid identifier not bound yet.
+func (rtr *router) AddRoute(port string, m Module) Router {
+	if err := validateIdentifier("port", port); err != nil {
+		panic(err)
+	}
+	if _, ok := rtr.routes[port]; ok {
+		panic("IBC port " + port + " is already bound")
+	}
+	rtr.routes[port] = m
+
+	return rtr
+}
+
+// Route returns the module bound to port, or nil.
+func (rtr *router) Route(port string) Module {
+	return rtr.routes[port]
+}
@@ -0,0 +1,106 @@
+package ibc
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/tepleton/tepleton/crypto"
+
+	sdk "github.com/tepleton/tepleton-sdk/types"
+)
+
+// pingModule records the packets of the ping port, failing the ones whose
+// data is "fail"
+type pingModule struct {
+	received     [][]byte
+	acknowledged []Receipt
+	timedOut     [][]byte
+}
+
+func (m *pingModule) OnRecvPacket(ctx sdk.Context, packet IBCPacket) sdk.Error {
+	if string(packet.Data) == "fail" {
+		return sdk.ErrUnknownRequest("ping failed")
+	}
+	m.received = append(m.received, packet.Data)
+	return nil
+}
+
+func (m *pingModule) OnAcknowledgePacket(ctx sdk.Context, packet IBCPacket, receipt Receipt) sdk.Error {
+	m.acknowledged = append(m.acknowledged, receipt)
+	return nil
+}
+
+func (m *pingModule) OnTimeoutPacket(ctx sdk.Context, packet IBCPacket) sdk.Error {
+	m.timedOut = append(m.timedOut, packet.Data)
+	return nil
+}
+
+func TestRouterBindsPortsOnce(t *testing.T) {
+	router := NewRouter().AddRoute("ping", &pingModule{})
+	require.NotNil(t, router.Route("ping"))
+	require.Nil(t, router.Route("pong"))
+
+	require.Panics(t, func() { router.AddRoute("ping", &pingModule{}) })
+	require.Panics(t, func() { router.AddRoute("ping/pong", &pingModule{}) })
+}
+
+func TestIBCRoutesPacketsToModules(t *testing.T) {
+	cdc := makeCodec()
+	chainA := newTestChain(cdc, "chain-a")
+	chainB := newTestChain(cdc, "chain-b")
+	privs := []crypto.PrivKey{crypto.GenPrivKeyEd25519()}
+	relayer := newAddress()
+
+	connA, connB := openConnection(t, chainA, chainB, privs)
+
+	// channels can only be opened on ports bound to a module
+	res := chainA.handler(chainA.ctx, MsgChannelOpenInit{"ping", "channel-0", connA, "ping", "channel-0", relayer})
+	require.Equal(t, sdk.ToWRSPCode(DefaultCodespace, CodeUnknownPort), res.Code)
+
+	pingA, pingB := &pingModule{}, &pingModule{}
+	chainA.router.AddRoute("ping", pingA)
+	chainB.router.AddRoute("ping", pingB)
+	openChannel(t, chainA, chainB, "ping", connA, connB, "channel-0", "channel-0", privs)
+
+	// modules send opaque data over the channels of their port
+	_, err := chainA.ibcm.SendPacket(chainA.ctx, NewIBCPacket(chainA.chainID, "ping", "channel-1",
+		chainB.chainID, "ping", "channel-0", []byte("hello"), 0))
+	require.Equal(t, CodeUnknownChannel, err.Code())
+	for i, data := range []string{"hello", "fail"} {
+		seq, err := chainA.ibcm.SendPacket(chainA.ctx, NewIBCPacket(chainA.chainID, "ping", "channel-0",
+			chainB.chainID, "ping", "channel-0", []byte(data), 0))
+		require.Nil(t, err)
+		require.Equal(t, int64(i), seq)
+	}
+
+	// the receiving module executes them, a failure only failing the receipt
+	h := chainB.update(t, chainA, privs)
+	for seq := int64(0); seq < 2; seq++ {
+		packet, found := chainA.ibcm.GetEgressPacket(chainA.ctx, "ping", "channel-0", seq)
+		require.True(t, found)
+		proof := chainA.prove(t, EgressKey("ping", "channel-0", seq))
+		chainB.deliver(t, IBCReceiveMsg{packet, relayer, seq, proof, h})
+	}
+	require.Equal(t, [][]byte{[]byte("hello")}, pingB.received)
+
+	failed, found := chainB.ibcm.GetReceipt(chainB.ctx, "ping", "channel-0", 1)
+	require.True(t, found)
+	require.Equal(t, sdk.ErrUnknownRequest("").WRSPCode(), failed.Code)
+
+	// and the sending module is handed the receipts
+	h = chainA.update(t, chainB, privs)
+	for seq := int64(0); seq < 2; seq++ {
+		receipt, _ := chainB.ibcm.GetReceipt(chainB.ctx, "ping", "channel-0", seq)
+		proof := chainB.prove(t, ReceiptKey("ping", "channel-0", seq))
+		chainA.deliver(t, MsgAcknowledgement{"ping", "channel-0", seq, receipt, proof, h, relayer})
+	}
+	require.Equal(t, []Receipt{{}, failed}, pingA.acknowledged)
+	require.Empty(t, pingA.timedOut)
+	require.Empty(t, pingA.received)
+
+	// the transfer module does not accept packets from other ports
+	packet := NewIBCPacket(chainA.chainID, "ping", "channel-0", chainB.chainID, PortTransfer, "channel-0", []byte("hello"), 0)
+	err = NewTransferModule(chainB.ibcm, chainB.ck).OnRecvPacket(chainB.ctx, packet)
+	require.Equal(t, CodeInvalidChannel, err.Code())
+}
@@ -0,0 +1,205 @@
+package ibc
+
+import (
+	"fmt"
+	"reflect"
+
+	sdk "github.com/tepleton/tepleton-sdk/types"
+	"github.com/tepleton/tepleton-sdk/x/bank"
+)
+
+// PortTransfer is the port coin transfers are sent from and received on.
+const PortTransfer = "transfer"
+
+// ------------------------------
+// TransferPacketData
+
+// TransferPacketData is the data of the packets sent between transfer ports,
+// moving Coins from SrcAddr on the source chain to DestAddr on the
+// destination chain.
+type TransferPacketData struct {
+	SrcAddr  sdk.Address
+	DestAddr sdk.Address
+	Coins    sdk.Coins
+}
+
+// nolint
+func (data TransferPacketData) ValidateBasic() sdk.Error {
+	if len(data.SrcAddr) == 0 || len(data.DestAddr) == 0 {
+		return sdk.ErrInvalidAddress("transfer address is empty")
+	}
+	if !data.Coins.IsValid() {
+		return sdk.ErrInvalidCoins(data.Coins.String())
+	}
+	return nil
+}
+
+// ----------------------------------
+// IBCTransferMsg
+
+// nolint - TODO rename to TransferMsg as folks will reference with ibc.TransferMsg
+// IBCTransferMsg sends coins over a channel of the transfer port to DestAddr
+// on DestChain, which must be the chain at the other end of the channel. The
+// coins are refunded if they have not been received once DestChain reaches
+// the Timeout height, zero meaning they never time out.
+type IBCTransferMsg struct {
+	SrcAddr    sdk.Address
+	DestAddr   sdk.Address
+	Coins      sdk.Coins
+	SrcChannel string
+	DestChain  string
+	Timeout    int64
+}
+
+// nolint
+func (msg IBCTransferMsg) Type() string { return PortTransfer }
+
+// x/bank/tx.go MsgSend.GetSigners()
+func (msg IBCTransferMsg) GetSigners() []sdk.Address { return []sdk.Address{msg.SrcAddr} }
+
+// get the sign bytes for ibc transfer message
+func (msg IBCTransferMsg) GetSignBytes() []byte {
+	b, err := msgCdc.MarshalJSON(struct {
+		SrcAddr    string
+		DestAddr   string
+		Coins      sdk.Coins
+		SrcChannel string
+		DestChain  string
+		Timeout    int64
+	}{
+		SrcAddr:    sdk.MustBech32ifyAcc(msg.SrcAddr),
+		DestAddr:   sdk.MustBech32ifyAcc(msg.DestAddr),
+		Coins:      msg.Coins,
+		SrcChannel: msg.SrcChannel,
+		DestChain:  msg.DestChain,
+		Timeout:    msg.Timeout,
+	})
+	if err != nil {
+		panic(err)
+	}
+	return b
+}
+
+// validate ibc transfer message
+func (msg IBCTransferMsg) ValidateBasic() sdk.Error {
+	err := validateIdentifier("channel", msg.SrcChannel)
+	if err != nil {
+		return err
+	}
+	err = validateIdentifier("chain", msg.DestChain)
+	if err != nil {
+		return err
+	}
+	if msg.Timeout < 0 {
+		return ErrInvalidTimeout(DefaultCodespace, msg.Timeout)
+	}
+	return msg.packetData().ValidateBasic()
+}
+
+func (msg IBCTransferMsg) packetData() TransferPacketData {
+	return TransferPacketData{
+		SrcAddr:  msg.SrcAddr,
+		DestAddr: msg.DestAddr,
+		Coins:    msg.Coins,
+	}
+}
+
+// ------------------------------
+// TransferModule
+
+// TransferModule is the IBC module moving coins between chains, bound to
+// PortTransfer. Coins sent to another chain are escrowed or burned, and
+// credited back to the sender if the packet fails or times out.
+type TransferModule struct {
+	ibcm Mapper
+	ck   bank.Keeper
+}
+
+var _ Module = TransferModule{}
+
+// NewTransferModule returns the coin transfer module.
+func NewTransferModule(ibcm Mapper, ck bank.Keeper) TransferModule {
+	return TransferModule{
+		ibcm: ibcm,
+		ck:   ck,
+	}
+}
+
+// NewTransferHandler returns the handler of IBCTransferMsg, to be registered
+// on the app's router under the transfer route.
+func NewTransferHandler(tm TransferModule) sdk.Handler {
+	return func(ctx sdk.Context, msg sdk.Msg) sdk.Result {
+		switch msg := msg.(type) {
+		case IBCTransferMsg:
+			return handleIBCTransferMsg(ctx, tm, msg)
+		default:
+			errMsg := "Unrecognized IBC transfer Msg type: " + reflect.TypeOf(msg).Name()
+			return sdk.ErrUnknownRequest(errMsg).Result()
+		}
+	}
+}
+
+// IBCTransferMsg deducts coins from the account, escrowing or burning them,
+// and sends a packet carrying them to the other end of the channel.
+func handleIBCTransferMsg(ctx sdk.Context, tm TransferModule, msg IBCTransferMsg) sdk.Result {
+	channel, found := tm.ibcm.GetChannel(ctx, PortTransfer, msg.SrcChannel)
+	if !found {
+		return ErrUnknownChannel(tm.ibcm.codespace, PortTransfer, msg.SrcChannel).Result()
+	}
+
+	data := msg.packetData()
+	packet := NewIBCPacket(ctx.ChainID(), PortTransfer, msg.SrcChannel,
+		msg.DestChain, channel.CounterpartyPort, channel.CounterpartyChannelID,
+		marshalBinaryPanic(tm.ibcm.cdc, data), msg.Timeout)
+
+	_, err := tm.ibcm.SendPacket(ctx, packet)
+	if err != nil {
+		return err.Result()
+	}
+
+	err = sendCoins(ctx, tm.ck, data, packet.SrcPort, packet.SrcChannel)
+	if err != nil {
+		return err.Result()
+	}
+
+	return sdk.Result{}
+}
+
+// OnRecvPacket credits the coins to the receiver, released from escrow or
+// minted as vouchers.
+func (tm TransferModule) OnRecvPacket(ctx sdk.Context, packet IBCPacket) sdk.Error {
+	if packet.SrcPort != PortTransfer {
+		return ErrInvalidChannel(tm.ibcm.codespace, fmt.Sprintf("coins cannot be received from port %s", packet.SrcPort))
+	}
+	data, err := tm.unmarshalPacketData(packet)
+	if err != nil {
+		return err
+	}
+	return receiveCoins(ctx, tm.ck, data, packet)
+}
+
+// OnAcknowledgePacket refunds the sender if the packet failed on the
+// destination chain.
+func (tm TransferModule) OnAcknowledgePacket(ctx sdk.Context, packet IBCPacket, receipt Receipt) sdk.Error {
+	if receipt.IsOK() {
+		return nil
+	}
+	return tm.OnTimeoutPacket(ctx, packet)
+}
+
+// OnTimeoutPacket refunds the sender.
+func (tm TransferModule) OnTimeoutPacket(ctx sdk.Context, packet IBCPacket) sdk.Error {
+	data, err := tm.unmarshalPacketData(packet)
+	if err != nil {
+		return err
+	}
+	return refundCoins(ctx, tm.ck, data, packet.SrcPort, packet.SrcChannel)
+}
+
+func (tm TransferModule) unmarshalPacketData(packet IBCPacket) (data TransferPacketData, err sdk.Error) {
+	err2 := tm.ibcm.cdc.UnmarshalBinary(packet.Data, &data)
+	if err2 != nil {
+		return data, ErrInvalidPacketData(tm.ibcm.codespace, err2.Error())
+	}
+	return data, data.ValidateBasic()
+}
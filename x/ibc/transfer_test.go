@@ -0,0 +1,62 @@
+package ibc
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	sdk "github.com/tepleton/tepleton-sdk/types"
+)
+
+func TestIBCTransferMsg(t *testing.T) {
+	msg := constructTransferMsg()
+
+	require.Equal(t, msg.Type(), PortTransfer)
+	require.Equal(t, []sdk.Address{msg.SrcAddr}, msg.GetSigners())
+}
+
+func TestIBCTransferMsgValidation(t *testing.T) {
+	valid := constructTransferMsg()
+
+	noChannel := valid
+	noChannel.SrcChannel = ""
+	noChain := valid
+	noChain.DestChain = ""
+	noReceiver := valid
+	noReceiver.DestAddr = nil
+	invalidCoins := valid
+	invalidCoins.Coins = sdk.Coins{sdk.NewCoin("atom", 0)}
+	invalidTimeout := valid
+	invalidTimeout.Timeout = -1
+
+	cases := []struct {
+		valid bool
+		msg   IBCTransferMsg
+	}{
+		{true, valid},
+		{false, noChannel},
+		{false, noChain},
+		{false, noReceiver},
+		{false, invalidCoins},
+		{false, invalidTimeout},
+	}
+
+	for i, tc := range cases {
+		err := tc.msg.ValidateBasic()
+		if tc.valid {
+			require.Nil(t, err, "%d: %+v", i, err)
+		} else {
+			require.NotNil(t, err, "%d", i)
+		}
+	}
+}
+
+func constructTransferMsg() IBCTransferMsg {
+	return IBCTransferMsg{
+		SrcAddr:    sdk.Address([]byte("source")),
+		DestAddr:   sdk.Address([]byte("destination")),
+		Coins:      sdk.Coins{sdk.NewCoin("atom", 10)},
+		SrcChannel: "channel-0",
+		DestChain:  "dest-chain",
+	}
+}
@@ -13,6 +13,7 @@ var (
 
 func init() {
 	msgCdc = wire.NewCodec()
+	wire.RegisterCrypto(msgCdc)
 }
 
 // ------------------------------
@@ -20,42 +21,42 @@ func init() {
 
 // nolint - TODO rename to Packet as IBCPacket stutters (golint)
 // IBCPacket defines a piece of data that can be send between two separate
-// blockchains.
+// blockchains, from a port and channel on the source chain to the port and
+// channel at the other end on the destination chain. Data is opaque to the
+// IBC handler and interpreted by the module bound to the destination port.
+// The packet can no longer be received once the destination chain reaches
+// the Timeout height, zero meaning it never times out.
 type IBCPacket struct {
-	SrcAddr   sdk.Address
-	DestAddr  sdk.Address
-	Coins     sdk.Coins
-	SrcChain  string
-	DestChain string
+	SrcChain    string
+	SrcPort     string
+	SrcChannel  string
+	DestChain   string
+	DestPort    string
+	DestChannel string
+	Data        []byte
+	Timeout     int64
 }
 
-func NewIBCPacket(srcAddr sdk.Address, destAddr sdk.Address, coins sdk.Coins,
-	srcChain string, destChain string) IBCPacket {
+// NewIBCPacket returns a packet carrying data from a channel of srcPort to
+// the other end of that channel.
+func NewIBCPacket(srcChain, srcPort, srcChannel, destChain, destPort, destChannel string,
+	data []byte, timeout int64) IBCPacket {
 
 	return IBCPacket{
-		SrcAddr:   srcAddr,
-		DestAddr:  destAddr,
-		Coins:     coins,
-		SrcChain:  srcChain,
-		DestChain: destChain,
+		SrcChain:    srcChain,
+		SrcPort:     srcPort,
+		SrcChannel:  srcChannel,
+		DestChain:   destChain,
+		DestPort:    destPort,
+		DestChannel: destChannel,
+		Data:        data,
+		Timeout:     timeout,
 	}
 }
 
 //nolint
 func (p IBCPacket) GetSignBytes() []byte {
-	b, err := msgCdc.MarshalJSON(struct {
-		SrcAddr   string
-		DestAddr  string
-		Coins     sdk.Coins
-		SrcChain  string
-		DestChain string
-	}{
-		SrcAddr:   sdk.MustBech32ifyAcc(p.SrcAddr),
-		DestAddr:  sdk.MustBech32ifyAcc(p.DestAddr),
-		Coins:     p.Coins,
-		SrcChain:  p.SrcChain,
-		DestChain: p.DestChain,
-	})
+	b, err := msgCdc.MarshalJSON(p)
 	if err != nil {
 		panic(err)
 	}
@@ -67,35 +68,33 @@ func (p IBCPacket) ValidateBasic() sdk.Error {
 	if p.SrcChain == p.DestChain {
 		return ErrIdenticalChains(DefaultCodespace).TraceSDK("")
 	}
-	if !p.Coins.IsValid() {
-		return sdk.ErrInvalidCoins("")
+	ids := []struct{ kind, id string }{
+		{"chain", p.SrcChain},
+		{"chain", p.DestChain},
+		{"port", p.SrcPort},
+		{"channel", p.SrcChannel},
+		{"port", p.DestPort},
+		{"channel", p.DestChannel},
+	}
+	for _, id := range ids {
+		err := validateIdentifier(id.kind, id.id)
+		if err != nil {
+			return err
+		}
+	}
+	if len(p.Data) == 0 {
+		return ErrInvalidPacketData(DefaultCodespace, "IBC packet carries no data")
+	}
+	if p.Timeout < 0 {
+		return ErrInvalidTimeout(DefaultCodespace, p.Timeout)
 	}
 	return nil
 }
 
-// ----------------------------------
-// IBCTransferMsg
-
-// nolint - TODO rename to TransferMsg as folks will reference with ibc.TransferMsg
-// IBCTransferMsg defines how another module can send an IBCPacket.
-type IBCTransferMsg struct {
-	IBCPacket
-}
-
-// nolint
-func (msg IBCTransferMsg) Type() string { return "ibc" }
-
-// x/bank/tx.go MsgSend.GetSigners()
-func (msg IBCTransferMsg) GetSigners() []sdk.Address { return []sdk.Address{msg.SrcAddr} }
-
-// get the sign bytes for ibc transfer message
-func (msg IBCTransferMsg) GetSignBytes() []byte {
-	return msg.IBCPacket.GetSignBytes()
-}
-
-// validate ibc transfer message
-func (msg IBCTransferMsg) ValidateBasic() sdk.Error {
-	return msg.IBCPacket.ValidateBasic()
+// TimedOut returns whether the packet can no longer be received on the
+// destination chain at height.
+func (p IBCPacket) TimedOut(height int64) bool {
+	return p.Timeout != 0 && height >= p.Timeout
 }
 
 // ----------------------------------
@@ -103,16 +102,30 @@ func (msg IBCTransferMsg) ValidateBasic() sdk.Error {
 
 // nolint - TODO rename to ReceiveMsg as folks will reference with ibc.ReceiveMsg
 // IBCReceiveMsg defines the message that a relayer uses to post an IBCPacket
-// to the destination chain.
+// to the destination chain. Proof proves the packet is stored under its egress
+// key on the source chain, against the app hash of the source chain header at
+// Height tracked by the light client.
 type IBCReceiveMsg struct {
 	IBCPacket
 	Relayer  sdk.Address
 	Sequence int64
+	Proof    []byte
+	Height   int64
 }
 
 // nolint
-func (msg IBCReceiveMsg) Type() string             { return "ibc" }
-func (msg IBCReceiveMsg) ValidateBasic() sdk.Error { return msg.IBCPacket.ValidateBasic() }
+func (msg IBCReceiveMsg) Type() string { return "ibc" }
+
+// validate ibc receive message
+func (msg IBCReceiveMsg) ValidateBasic() sdk.Error {
+	if len(msg.Proof) == 0 {
+		return ErrInvalidProof(DefaultCodespace, "missing IBC packet proof")
+	}
+	if msg.Height <= 0 {
+		return ErrInvalidProof(DefaultCodespace, "IBC packet proof has no height")
+	}
+	return msg.IBCPacket.ValidateBasic()
+}
 
 // x/bank/tx.go MsgSend.GetSigners()
 func (msg IBCReceiveMsg) GetSigners() []sdk.Address { return []sdk.Address{msg.Relayer} }
@@ -123,13 +136,151 @@ func (msg IBCReceiveMsg) GetSignBytes() []byte {
 		IBCPacket json.RawMessage
 		Relayer   string
 		Sequence  int64
+		Proof     []byte
+		Height    int64
 	}{
 		IBCPacket: json.RawMessage(msg.IBCPacket.GetSignBytes()),
 		Relayer:   sdk.MustBech32ifyAcc(msg.Relayer),
 		Sequence:  msg.Sequence,
+		Proof:     msg.Proof,
+		Height:    msg.Height,
 	})
 	if err != nil {
 		panic(err)
 	}
 	return b
 }
+
+// ----------------------------------
+// Receipt
+
+// Receipt is written by the destination chain for every packet it processes,
+// so that the source chain can settle the packet. Code is the result of
+// executing the packet, which failed unless it is OK.
+type Receipt struct {
+	Code sdk.WRSPCodeType
+}
+
+// nolint
+func (r Receipt) IsOK() bool { return r.Code.IsOK() }
+
+// ----------------------------------
+// MsgAcknowledgement
+
+// MsgAcknowledgement settles the packet sent on a channel of the source chain
+// with the receipt the destination chain wrote for it. Proof proves the receipt
+// against the app hash of the destination chain header at Height. The module
+// bound to the port is handed the receipt to settle the packet.
+type MsgAcknowledgement struct {
+	Port      string
+	ChannelID string
+	Sequence  int64
+	Receipt   Receipt
+	Proof     []byte
+	Height    int64
+	Relayer   sdk.Address
+}
+
+// nolint
+func (msg MsgAcknowledgement) Type() string              { return "ibc" }
+func (msg MsgAcknowledgement) GetSigners() []sdk.Address { return []sdk.Address{msg.Relayer} }
+
+// get the sign bytes for acknowledgement message
+func (msg MsgAcknowledgement) GetSignBytes() []byte {
+	b, err := msgCdc.MarshalJSON(struct {
+		Port      string
+		ChannelID string
+		Sequence  int64
+		Receipt   Receipt
+		Proof     []byte
+		Height    int64
+		Relayer   string
+	}{
+		Port:      msg.Port,
+		ChannelID: msg.ChannelID,
+		Sequence:  msg.Sequence,
+		Receipt:   msg.Receipt,
+		Proof:     msg.Proof,
+		Height:    msg.Height,
+		Relayer:   sdk.MustBech32ifyAcc(msg.Relayer),
+	})
+	if err != nil {
+		panic(err)
+	}
+	return b
+}
+
+// validate acknowledgement message
+func (msg MsgAcknowledgement) ValidateBasic() sdk.Error {
+	return validateSettleMsg(msg.Port, msg.ChannelID, msg.Proof, msg.Height, msg.Relayer)
+}
+
+// ----------------------------------
+// MsgTimeout
+
+// MsgTimeout settles the packet sent on a channel of the source chain once it
+// has timed out without being received, notifying the module bound to the
+// port. Proof proves the absence of a receipt for the packet against the app
+// hash of the destination chain header at Height, which must be at or above
+// the packet timeout.
+type MsgTimeout struct {
+	Port      string
+	ChannelID string
+	Sequence  int64
+	Proof     []byte
+	Height    int64
+	Relayer   sdk.Address
+}
+
+// nolint
+func (msg MsgTimeout) Type() string              { return "ibc" }
+func (msg MsgTimeout) GetSigners() []sdk.Address { return []sdk.Address{msg.Relayer} }
+
+// get the sign bytes for timeout message
+func (msg MsgTimeout) GetSignBytes() []byte {
+	b, err := msgCdc.MarshalJSON(struct {
+		Port      string
+		ChannelID string
+		Sequence  int64
+		Proof     []byte
+		Height    int64
+		Relayer   string
+	}{
+		Port:      msg.Port,
+		ChannelID: msg.ChannelID,
+		Sequence:  msg.Sequence,
+		Proof:     msg.Proof,
+		Height:    msg.Height,
+		Relayer:   sdk.MustBech32ifyAcc(msg.Relayer),
+	})
+	if err != nil {
+		panic(err)
+	}
+	return b
+}
+
+// validate timeout message
+func (msg MsgTimeout) ValidateBasic() sdk.Error {
+	return validateSettleMsg(msg.Port, msg.ChannelID, msg.Proof, msg.Height, msg.Relayer)
+}
+
+func validateSettleMsg(port, channelID string, proof []byte, height int64, relayer sdk.Address) sdk.Error {
+	err := validateIdentifier("port", port)
+	if err != nil {
+		return err
+	}
+	err = validateIdentifier("channel", channelID)
+	if err != nil {
+		return err
+	}
+	if len(proof) == 0 {
+		return ErrInvalidProof(DefaultCodespace, "missing IBC receipt proof")
+	}
+	if height <= 0 {
+		return ErrInvalidProof(DefaultCodespace, "IBC receipt proof has no height")
+	}
+	if len(relayer) == 0 {
+		return sdk.ErrInvalidAddress("relayer address is empty")
+	}
+	return nil
+}
@@ -18,6 +18,8 @@ func TestIBCPacketValidation(t *testing.T) {
 	}{
 		{true, constructIBCPacket(true)},
 		{false, constructIBCPacket(false)},
+		{false, NewIBCPacket("chain", PortTransfer, "channel-0", "chain", PortTransfer, "channel-1", []byte("data"), 0)},
+		{false, NewIBCPacket("source-chain", PortTransfer, "channel-0", "dest-chain", PortTransfer, "channel-1", nil, 0)},
 	}
 
 	for i, tc := range cases {
@@ -30,26 +32,41 @@ func TestIBCPacketValidation(t *testing.T) {
 	}
 }
 
+func TestIBCPacketTimedOut(t *testing.T) {
+	packet := constructIBCPacket(true)
+	require.False(t, packet.TimedOut(100))
+
+	packet.Timeout = 10
+	require.False(t, packet.TimedOut(9))
+	require.True(t, packet.TimedOut(10))
+	require.True(t, packet.TimedOut(11))
+
+	packet.Timeout = -1
+	require.NotNil(t, packet.ValidateBasic())
+}
+
 // -------------------------------
-// IBCTransferMsg Tests
+// IBCReceiveMsg Tests
 
-func TestIBCTransferMsg(t *testing.T) {
+func TestIBCReceiveMsg(t *testing.T) {
 	packet := constructIBCPacket(true)
-	msg := IBCTransferMsg{packet}
+	msg := IBCReceiveMsg{packet, sdk.Address([]byte("relayer")), 0, []byte("proof"), 1}
 
 	require.Equal(t, msg.Type(), "ibc")
 }
 
-func TestIBCTransferMsgValidation(t *testing.T) {
+func TestIBCReceiveMsgValidation(t *testing.T) {
 	validPacket := constructIBCPacket(true)
 	invalidPacket := constructIBCPacket(false)
 
 	cases := []struct {
 		valid bool
-		msg   IBCTransferMsg
+		msg   IBCReceiveMsg
 	}{
-		{true, IBCTransferMsg{validPacket}},
-		{false, IBCTransferMsg{invalidPacket}},
+		{true, IBCReceiveMsg{validPacket, sdk.Address([]byte("relayer")), 0, []byte("proof"), 1}},
+		{false, IBCReceiveMsg{invalidPacket, sdk.Address([]byte("relayer")), 0, []byte("proof"), 1}},
+		{false, IBCReceiveMsg{validPacket, sdk.Address([]byte("relayer")), 0, nil, 1}},
+		{false, IBCReceiveMsg{validPacket, sdk.Address([]byte("relayer")), 0, []byte("proof"), 0}},
 	}
 
 	for i, tc := range cases {
@@ -63,25 +80,20 @@ func TestIBCTransferMsgValidation(t *testing.T) {
 }
 
 // -------------------------------
-// IBCReceiveMsg Tests
-
-func TestIBCReceiveMsg(t *testing.T) {
-	packet := constructIBCPacket(true)
-	msg := IBCReceiveMsg{packet, sdk.Address([]byte("relayer")), 0}
-
-	require.Equal(t, msg.Type(), "ibc")
-}
-
-func TestIBCReceiveMsgValidation(t *testing.T) {
-	validPacket := constructIBCPacket(true)
-	invalidPacket := constructIBCPacket(false)
+// MsgTimeout Tests
 
+func TestMsgTimeoutValidation(t *testing.T) {
+	relayer := sdk.Address([]byte("relayer"))
 	cases := []struct {
 		valid bool
-		msg   IBCReceiveMsg
+		msg   MsgTimeout
 	}{
-		{true, IBCReceiveMsg{validPacket, sdk.Address([]byte("relayer")), 0}},
-		{false, IBCReceiveMsg{invalidPacket, sdk.Address([]byte("relayer")), 0}},
+		{true, MsgTimeout{PortTransfer, "channel-0", 0, []byte("proof"), 1, relayer}},
+		{false, MsgTimeout{"", "channel-0", 0, []byte("proof"), 1, relayer}},
+		{false, MsgTimeout{PortTransfer, "channel/0", 0, []byte("proof"), 1, relayer}},
+		{false, MsgTimeout{PortTransfer, "channel-0", 0, nil, 1, relayer}},
+		{false, MsgTimeout{PortTransfer, "channel-0", 0, []byte("proof"), 0, relayer}},
+		{false, MsgTimeout{PortTransfer, "channel-0", 0, []byte("proof"), 1, nil}},
 	}
 
 	for i, tc := range cases {
@@ -98,14 +110,12 @@ func TestIBCReceiveMsgValidation(t *testing.T) {
 // Helpers
 
 func constructIBCPacket(valid bool) IBCPacket {
-	srcAddr := sdk.Address([]byte("source"))
-	destAddr := sdk.Address([]byte("destination"))
-	coins := sdk.Coins{sdk.NewCoin("atom", 10)}
+	data := []byte("data")
 	srcChain := "source-chain"
 	destChain := "dest-chain"
 
 	if valid {
-		return NewIBCPacket(srcAddr, destAddr, coins, srcChain, destChain)
+		return NewIBCPacket(srcChain, PortTransfer, "channel-0", destChain, PortTransfer, "channel-1", data, 0)
 	}
-	return NewIBCPacket(srcAddr, destAddr, coins, srcChain, srcChain)
+	return NewIBCPacket(srcChain, PortTransfer, "channel-0", destChain, PortTransfer, "", data, 0)
 }
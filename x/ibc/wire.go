@@ -8,4 +8,16 @@ import (
 func RegisterWire(cdc *wire.Codec) {
 	cdc.RegisterConcrete(IBCTransferMsg{}, "tepleton-sdk/IBCTransferMsg", nil)
 	cdc.RegisterConcrete(IBCReceiveMsg{}, "tepleton-sdk/IBCReceiveMsg", nil)
+	cdc.RegisterConcrete(MsgAcknowledgement{}, "tepleton-sdk/MsgAcknowledgement", nil)
+	cdc.RegisterConcrete(MsgTimeout{}, "tepleton-sdk/MsgTimeout", nil)
+	cdc.RegisterConcrete(MsgCreateClient{}, "tepleton-sdk/MsgCreateClient", nil)
+	cdc.RegisterConcrete(MsgUpdateClient{}, "tepleton-sdk/MsgUpdateClient", nil)
+	cdc.RegisterConcrete(MsgConnectionOpenInit{}, "tepleton-sdk/MsgConnectionOpenInit", nil)
+	cdc.RegisterConcrete(MsgConnectionOpenTry{}, "tepleton-sdk/MsgConnectionOpenTry", nil)
+	cdc.RegisterConcrete(MsgConnectionOpenAck{}, "tepleton-sdk/MsgConnectionOpenAck", nil)
+	cdc.RegisterConcrete(MsgConnectionOpenConfirm{}, "tepleton-sdk/MsgConnectionOpenConfirm", nil)
+	cdc.RegisterConcrete(MsgChannelOpenInit{}, "tepleton-sdk/MsgChannelOpenInit", nil)
+	cdc.RegisterConcrete(MsgChannelOpenTry{}, "tepleton-sdk/MsgChannelOpenTry", nil)
+	cdc.RegisterConcrete(MsgChannelOpenAck{}, "tepleton-sdk/MsgChannelOpenAck", nil)
+	cdc.RegisterConcrete(MsgChannelOpenConfirm{}, "tepleton-sdk/MsgChannelOpenConfirm", nil)
 }
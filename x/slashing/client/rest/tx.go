@@ -9,6 +9,7 @@ import (
 
 	"github.com/gorilla/mux"
 
+	"github.com/tepleton/tepleton-sdk/client"
 	"github.com/tepleton/tepleton-sdk/client/context"
 	"github.com/tepleton/tepleton-sdk/crypto/keys"
 	sdk "github.com/tepleton/tepleton-sdk/types"
@@ -30,7 +31,7 @@ type UnrevokeBody struct {
 	ChainID          string `json:"chain_id"`
 	AccountNumber    int64  `json:"account_number"`
 	Sequence         int64  `json:"sequence"`
-	Gas              int64  `json:"gas"`
+	Gas              string `json:"gas"`
 	ValidatorAddr    string `json:"validator_addr"`
 }
 
@@ -70,13 +71,25 @@ func unrevokeRequestHandlerFn(cdc *wire.Codec, kb keys.Keybase, ctx context.Core
 			return
 		}
 
-		ctx = ctx.WithGas(m.Gas)
+		simulate, gas, err := client.ParseGas(m.Gas)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(err.Error()))
+			return
+		}
+		ctx = ctx.WithGas(gas).WithSimulate(simulate)
 		ctx = ctx.WithChainID(m.ChainID)
 		ctx = ctx.WithAccountNumber(m.AccountNumber)
 		ctx = ctx.WithSequence(m.Sequence)
 
 		msg := slashing.NewMsgUnrevoke(validatorAddr)
 
+		ctx, err = ctx.EnsureGas(m.LocalAccountName, m.Password, []sdk.Msg{msg}, cdc)
+		if err != nil {
+			w.WriteHeader(http.StatusUnauthorized)
+			w.Write([]byte(err.Error()))
+			return
+		}
 		txBytes, err := ctx.SignAndBuild(m.LocalAccountName, m.Password, []sdk.Msg{msg}, cdc)
 		if err != nil {
 			w.WriteHeader(http.StatusUnauthorized)
@@ -0,0 +1,13 @@
+// nolint
+package slashing
+
+// slashing events
+const (
+	ActionUnrevoke = "unrevoke"
+
+	// EventTypeBeginBlock is emitted by the begin blocker with the height
+	EventTypeBeginBlock = "beginBlock"
+
+	AttributeKeyValidator = "validator"
+	AttributeKeyHeight    = "height"
+)
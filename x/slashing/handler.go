@@ -50,9 +50,12 @@ func handleMsgUnrevoke(ctx sdk.Context, msg MsgUnrevoke, k Keeper) sdk.Result {
 	// Unrevoke the validator
 	k.validatorSet.Unrevoke(ctx, validator.GetPubKey())
 
-	tags := sdk.NewTags("action", []byte("unrevoke"), "validator", []byte(msg.ValidatorAddr.String()))
-
-	return sdk.Result{
-		Tags: tags,
-	}
+	ctx.EventManager().EmitEvent(sdk.NewEvent(sdk.EventTypeMessage,
+		sdk.NewAttribute(sdk.AttributeKeyModule, MsgType),
+		sdk.NewAttribute(sdk.AttributeKeyAction, ActionUnrevoke),
+		sdk.NewAttribute(sdk.AttributeKeySender, msg.ValidatorAddr.String()),
+		sdk.NewAttribute(AttributeKeyValidator, msg.ValidatorAddr.String()),
+	))
+
+	return sdk.Result{}
 }
@@ -34,9 +34,9 @@ var (
 var (
 	// SlashFractionDoubleSign - currently 5%
 	// TODO Governance parameter?
-	SlashFractionDoubleSign = sdk.NewRat(1).Quo(sdk.NewRat(20))
+	SlashFractionDoubleSign = sdk.NewDecWithPrec(5, 2)
 
 	// SlashFractionDowntime - currently 1%
 	// TODO Governance parameter?
-	SlashFractionDowntime = sdk.NewRat(1).Quo(sdk.NewRat(100))
+	SlashFractionDowntime = sdk.NewDecWithPrec(1, 2)
 )
@@ -1,8 +1,8 @@
 package slashing
 
 import (
-	"encoding/binary"
 	"fmt"
+	"strconv"
 
 	sdk "github.com/tepleton/tepleton-sdk/types"
 	wrsp "github.com/tepleton/tepleton/wrsp/types"
@@ -10,11 +10,11 @@ import (
 )
 
 // slashing begin block functionality
-func BeginBlocker(ctx sdk.Context, req wrsp.RequestBeginBlock, sk Keeper) (tags sdk.Tags) {
-	// Tag the height
-	heightBytes := make([]byte, 8)
-	binary.LittleEndian.PutUint64(heightBytes, uint64(req.Header.Height))
-	tags = sdk.NewTags("height", heightBytes)
+func BeginBlocker(ctx sdk.Context, req wrsp.RequestBeginBlock, sk Keeper) {
+	// Emit the height
+	ctx.EventManager().EmitEvent(sdk.NewEvent(EventTypeBeginBlock,
+		sdk.NewAttribute(AttributeKeyHeight, strconv.FormatInt(req.Header.Height, 10)),
+	))
 
 	// Iterate over all the validators  which *should* have signed this block
 	// Store whether or not they have actually signed it and slash/unbond any
@@ -43,6 +43,4 @@ func BeginBlocker(ctx sdk.Context, req wrsp.RequestBeginBlock, sk Keeper) (tags
 			ctx.Logger().With("module", "x/slashing").Error(fmt.Sprintf("ignored unknown evidence type: %s", evidence.Type))
 		}
 	}
-
-	return
 }
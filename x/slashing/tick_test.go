@@ -21,7 +21,7 @@ func TestBeginBlocker(t *testing.T) {
 	require.True(t, got.IsOK())
 	stake.EndBlocker(ctx, sk)
 	require.Equal(t, ck.GetCoins(ctx, addr), sdk.Coins{{sk.GetParams(ctx).BondDenom, initCoins.Sub(amt)}})
-	require.True(t, sdk.NewRatFromInt(amt).Equal(sk.Validator(ctx, addr).GetPower()))
+	require.True(t, sdk.NewDecFromInt(amt).Equal(sk.Validator(ctx, addr).GetPower()))
 
 	val := wrsp.Validator{
 		PubKey: tmtypes.TM2PB.PubKey(pk),
@@ -30,12 +30,15 @@ func TestBeginBlocker(t *testing.T) {
 
 	// mark the validator as having signed
 	req := wrsp.RequestBeginBlock{
+		Header: wrsp.Header{Height: 1},
 		Validators: []wrsp.SigningValidator{{
 			Validator:       val,
 			SignedLastBlock: true,
 		}},
 	}
-	BeginBlocker(ctx, req, keeper)
+	em := sdk.NewEventManager()
+	BeginBlocker(ctx.WithEventManager(em), req, keeper)
+	require.Equal(t, sdk.Tags{sdk.MakeTag("beginBlock.height", []byte("1"))}, em.Events().ToTags())
 
 	info, found := keeper.getValidatorSigningInfo(ctx, pk.Address())
 	require.True(t, found)
@@ -35,13 +35,15 @@ func getMockApp(t *testing.T) (*mock.App, Keeper) {
 
 	RegisterWire(mapp.Cdc)
 	keyStake := sdk.NewKVStoreKey("stake")
+	tkeyStake := sdk.NewTransientStoreKey("transient_stake")
 	coinKeeper := bank.NewKeeper(mapp.AccountMapper)
-	keeper := NewKeeper(mapp.Cdc, keyStake, coinKeeper, mapp.RegisterCodespace(DefaultCodespace))
+	keeper := NewKeeper(mapp.Cdc, keyStake, tkeyStake, coinKeeper, mapp.RegisterCodespace(DefaultCodespace))
 	mapp.Router().AddRoute("stake", NewHandler(keeper))
 
 	mapp.SetEndBlocker(getEndBlocker(keeper))
 	mapp.SetInitChainer(getInitChainer(mapp, keeper))
 
+	mapp.MountStoresTransient(tkeyStake)
 	require.NoError(t, mapp.CompleteSetup([]*sdk.KVStoreKey{keyStake}))
 	return mapp, keeper
 }
@@ -80,13 +82,13 @@ func checkValidator(t *testing.T, mapp *mock.App, keeper Keeper,
 }
 
 func checkDelegation(t *testing.T, mapp *mock.App, keeper Keeper, delegatorAddr,
-	validatorAddr sdk.Address, expFound bool, expShares sdk.Rat) {
+	validatorAddr sdk.Address, expFound bool, expShares sdk.Dec) {
 
 	ctxCheck := mapp.BaseApp.NewContext(true, wrsp.Header{})
 	delegation, found := keeper.GetDelegation(ctxCheck, delegatorAddr, validatorAddr)
 	if expFound {
 		require.True(t, found)
-		assert.True(sdk.RatEq(t, expShares, delegation.Shares))
+		assert.True(sdk.DecEq(t, expShares, delegation.Shares))
 		return
 	}
 	require.False(t, found)
@@ -126,10 +128,10 @@ func TestStakeMsgs(t *testing.T) {
 	validator := checkValidator(t, mapp, keeper, addr1, true)
 	require.Equal(t, addr1, validator.Owner)
 	require.Equal(t, sdk.Bonded, validator.Status())
-	require.True(sdk.RatEq(t, sdk.NewRat(10), validator.PoolShares.Bonded()))
+	require.True(sdk.DecEq(t, sdk.NewDec(10), validator.PoolShares.Bonded()))
 
 	// check the bond that should have been created as well
-	checkDelegation(t, mapp, keeper, addr1, addr1, true, sdk.NewRat(10))
+	checkDelegation(t, mapp, keeper, addr1, addr1, true, sdk.NewDec(10))
 
 	////////////////////
 	// Edit Validator
@@ -147,16 +149,16 @@ func TestStakeMsgs(t *testing.T) {
 	delegateMsg := NewMsgDelegate(addr2, addr1, bondCoin)
 	mock.SignCheckDeliver(t, mapp.BaseApp, []sdk.Msg{delegateMsg}, []int64{1}, []int64{0}, true, priv2)
 	mock.CheckBalance(t, mapp, addr2, sdk.Coins{genCoin.Minus(bondCoin)})
-	checkDelegation(t, mapp, keeper, addr2, addr1, true, sdk.NewRat(10))
+	checkDelegation(t, mapp, keeper, addr2, addr1, true, sdk.NewDec(10))
 
 	////////////////////
 	// Begin Unbonding
 
-	beginUnbondingMsg := NewMsgBeginUnbonding(addr2, addr1, sdk.NewRat(10))
+	beginUnbondingMsg := NewMsgBeginUnbonding(addr2, addr1, sdk.NewDec(10))
 	mock.SignCheckDeliver(t, mapp.BaseApp, []sdk.Msg{beginUnbondingMsg}, []int64{1}, []int64{1}, true, priv2)
 
 	// delegation should exist anymore
-	checkDelegation(t, mapp, keeper, addr2, addr1, false, sdk.Rat{})
+	checkDelegation(t, mapp, keeper, addr2, addr1, false, sdk.Dec{})
 
 	// balance should be the same because bonding not yet complete
 	mock.CheckBalance(t, mapp, addr2, sdk.Coins{genCoin.Minus(bondCoin)})
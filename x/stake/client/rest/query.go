@@ -40,6 +40,12 @@ func registerQueryRoutes(ctx context.CoreContext, r *mux.Router, cdc *wire.Codec
 // http request handler to query a delegation
 func delegationHandlerFn(ctx context.CoreContext, cdc *wire.Codec) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
+		ctx, err := ctx.WithHeightFromRequest(r)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(err.Error()))
+			return
+		}
 
 		// read parameters
 		vars := mux.Vars(r)
@@ -97,6 +103,12 @@ func delegationHandlerFn(ctx context.CoreContext, cdc *wire.Codec) http.HandlerF
 // http request handler to query an unbonding-delegation
 func ubdHandlerFn(ctx context.CoreContext, cdc *wire.Codec) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
+		ctx, err := ctx.WithHeightFromRequest(r)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(err.Error()))
+			return
+		}
 
 		// read parameters
 		vars := mux.Vars(r)
@@ -154,6 +166,12 @@ func ubdHandlerFn(ctx context.CoreContext, cdc *wire.Codec) http.HandlerFunc {
 // http request handler to query an redelegation
 func redHandlerFn(ctx context.CoreContext, cdc *wire.Codec) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
+		ctx, err := ctx.WithHeightFromRequest(r)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(err.Error()))
+			return
+		}
 
 		// read parameters
 		vars := mux.Vars(r)
@@ -223,20 +241,20 @@ type StakeValidatorOutput struct {
 	Revoked bool   `json:"revoked"` // has the validator been revoked from bonded status?
 
 	PoolShares      stake.PoolShares `json:"pool_shares"`      // total shares for tokens held in the pool
-	DelegatorShares sdk.Rat          `json:"delegator_shares"` // total shares issued to a validator's delegators
+	DelegatorShares sdk.Dec          `json:"delegator_shares"` // total shares issued to a validator's delegators
 
 	Description        stake.Description `json:"description"`           // description terms for the validator
 	BondHeight         int64             `json:"bond_height"`           // earliest height as a bonded validator
 	BondIntraTxCounter int16             `json:"bond_intra_tx_counter"` // block-local tx index of validator change
 	ProposerRewardPool sdk.Coins         `json:"proposer_reward_pool"`  // XXX reward pool collected from being the proposer
 
-	Commission            sdk.Rat `json:"commission"`              // XXX the commission rate of fees charged to any delegators
-	CommissionMax         sdk.Rat `json:"commission_max"`          // XXX maximum commission rate which this validator can ever charge
-	CommissionChangeRate  sdk.Rat `json:"commission_change_rate"`  // XXX maximum daily increase of the validator commission
-	CommissionChangeToday sdk.Rat `json:"commission_change_today"` // XXX commission rate change today, reset each day (UTC time)
+	Commission            sdk.Dec `json:"commission"`              // XXX the commission rate of fees charged to any delegators
+	CommissionMax         sdk.Dec `json:"commission_max"`          // XXX maximum commission rate which this validator can ever charge
+	CommissionChangeRate  sdk.Dec `json:"commission_change_rate"`  // XXX maximum daily increase of the validator commission
+	CommissionChangeToday sdk.Dec `json:"commission_change_today"` // XXX commission rate change today, reset each day (UTC time)
 
 	// fee related
-	PrevBondedShares sdk.Rat `json:"prev_bonded_shares"` // total shares of a global hold pools
+	PrevBondedShares sdk.Dec `json:"prev_bonded_shares"` // total shares of a global hold pools
 }
 
 func bech32StakeValidatorOutput(validator stake.Validator) (StakeValidatorOutput, error) {
@@ -275,6 +293,12 @@ func bech32StakeValidatorOutput(validator stake.Validator) (StakeValidatorOutput
 // http request handler to query list of validators
 func validatorsHandlerFn(ctx context.CoreContext, cdc *wire.Codec) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
+		ctx, err := ctx.WithHeightFromRequest(r)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(err.Error()))
+			return
+		}
 		kvs, err := ctx.QuerySubspace(cdc, stake.ValidatorsKey, storeName)
 		if err != nil {
 			w.WriteHeader(http.StatusInternalServerError)
@@ -82,15 +82,12 @@ func handleMsgCreateValidator(ctx sdk.Context, msg types.MsgCreateValidator, k k
 		return err.Result()
 	}
 
-	tags := sdk.NewTags(
-		tags.Action, tags.ActionCreateValidator,
-		tags.DstValidator, []byte(msg.ValidatorAddr.String()),
-		tags.Moniker, []byte(msg.Description.Moniker),
-		tags.Identity, []byte(msg.Description.Identity),
+	emitMsgEvent(ctx, tags.ActionCreateValidator, msg.ValidatorAddr,
+		sdk.NewAttribute(tags.DstValidator, msg.ValidatorAddr.String()),
+		sdk.NewAttribute(tags.Moniker, msg.Description.Moniker),
+		sdk.NewAttribute(tags.Identity, msg.Description.Identity),
 	)
-	return sdk.Result{
-		Tags: tags,
-	}
+	return sdk.Result{}
 }
 
 func handleMsgEditValidator(ctx sdk.Context, msg types.MsgEditValidator, k keeper.Keeper) sdk.Result {
@@ -109,15 +106,12 @@ func handleMsgEditValidator(ctx sdk.Context, msg types.MsgEditValidator, k keepe
 	validator.Description = description
 
 	k.UpdateValidator(ctx, validator)
-	tags := sdk.NewTags(
-		tags.Action, tags.ActionEditValidator,
-		tags.DstValidator, []byte(msg.ValidatorAddr.String()),
-		tags.Moniker, []byte(description.Moniker),
-		tags.Identity, []byte(description.Identity),
+	emitMsgEvent(ctx, tags.ActionEditValidator, msg.ValidatorAddr,
+		sdk.NewAttribute(tags.DstValidator, msg.ValidatorAddr.String()),
+		sdk.NewAttribute(tags.Moniker, description.Moniker),
+		sdk.NewAttribute(tags.Identity, description.Identity),
 	)
-	return sdk.Result{
-		Tags: tags,
-	}
+	return sdk.Result{}
 }
 
 func handleMsgDelegate(ctx sdk.Context, msg types.MsgDelegate, k keeper.Keeper) sdk.Result {
@@ -137,14 +131,11 @@ func handleMsgDelegate(ctx sdk.Context, msg types.MsgDelegate, k keeper.Keeper)
 		return err.Result()
 	}
 
-	tags := sdk.NewTags(
-		tags.Action, tags.ActionDelegate,
-		tags.Delegator, []byte(msg.DelegatorAddr.String()),
-		tags.DstValidator, []byte(msg.ValidatorAddr.String()),
+	emitMsgEvent(ctx, tags.ActionDelegate, msg.DelegatorAddr,
+		sdk.NewAttribute(tags.Delegator, msg.DelegatorAddr.String()),
+		sdk.NewAttribute(tags.DstValidator, msg.ValidatorAddr.String()),
 	)
-	return sdk.Result{
-		Tags: tags,
-	}
+	return sdk.Result{}
 }
 
 func handleMsgBeginUnbonding(ctx sdk.Context, msg types.MsgBeginUnbonding, k keeper.Keeper) sdk.Result {
@@ -153,12 +144,11 @@ func handleMsgBeginUnbonding(ctx sdk.Context, msg types.MsgBeginUnbonding, k kee
 		return err.Result()
 	}
 
-	tags := sdk.NewTags(
-		tags.Action, tags.ActionBeginUnbonding,
-		tags.Delegator, []byte(msg.DelegatorAddr.String()),
-		tags.SrcValidator, []byte(msg.ValidatorAddr.String()),
+	emitMsgEvent(ctx, tags.ActionBeginUnbonding, msg.DelegatorAddr,
+		sdk.NewAttribute(tags.Delegator, msg.DelegatorAddr.String()),
+		sdk.NewAttribute(tags.SrcValidator, msg.ValidatorAddr.String()),
 	)
-	return sdk.Result{Tags: tags}
+	return sdk.Result{}
 }
 
 func handleMsgCompleteUnbonding(ctx sdk.Context, msg types.MsgCompleteUnbonding, k keeper.Keeper) sdk.Result {
@@ -168,13 +158,11 @@ func handleMsgCompleteUnbonding(ctx sdk.Context, msg types.MsgCompleteUnbonding,
 		return err.Result()
 	}
 
-	tags := sdk.NewTags(
-		tags.Action, ActionCompleteUnbonding,
-		tags.Delegator, []byte(msg.DelegatorAddr.String()),
-		tags.SrcValidator, []byte(msg.ValidatorAddr.String()),
+	emitMsgEvent(ctx, tags.ActionCompleteUnbonding, msg.DelegatorAddr,
+		sdk.NewAttribute(tags.Delegator, msg.DelegatorAddr.String()),
+		sdk.NewAttribute(tags.SrcValidator, msg.ValidatorAddr.String()),
 	)
-
-	return sdk.Result{Tags: tags}
+	return sdk.Result{}
 }
 
 func handleMsgBeginRedelegate(ctx sdk.Context, msg types.MsgBeginRedelegate, k keeper.Keeper) sdk.Result {
@@ -184,13 +172,12 @@ func handleMsgBeginRedelegate(ctx sdk.Context, msg types.MsgBeginRedelegate, k k
 		return err.Result()
 	}
 
-	tags := sdk.NewTags(
-		tags.Action, tags.ActionBeginRedelegation,
-		tags.Delegator, []byte(msg.DelegatorAddr.String()),
-		tags.SrcValidator, []byte(msg.ValidatorSrcAddr.String()),
-		tags.DstValidator, []byte(msg.ValidatorDstAddr.String()),
+	emitMsgEvent(ctx, tags.ActionBeginRedelegation, msg.DelegatorAddr,
+		sdk.NewAttribute(tags.Delegator, msg.DelegatorAddr.String()),
+		sdk.NewAttribute(tags.SrcValidator, msg.ValidatorSrcAddr.String()),
+		sdk.NewAttribute(tags.DstValidator, msg.ValidatorDstAddr.String()),
 	)
-	return sdk.Result{Tags: tags}
+	return sdk.Result{}
 }
 
 func handleMsgCompleteRedelegate(ctx sdk.Context, msg types.MsgCompleteRedelegate, k keeper.Keeper) sdk.Result {
@@ -199,11 +186,19 @@ func handleMsgCompleteRedelegate(ctx sdk.Context, msg types.MsgCompleteRedelegat
 		return err.Result()
 	}
 
-	tags := sdk.NewTags(
-		tags.Action, tags.ActionCompleteRedelegation,
-		tags.Delegator, []byte(msg.DelegatorAddr.String()),
-		tags.SrcValidator, []byte(msg.ValidatorSrcAddr.String()),
-		tags.DstValidator, []byte(msg.ValidatorDstAddr.String()),
+	emitMsgEvent(ctx, tags.ActionCompleteRedelegation, msg.DelegatorAddr,
+		sdk.NewAttribute(tags.Delegator, msg.DelegatorAddr.String()),
+		sdk.NewAttribute(tags.SrcValidator, msg.ValidatorSrcAddr.String()),
+		sdk.NewAttribute(tags.DstValidator, msg.ValidatorDstAddr.String()),
 	)
-	return sdk.Result{Tags: tags}
+	return sdk.Result{}
+}
+
+// emit the message event of a stake msg, with the attributes of its action
+func emitMsgEvent(ctx sdk.Context, action string, sender sdk.Address, attrs ...sdk.Attribute) {
+	ctx.EventManager().EmitEvent(sdk.NewEvent(sdk.EventTypeMessage,
+		sdk.NewAttribute(sdk.AttributeKeyModule, types.MsgType),
+		sdk.NewAttribute(sdk.AttributeKeyAction, action),
+		sdk.NewAttribute(sdk.AttributeKeySender, sender.String()),
+	).AppendAttributes(attrs...))
 }
@@ -219,7 +219,7 @@ func (k Keeper) RemoveRedelegation(ctx sdk.Context, red types.Redelegation) {
 
 // Perform a delegation, set/update everything necessary within the store
 func (k Keeper) Delegate(ctx sdk.Context, delegatorAddr sdk.Address, bondAmt sdk.Coin,
-	validator types.Validator) (newShares sdk.Rat, err sdk.Error) {
+	validator types.Validator) (newShares sdk.Dec, err sdk.Error) {
 
 	// Get or create the delegator delegation
 	delegation, found := k.GetDelegation(ctx, delegatorAddr, validator.Owner)
@@ -227,7 +227,7 @@ func (k Keeper) Delegate(ctx sdk.Context, delegatorAddr sdk.Address, bondAmt sdk
 		delegation = types.Delegation{
 			DelegatorAddr: delegatorAddr,
 			ValidatorAddr: validator.Owner,
-			Shares:        sdk.ZeroRat(),
+			Shares:        sdk.ZeroDec(),
 		}
 	}
 
@@ -252,7 +252,7 @@ func (k Keeper) Delegate(ctx sdk.Context, delegatorAddr sdk.Address, bondAmt sdk
 
 // unbond the the delegation return
 func (k Keeper) unbond(ctx sdk.Context, delegatorAddr, validatorAddr sdk.Address,
-	shares sdk.Rat) (amount int64, err sdk.Error) {
+	shares sdk.Dec) (amount int64, err sdk.Error) {
 
 	// check if delegation has any shares in it unbond
 	delegation, found := k.GetDelegation(ctx, delegatorAddr, validatorAddr)
@@ -310,7 +310,7 @@ func (k Keeper) unbond(ctx sdk.Context, delegatorAddr, validatorAddr sdk.Address
 //______________________________________________________________________________________________________
 
 // complete unbonding an unbonding record
-func (k Keeper) BeginUnbonding(ctx sdk.Context, delegatorAddr, validatorAddr sdk.Address, sharesAmount sdk.Rat) sdk.Error {
+func (k Keeper) BeginUnbonding(ctx sdk.Context, delegatorAddr, validatorAddr sdk.Address, sharesAmount sdk.Dec) sdk.Error {
 
 	returnAmount, err := k.unbond(ctx, delegatorAddr, validatorAddr, sharesAmount)
 	if err != nil {
@@ -357,7 +357,7 @@ func (k Keeper) CompleteUnbonding(ctx sdk.Context, delegatorAddr, validatorAddr
 
 // complete unbonding an unbonding record
 func (k Keeper) BeginRedelegation(ctx sdk.Context, delegatorAddr, validatorSrcAddr,
-	validatorDstAddr sdk.Address, sharesAmount sdk.Rat) sdk.Error {
+	validatorDstAddr sdk.Address, sharesAmount sdk.Dec) sdk.Error {
 
 	// check if this is a transitive redelegation
 	if k.HasReceivingRedelegation(ctx, delegatorAddr, validatorSrcAddr) {
@@ -32,7 +32,7 @@ func TestDelegation(t *testing.T) {
 	bond1to1 := types.Delegation{
 		DelegatorAddr: addrDels[0],
 		ValidatorAddr: addrVals[0],
-		Shares:        sdk.NewRat(9),
+		Shares:        sdk.NewDec(9),
 	}
 
 	// check the empty keeper first
@@ -46,18 +46,18 @@ func TestDelegation(t *testing.T) {
 	require.True(t, bond1to1.Equal(resBond))
 
 	// modify a records, save, and retrieve
-	bond1to1.Shares = sdk.NewRat(99)
+	bond1to1.Shares = sdk.NewDec(99)
 	keeper.SetDelegation(ctx, bond1to1)
 	resBond, found = keeper.GetDelegation(ctx, addrDels[0], addrVals[0])
 	require.True(t, found)
 	require.True(t, bond1to1.Equal(resBond))
 
 	// add some more records
-	bond1to2 := types.Delegation{addrDels[0], addrVals[1], sdk.NewRat(9), 0}
-	bond1to3 := types.Delegation{addrDels[0], addrVals[2], sdk.NewRat(9), 1}
-	bond2to1 := types.Delegation{addrDels[1], addrVals[0], sdk.NewRat(9), 2}
-	bond2to2 := types.Delegation{addrDels[1], addrVals[1], sdk.NewRat(9), 3}
-	bond2to3 := types.Delegation{addrDels[1], addrVals[2], sdk.NewRat(9), 4}
+	bond1to2 := types.Delegation{addrDels[0], addrVals[1], sdk.NewDec(9), 0}
+	bond1to3 := types.Delegation{addrDels[0], addrVals[2], sdk.NewDec(9), 1}
+	bond2to1 := types.Delegation{addrDels[1], addrVals[0], sdk.NewDec(9), 2}
+	bond2to2 := types.Delegation{addrDels[1], addrVals[1], sdk.NewDec(9), 3}
+	bond2to3 := types.Delegation{addrDels[1], addrVals[2], sdk.NewDec(9), 4}
 	keeper.SetDelegation(ctx, bond1to2)
 	keeper.SetDelegation(ctx, bond1to3)
 	keeper.SetDelegation(ctx, bond2to1)
@@ -164,7 +164,7 @@ func TestUnbondDelegation(t *testing.T) {
 
 	var err error
 	var amount int64
-	amount, err = keeper.unbond(ctx, addrDels[0], addrVals[0], sdk.NewRat(6))
+	amount, err = keeper.unbond(ctx, addrDels[0], addrVals[0], sdk.NewDec(6))
 	require.NoError(t, err)
 	require.Equal(t, int64(6), amount) // shares to be added to an unbonding delegation / redelegation
 
@@ -190,8 +190,8 @@ func TestGetRedelegationsFromValidator(t *testing.T) {
 		ValidatorDstAddr: addrVals[1],
 		CreationHeight:   0,
 		MinTime:          0,
-		SharesSrc:        sdk.NewRat(5),
-		SharesDst:        sdk.NewRat(5),
+		SharesSrc:        sdk.NewDec(5),
+		SharesDst:        sdk.NewDec(5),
 	}
 
 	// set and retrieve a record
@@ -224,8 +224,8 @@ func TestRedelegation(t *testing.T) {
 		ValidatorDstAddr: addrVals[1],
 		CreationHeight:   0,
 		MinTime:          0,
-		SharesSrc:        sdk.NewRat(5),
-		SharesDst:        sdk.NewRat(5),
+		SharesSrc:        sdk.NewDec(5),
+		SharesDst:        sdk.NewDec(5),
 	}
 
 	// test shouldn't have and redelegations
@@ -246,8 +246,8 @@ func TestRedelegation(t *testing.T) {
 	require.True(t, has)
 
 	// modify a records, save, and retrieve
-	rd.SharesSrc = sdk.NewRat(21)
-	rd.SharesDst = sdk.NewRat(21)
+	rd.SharesSrc = sdk.NewDec(21)
+	rd.SharesDst = sdk.NewDec(21)
 
 	keeper.SetRedelegation(ctx, rd)
 
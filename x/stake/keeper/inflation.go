@@ -5,12 +5,9 @@ import (
 	"github.com/tepleton/tepleton-sdk/x/stake/types"
 )
 
-const (
-	hrsPerYr  = 8766         // as defined by a julian year of 365.25 days
-	precision = 100000000000 // increased to this precision for accuracy
-)
+const hrsPerYr = 8766 // as defined by a julian year of 365.25 days
 
-var hrsPerYrRat = sdk.NewRat(hrsPerYr)
+var hrsPerYrDec = sdk.NewDec(hrsPerYr)
 
 // process provisions for an hour period
 func (k Keeper) ProcessProvisions(ctx sdk.Context) types.Pool {
@@ -18,7 +15,7 @@ func (k Keeper) ProcessProvisions(ctx sdk.Context) types.Pool {
 	pool := k.GetPool(ctx)
 	pool.Inflation = k.NextInflation(ctx)
 
-	provisions := pool.Inflation.Mul(sdk.NewRat(pool.TokenSupply())).Quo(hrsPerYrRat).RoundInt64()
+	provisions := pool.Inflation.Mul(sdk.NewDec(pool.TokenSupply())).Quo(hrsPerYrDec).RoundInt64()
 
 	// TODO add to the fees provisions
 	pool.LooseTokens += provisions
@@ -26,7 +23,7 @@ func (k Keeper) ProcessProvisions(ctx sdk.Context) types.Pool {
 }
 
 // get the next inflation rate for the hour
-func (k Keeper) NextInflation(ctx sdk.Context) (inflation sdk.Rat) {
+func (k Keeper) NextInflation(ctx sdk.Context) (inflation sdk.Dec) {
 
 	params := k.GetParams(ctx)
 	pool := k.GetPool(ctx)
@@ -37,8 +34,8 @@ func (k Keeper) NextInflation(ctx sdk.Context) (inflation sdk.Rat) {
 	// 7% and 20%.
 
 	// (1 - bondedRatio/GoalBonded) * InflationRateChange
-	inflationRateChangePerYear := sdk.OneRat().Sub(pool.BondedRatio().Quo(params.GoalBonded)).Mul(params.InflationRateChange)
-	inflationRateChange := inflationRateChangePerYear.Quo(hrsPerYrRat)
+	inflationRateChangePerYear := sdk.OneDec().Sub(pool.BondedRatio().Quo(params.GoalBonded)).Mul(params.InflationRateChange)
+	inflationRateChange := inflationRateChangePerYear.Quo(hrsPerYrDec)
 
 	// increase the new annual inflation for this next cycle
 	inflation = pool.Inflation.Add(inflationRateChange)
@@ -49,5 +46,5 @@ func (k Keeper) NextInflation(ctx sdk.Context) (inflation sdk.Rat) {
 		inflation = params.InflationMin
 	}
 
-	return inflation.Round(precision)
+	return inflation
 }
@@ -18,7 +18,7 @@ func TestGetInflation(t *testing.T) {
 	ctx, _, keeper := CreateTestInput(t, false, 0)
 	pool := keeper.GetPool(ctx)
 	params := keeper.GetParams(ctx)
-	hrsPerYrRat := sdk.NewRat(hrsPerYr)
+	hrsPerYrDec := sdk.NewDec(hrsPerYr)
 
 	// Governing Mechanism:
 	//    BondedRatio = BondedTokens / TotalSupply
@@ -27,30 +27,30 @@ func TestGetInflation(t *testing.T) {
 	tests := []struct {
 		name                            string
 		setBondedTokens, setLooseTokens int64
-		setInflation, expectedChange    sdk.Rat
+		setInflation, expectedChange    sdk.Dec
 	}{
 		// with 0% bonded atom supply the inflation should increase by InflationRateChange
-		{"test 1", 0, 0, sdk.NewRat(7, 100), params.InflationRateChange.Quo(hrsPerYrRat).Round(precision)},
+		{"test 1", 0, 0, sdk.NewDecWithPrec(7, 2), params.InflationRateChange.Quo(hrsPerYrDec)},
 
 		// 100% bonded, starting at 20% inflation and being reduced
 		// (1 - (1/0.67))*(0.13/8667)
-		{"test 2", 1, 0, sdk.NewRat(20, 100),
-			sdk.OneRat().Sub(sdk.OneRat().Quo(params.GoalBonded)).Mul(params.InflationRateChange).Quo(hrsPerYrRat).Round(precision)},
+		{"test 2", 1, 0, sdk.NewDecWithPrec(20, 2),
+			sdk.OneDec().Sub(sdk.OneDec().Quo(params.GoalBonded)).Mul(params.InflationRateChange).Quo(hrsPerYrDec)},
 
 		// 50% bonded, starting at 10% inflation and being increased
-		{"test 3", 1, 1, sdk.NewRat(10, 100),
-			sdk.OneRat().Sub(sdk.NewRat(1, 2).Quo(params.GoalBonded)).Mul(params.InflationRateChange).Quo(hrsPerYrRat).Round(precision)},
+		{"test 3", 1, 1, sdk.NewDecWithPrec(10, 2),
+			sdk.OneDec().Sub(sdk.NewDecWithPrec(5, 1).Quo(params.GoalBonded)).Mul(params.InflationRateChange).Quo(hrsPerYrDec)},
 
 		// test 7% minimum stop (testing with 100% bonded)
-		{"test 4", 1, 0, sdk.NewRat(7, 100), sdk.ZeroRat()},
-		{"test 5", 1, 0, sdk.NewRat(70001, 1000000), sdk.NewRat(-1, 1000000).Round(precision)},
+		{"test 4", 1, 0, sdk.NewDecWithPrec(7, 2), sdk.ZeroDec()},
+		{"test 5", 1, 0, sdk.NewDecWithPrec(70001, 6), sdk.NewDecWithPrec(-1, 6)},
 
 		// test 20% maximum stop (testing with 0% bonded)
-		{"test 6", 0, 0, sdk.NewRat(20, 100), sdk.ZeroRat()},
-		{"test 7", 0, 0, sdk.NewRat(199999, 1000000), sdk.NewRat(1, 1000000).Round(precision)},
+		{"test 6", 0, 0, sdk.NewDecWithPrec(20, 2), sdk.ZeroDec()},
+		{"test 7", 0, 0, sdk.NewDecWithPrec(199999, 6), sdk.NewDecWithPrec(1, 6)},
 
 		// perfect balance shouldn't change inflation
-		{"test 8", 67, 33, sdk.NewRat(15, 100), sdk.ZeroRat()},
+		{"test 8", 67, 33, sdk.NewDecWithPrec(15, 2), sdk.ZeroDec()},
 	}
 	for _, tc := range tests {
 		pool.BondedTokens, pool.LooseTokens = tc.setBondedTokens, tc.setLooseTokens
@@ -141,9 +141,9 @@ func TestLargeUnbond(t *testing.T) {
 		initialBondedTokens   int64 = 900000000
 		initialUnbondedTokens int64 = 300000000
 		val0UnbondedTokens    int64
-		bondedShares                 = sdk.NewRat(900000000, 1)
-		unbondedShares               = sdk.NewRat(300000000, 1)
-		bondSharesVal0               = sdk.NewRat(300000000, 1)
+		bondedShares                 = sdk.NewDec(900000000)
+		unbondedShares               = sdk.NewDec(300000000)
+		bondSharesVal0               = sdk.NewDec(300000000)
 		validatorTokens              = []int64{300000000, 100000000, 100000000, 100000000, 100000000, 100000000, 100000000, 100000000, 100000000, 100000000}
 		bondedValidators      uint16 = 7
 	)
@@ -168,10 +168,10 @@ func TestLargeUnbond(t *testing.T) {
 
 	bondedShares = bondedShares.Sub(bondSharesVal0)
 	val0UnbondedTokens = pool.UnbondedShareExRate().Mul(validator.PoolShares.Unbonded()).RoundInt64()
-	unbondedShares = unbondedShares.Add(sdk.NewRat(val0UnbondedTokens, 1).Mul(pool.UnbondedShareExRate()))
+	unbondedShares = unbondedShares.Add(sdk.NewDec(val0UnbondedTokens).Mul(pool.UnbondedShareExRate()))
 
 	// unbonded shares should increase
-	require.True(t, unbondedShares.GT(sdk.NewRat(300000000, 1)))
+	require.True(t, unbondedShares.GT(sdk.NewDec(300000000)))
 	// Ensure that new bonded ratio is less than old bonded ratio , because before they were increasing (i.e. 50% < 75)
 	require.True(t, (pool.BondedRatio().LT(initialBondedRatio)))
 
@@ -189,8 +189,8 @@ func TestLargeBond(t *testing.T) {
 		initialTotalTokens    int64  = 1600000000
 		initialBondedTokens   int64  = 400000000
 		initialUnbondedTokens int64  = 1200000000
-		unbondedShares               = sdk.NewRat(1200000000, 1)
-		unbondedSharesVal9           = sdk.NewRat(400000000, 1)
+		unbondedShares               = sdk.NewDec(1200000000)
+		unbondedSharesVal9           = sdk.NewDec(400000000)
 		validatorTokens              = []int64{400000000, 100000000, 100000000, 100000000, 100000000, 100000000, 100000000, 100000000, 100000000, 400000000}
 		bondedValidators      uint16 = 1
 	)
@@ -219,7 +219,7 @@ func TestLargeBond(t *testing.T) {
 	unbondedShares = unbondedShares.Sub(unbondedSharesVal9)
 
 	// unbonded shares should decrease
-	require.True(t, unbondedShares.LT(sdk.NewRat(1200000000, 1)))
+	require.True(t, unbondedShares.LT(sdk.NewDec(1200000000)))
 	// Ensure that new bonded ratio is greater than old bonded ratio (i.e. 50% > 25%)
 	require.True(t, (pool.BondedRatio().GT(initialBondedRatio)))
 	// Final check that the pool equals initial values + provisions and adjustments we recorded
@@ -293,9 +293,9 @@ func checkFinalPoolValues(t *testing.T, pool types.Pool, initialTotalTokens, cum
 
 // Processes provisions are added to the pool correctly every hour
 // Returns expected Provisions, expected Inflation, and pool, to help with cumulative calculations back in main Tests
-func updateProvisions(t *testing.T, keeper Keeper, pool types.Pool, ctx sdk.Context, hr int) (sdk.Rat, int64, types.Pool) {
+func updateProvisions(t *testing.T, keeper Keeper, pool types.Pool, ctx sdk.Context, hr int) (sdk.Dec, int64, types.Pool) {
 	expInflation := keeper.NextInflation(ctx)
-	expProvisions := (expInflation.Mul(sdk.NewRat(pool.TokenSupply())).Quo(hrsPerYrRat)).RoundInt64()
+	expProvisions := (expInflation.Mul(sdk.NewDec(pool.TokenSupply())).Quo(hrsPerYrDec)).RoundInt64()
 	startTotalSupply := pool.TokenSupply()
 	pool = keeper.ProcessProvisions(ctx)
 	keeper.SetPool(ctx, pool)
@@ -336,42 +336,42 @@ func checkValidatorSetup(t *testing.T, pool types.Pool, initialTotalTokens, init
 	require.Equal(t, initialUnbondedTokens, pool.UnbondedTokens, "%v", pool)
 
 	// test initial bonded ratio
-	require.True(t, pool.BondedRatio().Equal(sdk.NewRat(initialBondedTokens, initialTotalTokens)), "%v", pool.BondedRatio())
+	require.True(t, pool.BondedRatio().Equal(sdk.NewDec(initialBondedTokens).Quo(sdk.NewDec(initialTotalTokens))), "%v", pool.BondedRatio())
 	// test the value of validator shares
-	require.True(t, pool.BondedShareExRate().Equal(sdk.OneRat()), "%v", pool.BondedShareExRate())
+	require.True(t, pool.BondedShareExRate().Equal(sdk.OneDec()), "%v", pool.BondedShareExRate())
 }
 
 // Checks that The inflation will correctly increase or decrease after an update to the pool
-func checkInflation(t *testing.T, pool types.Pool, previousInflation, updatedInflation sdk.Rat, msg string) {
+func checkInflation(t *testing.T, pool types.Pool, previousInflation, updatedInflation sdk.Dec, msg string) {
 	inflationChange := updatedInflation.Sub(previousInflation)
 
 	switch {
 	//BELOW 67% - Rate of change positive and increasing, while we are between 7% <= and < 20% inflation
-	case pool.BondedRatio().LT(sdk.NewRat(67, 100)) && updatedInflation.LT(sdk.NewRat(20, 100)):
-		require.Equal(t, true, inflationChange.GT(sdk.ZeroRat()), msg)
+	case pool.BondedRatio().LT(sdk.NewDecWithPrec(67, 2)) && updatedInflation.LT(sdk.NewDecWithPrec(20, 2)):
+		require.Equal(t, true, inflationChange.GT(sdk.ZeroDec()), msg)
 
 	//BELOW 67% - Rate of change should be 0 while inflation continually stays at 20% until we reach 67% bonded ratio
-	case pool.BondedRatio().LT(sdk.NewRat(67, 100)) && updatedInflation.Equal(sdk.NewRat(20, 100)):
-		if previousInflation.Equal(sdk.NewRat(20, 100)) {
+	case pool.BondedRatio().LT(sdk.NewDecWithPrec(67, 2)) && updatedInflation.Equal(sdk.NewDecWithPrec(20, 2)):
+		if previousInflation.Equal(sdk.NewDecWithPrec(20, 2)) {
 			require.Equal(t, true, inflationChange.IsZero(), msg)
 
 			//This else statement covers the one off case where we first hit 20%, but we still needed a positive ROC to get to 67% bonded ratio (i.e. we went from 19.99999% to 20%)
 		} else {
-			require.Equal(t, true, inflationChange.GT(sdk.ZeroRat()), msg)
+			require.Equal(t, true, inflationChange.GT(sdk.ZeroDec()), msg)
 		}
 
 	//ABOVE 67% - Rate of change should be negative while the bond is above 67, and should stay negative until we reach inflation of 7%
-	case pool.BondedRatio().GT(sdk.NewRat(67, 100)) && updatedInflation.LT(sdk.NewRat(20, 100)) && updatedInflation.GT(sdk.NewRat(7, 100)):
-		require.Equal(t, true, inflationChange.LT(sdk.ZeroRat()), msg)
+	case pool.BondedRatio().GT(sdk.NewDecWithPrec(67, 2)) && updatedInflation.LT(sdk.NewDecWithPrec(20, 2)) && updatedInflation.GT(sdk.NewDecWithPrec(7, 2)):
+		require.Equal(t, true, inflationChange.LT(sdk.ZeroDec()), msg)
 
 	//ABOVE 67% - Rate of change should be 0 while inflation continually stays at 7%.
-	case pool.BondedRatio().GT(sdk.NewRat(67, 100)) && updatedInflation.Equal(sdk.NewRat(7, 100)):
-		if previousInflation.Equal(sdk.NewRat(7, 100)) {
+	case pool.BondedRatio().GT(sdk.NewDecWithPrec(67, 2)) && updatedInflation.Equal(sdk.NewDecWithPrec(7, 2)):
+		if previousInflation.Equal(sdk.NewDecWithPrec(7, 2)) {
 			require.Equal(t, true, inflationChange.IsZero(), msg)
 
 			//This else statement covers the one off case where we first hit 7%, but we still needed a negative ROC to continue to get down to 67%. (i.e. we went from 7.00001% to 7%)
 		} else {
-			require.Equal(t, true, inflationChange.LT(sdk.ZeroRat()), msg)
+			require.Equal(t, true, inflationChange.LT(sdk.ZeroDec()), msg)
 		}
 	}
 }
@@ -11,6 +11,7 @@ import (
 // keeper of the stake store
 type Keeper struct {
 	storeKey   sdk.StoreKey
+	tstoreKey  sdk.StoreKey // transient store of the per-block data
 	cdc        *wire.Codec
 	coinKeeper bank.Keeper
 
@@ -18,9 +19,10 @@ type Keeper struct {
 	codespace sdk.CodespaceType
 }
 
-func NewKeeper(cdc *wire.Codec, key sdk.StoreKey, ck bank.Keeper, codespace sdk.CodespaceType) Keeper {
+func NewKeeper(cdc *wire.Codec, key, tkey sdk.StoreKey, ck bank.Keeper, codespace sdk.CodespaceType) Keeper {
 	keeper := Keeper{
 		storeKey:   key,
+		tstoreKey:  tkey,
 		cdc:        cdc,
 		coinKeeper: ck,
 		codespace:  codespace,
@@ -98,17 +100,21 @@ func (k Keeper) SetPool(ctx sdk.Context, pool types.Pool) {
 
 // get the current in-block validator operation counter
 func (k Keeper) InitIntraTxCounter(ctx sdk.Context) {
-	store := ctx.KVStore(k.storeKey)
-	b := store.Get(IntraTxCounterKey)
+	tstore := ctx.KVStore(k.tstoreKey)
+	b := tstore.Get(IntraTxCounterKey)
 	if b == nil {
 		k.SetIntraTxCounter(ctx, 0)
 	}
 }
 
-// get the current in-block validator operation counter
+// get the current in-block validator operation counter,
+// which starts from 0 in each block as the transient store is wiped
 func (k Keeper) GetIntraTxCounter(ctx sdk.Context) int16 {
-	store := ctx.KVStore(k.storeKey)
-	b := store.Get(IntraTxCounterKey)
+	tstore := ctx.KVStore(k.tstoreKey)
+	b := tstore.Get(IntraTxCounterKey)
+	if b == nil {
+		return 0
+	}
 	var counter int16
 	k.cdc.MustUnmarshalBinary(b, &counter)
 	return counter
@@ -116,7 +122,7 @@ func (k Keeper) GetIntraTxCounter(ctx sdk.Context) int16 {
 
 // set the current in-block validator operation counter
 func (k Keeper) SetIntraTxCounter(ctx sdk.Context, counter int16) {
-	store := ctx.KVStore(k.storeKey)
+	tstore := ctx.KVStore(k.tstoreKey)
 	bz := k.cdc.MustMarshalBinary(counter)
-	store.Set(IntraTxCounterKey, bz)
+	tstore.Set(IntraTxCounterKey, bz)
 }
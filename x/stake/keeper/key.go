@@ -23,8 +23,8 @@ var (
 	ValidatorsByPowerIndexKey        = []byte{0x05} // prefix for each key to a validator index, sorted by power
 	ValidatorCliffIndexKey           = []byte{0x06} // key for the validator index of the cliff validator
 	ValidatorPowerCliffKey           = []byte{0x07} // key for the power of the validator on the cliff
-	TendermintUpdatesKey             = []byte{0x08} // prefix for each key to a validator which is being updated
-	IntraTxCounterKey                = []byte{0x09} // key for intra-block tx index
+	TendermintUpdatesKey             = []byte{0x08} // prefix for each key to a validator which is being updated, in the transient store
+	IntraTxCounterKey                = []byte{0x09} // key for intra-block tx index, in the transient store
 	DelegationKey                    = []byte{0x0A} // key for a delegation
 	UnbondingDelegationKey           = []byte{0x0B} // key for an unbonding-delegation
 	UnbondingDelegationByValIndexKey = []byte{0x0C} // prefix for each key for an unbonding-delegation, by validator owner
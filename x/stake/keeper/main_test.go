@@ -0,0 +1,22 @@
+package keeper
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/tepleton/tepleton-sdk/store"
+)
+
+// See https://golang.org/pkg/testing/#hdr-Main
+// for more details
+func TestMain(m *testing.M) {
+	code := m.Run()
+
+	// fail the tests if an iterator of an IAVL store was left open
+	if open := store.OpenIAVLIterators(); open != 0 {
+		fmt.Printf("%d IAVL iterators were not closed\n", open)
+		code = 1
+	}
+	os.Exit(code)
+}
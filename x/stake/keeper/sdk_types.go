@@ -59,7 +59,7 @@ func (k Keeper) Validator(ctx sdk.Context, address sdk.Address) sdk.Validator {
 }
 
 // total power from the bond
-func (k Keeper) TotalPower(ctx sdk.Context) sdk.Rat {
+func (k Keeper) TotalPower(ctx sdk.Context) sdk.Dec {
 	pool := k.GetPool(ctx)
 	return pool.BondedShares
 }
@@ -61,7 +61,7 @@ func TestRevocation(t *testing.T) {
 // tests slashUnbondingDelegation
 func TestSlashUnbondingDelegation(t *testing.T) {
 	ctx, keeper, params := setupHelper(t, 10)
-	fraction := sdk.NewRat(1, 2)
+	fraction := sdk.NewDecWithPrec(5, 1)
 
 	// set an unbonding delegation
 	ubd := types.UnbondingDelegation{
@@ -104,7 +104,7 @@ func TestSlashUnbondingDelegation(t *testing.T) {
 // tests slashRedelegation
 func TestSlashRedelegation(t *testing.T) {
 	ctx, keeper, params := setupHelper(t, 10)
-	fraction := sdk.NewRat(1, 2)
+	fraction := sdk.NewDecWithPrec(5, 1)
 
 	// set a redelegation
 	rd := types.Redelegation{
@@ -114,8 +114,8 @@ func TestSlashRedelegation(t *testing.T) {
 		CreationHeight:   0,
 		// expiration timestamp (beyond which the redelegation shouldn't be slashed)
 		MinTime:        0,
-		SharesSrc:      sdk.NewRat(10),
-		SharesDst:      sdk.NewRat(10),
+		SharesSrc:      sdk.NewDec(10),
+		SharesDst:      sdk.NewDec(10),
 		InitialBalance: sdk.NewCoin(params.BondDenom, 10),
 		Balance:        sdk.NewCoin(params.BondDenom, 10),
 	}
@@ -125,7 +125,7 @@ func TestSlashRedelegation(t *testing.T) {
 	del := types.Delegation{
 		DelegatorAddr: addrDels[0],
 		ValidatorAddr: addrVals[1],
-		Shares:        sdk.NewRat(10),
+		Shares:        sdk.NewDec(10),
 	}
 	keeper.SetDelegation(ctx, del)
 
@@ -170,7 +170,7 @@ func TestSlashRedelegation(t *testing.T) {
 func TestSlashAtFutureHeight(t *testing.T) {
 	ctx, keeper, _ := setupHelper(t, 10)
 	pk := PKs[0]
-	fraction := sdk.NewRat(1, 2)
+	fraction := sdk.NewDecWithPrec(5, 1)
 	require.Panics(t, func() { keeper.Slash(ctx, pk, 1, 10, fraction) })
 }
 
@@ -178,7 +178,7 @@ func TestSlashAtFutureHeight(t *testing.T) {
 func TestSlashAtCurrentHeight(t *testing.T) {
 	ctx, keeper, _ := setupHelper(t, 10)
 	pk := PKs[0]
-	fraction := sdk.NewRat(1, 2)
+	fraction := sdk.NewDecWithPrec(5, 1)
 
 	oldPool := keeper.GetPool(ctx)
 	validator, found := keeper.GetValidatorByPubKey(ctx, pk)
@@ -191,16 +191,16 @@ func TestSlashAtCurrentHeight(t *testing.T) {
 	newPool := keeper.GetPool(ctx)
 
 	// power decreased
-	require.Equal(t, sdk.NewRat(5), validator.GetPower())
+	require.Equal(t, sdk.NewDec(5), validator.GetPower())
 	// pool bonded shares decreased
-	require.Equal(t, sdk.NewRat(5).RoundInt64(), oldPool.BondedShares.Sub(newPool.BondedShares).RoundInt64())
+	require.Equal(t, sdk.NewDec(5).RoundInt64(), oldPool.BondedShares.Sub(newPool.BondedShares).RoundInt64())
 }
 
 // tests Slash at a previous height with an unbonding delegation
 func TestSlashWithUnbondingDelegation(t *testing.T) {
 	ctx, keeper, params := setupHelper(t, 10)
 	pk := PKs[0]
-	fraction := sdk.NewRat(1, 2)
+	fraction := sdk.NewDecWithPrec(5, 1)
 
 	// set an unbonding delegation
 	ubd := types.UnbondingDelegation{
@@ -237,7 +237,7 @@ func TestSlashWithUnbondingDelegation(t *testing.T) {
 	// was still bonded at the time of discovery and was slashed by half, 4 stake
 	// bonded at the time of discovery hadn't been bonded at the time of infraction
 	// and wasn't slashed
-	require.Equal(t, sdk.NewRat(7), validator.GetPower())
+	require.Equal(t, sdk.NewDec(7), validator.GetPower())
 
 	// slash validator again
 	ctx = ctx.WithBlockHeight(13)
@@ -254,7 +254,7 @@ func TestSlashWithUnbondingDelegation(t *testing.T) {
 	validator, found = keeper.GetValidatorByPubKey(ctx, pk)
 	require.True(t, found)
 	// power decreased by 3 again
-	require.Equal(t, sdk.NewRat(4), validator.GetPower())
+	require.Equal(t, sdk.NewDec(4), validator.GetPower())
 
 	// slash validator again
 	// all originally bonded stake has been slashed, so this will have no effect
@@ -274,7 +274,7 @@ func TestSlashWithUnbondingDelegation(t *testing.T) {
 	validator, found = keeper.GetValidatorByPubKey(ctx, pk)
 	require.True(t, found)
 	// power decreased by 3 again
-	require.Equal(t, sdk.NewRat(1), validator.GetPower())
+	require.Equal(t, sdk.NewDec(1), validator.GetPower())
 
 	// slash validator again
 	// all originally bonded stake has been slashed, so this will have no effect
@@ -294,14 +294,14 @@ func TestSlashWithUnbondingDelegation(t *testing.T) {
 	validator, found = keeper.GetValidatorByPubKey(ctx, pk)
 	require.True(t, found)
 	// power decreased by 1 again, validator is out of stake
-	require.Equal(t, sdk.NewRat(0), validator.GetPower())
+	require.Equal(t, sdk.NewDec(0), validator.GetPower())
 }
 
 // tests Slash at a previous height with a redelegation
 func TestSlashWithRedelegation(t *testing.T) {
 	ctx, keeper, params := setupHelper(t, 10)
 	pk := PKs[0]
-	fraction := sdk.NewRat(1, 2)
+	fraction := sdk.NewDecWithPrec(5, 1)
 
 	// set a redelegation
 	rd := types.Redelegation{
@@ -310,8 +310,8 @@ func TestSlashWithRedelegation(t *testing.T) {
 		ValidatorDstAddr: addrVals[1],
 		CreationHeight:   11,
 		MinTime:          0,
-		SharesSrc:        sdk.NewRat(6),
-		SharesDst:        sdk.NewRat(6),
+		SharesSrc:        sdk.NewDec(6),
+		SharesDst:        sdk.NewDec(6),
 		InitialBalance:   sdk.NewCoin(params.BondDenom, 6),
 		Balance:          sdk.NewCoin(params.BondDenom, 6),
 	}
@@ -321,7 +321,7 @@ func TestSlashWithRedelegation(t *testing.T) {
 	del := types.Delegation{
 		DelegatorAddr: addrDels[0],
 		ValidatorAddr: addrVals[1],
-		Shares:        sdk.NewRat(6),
+		Shares:        sdk.NewDec(6),
 	}
 	keeper.SetDelegation(ctx, del)
 
@@ -348,13 +348,13 @@ func TestSlashWithRedelegation(t *testing.T) {
 	// was still bonded at the time of discovery and was slashed by half, 4 stake
 	// bonded at the time of discovery hadn't been bonded at the time of infraction
 	// and wasn't slashed
-	require.Equal(t, sdk.NewRat(8), validator.GetPower())
+	require.Equal(t, sdk.NewDec(8), validator.GetPower())
 
 	// slash the validator again
 	ctx = ctx.WithBlockHeight(12)
 	validator, found = keeper.GetValidatorByPubKey(ctx, pk)
 	require.True(t, found)
-	keeper.Slash(ctx, pk, 10, 10, sdk.NewRat(3, 4))
+	keeper.Slash(ctx, pk, 10, 10, sdk.NewDecWithPrec(75, 2))
 
 	// read updating redelegation
 	rd, found = keeper.GetRedelegation(ctx, addrDels[0], addrVals[0], addrVals[1])
@@ -369,13 +369,13 @@ func TestSlashWithRedelegation(t *testing.T) {
 	validator, found = keeper.GetValidatorByPubKey(ctx, pk)
 	require.True(t, found)
 	// power decreased by 4
-	require.Equal(t, sdk.NewRat(4), validator.GetPower())
+	require.Equal(t, sdk.NewDec(4), validator.GetPower())
 
 	// slash the validator again, by 100%
 	ctx = ctx.WithBlockHeight(12)
 	validator, found = keeper.GetValidatorByPubKey(ctx, pk)
 	require.True(t, found)
-	keeper.Slash(ctx, pk, 10, 10, sdk.OneRat())
+	keeper.Slash(ctx, pk, 10, 10, sdk.OneDec())
 
 	// read updating redelegation
 	rd, found = keeper.GetRedelegation(ctx, addrDels[0], addrVals[0], addrVals[1])
@@ -390,14 +390,14 @@ func TestSlashWithRedelegation(t *testing.T) {
 	validator, found = keeper.GetValidatorByPubKey(ctx, pk)
 	require.True(t, found)
 	// power decreased by 4, down to 0
-	require.Equal(t, sdk.NewRat(0), validator.GetPower())
+	require.Equal(t, sdk.NewDec(0), validator.GetPower())
 
 	// slash the validator again, by 100%
 	// no stake remains to be slashed
 	ctx = ctx.WithBlockHeight(12)
 	validator, found = keeper.GetValidatorByPubKey(ctx, pk)
 	require.True(t, found)
-	keeper.Slash(ctx, pk, 10, 10, sdk.OneRat())
+	keeper.Slash(ctx, pk, 10, 10, sdk.OneDec())
 
 	// read updating redelegation
 	rd, found = keeper.GetRedelegation(ctx, addrDels[0], addrVals[0], addrVals[1])
@@ -412,13 +412,13 @@ func TestSlashWithRedelegation(t *testing.T) {
 	validator, found = keeper.GetValidatorByPubKey(ctx, pk)
 	require.True(t, found)
 	// power still zero
-	require.Equal(t, sdk.NewRat(0), validator.GetPower())
+	require.Equal(t, sdk.NewDec(0), validator.GetPower())
 }
 
 // tests Slash at a previous height with both an unbonding delegation and a redelegation
 func TestSlashBoth(t *testing.T) {
 	ctx, keeper, params := setupHelper(t, 10)
-	fraction := sdk.NewRat(1, 2)
+	fraction := sdk.NewDecWithPrec(5, 1)
 
 	// set a redelegation
 	rdA := types.Redelegation{
@@ -428,8 +428,8 @@ func TestSlashBoth(t *testing.T) {
 		CreationHeight:   11,
 		// expiration timestamp (beyond which the redelegation shouldn't be slashed)
 		MinTime:        0,
-		SharesSrc:      sdk.NewRat(6),
-		SharesDst:      sdk.NewRat(6),
+		SharesSrc:      sdk.NewDec(6),
+		SharesDst:      sdk.NewDec(6),
 		InitialBalance: sdk.NewCoin(params.BondDenom, 6),
 		Balance:        sdk.NewCoin(params.BondDenom, 6),
 	}
@@ -439,7 +439,7 @@ func TestSlashBoth(t *testing.T) {
 	delA := types.Delegation{
 		DelegatorAddr: addrDels[0],
 		ValidatorAddr: addrVals[1],
-		Shares:        sdk.NewRat(6),
+		Shares:        sdk.NewDec(6),
 	}
 	keeper.SetDelegation(ctx, delA)
 
@@ -477,5 +477,5 @@ func TestSlashBoth(t *testing.T) {
 	validator, found = keeper.GetValidatorByPubKey(ctx, PKs[0])
 	require.True(t, found)
 	// power not decreased, all stake was bonded since
-	require.Equal(t, sdk.NewRat(10), validator.GetPower())
+	require.Equal(t, sdk.NewDec(10), validator.GetPower())
 }
@@ -168,9 +168,9 @@ func (k Keeper) GetValidatorsByPower(ctx sdk.Context) []types.Validator {
 
 // get the most recently updated validators
 func (k Keeper) GetTendermintUpdates(ctx sdk.Context) (updates []wrsp.Validator) {
-	store := ctx.KVStore(k.storeKey)
+	tstore := ctx.KVStore(k.tstoreKey)
 
-	iterator := sdk.KVStorePrefixIterator(store, TendermintUpdatesKey) //smallest to largest
+	iterator := sdk.KVStorePrefixIterator(tstore, TendermintUpdatesKey) //smallest to largest
 	for ; iterator.Valid(); iterator.Next() {
 		valBytes := iterator.Value()
 		var val wrsp.Validator
@@ -181,14 +181,15 @@ func (k Keeper) GetTendermintUpdates(ctx sdk.Context) (updates []wrsp.Validator)
 	return
 }
 
-// remove all validator update entries after applied to Tendermint
+// remove all validator update entries after applied to Tendermint,
+// which the commit of the transient store does at the end of each block
 func (k Keeper) ClearTendermintUpdates(ctx sdk.Context) {
-	store := ctx.KVStore(k.storeKey)
+	tstore := ctx.KVStore(k.tstoreKey)
 
 	// delete subspace
-	iterator := sdk.KVStorePrefixIterator(store, TendermintUpdatesKey)
+	iterator := sdk.KVStorePrefixIterator(tstore, TendermintUpdatesKey)
 	for ; iterator.Valid(); iterator.Next() {
-		store.Delete(iterator.Key())
+		tstore.Delete(iterator.Key())
 	}
 	iterator.Close()
 }
@@ -248,7 +249,8 @@ func (k Keeper) UpdateValidator(ctx sdk.Context, validator types.Validator) type
 	// if already bonded and power increasing only need to update tepleton
 	if powerIncreasing && !validator.Revoked && oldValidator.Status() == sdk.Bonded {
 		bz := k.cdc.MustMarshalBinary(validator.WRSPValidator())
-		store.Set(GetTendermintUpdatesKey(ownerAddr), bz)
+		tstore := ctx.KVStore(k.tstoreKey)
+		tstore.Set(GetTendermintUpdatesKey(ownerAddr), bz)
 		return validator
 	}
 
@@ -446,7 +448,8 @@ func (k Keeper) unbondValidator(ctx sdk.Context, validator types.Validator) type
 
 	// add to accumulated changes for tepleton
 	bzWRSP := k.cdc.MustMarshalBinary(validator.WRSPValidatorZero())
-	store.Set(GetTendermintUpdatesKey(validator.Owner), bzWRSP)
+	tstore := ctx.KVStore(k.tstoreKey)
+	tstore.Set(GetTendermintUpdatesKey(validator.Owner), bzWRSP)
 
 	// also remove from the Bonded types.Validators Store
 	store.Delete(GetValidatorsBondedIndexKey(validator.Owner))
@@ -475,7 +478,8 @@ func (k Keeper) bondValidator(ctx sdk.Context, validator types.Validator) types.
 
 	// add to accumulated changes for tepleton
 	bzWRSP := k.cdc.MustMarshalBinary(validator.WRSPValidator())
-	store.Set(GetTendermintUpdatesKey(validator.Owner), bzWRSP)
+	tstore := ctx.KVStore(k.tstoreKey)
+	tstore.Set(GetTendermintUpdatesKey(validator.Owner), bzWRSP)
 
 	return validator
 }
@@ -504,7 +508,8 @@ func (k Keeper) RemoveValidator(ctx sdk.Context, address sdk.Address) {
 	store.Delete(GetValidatorsBondedIndexKey(validator.Owner))
 
 	bz := k.cdc.MustMarshalBinary(validator.WRSPValidatorZero())
-	store.Set(GetTendermintUpdatesKey(address), bz)
+	tstore := ctx.KVStore(k.tstoreKey)
+	tstore.Set(GetTendermintUpdatesKey(address), bz)
 }
 
 //__________________________________________________________________________
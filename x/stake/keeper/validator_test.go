@@ -19,8 +19,8 @@ func TestSetValidator(t *testing.T) {
 	validator := types.NewValidator(addrVals[0], PKs[0], types.Description{})
 	validator, pool, _ = validator.AddTokensFromDel(pool, 10)
 	require.Equal(t, sdk.Unbonded, validator.Status())
-	assert.True(sdk.RatEq(t, sdk.NewRat(10), validator.PoolShares.Unbonded()))
-	assert.True(sdk.RatEq(t, sdk.NewRat(10), validator.DelegatorShares))
+	assert.True(sdk.DecEq(t, sdk.NewDec(10), validator.PoolShares.Unbonded()))
+	assert.True(sdk.DecEq(t, sdk.NewDec(10), validator.DelegatorShares))
 	keeper.SetPool(ctx, pool)
 	keeper.UpdateValidator(ctx, validator)
 
@@ -28,8 +28,8 @@ func TestSetValidator(t *testing.T) {
 	validator, found := keeper.GetValidator(ctx, addrVals[0])
 	require.True(t, found)
 	require.Equal(t, sdk.Bonded, validator.Status())
-	assert.True(sdk.RatEq(t, sdk.NewRat(10), validator.PoolShares.Bonded()))
-	assert.True(sdk.RatEq(t, sdk.NewRat(10), validator.DelegatorShares))
+	assert.True(sdk.DecEq(t, sdk.NewDec(10), validator.PoolShares.Bonded()))
+	assert.True(sdk.DecEq(t, sdk.NewDec(10), validator.DelegatorShares))
 
 	// Check each store for being saved
 	resVal, found := keeper.GetValidator(ctx, addrVals[0])
@@ -57,11 +57,11 @@ func TestUpdateValidatorByPowerIndex(t *testing.T) {
 	// create a random pool
 	pool.LooseTokens = 10000
 	pool.BondedTokens = 1234
-	pool.BondedShares = sdk.NewRat(124)
+	pool.BondedShares = sdk.NewDec(124)
 	pool.UnbondingTokens = 13934
-	pool.UnbondingShares = sdk.NewRat(145)
+	pool.UnbondingShares = sdk.NewDec(145)
 	pool.UnbondedTokens = 154
-	pool.UnbondedShares = sdk.NewRat(1333)
+	pool.UnbondedShares = sdk.NewDec(1333)
 	keeper.SetPool(ctx, pool)
 
 	// add a validator
@@ -80,7 +80,7 @@ func TestUpdateValidatorByPowerIndex(t *testing.T) {
 	require.True(t, keeper.validatorByPowerIndexExists(ctx, power))
 
 	// burn half the delegator shares
-	validator, pool, burned := validator.RemoveDelShares(pool, delSharesCreated.Quo(sdk.NewRat(2)))
+	validator, pool, burned := validator.RemoveDelShares(pool, delSharesCreated.Quo(sdk.NewDec(2)))
 	require.Equal(t, int64(50), burned)
 	keeper.SetPool(ctx, pool)              // update the pool
 	keeper.UpdateValidator(ctx, validator) // update the validator, possibly kicking it out
@@ -103,7 +103,7 @@ func TestValidatorBasics(t *testing.T) {
 	amts := []int64{9, 8, 7}
 	for i, amt := range amts {
 		validators[i] = types.NewValidator(addrVals[i], PKs[i], types.Description{})
-		validators[i].PoolShares = types.NewUnbondedShares(sdk.ZeroRat())
+		validators[i].PoolShares = types.NewUnbondedShares(sdk.ZeroDec())
 		validators[i].AddTokensFromDel(pool, amt)
 	}
 
@@ -124,8 +124,8 @@ func TestValidatorBasics(t *testing.T) {
 	assert.True(ValEq(t, validators[0], resVals[0]))
 
 	// modify a records, save, and retrieve
-	validators[0].PoolShares = types.NewBondedShares(sdk.NewRat(10))
-	validators[0].DelegatorShares = sdk.NewRat(10)
+	validators[0].PoolShares = types.NewBondedShares(sdk.NewDec(10))
+	validators[0].DelegatorShares = sdk.NewDec(10)
 	validators[0] = keeper.UpdateValidator(ctx, validators[0])
 	resVal, found = keeper.GetValidator(ctx, addrVals[0])
 	require.True(t, found)
@@ -167,19 +167,19 @@ func GetValidatorSortingUnmixed(t *testing.T) {
 	var validators [5]types.Validator
 	for i, amt := range amts {
 		validators[i] = types.NewValidator(Addrs[i], PKs[i], types.Description{})
-		validators[i].PoolShares = types.NewBondedShares(sdk.NewRat(amt))
-		validators[i].DelegatorShares = sdk.NewRat(amt)
+		validators[i].PoolShares = types.NewBondedShares(sdk.NewDec(amt))
+		validators[i].DelegatorShares = sdk.NewDec(amt)
 		keeper.UpdateValidator(ctx, validators[i])
 	}
 
 	// first make sure everything made it in to the gotValidator group
 	resValidators := keeper.GetValidatorsByPower(ctx)
 	assert.Equal(t, n, len(resValidators))
-	assert.Equal(t, sdk.NewRat(400), resValidators[0].PoolShares.Bonded(), "%v", resValidators)
-	assert.Equal(t, sdk.NewRat(200), resValidators[1].PoolShares.Bonded(), "%v", resValidators)
-	assert.Equal(t, sdk.NewRat(100), resValidators[2].PoolShares.Bonded(), "%v", resValidators)
-	assert.Equal(t, sdk.NewRat(1), resValidators[3].PoolShares.Bonded(), "%v", resValidators)
-	assert.Equal(t, sdk.NewRat(0), resValidators[4].PoolShares.Bonded(), "%v", resValidators)
+	assert.Equal(t, sdk.NewDec(400), resValidators[0].PoolShares.Bonded(), "%v", resValidators)
+	assert.Equal(t, sdk.NewDec(200), resValidators[1].PoolShares.Bonded(), "%v", resValidators)
+	assert.Equal(t, sdk.NewDec(100), resValidators[2].PoolShares.Bonded(), "%v", resValidators)
+	assert.Equal(t, sdk.NewDec(1), resValidators[3].PoolShares.Bonded(), "%v", resValidators)
+	assert.Equal(t, sdk.NewDec(0), resValidators[4].PoolShares.Bonded(), "%v", resValidators)
 	assert.Equal(t, validators[3].Owner, resValidators[0].Owner, "%v", resValidators)
 	assert.Equal(t, validators[4].Owner, resValidators[1].Owner, "%v", resValidators)
 	assert.Equal(t, validators[1].Owner, resValidators[2].Owner, "%v", resValidators)
@@ -187,14 +187,14 @@ func GetValidatorSortingUnmixed(t *testing.T) {
 	assert.Equal(t, validators[0].Owner, resValidators[4].Owner, "%v", resValidators)
 
 	// test a basic increase in voting power
-	validators[3].PoolShares = types.NewBondedShares(sdk.NewRat(500))
+	validators[3].PoolShares = types.NewBondedShares(sdk.NewDec(500))
 	keeper.UpdateValidator(ctx, validators[3])
 	resValidators = keeper.GetValidatorsByPower(ctx)
 	require.Equal(t, len(resValidators), n)
 	assert.True(ValEq(t, validators[3], resValidators[0]))
 
 	// test a decrease in voting power
-	validators[3].PoolShares = types.NewBondedShares(sdk.NewRat(300))
+	validators[3].PoolShares = types.NewBondedShares(sdk.NewDec(300))
 	keeper.UpdateValidator(ctx, validators[3])
 	resValidators = keeper.GetValidatorsByPower(ctx)
 	require.Equal(t, len(resValidators), n)
@@ -202,7 +202,7 @@ func GetValidatorSortingUnmixed(t *testing.T) {
 	assert.True(ValEq(t, validators[4], resValidators[1]))
 
 	// test equal voting power, different age
-	validators[3].PoolShares = types.NewBondedShares(sdk.NewRat(200))
+	validators[3].PoolShares = types.NewBondedShares(sdk.NewDec(200))
 	ctx = ctx.WithBlockHeight(10)
 	keeper.UpdateValidator(ctx, validators[3])
 	resValidators = keeper.GetValidatorsByPower(ctx)
@@ -221,8 +221,8 @@ func GetValidatorSortingUnmixed(t *testing.T) {
 	assert.True(ValEq(t, validators[4], resValidators[1]))
 
 	// change in voting power of both validators, both still in v-set, no age change
-	validators[3].PoolShares = types.NewBondedShares(sdk.NewRat(300))
-	validators[4].PoolShares = types.NewBondedShares(sdk.NewRat(300))
+	validators[3].PoolShares = types.NewBondedShares(sdk.NewDec(300))
+	validators[4].PoolShares = types.NewBondedShares(sdk.NewDec(300))
 	keeper.UpdateValidator(ctx, validators[3])
 	resValidators = keeper.GetValidatorsByPower(ctx)
 	require.Equal(t, len(resValidators), n)
@@ -249,13 +249,13 @@ func GetValidatorSortingMixed(t *testing.T) {
 	var validators [5]types.Validator
 	for i, amt := range amts {
 		validators[i] = types.NewValidator(Addrs[i], PKs[i], types.Description{})
-		validators[i].DelegatorShares = sdk.NewRat(amt)
+		validators[i].DelegatorShares = sdk.NewDec(amt)
 	}
-	validators[0].PoolShares = types.NewUnbondedShares(sdk.NewRat(amts[0]))
-	validators[1].PoolShares = types.NewUnbondedShares(sdk.NewRat(amts[1]))
-	validators[2].PoolShares = types.NewUnbondedShares(sdk.NewRat(amts[2]))
-	validators[3].PoolShares = types.NewBondedShares(sdk.NewRat(amts[3]))
-	validators[4].PoolShares = types.NewBondedShares(sdk.NewRat(amts[4]))
+	validators[0].PoolShares = types.NewUnbondedShares(sdk.NewDec(amts[0]))
+	validators[1].PoolShares = types.NewUnbondedShares(sdk.NewDec(amts[1]))
+	validators[2].PoolShares = types.NewUnbondedShares(sdk.NewDec(amts[2]))
+	validators[3].PoolShares = types.NewBondedShares(sdk.NewDec(amts[3]))
+	validators[4].PoolShares = types.NewBondedShares(sdk.NewDec(amts[4]))
 	for i := range amts {
 		keeper.UpdateValidator(ctx, validators[i])
 	}
@@ -278,11 +278,11 @@ func GetValidatorSortingMixed(t *testing.T) {
 	// first make sure everything made it in to the gotValidator group
 	resValidators := keeper.GetValidatorsByPower(ctx)
 	assert.Equal(t, n, len(resValidators))
-	assert.Equal(t, sdk.NewRat(400), resValidators[0].PoolShares.Bonded(), "%v", resValidators)
-	assert.Equal(t, sdk.NewRat(200), resValidators[1].PoolShares.Bonded(), "%v", resValidators)
-	assert.Equal(t, sdk.NewRat(100), resValidators[2].PoolShares.Bonded(), "%v", resValidators)
-	assert.Equal(t, sdk.NewRat(1), resValidators[3].PoolShares.Bonded(), "%v", resValidators)
-	assert.Equal(t, sdk.NewRat(0), resValidators[4].PoolShares.Bonded(), "%v", resValidators)
+	assert.Equal(t, sdk.NewDec(400), resValidators[0].PoolShares.Bonded(), "%v", resValidators)
+	assert.Equal(t, sdk.NewDec(200), resValidators[1].PoolShares.Bonded(), "%v", resValidators)
+	assert.Equal(t, sdk.NewDec(100), resValidators[2].PoolShares.Bonded(), "%v", resValidators)
+	assert.Equal(t, sdk.NewDec(1), resValidators[3].PoolShares.Bonded(), "%v", resValidators)
+	assert.Equal(t, sdk.NewDec(0), resValidators[4].PoolShares.Bonded(), "%v", resValidators)
 	assert.Equal(t, validators[3].Owner, resValidators[0].Owner, "%v", resValidators)
 	assert.Equal(t, validators[4].Owner, resValidators[1].Owner, "%v", resValidators)
 	assert.Equal(t, validators[1].Owner, resValidators[2].Owner, "%v", resValidators)
@@ -347,7 +347,7 @@ func TestGetValidatorsEdgeCases(t *testing.T) {
 	assert.True(ValEq(t, validators[3], resValidators[1]))
 
 	// validator 3 kicked out temporarily
-	validators[3], pool, _ = validators[3].RemoveDelShares(pool, sdk.NewRat(201))
+	validators[3], pool, _ = validators[3].RemoveDelShares(pool, sdk.NewDec(201))
 	keeper.SetPool(ctx, pool)
 	validators[3] = keeper.UpdateValidator(ctx, validators[3])
 	resValidators = keeper.GetValidatorsByPower(ctx)
@@ -554,7 +554,7 @@ func TestGetTendermintUpdatesSingleValueChange(t *testing.T) {
 
 	// test single value change
 	//  tepletonUpdate set: {} -> {c1'}
-	validators[0].PoolShares = types.NewBondedShares(sdk.NewRat(600))
+	validators[0].PoolShares = types.NewBondedShares(sdk.NewDec(600))
 	validators[0] = keeper.UpdateValidator(ctx, validators[0])
 
 	updates := keeper.GetTendermintUpdates(ctx)
@@ -127,7 +127,7 @@ var (
 )
 
 // tags
-var (
+const (
 	ActionCreateValidator      = tags.ActionCreateValidator
 	ActionEditValidator        = tags.ActionEditValidator
 	ActionDelegate             = tags.ActionDelegate
@@ -5,19 +5,23 @@ import (
 	"github.com/tepleton/tepleton-sdk/types"
 )
 
-var (
-	ActionCreateValidator      = []byte("create-validator")
-	ActionEditValidator        = []byte("edit-validator")
-	ActionDelegate             = []byte("delegate")
-	ActionBeginUnbonding       = []byte("begin-unbonding")
-	ActionCompleteUnbonding    = []byte("complete-unbonding")
-	ActionBeginRedelegation    = []byte("begin-redelegation")
-	ActionCompleteRedelegation = []byte("complete-redelegation")
+// the actions of the stake messages, emitted in their message events
+const (
+	ActionCreateValidator      = "create-validator"
+	ActionEditValidator        = "edit-validator"
+	ActionDelegate             = "delegate"
+	ActionBeginUnbonding       = "begin-unbonding"
+	ActionCompleteUnbonding    = "complete-unbonding"
+	ActionBeginRedelegation    = "begin-redelegation"
+	ActionCompleteRedelegation = "complete-redelegation"
+)
 
-	Action       = types.TagAction
-	SrcValidator = types.TagSrcValidator
-	DstValidator = types.TagDstValidator
-	Delegator    = types.TagDelegator
+// the attribute keys of the stake events
+const (
+	Action       = types.AttributeKeyAction
+	SrcValidator = "source-validator"
+	DstValidator = "destination-validator"
+	Delegator    = "delegator"
 	Moniker      = "moniker"
-	Identity     = "Identity"
+	Identity     = "identity"
 )
@@ -13,7 +13,7 @@ import (
 type Delegation struct {
 	DelegatorAddr sdk.Address `json:"delegator_addr"`
 	ValidatorAddr sdk.Address `json:"validator_addr"`
-	Shares        sdk.Rat     `json:"shares"`
+	Shares        sdk.Dec     `json:"shares"`
 	Height        int64       `json:"height"` // Last height bond updated
 }
 
@@ -31,7 +31,7 @@ var _ sdk.Delegation = Delegation{}
 // nolint - for sdk.Delegation
 func (d Delegation) GetDelegator() sdk.Address { return d.DelegatorAddr }
 func (d Delegation) GetValidator() sdk.Address { return d.ValidatorAddr }
-func (d Delegation) GetBondShares() sdk.Rat    { return d.Shares }
+func (d Delegation) GetBondShares() sdk.Dec    { return d.Shares }
 
 //Human Friendly pretty printer
 func (d Delegation) HumanReadableString() (string, error) {
@@ -104,8 +104,8 @@ type Redelegation struct {
 	MinTime          int64       `json:"min_time"`           // unix time for redelegation completion
 	InitialBalance   sdk.Coin    `json:"initial_balance"`    // initial balance when redelegation started
 	Balance          sdk.Coin    `json:"balance"`            // current balance
-	SharesSrc        sdk.Rat     `json:"shares_src"`         // amount of source shares redelegating
-	SharesDst        sdk.Rat     `json:"shares_dst"`         // amount of destination shares redelegating
+	SharesSrc        sdk.Dec     `json:"shares_src"`         // amount of source shares redelegating
+	SharesDst        sdk.Dec     `json:"shares_dst"`         // amount of destination shares redelegating
 }
 
 // nolint
@@ -13,16 +13,16 @@ type Pool struct {
 	UnbondedTokens    int64   `json:"unbonded_tokens"`     // reserve of unbonded tokens held with validators
 	UnbondingTokens   int64   `json:"unbonding_tokens"`    // tokens moving from bonded to unbonded pool
 	BondedTokens      int64   `json:"bonded_tokens"`       // reserve of bonded tokens
-	UnbondedShares    sdk.Rat `json:"unbonded_shares"`     // sum of all shares distributed for the Unbonded Pool
-	UnbondingShares   sdk.Rat `json:"unbonding_shares"`    // shares moving from Bonded to Unbonded Pool
-	BondedShares      sdk.Rat `json:"bonded_shares"`       // sum of all shares distributed for the Bonded Pool
+	UnbondedShares    sdk.Dec `json:"unbonded_shares"`     // sum of all shares distributed for the Unbonded Pool
+	UnbondingShares   sdk.Dec `json:"unbonding_shares"`    // shares moving from Bonded to Unbonded Pool
+	BondedShares      sdk.Dec `json:"bonded_shares"`       // sum of all shares distributed for the Bonded Pool
 	InflationLastTime int64   `json:"inflation_last_time"` // block which the last inflation was processed // TODO make time
-	Inflation         sdk.Rat `json:"inflation"`           // current annual inflation rate
+	Inflation         sdk.Dec `json:"inflation"`           // current annual inflation rate
 
 	DateLastCommissionReset int64 `json:"date_last_commission_reset"` // unix timestamp for last commission accounting reset (daily)
 
 	// Fee Related
-	PrevBondedShares sdk.Rat `json:"prev_bonded_shares"` // last recorded bonded shares - for fee calculations
+	PrevBondedShares sdk.Dec `json:"prev_bonded_shares"` // last recorded bonded shares - for fee calculations
 }
 
 // nolint
@@ -39,13 +39,13 @@ func InitialPool() Pool {
 		BondedTokens:            0,
 		UnbondingTokens:         0,
 		UnbondedTokens:          0,
-		BondedShares:            sdk.ZeroRat(),
-		UnbondingShares:         sdk.ZeroRat(),
-		UnbondedShares:          sdk.ZeroRat(),
+		BondedShares:            sdk.ZeroDec(),
+		UnbondingShares:         sdk.ZeroDec(),
+		UnbondedShares:          sdk.ZeroDec(),
 		InflationLastTime:       0,
-		Inflation:               sdk.NewRat(7, 100),
+		Inflation:               sdk.NewDecWithPrec(7, 2),
 		DateLastCommissionReset: 0,
-		PrevBondedShares:        sdk.ZeroRat(),
+		PrevBondedShares:        sdk.ZeroDec(),
 	}
 }
 
@@ -59,41 +59,41 @@ func (p Pool) TokenSupply() int64 {
 //____________________________________________________________________
 
 // get the bond ratio of the global state
-func (p Pool) BondedRatio() sdk.Rat {
+func (p Pool) BondedRatio() sdk.Dec {
 	if p.TokenSupply() > 0 {
-		return sdk.NewRat(p.BondedTokens, p.TokenSupply())
+		return sdk.NewDec(p.BondedTokens).Quo(sdk.NewDec(p.TokenSupply()))
 	}
-	return sdk.ZeroRat()
+	return sdk.ZeroDec()
 }
 
 // get the exchange rate of bonded token per issued share
-func (p Pool) BondedShareExRate() sdk.Rat {
+func (p Pool) BondedShareExRate() sdk.Dec {
 	if p.BondedShares.IsZero() {
-		return sdk.OneRat()
+		return sdk.OneDec()
 	}
-	return sdk.NewRat(p.BondedTokens).Quo(p.BondedShares)
+	return sdk.NewDec(p.BondedTokens).Quo(p.BondedShares)
 }
 
 // get the exchange rate of unbonding tokens held in validators per issued share
-func (p Pool) UnbondingShareExRate() sdk.Rat {
+func (p Pool) UnbondingShareExRate() sdk.Dec {
 	if p.UnbondingShares.IsZero() {
-		return sdk.OneRat()
+		return sdk.OneDec()
 	}
-	return sdk.NewRat(p.UnbondingTokens).Quo(p.UnbondingShares)
+	return sdk.NewDec(p.UnbondingTokens).Quo(p.UnbondingShares)
 }
 
 // get the exchange rate of unbonded tokens held in validators per issued share
-func (p Pool) UnbondedShareExRate() sdk.Rat {
+func (p Pool) UnbondedShareExRate() sdk.Dec {
 	if p.UnbondedShares.IsZero() {
-		return sdk.OneRat()
+		return sdk.OneDec()
 	}
-	return sdk.NewRat(p.UnbondedTokens).Quo(p.UnbondedShares)
+	return sdk.NewDec(p.UnbondedTokens).Quo(p.UnbondedShares)
 }
 
 //_______________________________________________________________________
 
 func (p Pool) addTokensUnbonded(amount int64) (p2 Pool, issuedShares PoolShares) {
-	issuedSharesAmount := sdk.NewRat(amount).Quo(p.UnbondedShareExRate()) // tokens * (shares/tokens)
+	issuedSharesAmount := sdk.NewDec(amount).Quo(p.UnbondedShareExRate()) // tokens * (shares/tokens)
 	p.UnbondedShares = p.UnbondedShares.Add(issuedSharesAmount)
 	p.UnbondedTokens += amount
 	p.LooseTokens -= amount
@@ -103,7 +103,7 @@ func (p Pool) addTokensUnbonded(amount int64) (p2 Pool, issuedShares PoolShares)
 	return p, NewUnbondedShares(issuedSharesAmount)
 }
 
-func (p Pool) removeSharesUnbonded(shares sdk.Rat) (p2 Pool, removedTokens int64) {
+func (p Pool) removeSharesUnbonded(shares sdk.Dec) (p2 Pool, removedTokens int64) {
 	removedTokens = p.UnbondedShareExRate().Mul(shares).RoundInt64() // (tokens/shares) * shares
 	p.UnbondedShares = p.UnbondedShares.Sub(shares)
 	p.UnbondedTokens -= removedTokens
@@ -115,7 +115,7 @@ func (p Pool) removeSharesUnbonded(shares sdk.Rat) (p2 Pool, removedTokens int64
 }
 
 func (p Pool) addTokensUnbonding(amount int64) (p2 Pool, issuedShares PoolShares) {
-	issuedSharesAmount := sdk.NewRat(amount).Quo(p.UnbondingShareExRate()) // tokens * (shares/tokens)
+	issuedSharesAmount := sdk.NewDec(amount).Quo(p.UnbondingShareExRate()) // tokens * (shares/tokens)
 	p.UnbondingShares = p.UnbondingShares.Add(issuedSharesAmount)
 	p.UnbondingTokens += amount
 	p.LooseTokens -= amount
@@ -125,7 +125,7 @@ func (p Pool) addTokensUnbonding(amount int64) (p2 Pool, issuedShares PoolShares
 	return p, NewUnbondingShares(issuedSharesAmount)
 }
 
-func (p Pool) removeSharesUnbonding(shares sdk.Rat) (p2 Pool, removedTokens int64) {
+func (p Pool) removeSharesUnbonding(shares sdk.Dec) (p2 Pool, removedTokens int64) {
 	removedTokens = p.UnbondingShareExRate().Mul(shares).RoundInt64() // (tokens/shares) * shares
 	p.UnbondingShares = p.UnbondingShares.Sub(shares)
 	p.UnbondingTokens -= removedTokens
@@ -137,7 +137,7 @@ func (p Pool) removeSharesUnbonding(shares sdk.Rat) (p2 Pool, removedTokens int6
 }
 
 func (p Pool) addTokensBonded(amount int64) (p2 Pool, issuedShares PoolShares) {
-	issuedSharesAmount := sdk.NewRat(amount).Quo(p.BondedShareExRate()) // tokens * (shares/tokens)
+	issuedSharesAmount := sdk.NewDec(amount).Quo(p.BondedShareExRate()) // tokens * (shares/tokens)
 	p.BondedShares = p.BondedShares.Add(issuedSharesAmount)
 	p.BondedTokens += amount
 	p.LooseTokens -= amount
@@ -147,7 +147,7 @@ func (p Pool) addTokensBonded(amount int64) (p2 Pool, issuedShares PoolShares) {
 	return p, NewBondedShares(issuedSharesAmount)
 }
 
-func (p Pool) removeSharesBonded(shares sdk.Rat) (p2 Pool, removedTokens int64) {
+func (p Pool) removeSharesBonded(shares sdk.Dec) (p2 Pool, removedTokens int64) {
 	removedTokens = p.BondedShareExRate().Mul(shares).RoundInt64() // (tokens/shares) * shares
 	p.BondedShares = p.BondedShares.Sub(shares)
 	p.BondedTokens -= removedTokens
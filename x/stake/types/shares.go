@@ -7,7 +7,7 @@ import (
 // pool shares held by a validator
 type PoolShares struct {
 	Status sdk.BondStatus `json:"status"`
-	Amount sdk.Rat        `json:"amount"` // total shares of type ShareKind
+	Amount sdk.Dec        `json:"amount"` // total shares of type ShareKind
 }
 
 // only the vitals - does not check bond height of IntraTxCounter
@@ -16,21 +16,21 @@ func (s PoolShares) Equal(s2 PoolShares) bool {
 		s.Amount.Equal(s2.Amount)
 }
 
-func NewUnbondedShares(amount sdk.Rat) PoolShares {
+func NewUnbondedShares(amount sdk.Dec) PoolShares {
 	return PoolShares{
 		Status: sdk.Unbonded,
 		Amount: amount,
 	}
 }
 
-func NewUnbondingShares(amount sdk.Rat) PoolShares {
+func NewUnbondingShares(amount sdk.Dec) PoolShares {
 	return PoolShares{
 		Status: sdk.Unbonding,
 		Amount: amount,
 	}
 }
 
-func NewBondedShares(amount sdk.Rat) PoolShares {
+func NewBondedShares(amount sdk.Dec) PoolShares {
 	return PoolShares{
 		Status: sdk.Bonded,
 		Amount: amount,
@@ -40,34 +40,34 @@ func NewBondedShares(amount sdk.Rat) PoolShares {
 //_________________________________________________________________________________________________________
 
 // amount of unbonded shares
-func (s PoolShares) Unbonded() sdk.Rat {
+func (s PoolShares) Unbonded() sdk.Dec {
 	if s.Status == sdk.Unbonded {
 		return s.Amount
 	}
-	return sdk.ZeroRat()
+	return sdk.ZeroDec()
 }
 
 // amount of unbonding shares
-func (s PoolShares) Unbonding() sdk.Rat {
+func (s PoolShares) Unbonding() sdk.Dec {
 	if s.Status == sdk.Unbonding {
 		return s.Amount
 	}
-	return sdk.ZeroRat()
+	return sdk.ZeroDec()
 }
 
 // amount of bonded shares
-func (s PoolShares) Bonded() sdk.Rat {
+func (s PoolShares) Bonded() sdk.Dec {
 	if s.Status == sdk.Bonded {
 		return s.Amount
 	}
-	return sdk.ZeroRat()
+	return sdk.ZeroDec()
 }
 
 //_________________________________________________________________________________________________________
 
 // equivalent amount of shares if the shares were unbonded
 func (s PoolShares) ToUnbonded(p Pool) PoolShares {
-	var amount sdk.Rat
+	var amount sdk.Dec
 	switch s.Status {
 	case sdk.Bonded:
 		exRate := p.BondedShareExRate().Quo(p.UnbondedShareExRate()) // (tok/bondedshr)/(tok/unbondedshr) = unbondedshr/bondedshr
@@ -83,7 +83,7 @@ func (s PoolShares) ToUnbonded(p Pool) PoolShares {
 
 // equivalent amount of shares if the shares were unbonding
 func (s PoolShares) ToUnbonding(p Pool) PoolShares {
-	var amount sdk.Rat
+	var amount sdk.Dec
 	switch s.Status {
 	case sdk.Bonded:
 		exRate := p.BondedShareExRate().Quo(p.UnbondingShareExRate()) // (tok/bondedshr)/(tok/unbondingshr) = unbondingshr/bondedshr
@@ -99,7 +99,7 @@ func (s PoolShares) ToUnbonding(p Pool) PoolShares {
 
 // equivalent amount of shares if the shares were bonded
 func (s PoolShares) ToBonded(p Pool) PoolShares {
-	var amount sdk.Rat
+	var amount sdk.Dec
 	switch s.Status {
 	case sdk.Bonded:
 		amount = s.Amount
@@ -117,7 +117,7 @@ func (s PoolShares) ToBonded(p Pool) PoolShares {
 
 // TODO better tests
 // get the equivalent amount of tokens contained by the shares
-func (s PoolShares) Tokens(p Pool) sdk.Rat {
+func (s PoolShares) Tokens(p Pool) sdk.Dec {
 	switch s.Status {
 	case sdk.Bonded:
 		return p.BondedShareExRate().Mul(s.Amount) // (tokens/shares) * shares
@@ -14,22 +14,22 @@ func TestPoolSharesTokens(t *testing.T) {
 	val := Validator{
 		Owner:           addr1,
 		PubKey:          pk1,
-		PoolShares:      NewBondedShares(sdk.NewRat(100)),
-		DelegatorShares: sdk.NewRat(100),
+		PoolShares:      NewBondedShares(sdk.NewDec(100)),
+		DelegatorShares: sdk.NewDec(100),
 	}
 
 	pool.BondedTokens = val.PoolShares.Bonded().RoundInt64()
 	pool.BondedShares = val.PoolShares.Bonded()
 
-	poolShares := NewBondedShares(sdk.NewRat(50))
+	poolShares := NewBondedShares(sdk.NewDec(50))
 	tokens := poolShares.Tokens(pool)
 	require.Equal(t, int64(50), tokens.RoundInt64())
 
-	poolShares = NewUnbondingShares(sdk.NewRat(50))
+	poolShares = NewUnbondingShares(sdk.NewDec(50))
 	tokens = poolShares.Tokens(pool)
 	require.Equal(t, int64(50), tokens.RoundInt64())
 
-	poolShares = NewUnbondedShares(sdk.NewRat(50))
+	poolShares = NewUnbondedShares(sdk.NewDec(50))
 	tokens = poolShares.Tokens(pool)
 	require.Equal(t, int64(50), tokens.RoundInt64())
 }
@@ -61,9 +61,9 @@ func OpAddTokens(r *rand.Rand, pool Pool, val Validator) (Pool, Validator, int64
 
 // operation: remove a random number of shares from a validator
 func OpRemoveShares(r *rand.Rand, pool Pool, val Validator) (Pool, Validator, int64, string) {
-	var shares sdk.Rat
+	var shares sdk.Dec
 	for {
-		shares = sdk.NewRat(int64(r.Int31n(1000)))
+		shares = sdk.NewDec(int64(r.Int31n(1000)))
 		if shares.LT(val.DelegatorShares) {
 			break
 		}
@@ -106,29 +106,29 @@ func AssertInvariants(t *testing.T, msg string,
 		pMod.UnbondedTokens, pMod.BondedTokens, tokens)
 
 	// nonnegative bonded shares
-	require.False(t, pMod.BondedShares.LT(sdk.ZeroRat()),
+	require.False(t, pMod.BondedShares.LT(sdk.ZeroDec()),
 		"Negative bonded shares - msg: %v\npOrig: %v\npMod: %v\ntokens: %v\n",
 		msg, pOrig, pMod, tokens)
 
 	// nonnegative unbonded shares
-	require.False(t, pMod.UnbondedShares.LT(sdk.ZeroRat()),
+	require.False(t, pMod.UnbondedShares.LT(sdk.ZeroDec()),
 		"Negative unbonded shares - msg: %v\npOrig: %v\npMod: %v\ntokens: %v\n",
 		msg, pOrig, pMod, tokens)
 
 	// nonnegative bonded ex rate
-	require.False(t, pMod.BondedShareExRate().LT(sdk.ZeroRat()),
+	require.False(t, pMod.BondedShareExRate().LT(sdk.ZeroDec()),
 		"Applying operation \"%s\" resulted in negative BondedShareExRate: %d",
 		msg, pMod.BondedShareExRate().RoundInt64())
 
 	// nonnegative unbonded ex rate
-	require.False(t, pMod.UnbondedShareExRate().LT(sdk.ZeroRat()),
+	require.False(t, pMod.UnbondedShareExRate().LT(sdk.ZeroDec()),
 		"Applying operation \"%s\" resulted in negative UnbondedShareExRate: %d",
 		msg, pMod.UnbondedShareExRate().RoundInt64())
 
 	for _, vMod := range vMods {
 
 		// nonnegative ex rate
-		require.False(t, vMod.DelegatorShareExRate(pMod).LT(sdk.ZeroRat()),
+		require.False(t, vMod.DelegatorShareExRate(pMod).LT(sdk.ZeroDec()),
 			"Applying operation \"%s\" resulted in negative validator.DelegatorShareExRate(): %v (validator.Owner: %s)",
 			msg,
 			vMod.DelegatorShareExRate(pMod),
@@ -136,7 +136,7 @@ func AssertInvariants(t *testing.T, msg string,
 		)
 
 		// nonnegative poolShares
-		require.False(t, vMod.PoolShares.Bonded().LT(sdk.ZeroRat()),
+		require.False(t, vMod.PoolShares.Bonded().LT(sdk.ZeroDec()),
 			"Applying operation \"%s\" resulted in negative validator.PoolShares.Bonded(): %v (validator.DelegatorShares: %v, validator.DelegatorShareExRate: %v, validator.Owner: %s)",
 			msg,
 			vMod.PoolShares.Bonded(),
@@ -146,7 +146,7 @@ func AssertInvariants(t *testing.T, msg string,
 		)
 
 		// nonnegative delShares
-		require.False(t, vMod.DelegatorShares.LT(sdk.ZeroRat()),
+		require.False(t, vMod.DelegatorShares.LT(sdk.ZeroDec()),
 			"Applying operation \"%s\" resulted in negative validator.DelegatorShares: %v (validator.PoolShares.Bonded(): %v, validator.DelegatorShareExRate: %v, validator.Owner: %s)",
 			msg,
 			vMod.DelegatorShares,
@@ -166,8 +166,8 @@ func AssertInvariants(t *testing.T, msg string,
 // nolint: unparam
 func randomValidator(r *rand.Rand, i int) Validator {
 
-	poolSharesAmt := sdk.NewRat(int64(r.Int31n(10000)))
-	delShares := sdk.NewRat(int64(r.Int31n(10000)))
+	poolSharesAmt := sdk.NewDec(int64(r.Int31n(10000)))
+	delShares := sdk.NewDec(int64(r.Int31n(10000)))
 
 	var pShares PoolShares
 	if r.Float64() < float64(0.5) {
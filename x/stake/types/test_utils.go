@@ -68,9 +68,9 @@ func OpAddTokens(r *rand.Rand, pool Pool, val Validator) (Pool, Validator, int64
 // OpRemoveShares implements an operation that removes a random number of
 // shares from a validator.
 func OpRemoveShares(r *rand.Rand, pool Pool, val Validator) (Pool, Validator, int64, string) {
-	var shares sdk.Rat
+	var shares sdk.Dec
 	for {
-		shares = sdk.NewRat(int64(r.Int31n(1000)))
+		shares = sdk.NewDec(int64(r.Int31n(1000)))
 		if shares.LT(val.DelegatorShares) {
 			break
 		}
@@ -114,28 +114,28 @@ func AssertInvariants(t *testing.T, msg string,
 		pMod.UnbondedTokens, pMod.BondedTokens, tokens)
 
 	// Nonnegative bonded shares
-	require.False(t, pMod.BondedShares.LT(sdk.ZeroRat()),
+	require.False(t, pMod.BondedShares.LT(sdk.ZeroDec()),
 		"Negative bonded shares - msg: %v\npOrig: %v\npMod: %v\ntokens: %v\n",
 		msg, pOrig, pMod, tokens)
 
 	// Nonnegative unbonded shares
-	require.False(t, pMod.UnbondedShares.LT(sdk.ZeroRat()),
+	require.False(t, pMod.UnbondedShares.LT(sdk.ZeroDec()),
 		"Negative unbonded shares - msg: %v\npOrig: %v\npMod: %v\ntokens: %v\n",
 		msg, pOrig, pMod, tokens)
 
 	// Nonnegative bonded ex rate
-	require.False(t, pMod.BondedShareExRate().LT(sdk.ZeroRat()),
+	require.False(t, pMod.BondedShareExRate().LT(sdk.ZeroDec()),
 		"Applying operation \"%s\" resulted in negative BondedShareExRate: %d",
 		msg, pMod.BondedShareExRate().RoundInt64())
 
 	// Nonnegative unbonded ex rate
-	require.False(t, pMod.UnbondedShareExRate().LT(sdk.ZeroRat()),
+	require.False(t, pMod.UnbondedShareExRate().LT(sdk.ZeroDec()),
 		"Applying operation \"%s\" resulted in negative UnbondedShareExRate: %d",
 		msg, pMod.UnbondedShareExRate().RoundInt64())
 
 	for _, vMod := range vMods {
 		// Nonnegative ex rate
-		require.False(t, vMod.DelegatorShareExRate(pMod).LT(sdk.ZeroRat()),
+		require.False(t, vMod.DelegatorShareExRate(pMod).LT(sdk.ZeroDec()),
 			"Applying operation \"%s\" resulted in negative validator.DelegatorShareExRate(): %v (validator.Owner: %s)",
 			msg,
 			vMod.DelegatorShareExRate(pMod),
@@ -143,7 +143,7 @@ func AssertInvariants(t *testing.T, msg string,
 		)
 
 		// Nonnegative poolShares
-		require.False(t, vMod.PoolShares.Bonded().LT(sdk.ZeroRat()),
+		require.False(t, vMod.PoolShares.Bonded().LT(sdk.ZeroDec()),
 			"Applying operation \"%s\" resulted in negative validator.PoolShares.Bonded(): %v (validator.DelegatorShares: %v, validator.DelegatorShareExRate: %v, validator.Owner: %s)",
 			msg,
 			vMod.PoolShares.Bonded(),
@@ -153,7 +153,7 @@ func AssertInvariants(t *testing.T, msg string,
 		)
 
 		// Nonnegative delShares
-		require.False(t, vMod.DelegatorShares.LT(sdk.ZeroRat()),
+		require.False(t, vMod.DelegatorShares.LT(sdk.ZeroDec()),
 			"Applying operation \"%s\" resulted in negative validator.DelegatorShares: %v (validator.PoolShares.Bonded(): %v, validator.DelegatorShareExRate: %v, validator.Owner: %s)",
 			msg,
 			vMod.DelegatorShares,
@@ -169,8 +169,8 @@ func AssertInvariants(t *testing.T, msg string,
 // randomValidator generates a random validator.
 // nolint: unparam
 func randomValidator(r *rand.Rand, i int) Validator {
-	poolSharesAmt := sdk.NewRat(int64(r.Int31n(10000)))
-	delShares := sdk.NewRat(int64(r.Int31n(10000)))
+	poolSharesAmt := sdk.NewDec(int64(r.Int31n(10000)))
+	delShares := sdk.NewDec(int64(r.Int31n(10000)))
 
 	var pShares PoolShares
 
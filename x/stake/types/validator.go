@@ -24,20 +24,20 @@ type Validator struct {
 	Revoked bool          `json:"revoked"` // has the validator been revoked from bonded status?
 
 	PoolShares      PoolShares `json:"pool_shares"`      // total shares for tokens held in the pool
-	DelegatorShares sdk.Rat    `json:"delegator_shares"` // total shares issued to a validator's delegators
+	DelegatorShares sdk.Dec    `json:"delegator_shares"` // total shares issued to a validator's delegators
 
 	Description        Description `json:"description"`           // description terms for the validator
 	BondHeight         int64       `json:"bond_height"`           // earliest height as a bonded validator
 	BondIntraTxCounter int16       `json:"bond_intra_tx_counter"` // block-local tx index of validator change
 	ProposerRewardPool sdk.Coins   `json:"proposer_reward_pool"`  // XXX reward pool collected from being the proposer
 
-	Commission            sdk.Rat `json:"commission"`              // XXX the commission rate of fees charged to any delegators
-	CommissionMax         sdk.Rat `json:"commission_max"`          // XXX maximum commission rate which this validator can ever charge
-	CommissionChangeRate  sdk.Rat `json:"commission_change_rate"`  // XXX maximum daily increase of the validator commission
-	CommissionChangeToday sdk.Rat `json:"commission_change_today"` // XXX commission rate change today, reset each day (UTC time)
+	Commission            sdk.Dec `json:"commission"`              // XXX the commission rate of fees charged to any delegators
+	CommissionMax         sdk.Dec `json:"commission_max"`          // XXX maximum commission rate which this validator can ever charge
+	CommissionChangeRate  sdk.Dec `json:"commission_change_rate"`  // XXX maximum daily increase of the validator commission
+	CommissionChangeToday sdk.Dec `json:"commission_change_today"` // XXX commission rate change today, reset each day (UTC time)
 
 	// fee related
-	PrevBondedShares sdk.Rat `json:"prev_bonded_shares"` // total shares of a global hold pools
+	PrevBondedShares sdk.Dec `json:"prev_bonded_shares"` // total shares of a global hold pools
 }
 
 // NewValidator - initialize a new validator
@@ -46,17 +46,17 @@ func NewValidator(owner sdk.Address, pubKey crypto.PubKey, description Descripti
 		Owner:                 owner,
 		PubKey:                pubKey,
 		Revoked:               false,
-		PoolShares:            NewUnbondedShares(sdk.ZeroRat()),
-		DelegatorShares:       sdk.ZeroRat(),
+		PoolShares:            NewUnbondedShares(sdk.ZeroDec()),
+		DelegatorShares:       sdk.ZeroDec(),
 		Description:           description,
 		BondHeight:            int64(0),
 		BondIntraTxCounter:    int16(0),
 		ProposerRewardPool:    sdk.Coins{},
-		Commission:            sdk.ZeroRat(),
-		CommissionMax:         sdk.ZeroRat(),
-		CommissionChangeRate:  sdk.ZeroRat(),
-		CommissionChangeToday: sdk.ZeroRat(),
-		PrevBondedShares:      sdk.ZeroRat(),
+		Commission:            sdk.ZeroDec(),
+		CommissionMax:         sdk.ZeroDec(),
+		CommissionChangeRate:  sdk.ZeroDec(),
+		CommissionChangeToday: sdk.ZeroDec(),
+		PrevBondedShares:      sdk.ZeroDec(),
 	}
 }
 
@@ -193,7 +193,7 @@ func (v Validator) UpdateStatus(pool Pool, NewStatus sdk.BondStatus) (Validator,
 // Remove pool shares
 // Returns corresponding tokens, which could be burned (e.g. when slashing
 // a validator) or redistributed elsewhere
-func (v Validator) RemovePoolShares(pool Pool, poolShares sdk.Rat) (Validator, Pool, int64) {
+func (v Validator) RemovePoolShares(pool Pool, poolShares sdk.Dec) (Validator, Pool, int64) {
 	var tokens int64
 	switch v.Status() {
 	case sdk.Unbonded:
@@ -212,7 +212,7 @@ func (v Validator) RemovePoolShares(pool Pool, poolShares sdk.Rat) (Validator, P
 // if bonded, the power is the BondedShares
 // if not bonded, the power is the amount of bonded shares which the
 //    the validator would have it was bonded
-func (v Validator) EquivalentBondedShares(pool Pool) (eqBondedShares sdk.Rat) {
+func (v Validator) EquivalentBondedShares(pool Pool) (eqBondedShares sdk.Dec) {
 	return v.PoolShares.ToBonded(pool).Amount
 }
 
@@ -220,12 +220,12 @@ func (v Validator) EquivalentBondedShares(pool Pool) (eqBondedShares sdk.Rat) {
 
 // add tokens to a validator
 func (v Validator) AddTokensFromDel(pool Pool,
-	amount int64) (validator2 Validator, p2 Pool, issuedDelegatorShares sdk.Rat) {
+	amount int64) (validator2 Validator, p2 Pool, issuedDelegatorShares sdk.Dec) {
 
 	exRate := v.DelegatorShareExRate(pool) // bshr/delshr
 
 	var poolShares PoolShares
-	var equivalentBondedShares sdk.Rat
+	var equivalentBondedShares sdk.Dec
 	switch v.Status() {
 	case sdk.Unbonded:
 		pool, poolShares = pool.addTokensUnbonded(amount)
@@ -246,7 +246,7 @@ func (v Validator) AddTokensFromDel(pool Pool,
 // remove delegator shares from a validator
 // NOTE this function assumes the shares have already been updated for the validator status
 func (v Validator) RemoveDelShares(pool Pool,
-	delShares sdk.Rat) (validator2 Validator, p2 Pool, createdCoins int64) {
+	delShares sdk.Dec) (validator2 Validator, p2 Pool, createdCoins int64) {
 
 	amount := v.DelegatorShareExRate(pool).Mul(delShares)
 	eqBondedSharesToRemove := NewBondedShares(amount)
@@ -270,9 +270,9 @@ func (v Validator) RemoveDelShares(pool Pool,
 
 // get the exchange rate of tokens over delegator shares
 // UNITS: eq-val-bonded-shares/delegator-shares
-func (v Validator) DelegatorShareExRate(pool Pool) sdk.Rat {
+func (v Validator) DelegatorShareExRate(pool Pool) sdk.Dec {
 	if v.DelegatorShares.IsZero() {
-		return sdk.OneRat()
+		return sdk.OneDec()
 	}
 	eqBondedShares := v.PoolShares.ToBonded(pool).Amount
 	return eqBondedShares.Quo(v.DelegatorShares)
@@ -289,8 +289,8 @@ func (v Validator) GetMoniker() string          { return v.Description.Moniker }
 func (v Validator) GetStatus() sdk.BondStatus   { return v.Status() }
 func (v Validator) GetOwner() sdk.Address       { return v.Owner }
 func (v Validator) GetPubKey() crypto.PubKey    { return v.PubKey }
-func (v Validator) GetPower() sdk.Rat           { return v.PoolShares.Bonded() }
-func (v Validator) GetDelegatorShares() sdk.Rat { return v.DelegatorShares }
+func (v Validator) GetPower() sdk.Dec           { return v.PoolShares.Bonded() }
+func (v Validator) GetDelegatorShares() sdk.Dec { return v.DelegatorShares }
 func (v Validator) GetBondHeight() int64        { return v.BondHeight }
 
 //Human Friendly pretty printer
@@ -306,8 +306,8 @@ func (v Validator) HumanReadableString() (string, error) {
 	resp := "Validator \n"
 	resp += fmt.Sprintf("Owner: %s\n", bechOwner)
 	resp += fmt.Sprintf("Validator: %s\n", bechVal)
-	resp += fmt.Sprintf("Shares: Status %s,  Amount: %s\n", sdk.BondStatusToString(v.PoolShares.Status), v.PoolShares.Amount.FloatString())
-	resp += fmt.Sprintf("Delegator Shares: %s\n", v.DelegatorShares.FloatString())
+	resp += fmt.Sprintf("Shares: Status %s,  Amount: %s\n", sdk.BondStatusToString(v.PoolShares.Status), v.PoolShares.Amount.String())
+	resp += fmt.Sprintf("Delegator Shares: %s\n", v.DelegatorShares.String())
 	resp += fmt.Sprintf("Description: %s\n", v.Description)
 	resp += fmt.Sprintf("Bond Height: %d\n", v.BondHeight)
 	resp += fmt.Sprintf("Proposer Reward Pool: %s\n", v.ProposerRewardPool.String())
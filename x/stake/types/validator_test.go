@@ -18,13 +18,13 @@ func TestAddTokensValidatorBonded(t *testing.T) {
 	val, pool = val.UpdateStatus(pool, sdk.Bonded)
 	val, pool, delShares := val.AddTokensFromDel(pool, 10)
 
-	require.Equal(t, sdk.OneRat(), val.DelegatorShareExRate(pool))
-	require.Equal(t, sdk.OneRat(), pool.BondedShareExRate())
-	require.Equal(t, sdk.OneRat(), pool.UnbondingShareExRate())
-	require.Equal(t, sdk.OneRat(), pool.UnbondedShareExRate())
+	require.Equal(t, sdk.OneDec(), val.DelegatorShareExRate(pool))
+	require.Equal(t, sdk.OneDec(), pool.BondedShareExRate())
+	require.Equal(t, sdk.OneDec(), pool.UnbondingShareExRate())
+	require.Equal(t, sdk.OneDec(), pool.UnbondedShareExRate())
 
-	assert.True(sdk.RatEq(t, sdk.NewRat(10), delShares))
-	assert.True(sdk.RatEq(t, sdk.NewRat(10), val.PoolShares.Bonded()))
+	assert.True(sdk.DecEq(t, sdk.NewDec(10), delShares))
+	assert.True(sdk.DecEq(t, sdk.NewDec(10), val.PoolShares.Bonded()))
 }
 
 func TestAddTokensValidatorUnbonding(t *testing.T) {
@@ -34,13 +34,13 @@ func TestAddTokensValidatorUnbonding(t *testing.T) {
 	val, pool = val.UpdateStatus(pool, sdk.Unbonding)
 	val, pool, delShares := val.AddTokensFromDel(pool, 10)
 
-	require.Equal(t, sdk.OneRat(), val.DelegatorShareExRate(pool))
-	require.Equal(t, sdk.OneRat(), pool.BondedShareExRate())
-	require.Equal(t, sdk.OneRat(), pool.UnbondingShareExRate())
-	require.Equal(t, sdk.OneRat(), pool.UnbondedShareExRate())
+	require.Equal(t, sdk.OneDec(), val.DelegatorShareExRate(pool))
+	require.Equal(t, sdk.OneDec(), pool.BondedShareExRate())
+	require.Equal(t, sdk.OneDec(), pool.UnbondingShareExRate())
+	require.Equal(t, sdk.OneDec(), pool.UnbondedShareExRate())
 
-	assert.True(sdk.RatEq(t, sdk.NewRat(10), delShares))
-	assert.True(sdk.RatEq(t, sdk.NewRat(10), val.PoolShares.Unbonding()))
+	assert.True(sdk.DecEq(t, sdk.NewDec(10), delShares))
+	assert.True(sdk.DecEq(t, sdk.NewDec(10), val.PoolShares.Unbonding()))
 }
 
 func TestAddTokensValidatorUnbonded(t *testing.T) {
@@ -50,13 +50,13 @@ func TestAddTokensValidatorUnbonded(t *testing.T) {
 	val, pool = val.UpdateStatus(pool, sdk.Unbonded)
 	val, pool, delShares := val.AddTokensFromDel(pool, 10)
 
-	require.Equal(t, sdk.OneRat(), val.DelegatorShareExRate(pool))
-	require.Equal(t, sdk.OneRat(), pool.BondedShareExRate())
-	require.Equal(t, sdk.OneRat(), pool.UnbondingShareExRate())
-	require.Equal(t, sdk.OneRat(), pool.UnbondedShareExRate())
+	require.Equal(t, sdk.OneDec(), val.DelegatorShareExRate(pool))
+	require.Equal(t, sdk.OneDec(), pool.BondedShareExRate())
+	require.Equal(t, sdk.OneDec(), pool.UnbondingShareExRate())
+	require.Equal(t, sdk.OneDec(), pool.UnbondedShareExRate())
 
-	assert.True(sdk.RatEq(t, sdk.NewRat(10), delShares))
-	assert.True(sdk.RatEq(t, sdk.NewRat(10), val.PoolShares.Unbonded()))
+	assert.True(sdk.DecEq(t, sdk.NewDec(10), delShares))
+	assert.True(sdk.DecEq(t, sdk.NewDec(10), val.PoolShares.Unbonded()))
 }
 
 // TODO refactor to make simpler like the AddToken tests above
@@ -66,26 +66,26 @@ func TestRemoveDelShares(t *testing.T) {
 	valA := Validator{
 		Owner:           addr1,
 		PubKey:          pk1,
-		PoolShares:      NewBondedShares(sdk.NewRat(100)),
-		DelegatorShares: sdk.NewRat(100),
+		PoolShares:      NewBondedShares(sdk.NewDec(100)),
+		DelegatorShares: sdk.NewDec(100),
 	}
 	poolA.BondedTokens = valA.PoolShares.Bonded().RoundInt64()
 	poolA.BondedShares = valA.PoolShares.Bonded()
-	require.Equal(t, valA.DelegatorShareExRate(poolA), sdk.OneRat())
-	require.Equal(t, poolA.BondedShareExRate(), sdk.OneRat())
-	require.Equal(t, poolA.UnbondedShareExRate(), sdk.OneRat())
-	valB, poolB, coinsB := valA.RemoveDelShares(poolA, sdk.NewRat(10))
+	require.Equal(t, valA.DelegatorShareExRate(poolA), sdk.OneDec())
+	require.Equal(t, poolA.BondedShareExRate(), sdk.OneDec())
+	require.Equal(t, poolA.UnbondedShareExRate(), sdk.OneDec())
+	valB, poolB, coinsB := valA.RemoveDelShares(poolA, sdk.NewDec(10))
 
 	// coins were created
 	require.Equal(t, coinsB, int64(10))
 	// pool shares were removed
-	require.Equal(t, valB.PoolShares.Bonded(), valA.PoolShares.Bonded().Sub(sdk.NewRat(10).Mul(valA.DelegatorShareExRate(poolA))))
+	require.Equal(t, valB.PoolShares.Bonded(), valA.PoolShares.Bonded().Sub(sdk.NewDec(10).Mul(valA.DelegatorShareExRate(poolA))))
 	// conservation of tokens
 	require.Equal(t, poolB.UnbondedTokens+poolB.BondedTokens+coinsB, poolA.UnbondedTokens+poolA.BondedTokens)
 
 	// specific case from random tests
-	poolShares := sdk.NewRat(5102)
-	delShares := sdk.NewRat(115)
+	poolShares := sdk.NewDec(5102)
+	delShares := sdk.NewDec(115)
 	val := Validator{
 		Owner:           addr1,
 		PubKey:          pk1,
@@ -93,14 +93,14 @@ func TestRemoveDelShares(t *testing.T) {
 		DelegatorShares: delShares,
 	}
 	pool := Pool{
-		BondedShares:      sdk.NewRat(248305),
-		UnbondedShares:    sdk.NewRat(232147),
+		BondedShares:      sdk.NewDec(248305),
+		UnbondedShares:    sdk.NewDec(232147),
 		BondedTokens:      248305,
 		UnbondedTokens:    232147,
 		InflationLastTime: 0,
-		Inflation:         sdk.NewRat(7, 100),
+		Inflation:         sdk.NewDecWithPrec(7, 2),
 	}
-	shares := sdk.NewRat(29)
+	shares := sdk.NewDec(29)
 	msg := fmt.Sprintf("validator %s (status: %d, poolShares: %v, delShares: %v, DelegatorShareExRate: %v)",
 		val.Owner, val.Status(), val.PoolShares.Bonded(), val.DelegatorShares, val.DelegatorShareExRate(pool))
 	msg = fmt.Sprintf("Removed %v shares from %s", shares, msg)
@@ -142,8 +142,8 @@ func TestUpdateStatus(t *testing.T) {
 }
 
 func TestPossibleOverflow(t *testing.T) {
-	poolShares := sdk.NewRat(2159)
-	delShares := sdk.NewRat(391432570689183511).Quo(sdk.NewRat(40113011844664))
+	poolShares := sdk.NewDec(2159)
+	delShares := sdk.NewDec(391432570689183511).Quo(sdk.NewDec(40113011844664))
 	val := Validator{
 		Owner:           addr1,
 		PubKey:          pk1,
@@ -153,11 +153,11 @@ func TestPossibleOverflow(t *testing.T) {
 	pool := Pool{
 		LooseTokens:       100,
 		BondedShares:      poolShares,
-		UnbondedShares:    sdk.ZeroRat(),
+		UnbondedShares:    sdk.ZeroDec(),
 		BondedTokens:      poolShares.RoundInt64(),
 		UnbondedTokens:    0,
 		InflationLastTime: 0,
-		Inflation:         sdk.NewRat(7, 100),
+		Inflation:         sdk.NewDecWithPrec(7, 2),
 	}
 	tokens := int64(71)
 	msg := fmt.Sprintf("validator %s (status: %d, poolShares: %v, delShares: %v, DelegatorShareExRate: %v)",
@@ -165,7 +165,7 @@ func TestPossibleOverflow(t *testing.T) {
 	newValidator, _, _ := val.AddTokensFromDel(pool, tokens)
 
 	msg = fmt.Sprintf("Added %d tokens to %s", tokens, msg)
-	require.False(t, newValidator.DelegatorShareExRate(pool).LT(sdk.ZeroRat()),
+	require.False(t, newValidator.DelegatorShareExRate(pool).LT(sdk.ZeroDec()),
 		"Applying operation \"%s\" resulted in negative DelegatorShareExRate(): %v",
 		msg, newValidator.DelegatorShareExRate(pool))
 }